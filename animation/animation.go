@@ -0,0 +1,96 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package animation implements easing functions for widgets that animate
+// changes of their values.
+package animation
+
+import "math"
+
+// Easing maps the progress of an animation to the progress of the animated
+// value. Both are fractions where zero is the start and one is the end of the
+// animation. The returned value can leave the range while the animation is in
+// progress, e.g. when the value overshoots its target.
+type Easing func(t float64) float64
+
+// clamp limits the progress to the range 0 <= t <= 1.
+func clamp(t float64) float64 {
+	if t < 0 {
+		return 0
+	}
+	if t > 1 {
+		return 1
+	}
+	return t
+}
+
+// Linear changes the value at a constant rate.
+func Linear(t float64) float64 {
+	return clamp(t)
+}
+
+// EaseIn starts slowly and accelerates towards the end, a quadratic curve.
+func EaseIn(t float64) float64 {
+	t = clamp(t)
+	return t * t
+}
+
+// EaseOut starts fast and decelerates towards the end, a quadratic curve.
+func EaseOut(t float64) float64 {
+	t = clamp(t)
+	return t * (2 - t)
+}
+
+// EaseInOut accelerates in the first half and decelerates in the second half,
+// a quadratic curve.
+func EaseInOut(t float64) float64 {
+	t = clamp(t)
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// CubicIn is like EaseIn, but follows a cubic curve.
+func CubicIn(t float64) float64 {
+	t = clamp(t)
+	return t * t * t
+}
+
+// CubicOut is like EaseOut, but follows a cubic curve.
+func CubicOut(t float64) float64 {
+	t = clamp(t) - 1
+	return t*t*t + 1
+}
+
+// CubicInOut is like EaseInOut, but follows a cubic curve.
+func CubicInOut(t float64) float64 {
+	t = clamp(t)
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// Elastic overshoots the target and oscillates around it with a decaying
+// amplitude before it settles, like a released spring.
+func Elastic(t float64) float64 {
+	t = clamp(t)
+	if t == 0 || t == 1 {
+		return t
+	}
+	const period = 0.3
+	return math.Pow(2, -10*t)*math.Sin((t-period/4)*(2*math.Pi)/period) + 1
+}
@@ -0,0 +1,117 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"testing"
+)
+
+// approxEqual asserts that the two floats are equal within a small tolerance.
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestEasing(t *testing.T) {
+	tests := []struct {
+		desc string
+		e    Easing
+		// check asserts the shape of the curve at t=0.5.
+		check func(v float64) bool
+		// shape describes the expected shape for error messages.
+		shape string
+	}{
+		{
+			desc:  "Linear",
+			e:     Linear,
+			check: func(v float64) bool { return approxEqual(v, 0.5) },
+			shape: "== 0.5",
+		},
+		{
+			desc:  "EaseIn",
+			e:     EaseIn,
+			check: func(v float64) bool { return v < 0.5 },
+			shape: "< 0.5",
+		},
+		{
+			desc:  "EaseOut",
+			e:     EaseOut,
+			check: func(v float64) bool { return v > 0.5 },
+			shape: "> 0.5",
+		},
+		{
+			desc:  "EaseInOut",
+			e:     EaseInOut,
+			check: func(v float64) bool { return approxEqual(v, 0.5) },
+			shape: "== 0.5",
+		},
+		{
+			desc:  "CubicIn",
+			e:     CubicIn,
+			check: func(v float64) bool { return v < EaseIn(0.5) },
+			shape: "below EaseIn",
+		},
+		{
+			desc:  "CubicOut",
+			e:     CubicOut,
+			check: func(v float64) bool { return v > EaseOut(0.5) },
+			shape: "above EaseOut",
+		},
+		{
+			desc:  "CubicInOut",
+			e:     CubicInOut,
+			check: func(v float64) bool { return approxEqual(v, 0.5) },
+			shape: "== 0.5",
+		},
+		{
+			desc:  "Elastic",
+			e:     Elastic,
+			check: func(v float64) bool { return v > 0.5 },
+			shape: "> 0.5",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.e(0); !approxEqual(got, 0) {
+				t.Errorf("%s(0) => %v, want 0", tc.desc, got)
+			}
+			if got := tc.e(1); !approxEqual(got, 1) {
+				t.Errorf("%s(1) => %v, want 1", tc.desc, got)
+			}
+			if got := tc.e(0.5); !tc.check(got) {
+				t.Errorf("%s(0.5) => %v, want %s", tc.desc, got, tc.shape)
+			}
+			if got := tc.e(-1); !approxEqual(got, 0) {
+				t.Errorf("%s(-1) => %v, want it clamped to 0", tc.desc, got)
+			}
+			if got := tc.e(2); !approxEqual(got, 1) {
+				t.Errorf("%s(2) => %v, want it clamped to 1", tc.desc, got)
+			}
+		})
+	}
+}
+
+func TestElasticOvershoots(t *testing.T) {
+	var max float64
+	for i := 0; i <= 100; i++ {
+		if v := Elastic(float64(i) / 100); v > max {
+			max = v
+		}
+	}
+	if max <= 1 {
+		t.Errorf("Elastic reached at most %v, want it to overshoot 1", max)
+	}
+}
@@ -25,6 +25,20 @@ type Option interface {
 type Options struct {
 	FgColor Color
 	BgColor Color
+
+	// Underline is the style of the underline and UnderlineColor its color.
+	// The color defaults to the foreground color on terminals that support
+	// colored underlines and is ignored by the other terminals.
+	Underline      Underline
+	UnderlineColor Color
+
+	// Italic indicates that the content of the cell is drawn in italics.
+	// Ignored by terminals that don't support italics.
+	Italic bool
+
+	// Protected indicates that the cell cannot be overwritten by subsequent
+	// calls to SetCell, only by an explicit overwrite.
+	Protected bool
 }
 
 // Set allows existing options to be passed as an option.
@@ -62,3 +76,40 @@ func BgColor(color Color) Option {
 		co.BgColor = color
 	})
 }
+
+// Protected marks the cell as protected. Protected cells aren't modified by
+// subsequent calls to SetCell, e.g. when drawing a watermark that must stay
+// on top of content drawn later. Use the OverwriteCell methods to change a
+// protected cell.
+func Protected() Option {
+	return option(func(co *Options) {
+		co.Protected = true
+	})
+}
+
+// UnderlineStyle underlines the content of the cell with the provided style.
+// Terminals that don't support the style draw a straight underline or ignore
+// the option.
+func UnderlineStyle(u Underline) Option {
+	return option(func(co *Options) {
+		co.Underline = u
+	})
+}
+
+// Italic sets whether the content of the cell is drawn in italics.
+// Passing false removes italics set by earlier options. Terminals that don't
+// support italics ignore the option.
+func Italic(on bool) Option {
+	return option(func(co *Options) {
+		co.Italic = on
+	})
+}
+
+// UnderlineColor sets the color of the underline, which is otherwise drawn in
+// the foreground color. Only has effect together with UnderlineStyle on
+// terminals that support colored underlines.
+func UnderlineColor(color Color) Option {
+	return option(func(co *Options) {
+		co.UnderlineColor = color
+	})
+}
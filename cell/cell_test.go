@@ -48,6 +48,50 @@ func TestNewOptions(t *testing.T) {
 				BgColor: ColorRed,
 			},
 		},
+		{
+			desc: "setting protected",
+			opts: []Option{
+				Protected(),
+			},
+			want: &Options{
+				Protected: true,
+			},
+		},
+		{
+			desc: "setting underline style",
+			opts: []Option{
+				UnderlineStyle(UnderlineCurly),
+			},
+			want: &Options{
+				Underline: UnderlineCurly,
+			},
+		},
+		{
+			desc: "setting underline color",
+			opts: []Option{
+				UnderlineColor(ColorRed),
+			},
+			want: &Options{
+				UnderlineColor: ColorRed,
+			},
+		},
+		{
+			desc: "setting italic",
+			opts: []Option{
+				Italic(true),
+			},
+			want: &Options{
+				Italic: true,
+			},
+		},
+		{
+			desc: "later option removes italic",
+			opts: []Option{
+				Italic(true),
+				Italic(false),
+			},
+			want: &Options{},
+		},
 		{
 			desc: "setting multiple options",
 			opts: []Option{
@@ -63,13 +107,19 @@ func TestNewOptions(t *testing.T) {
 			desc: "setting options by passing the options struct",
 			opts: []Option{
 				&Options{
-					FgColor: ColorCyan,
-					BgColor: ColorMagenta,
+					FgColor:        ColorCyan,
+					BgColor:        ColorMagenta,
+					Underline:      UnderlineDouble,
+					UnderlineColor: ColorRed,
+					Italic:         true,
 				},
 			},
 			want: &Options{
-				FgColor: ColorCyan,
-				BgColor: ColorMagenta,
+				FgColor:        ColorCyan,
+				BgColor:        ColorMagenta,
+				Underline:      UnderlineDouble,
+				UnderlineColor: ColorRed,
+				Italic:         true,
 			},
 		},
 	}
@@ -0,0 +1,49 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cell
+
+// cycle.go implements a helper that assigns distinct cell options to the
+// series of multi-series charts.
+
+// cycleUnderlines are the underline styles Cycle combines with the palette
+// colors once the palette wraps.
+var cycleUnderlines = []Underline{
+	UnderlineNone,
+	UnderlineStraight,
+	UnderlineDouble,
+	UnderlineCurly,
+}
+
+// Cycle returns cell options for the i-th series of a chart drawn using the
+// provided palette.
+// Indices within the palette get the foreground color at that index. Indices
+// beyond the palette reuse its colors combined with a different underline
+// style on each pass, so that the series remain distinguishable after the
+// palette wraps. The combinations repeat after len(palette) * 4 indices.
+// Returns no options if the palette is empty or the index is negative.
+func Cycle(palette []Color, i int) []Option {
+	if len(palette) == 0 || i < 0 {
+		return nil
+	}
+
+	opts := []Option{
+		FgColor(palette[i%len(palette)]),
+	}
+	pass := i / len(palette) % len(cycleUnderlines)
+	if u := cycleUnderlines[pass]; u != UnderlineNone {
+		opts = append(opts, UnderlineStyle(u))
+	}
+	return opts
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cell
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestCycle(t *testing.T) {
+	palette := []Color{ColorRed, ColorGreen, ColorBlue}
+
+	tests := []struct {
+		desc    string
+		palette []Color
+		i       int
+		want    *Options
+	}{
+		{
+			desc: "no options for an empty palette",
+			i:    1,
+			want: &Options{},
+		},
+		{
+			desc:    "no options for a negative index",
+			palette: palette,
+			i:       -1,
+			want:    &Options{},
+		},
+		{
+			desc:    "first index gets the first palette color",
+			palette: palette,
+			i:       0,
+			want:    &Options{FgColor: ColorRed},
+		},
+		{
+			desc:    "last index within the palette gets the last color",
+			palette: palette,
+			i:       2,
+			want:    &Options{FgColor: ColorBlue},
+		},
+		{
+			desc:    "first index after the palette wraps adds an underline",
+			palette: palette,
+			i:       3,
+			want: &Options{
+				FgColor:   ColorRed,
+				Underline: UnderlineStraight,
+			},
+		},
+		{
+			desc:    "second wrap uses another underline style",
+			palette: palette,
+			i:       7,
+			want: &Options{
+				FgColor:   ColorGreen,
+				Underline: UnderlineDouble,
+			},
+		},
+		{
+			desc:    "third wrap uses another underline style",
+			palette: palette,
+			i:       11,
+			want: &Options{
+				FgColor:   ColorBlue,
+				Underline: UnderlineCurly,
+			},
+		},
+		{
+			desc:    "combinations repeat once exhausted",
+			palette: palette,
+			i:       12,
+			want:    &Options{FgColor: ColorRed},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := NewOptions(Cycle(tc.palette, tc.i)...)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Cycle => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCycleDistinct(t *testing.T) {
+	palette := []Color{ColorRed, ColorGreen, ColorBlue}
+	distinct := len(palette) * len(cycleUnderlines)
+
+	seen := map[Options]int{}
+	for i := 0; i < distinct; i++ {
+		o := *NewOptions(Cycle(palette, i)...)
+		if prev, ok := seen[o]; ok {
+			t.Errorf("Cycle(%d) => %+v, same as Cycle(%d)", i, o, prev)
+		}
+		seen[o] = i
+	}
+}
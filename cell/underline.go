@@ -0,0 +1,50 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cell
+
+import (
+	"fmt"
+)
+
+// underline.go defines constants for the styles of cell underlines.
+
+// Underline is the style of the line drawn under the content of a cell.
+type Underline int
+
+// String implements fmt.Stringer()
+func (u Underline) String() string {
+	if n, ok := underlineNames[u]; ok {
+		return n
+	}
+	return fmt.Sprintf("Underline:%d", u)
+}
+
+// underlineNames maps Underline values to human readable names.
+var underlineNames = map[Underline]string{
+	UnderlineNone:     "UnderlineNone",
+	UnderlineStraight: "UnderlineStraight",
+	UnderlineDouble:   "UnderlineDouble",
+	UnderlineCurly:    "UnderlineCurly",
+}
+
+// The supported underline styles.
+// Terminals that don't support a style draw a straight underline or no
+// underline at all.
+const (
+	UnderlineNone Underline = iota
+	UnderlineStraight
+	UnderlineDouble
+	UnderlineCurly
+)
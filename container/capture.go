@@ -0,0 +1,119 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// capture.go contains code that runs the event capture hooks of containers.
+
+import "github.com/mum4k/termdash/terminal/terminalapi"
+
+// KeyCaptureFn is a function that intercepts a keyboard event before it
+// reaches the widgets in the container and its sub containers.
+// Returning true indicates that the event was handled and stops its
+// propagation to these widgets.
+//
+// The function is called without holding any locks on the container tree, so
+// it can perform dynamic changes to the layout. The function must be thread
+// safe and shouldn't block.
+type KeyCaptureFn func(*terminalapi.Keyboard) bool
+
+// MouseCaptureFn is a function that intercepts a mouse event before it
+// reaches the widgets in the container and its sub containers.
+// Returning true indicates that the event was handled and stops its
+// propagation to these widgets.
+//
+// The mouse event contains the position on the terminal, it isn't relative to
+// the area of the container.
+//
+// The function is called without holding any locks on the container tree, so
+// it can perform dynamic changes to the layout. The function must be thread
+// safe and shouldn't block.
+type MouseCaptureFn func(*terminalapi.Mouse) bool
+
+// captureHook is a capture hook of a container that applies to an event.
+type captureHook struct {
+	// parent is the hook of the nearest ancestor container that has one, nil
+	// if there is no such container.
+	parent *captureHook
+	// capture runs the hook and reports if the event was handled.
+	capture func() bool
+	// handled indicates if either this hook or the hook of an ancestor
+	// handled the event.
+	handled bool
+}
+
+// consumed determines if the event shouldn't reach widgets under this hook.
+// A nil hook never consumes events.
+func (ch *captureHook) consumed() bool {
+	return ch != nil && ch.handled
+}
+
+// captureHooks are all the capture hooks that apply to an event.
+type captureHooks struct {
+	// ordered are the hooks in the order in which they are run, hooks of
+	// parent containers come before hooks of their sub containers.
+	ordered []*captureHook
+	// byCont maps containers to their hooks.
+	byCont map[*Container]*captureHook
+}
+
+// newCaptureHooks collects the capture hooks of the visible containers. The
+// hookFn returns the hook for the container or nil if the container doesn't
+// have a hook that applies to the event.
+// Caller must hold c.mu.
+func newCaptureHooks(c *Container, hookFn func(*Container) func() bool) *captureHooks {
+	hooks := &captureHooks{
+		byCont: map[*Container]*captureHook{},
+	}
+
+	var errStr string
+	preOrderVisible(visibleRoot(c), &errStr, visitFunc(func(cur *Container) error {
+		fn := hookFn(cur)
+		if fn == nil {
+			return nil
+		}
+		h := &captureHook{
+			parent:  hooks.nearest(cur.parent),
+			capture: fn,
+		}
+		hooks.ordered = append(hooks.ordered, h)
+		hooks.byCont[cur] = h
+		return nil
+	}))
+	return hooks
+}
+
+// nearest returns the hook of the container or of its nearest ancestor that
+// has one. Returns nil if there is no such container.
+func (ch *captureHooks) nearest(c *Container) *captureHook {
+	for cur := c; cur != nil; cur = cur.parent {
+		if h, ok := ch.byCont[cur]; ok {
+			return h
+		}
+	}
+	return nil
+}
+
+// run runs the hooks in order. Hooks under a hook that handled the event
+// aren't run.
+// Must be called without holding c.mu.
+func (ch *captureHooks) run() {
+	for _, h := range ch.ordered {
+		if h.parent.consumed() {
+			h.handled = true
+			continue
+		}
+		h.handled = h.capture()
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/mum4k/termdash/internal/alignfor"
 	"github.com/mum4k/termdash/internal/area"
 	"github.com/mum4k/termdash/internal/event"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
@@ -60,12 +61,40 @@ type Container struct {
 	// opts are the options provided to the container.
 	opts *options
 
+	// hidden indicates if this container and all of its sub containers were
+	// hidden by a call to SetVisible.
+	hidden bool
+
+	// zoomed is the container that was zoomed by a call to ToggleZoom and
+	// occupies the entire terminal. Only set on the root container, nil if no
+	// container is zoomed.
+	zoomed *Container
+
+	// borderRunes are the line characters of the borders drawn so far during
+	// the current draw, keyed by their position on the terminal. Only set on
+	// the root container, used to join borders that share a line.
+	borderRunes map[image.Point]rune
+
 	// clearNeeded indicates if the terminal needs to be cleared next time we
 	// are clearNeeded the container.
 	// This is required if the container was updated and thus the layout might
 	// have changed.
 	clearNeeded bool
 
+	// hints are the containers labeled in the hint mode keyed by their hints,
+	// see FocusHints. Only set on the root container, nil if the hint mode
+	// isn't active.
+	hints map[keyboard.Key]*Container
+
+	// tooltipAreas are the areas of the terminal occupied by the tooltips on
+	// the last call to Draw. Only set on the root container.
+	tooltipAreas []image.Rectangle
+
+	// changed indicates if the container tree changed since the last call to
+	// Draw, e.g. because it processed an event that might have moved the
+	// focus. Only set on the root container.
+	changed bool
+
 	// mu protects the container tree.
 	// All containers in the tree share the same lock.
 	mu *sync.Mutex
@@ -81,9 +110,10 @@ func (c *Container) String() string {
 // applies the provided options.
 func New(t terminalapi.Terminal, opts ...Option) (*Container, error) {
 	root := &Container{
-		term: t,
-		opts: newOptions( /* parent = */ nil),
-		mu:   &sync.Mutex{},
+		term:    t,
+		opts:    newOptions( /* parent = */ nil),
+		changed: true,
+		mu:      &sync.Mutex{},
 	}
 
 	// Initially the root is focused.
@@ -125,10 +155,33 @@ func (c *Container) hasWidget() bool {
 // usable returns the usable area in this container.
 // This depends on whether the container has a border, etc.
 func (c *Container) usable() image.Rectangle {
-	if c.hasBorder() {
+	if !c.hasBorder() {
+		return c.area
+	}
+	sides := c.opts.borderSides
+	if sides == allBorderSides {
 		return area.ExcludeBorder(c.area)
 	}
-	return c.area
+
+	// The border can't be drawn in a smaller area, see area.ExcludeBorder.
+	const minDim = 2
+	if c.area.Dx() < minDim || c.area.Dy() < minDim {
+		return image.ZR
+	}
+	us := c.area
+	if sides.has(BorderTop) {
+		us.Min.Y++
+	}
+	if sides.has(BorderRight) {
+		us.Max.X--
+	}
+	if sides.has(BorderBottom) {
+		us.Max.Y--
+	}
+	if sides.has(BorderLeft) {
+		us.Min.X++
+	}
+	return us
 }
 
 // widgetArea returns the area in the container that is available for the
@@ -165,16 +218,134 @@ func (c *Container) widgetArea() (image.Rectangle, error) {
 }
 
 // split splits the container's usable area into child areas.
+// If one of the sub containers is hidden, the other one gets the entire area.
+// The third returned area is the line between the child areas reserved for the
+// separator, it is empty when no separator should be drawn.
 // Panics if the container isn't configured for a split.
-func (c *Container) split() (image.Rectangle, image.Rectangle, error) {
+func (c *Container) split() (image.Rectangle, image.Rectangle, image.Rectangle, error) {
 	ar, err := c.opts.padding.apply(c.usable())
 	if err != nil {
-		return image.ZR, image.ZR, err
+		return image.ZR, image.ZR, image.ZR, err
 	}
+	if c.first != nil && c.first.hidden {
+		return image.ZR, ar, image.ZR, nil
+	}
+	if c.second != nil && c.second.hidden {
+		return ar, image.ZR, image.ZR, nil
+	}
+
+	compact := c.compactSplit()
+	sep := c.opts.separator != linestyle.None && !compact
 	if c.opts.split == splitTypeVertical {
+		if sep && ar.Dx() > 1 {
+			ar.Max.X--
+		} else {
+			sep = false
+		}
+		first, second, err := c.splitArea(ar)
+		if err != nil {
+			return image.ZR, image.ZR, image.ZR, err
+		}
+		if compact && !first.Empty() && !second.Empty() {
+			first.Max.X++
+		}
+		if !sep {
+			return first, second, image.ZR, nil
+		}
+		second = second.Add(image.Point{1, 0})
+		return first, second, image.Rect(second.Min.X-1, ar.Min.Y, second.Min.X, ar.Max.Y), nil
+	}
+
+	if sep && ar.Dy() > 1 {
+		ar.Max.Y--
+	} else {
+		sep = false
+	}
+	first, second, err := c.splitArea(ar)
+	if err != nil {
+		return image.ZR, image.ZR, image.ZR, err
+	}
+	if compact && !first.Empty() && !second.Empty() {
+		first.Max.Y++
+	}
+	if !sep {
+		return first, second, image.ZR, nil
+	}
+	second = second.Add(image.Point{0, 1})
+	return first, second, image.Rect(ar.Min.X, second.Min.Y-1, ar.Max.X, second.Min.Y), nil
+}
+
+// splitArea splits the area between the sub containers along the configured
+// split, according to the SplitPercent or the SplitFitFirst options.
+func (c *Container) splitArea(ar image.Rectangle) (image.Rectangle, image.Rectangle, error) {
+	cells, fit, err := c.fitFirst(ar)
+	if err != nil {
+		return image.ZR, image.ZR, err
+	}
+	switch {
+	case c.opts.split == splitTypeVertical && fit:
+		return area.VSplitCells(ar, cells)
+	case c.opts.split == splitTypeVertical:
 		return area.VSplit(ar, c.opts.splitPercent)
+	case fit:
+		return area.HSplitCells(ar, cells)
+	default:
+		return area.HSplit(ar, c.opts.splitPercent)
 	}
-	return area.HSplit(ar, c.opts.splitPercent)
+}
+
+// fitFirst returns the number of cells along the split the first sub
+// container needs to fit the content of its widget when the SplitFitFirst
+// option was provided. The returned bool is false if the size cannot be
+// measured.
+func (c *Container) fitFirst(ar image.Rectangle) (int, bool, error) {
+	f := c.first
+	if !c.opts.splitFitFirst || f == nil || !f.hasWidget() {
+		return 0, false, nil
+	}
+	m, ok := f.opts.widget.(widgetapi.Measurer)
+	if !ok {
+		return 0, false, nil
+	}
+
+	// Determine the space available to the widget if the first sub container
+	// occupied the entire area.
+	prev := f.area
+	defer func() { f.area = prev }()
+	fAr, err := f.opts.margin.apply(ar)
+	if err != nil {
+		return 0, false, err
+	}
+	f.area = fAr
+	inner, err := f.opts.padding.apply(f.usable())
+	if err != nil {
+		return 0, false, err
+	}
+	if inner.Empty() {
+		return 0, false, nil
+	}
+
+	if c.opts.split == splitTypeVertical {
+		size := m.Measure(image.Point{0, inner.Dy()})
+		return size.X + ar.Dx() - inner.Dx(), true, nil
+	}
+	size := m.Measure(image.Point{inner.Dx(), 0})
+	return size.Y + ar.Dy() - inner.Dy(), true, nil
+}
+
+// compactSplit determines if the sub containers of this container share the
+// border line along the split. Both of them must have the side of the border
+// along the split.
+func (c *Container) compactSplit() bool {
+	if !c.opts.compactBorders ||
+		c.first == nil || !c.first.hasBorder() ||
+		c.second == nil || !c.second.hasBorder() {
+		return false
+	}
+	if c.opts.split == splitTypeVertical {
+		return c.first.opts.borderSides.has(BorderRight) && c.second.opts.borderSides.has(BorderLeft)
+	}
+	return c.first.opts.borderSides.has(BorderBottom) && c.second.opts.borderSides.has(BorderTop)
 }
 
 // createFirst creates and returns the first sub container of this container.
@@ -197,7 +368,8 @@ func (c *Container) createSecond(opts []Option) error {
 	return nil
 }
 
-// Draw draws this container and all of its sub containers.
+// Draw draws this container and all of its sub containers. The tooltips of
+// the widgets are drawn last, see widgetapi.Tooltipper.
 func (c *Container) Draw() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -216,7 +388,46 @@ func (c *Container) Draw() error {
 		return err
 	}
 	c.focusTracker.updateArea(ar)
-	return drawTree(c)
+	rootCont(c).changed = false
+	if err := drawTree(c); err != nil {
+		return err
+	}
+	if err := drawHints(c); err != nil {
+		return err
+	}
+	return drawTooltips(c)
+}
+
+// Changed asserts whether drawing the container would produce a different
+// content than the last call to Draw, i.e. whether the layout changed, the
+// container processed any events or any of the visible widgets changed.
+// Widgets report their changes by implementing widgetapi.ChangeReporter,
+// widgets that don't implement it are assumed to always change.
+func (c *Container) Changed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	root := rootCont(c)
+	if root.changed || root.clearNeeded {
+		return true
+	}
+
+	var (
+		errStr  string
+		changed bool
+	)
+	preOrderVisible(visibleRoot(root), &errStr, visitFunc(func(cont *Container) error {
+		if !cont.hasWidget() {
+			return nil
+		}
+		for _, w := range cont.layered() {
+			if cr, ok := w.(widgetapi.ChangeReporter); !ok || cr.Changed() {
+				changed = true
+			}
+		}
+		return nil
+	}))
+	return changed
 }
 
 // Update updates container with the specified id by setting the provided
@@ -247,9 +458,106 @@ func (c *Container) Update(id string, opts ...Option) error {
 	if !c.focusTracker.reachableFrom(c) {
 		c.focusTracker.setActive(target)
 	}
+	// The zoomed container might not be reachable anymore either.
+	if z := c.zoomed; z != nil && !isReachable(c, z) {
+		c.zoomed = nil
+	}
 	return nil
 }
 
+// SetVisible shows or hides the container with the specified id along with
+// all of its sub containers. A hidden container yields its space, so its
+// sibling occupies the entire area of their parent container. Widgets in
+// hidden containers aren't drawn and don't receive any keyboard or mouse
+// events. If the focused container gets hidden, the focus moves to the parent
+// of the hidden container.
+// The argument id must match exactly one container with that was created with
+// matching ID() option. The argument id must not be an empty string. The root
+// container cannot be hidden.
+func (c *Container) SetVisible(id string, visible bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := findID(c, id)
+	if err != nil {
+		return err
+	}
+	if target.parent == nil && !visible {
+		return fmt.Errorf("the root container with ID %q cannot be hidden", id)
+	}
+	if target.hidden == !visible {
+		return nil
+	}
+	target.hidden = !visible
+	c.clearNeeded = true
+
+	if target.hidden && c.focusTracker.reachableFrom(target) {
+		c.focusTracker.setActive(target.parent)
+	}
+	if target.hidden && c.zoomed != nil && isReachable(target, c.zoomed) {
+		c.zoomed = nil
+	}
+	return nil
+}
+
+// ToggleZoom zooms the container with the specified id, so that it and its
+// sub containers occupy the entire terminal while all the other containers
+// aren't drawn and don't receive any keyboard or mouse events. Calling
+// ToggleZoom again with the same id restores the original layout, calling it
+// with the id of another container zooms that container instead. If the
+// focused container isn't within the zoomed one, the focus moves to the
+// zoomed container.
+// Hiding the zoomed container or one of its parents via SetVisible restores
+// the original layout.
+// The argument id must match exactly one container with that was created with
+// matching ID() option. The argument id must not be an empty string. The root
+// container and hidden containers cannot be zoomed.
+func (c *Container) ToggleZoom(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := findID(c, id)
+	if err != nil {
+		return err
+	}
+	if target.parent == nil {
+		return fmt.Errorf("the root container with ID %q cannot be zoomed", id)
+	}
+	c.clearNeeded = true
+
+	if c.zoomed == target {
+		c.zoomed = nil
+		return nil
+	}
+	for cur := target; cur != nil; cur = cur.parent {
+		if cur.hidden {
+			return fmt.Errorf("the container with ID %q is hidden and cannot be zoomed", id)
+		}
+	}
+	c.zoomed = target
+	if !c.focusTracker.reachableFrom(target) {
+		c.focusTracker.setActive(target)
+	}
+	return nil
+}
+
+// IDAt returns the ID of the top-most visible container whose area contains
+// the provided point on the terminal. If that container wasn't created with
+// the ID() option, returns the ID of its nearest ancestor that was. Returns
+// an empty string if there is no such container. The container areas are
+// only known once the container was drawn.
+func (c *Container) IDAt(p image.Point) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for cont := pointCont(c, p); cont != nil; cont = cont.parent {
+		if cont.opts.id != "" {
+			return cont.opts.id
+		}
+	}
+	return ""
+}
+
 // updateFocus processes the mouse event and determines if it changes the
 // focused container.
 // Caller must hold c.mu.
@@ -271,6 +579,7 @@ func (c *Container) processEvent(ev terminalapi.Event) error {
 	//    because some widgets might try to mutate the container when they
 	//    receive the event, like dynamically change the layout.
 	c.mu.Lock()
+	rootCont(c).changed = true
 	sendFn, err := c.prepareEvTargets(ev)
 	c.mu.Unlock()
 	if err != nil {
@@ -288,12 +597,23 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 	case *terminalapi.Mouse:
 		c.updateFocus(ev.(*terminalapi.Mouse))
 
-		targets, err := c.mouseEvTargets(e)
+		hooks := newCaptureHooks(c, func(cur *Container) func() bool {
+			fn := cur.opts.mouseCapture
+			if fn == nil || !e.Position.In(cur.area) {
+				return nil
+			}
+			return func() bool { return fn(e) }
+		})
+		targets, err := c.mouseEvTargets(e, hooks)
 		if err != nil {
 			return nil, err
 		}
 		return func() error {
+			hooks.run()
 			for _, mt := range targets {
+				if mt.hook.consumed() {
+					continue
+				}
 				if err := mt.widget.Mouse(mt.ev); err != nil {
 					return err
 				}
@@ -302,10 +622,36 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 		}, nil
 
 	case *terminalapi.Keyboard:
-		targets := c.keyEvTargets()
+		if c.hintEvent(e) {
+			return func() error { return nil }, nil
+		}
+
+		hooks := newCaptureHooks(c, func(cur *Container) func() bool {
+			fn := cur.opts.keyCapture
+			if fn == nil {
+				return nil
+			}
+			return func() bool { return fn(e) }
+		})
+		targets := c.keyEvTargets(e, hooks)
+		return func() error {
+			hooks.run()
+			for _, kt := range targets {
+				if kt.hook.consumed() {
+					continue
+				}
+				if err := kt.widget.Keyboard(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+
+	case *terminalapi.Custom:
+		targets := c.customEvTargets()
 		return func() error {
-			for _, w := range targets {
-				if err := w.Keyboard(e); err != nil {
+			for _, h := range targets {
+				if err := h.Custom(e); err != nil {
 					return err
 				}
 			}
@@ -317,35 +663,91 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 	}
 }
 
+// customEvTargets returns all the widgets in the container tree that handle
+// custom events, including widgets in hidden containers.
+// Caller must hold c.mu.
+func (c *Container) customEvTargets() []widgetapi.CustomHandler {
+	var (
+		errStr  string
+		widgets []widgetapi.CustomHandler
+	)
+	preOrder(rootCont(c), &errStr, visitFunc(func(cur *Container) error {
+		if !cur.hasWidget() {
+			return nil
+		}
+		for _, w := range cur.topDown() {
+			if h, ok := w.(widgetapi.CustomHandler); ok {
+				widgets = append(widgets, h)
+			}
+		}
+		return nil
+	}))
+	return widgets
+}
+
+// keyEvTarget is a widget that should receive a keyboard event.
+type keyEvTarget struct {
+	// widget is the widget that should receive the keyboard event.
+	widget widgetapi.Widget
+	// hook is the nearest capture hook that can stop the event from reaching
+	// the widget, nil if there is none.
+	hook *captureHook
+}
+
+// secondaryFocus returns the visible container that is the secondary focus
+// for the key or nil if there is none, see SecondaryFocusKeys.
+// Caller must hold c.mu.
+func (c *Container) secondaryFocus(k keyboard.Key) *Container {
+	var (
+		errStr string
+		found  *Container
+	)
+	preOrderVisible(visibleRoot(c), &errStr, visitFunc(func(cur *Container) error {
+		if found == nil && cur.hasWidget() && cur.opts.secondaryKeys[k] &&
+			cur.opts.widget.Options().WantKeyboard != widgetapi.KeyScopeNone {
+			found = cur
+		}
+		return nil
+	}))
+	return found
+}
+
 // keyEvTargets returns those widgets found in the container that should
 // receive this keyboard event.
 // Caller must hold c.mu.
-func (c *Container) keyEvTargets() []widgetapi.Widget {
+func (c *Container) keyEvTargets(k *terminalapi.Keyboard, hooks *captureHooks) []*keyEvTarget {
 	var (
 		errStr  string
-		widgets []widgetapi.Widget
+		widgets []*keyEvTarget
 	)
 
+	// Keys with a secondary focus don't reach the focused widget.
+	secondary := c.secondaryFocus(k.Key)
+
 	// All the widgets that should receive this event.
 	// For now stable ordering (preOrder).
-	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
+	preOrderVisible(visibleRoot(c), &errStr, visitFunc(func(cur *Container) error {
 		if !cur.hasWidget() {
 			return nil
 		}
 
-		wOpt := cur.opts.widget.Options()
-		switch wOpt.WantKeyboard {
-		case widgetapi.KeyScopeNone:
-			// Widget doesn't want any keyboard events.
-			return nil
+		for _, w := range cur.topDown() {
+			switch w.Options().WantKeyboard {
+			case widgetapi.KeyScopeNone:
+				// Widget doesn't want any keyboard events.
 
-		case widgetapi.KeyScopeFocused:
-			if cur.focusTracker.isActive(cur) {
-				widgets = append(widgets, cur.opts.widget)
-			}
+			case widgetapi.KeyScopeFocused:
+				active := cur.focusTracker.isActive(cur)
+				if secondary != nil {
+					active = cur == secondary
+				}
+				if active {
+					widgets = append(widgets, &keyEvTarget{w, hooks.nearest(cur)})
+				}
 
-		case widgetapi.KeyScopeGlobal:
-			widgets = append(widgets, cur.opts.widget)
+			case widgetapi.KeyScopeGlobal:
+				widgets = append(widgets, &keyEvTarget{w, hooks.nearest(cur)})
+			}
 		}
 		return nil
 	}))
@@ -359,20 +761,24 @@ type mouseEvTarget struct {
 	widget widgetapi.Widget
 	// ev is the adjusted mouse event.
 	ev *terminalapi.Mouse
+	// hook is the nearest capture hook that can stop the event from reaching
+	// the widget, nil if there is none.
+	hook *captureHook
 }
 
 // newMouseEvTarget returns a new newMouseEvTarget.
-func newMouseEvTarget(w widgetapi.Widget, wArea image.Rectangle, ev *terminalapi.Mouse) *mouseEvTarget {
+func newMouseEvTarget(w widgetapi.Widget, wArea image.Rectangle, ev *terminalapi.Mouse, hook *captureHook) *mouseEvTarget {
 	return &mouseEvTarget{
 		widget: w,
 		ev:     adjustMouseEv(ev, wArea),
+		hook:   hook,
 	}
 }
 
 // mouseEvTargets returns those widgets found in the container that should
 // receive this mouse event.
 // Caller must hold c.mu.
-func (c *Container) mouseEvTargets(m *terminalapi.Mouse) ([]*mouseEvTarget, error) {
+func (c *Container) mouseEvTargets(m *terminalapi.Mouse, hooks *captureHooks) ([]*mouseEvTarget, error) {
 	var (
 		errStr  string
 		widgets []*mouseEvTarget
@@ -380,37 +786,37 @@ func (c *Container) mouseEvTargets(m *terminalapi.Mouse) ([]*mouseEvTarget, erro
 
 	// All the widgets that should receive this event.
 	// For now stable ordering (preOrder).
-	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
+	preOrderVisible(visibleRoot(c), &errStr, visitFunc(func(cur *Container) error {
 		if !cur.hasWidget() {
 			return nil
 		}
 
-		wOpts := cur.opts.widget.Options()
 		wa, err := cur.widgetArea()
 		if err != nil {
 			return err
 		}
 
-		switch wOpts.WantMouse {
-		case widgetapi.MouseScopeNone:
-			// Widget doesn't want any mouse events.
-			return nil
+		for _, w := range cur.topDown() {
+			switch w.Options().WantMouse {
+			case widgetapi.MouseScopeNone:
+				// Widget doesn't want any mouse events.
 
-		case widgetapi.MouseScopeWidget:
-			// Only if the event falls inside of the widget's canvas.
-			if m.Position.In(wa) {
-				widgets = append(widgets, newMouseEvTarget(cur.opts.widget, wa, m))
-			}
+			case widgetapi.MouseScopeWidget:
+				// Only if the event falls inside of the widget's canvas.
+				if m.Position.In(wa) {
+					widgets = append(widgets, newMouseEvTarget(w, wa, m, hooks.nearest(cur)))
+				}
 
-		case widgetapi.MouseScopeContainer:
-			// Only if the event falls inside the widget's parent container.
-			if m.Position.In(cur.area) {
-				widgets = append(widgets, newMouseEvTarget(cur.opts.widget, wa, m))
-			}
+			case widgetapi.MouseScopeContainer:
+				// Only if the event falls inside the widget's parent container.
+				if m.Position.In(cur.area) {
+					widgets = append(widgets, newMouseEvTarget(w, wa, m, hooks.nearest(cur)))
+				}
 
-		case widgetapi.MouseScopeGlobal:
-			// Widget wants all mouse events.
-			widgets = append(widgets, newMouseEvTarget(cur.opts.widget, wa, m))
+			case widgetapi.MouseScopeGlobal:
+				// Widget wants all mouse events.
+				widgets = append(widgets, newMouseEvTarget(w, wa, m, hooks.nearest(cur)))
+			}
 		}
 		return nil
 	}))
@@ -437,6 +843,7 @@ func (c *Container) Subscribe(eds *event.DistributionSystem) {
 	want := []terminalapi.Event{
 		&terminalapi.Keyboard{},
 		&terminalapi.Mouse{},
+		&terminalapi.Custom{},
 	}
 	eds.Subscribe(want, func(ev terminalapi.Event) {
 		if err := c.processEvent(ev); err != nil {
@@ -445,6 +852,17 @@ func (c *Container) Subscribe(eds *event.DistributionSystem) {
 	}, event.MaxRepetitive(maxReps))
 }
 
+// ProcessEvent synchronously processes the keyboard, mouse or custom event on
+// behalf of the container and delivers it to the widgets that registered for
+// it.
+// Used when termdash is driven by an external event loop instead of being
+// subscribed to an event distribution system.
+// This method is private to termdash, stability isn't guaranteed and changes
+// won't be backward compatible.
+func (c *Container) ProcessEvent(ev terminalapi.Event) error {
+	return c.processEvent(ev)
+}
+
 // adjustMouseEv adjusts the mouse event relative to the widget area.
 func adjustMouseEv(m *terminalapi.Mouse, wArea image.Rectangle) *terminalapi.Mouse {
 	// The sent mouse coordinate is relative to the widget canvas, i.e. zero
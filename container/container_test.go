@@ -15,14 +15,17 @@
 package container
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
 	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/draw/testdraw"
@@ -36,6 +39,7 @@ import (
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/barchart"
+	"github.com/mum4k/termdash/widgets/gauge"
 )
 
 // Example demonstrates how to use the Container API.
@@ -91,6 +95,22 @@ func TestNew(t *testing.T) {
 			},
 			wantContainerErr: true,
 		},
+		{
+			desc:     "fails on BorderSides without any sides",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, BorderSides(0))
+			},
+			wantContainerErr: true,
+		},
+		{
+			desc:     "fails on BorderSides with an unknown side",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, BorderSides(BorderLeft<<1))
+			},
+			wantContainerErr: true,
+		},
 		{
 			desc:     "fails on invalid option on the first vertical child container",
 			termSize: image.Point{10, 10},
@@ -490,6 +510,20 @@ func TestNew(t *testing.T) {
 			},
 			wantContainerErr: true,
 		},
+		{
+			desc:     "fails when a secondary focus key is provided to multiple containers",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(SecondaryFocusKeys(keyboard.KeyArrowUp, 'a')),
+						Bottom(SecondaryFocusKeys('a')),
+					),
+				)
+			},
+			wantContainerErr: true,
+		},
 		{
 			desc:     "empty container",
 			termSize: image.Point{10, 10},
@@ -1057,6 +1091,140 @@ func TestKeyboard(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "keys with a secondary focus reach the secondary widget, other keys the focused widget",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							SecondaryFocusKeys(keyboard.KeyArrowUp),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the primary container.
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+				// Send the keyboard events.
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				// The focused widget receives the primary keys only.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+
+				// The secondary widget receives its keys without being focused.
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "focused widget receives keys of a secondary focus that isn't visible",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				c, err := New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							ID("secondary"),
+							SecondaryFocusKeys(keyboard.KeyArrowUp),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+					),
+				)
+				if err != nil {
+					return nil, err
+				}
+				if err := c.SetVisible("secondary", false); err != nil {
+					return nil, err
+				}
+				return c, nil
+			},
+			events: []terminalapi.Event{
+				// Move focus to the primary container.
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+				// Send the keyboard event.
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 40, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "secondary focus key isn't delivered twice when the secondary container is focused",
+			termSize: image.Point{40, 20},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Right(
+							SecondaryFocusKeys(keyboard.KeyArrowUp),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the secondary container.
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{39, 19}, Button: mouse.ButtonRelease},
+				// Send the keyboard event.
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 20)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(20, 0, 40, 20)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				)
+				return ft
+			},
+		},
 		{
 			desc:     "event forwarded to all widgets that requested global key scope",
 			termSize: image.Point{40, 20},
@@ -2367,3 +2535,1611 @@ func TestUpdate(t *testing.T) {
 	}
 
 }
+
+// visibility is a call to SetVisible.
+type visibility struct {
+	id      string
+	visible bool
+}
+
+func TestSetVisible(t *testing.T) {
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		// beforeEvents are events delivered before the calls to SetVisible.
+		beforeEvents []terminalapi.Event
+		// setVisible are the calls to SetVisible, executed in order.
+		setVisible []visibility
+		// afterEvents are events delivered after the calls to SetVisible.
+		afterEvents []terminalapi.Event
+		wantErr     bool
+		want        func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "fails on empty ID",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			setVisible: []visibility{{id: "", visible: false}},
+			wantErr:    true,
+		},
+		{
+			desc:     "fails when no container with the ID is found",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			setVisible: []visibility{{id: "myID", visible: false}},
+			wantErr:    true,
+		},
+		{
+			desc:     "fails when hiding the root container",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, ID("myRoot"))
+			},
+			setVisible: []visibility{{id: "myRoot", visible: false}},
+			wantErr:    true,
+		},
+		{
+			desc:     "showing the visible root container is a no-op",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, ID("myRoot"))
+			},
+			setVisible: []visibility{{id: "myRoot", visible: true}},
+		},
+		{
+			desc:     "hidden left container yields its space to the right one",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			setVisible: []visibility{{id: "left", visible: false}},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "hidden bottom container with sub containers yields its space to the top one",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Bottom(
+							ID("bottom"),
+							SplitVertical(
+								Left(
+									PlaceWidget(fakewidget.New(widgetapi.Options{})),
+								),
+								Right(
+									PlaceWidget(fakewidget.New(widgetapi.Options{})),
+								),
+							),
+						),
+					),
+				)
+			},
+			setVisible: []visibility{{id: "bottom", visible: false}},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "shown container gets its space back",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			setVisible: []visibility{
+				{id: "left", visible: false},
+				{id: "left", visible: true},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 10, 10)), &widgetapi.Meta{}, widgetapi.Options{})
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(10, 0, 20, 10)), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "hiding both sub containers leaves the parent empty",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					SplitVertical(
+						Left(
+							ID("left"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							ID("right"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			setVisible: []visibility{
+				{id: "left", visible: false},
+				{id: "right", visible: false},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "widgets in hidden containers don't receive keyboard events",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+					),
+				)
+			},
+			setVisible: []visibility{
+				{id: "left", visible: false},
+			},
+			afterEvents: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "mouse events in the space of a hidden container go to its sibling",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})),
+						),
+					),
+				)
+			},
+			setVisible: []visibility{
+				{id: "left", visible: false},
+			},
+			afterEvents: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "focus moves to the parent when the focused container is hidden",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					SplitVertical(
+						Left(
+							ID("left"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			beforeEvents: []terminalapi.Event{
+				// Move focus to the left container.
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+			},
+			setVisible: []visibility{
+				{id: "left", visible: false},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(1, 1, 19, 9)), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			cont, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			eh := &errorHandler{}
+			// Subscribe to receive errors.
+			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
+				eh.handle(ev.(*terminalapi.Error).Error())
+			})
+			cont.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			// Deliver the before events.
+			for _, ev := range tc.beforeEvents {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.beforeEvents); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			for _, v := range tc.setVisible {
+				err := cont.SetVisible(v.id, v.visible)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("SetVisible(%q, %v) => unexpected error:%v, wantErr:%v", v.id, v.visible, err, tc.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+			// Draw to determine the new sizes of containers.
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			// Deliver the after events.
+			for _, ev := range tc.afterEvents {
+				eds.Event(ev)
+			}
+			wantEv := len(tc.beforeEvents) + len(tc.afterEvents)
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), wantEv; got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+			if err := eh.get(); err != nil {
+				t.Fatalf("the event distribution system reported an error: %v", err)
+			}
+
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			var want *faketerm.Terminal
+			if tc.want != nil {
+				want = tc.want(tc.termSize)
+			} else {
+				w, err := faketerm.New(tc.termSize)
+				if err != nil {
+					t.Fatalf("faketerm.New => unexpected error: %v", err)
+				}
+				want = w
+			}
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+// drawCounter is a widget that counts the calls to Draw.
+type drawCounter struct {
+	*fakewidget.Mirror
+
+	mu    sync.Mutex
+	draws int
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (dc *drawCounter) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	dc.mu.Lock()
+	dc.draws++
+	dc.mu.Unlock()
+	return dc.Mirror.Draw(cvs, meta)
+}
+
+// count returns the number of calls to Draw.
+func (dc *drawCounter) count() int {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	return dc.draws
+}
+
+func TestSetVisibleStopsDrawing(t *testing.T) {
+	ft, err := faketerm.New(image.Point{20, 10})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+
+	dc := &drawCounter{Mirror: fakewidget.New(widgetapi.Options{})}
+	cont, err := New(
+		ft,
+		SplitVertical(
+			Left(
+				ID("left"),
+				SplitHorizontal(
+					Top(PlaceWidget(dc)),
+					Bottom(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+				),
+			),
+			Right(
+				PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	steps := []struct {
+		desc string
+		// visible is the visibility set before the draw.
+		visible bool
+		want    int
+	}{
+		{desc: "visible widget is drawn", visible: true, want: 1},
+		{desc: "hidden widget isn't drawn", visible: false, want: 1},
+		{desc: "widget is still hidden", visible: false, want: 1},
+		{desc: "widget is drawn again once visible", visible: true, want: 2},
+	}
+	for _, s := range steps {
+		if err := cont.SetVisible("left", s.visible); err != nil {
+			t.Fatalf("%s: SetVisible => unexpected error: %v", s.desc, err)
+		}
+		if err := cont.Draw(); err != nil {
+			t.Fatalf("%s: Draw => unexpected error: %v", s.desc, err)
+		}
+		if got := dc.count(); got != s.want {
+			t.Errorf("%s: the widget was drawn %d times, want %d", s.desc, got, s.want)
+		}
+	}
+}
+
+func TestToggleZoom(t *testing.T) {
+	// twoWidgets returns a container split vertically into two widget
+	// containers with the IDs "left" and "right".
+	twoWidgets := func(ft *faketerm.Terminal, wOpts widgetapi.Options, opts ...Option) (*Container, error) {
+		return New(
+			ft,
+			append(opts,
+				SplitVertical(
+					Left(
+						ID("left"),
+						PlaceWidget(fakewidget.New(wOpts)),
+					),
+					Right(
+						ID("right"),
+						PlaceWidget(fakewidget.New(wOpts)),
+					),
+				),
+			)...,
+		)
+	}
+
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		// beforeVisible are the calls to SetVisible executed before the calls
+		// to ToggleZoom.
+		beforeVisible []visibility
+		// toggles are the IDs passed to calls to ToggleZoom, executed in order.
+		toggles []string
+		// afterVisible are the calls to SetVisible executed after the calls
+		// to ToggleZoom.
+		afterVisible []visibility
+		// events are delivered after the calls to ToggleZoom and SetVisible.
+		events  []terminalapi.Event
+		wantErr bool
+		want    func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "fails on empty ID",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			toggles: []string{""},
+			wantErr: true,
+		},
+		{
+			desc:     "fails when no container with the ID is found",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft)
+			},
+			toggles: []string{"myID"},
+			wantErr: true,
+		},
+		{
+			desc:     "fails when zooming the root container",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, ID("myRoot"))
+			},
+			toggles: []string{"myRoot"},
+			wantErr: true,
+		},
+		{
+			desc:     "fails when zooming a hidden container",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return twoWidgets(ft, widgetapi.Options{})
+			},
+			beforeVisible: []visibility{{id: "left", visible: false}},
+			toggles:       []string{"left"},
+			wantErr:       true,
+		},
+		{
+			desc:     "zoomed container hides its sibling and occupies the full area",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return twoWidgets(ft, widgetapi.Options{})
+			},
+			toggles: []string{"left"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{Focused: true}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "zoomed container ignores the border and margin of the root",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return twoWidgets(ft, widgetapi.Options{}, Border(linestyle.Light), MarginTop(1))
+			},
+			toggles: []string{"right"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{Focused: true}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "zoomed container draws its sub containers",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							ID("right"),
+							SplitHorizontal(
+								Top(
+									PlaceWidget(fakewidget.New(widgetapi.Options{})),
+								),
+								Bottom(
+									PlaceWidget(fakewidget.New(widgetapi.Options{})),
+								),
+							),
+						),
+					),
+				)
+			},
+			toggles: []string{"right"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 20, 5)), &widgetapi.Meta{}, widgetapi.Options{})
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 5, 20, 10)), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "un-zooming restores the original layout",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return twoWidgets(ft, widgetapi.Options{})
+			},
+			toggles: []string{"left", "left"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 10, 10)), &widgetapi.Meta{Focused: true}, widgetapi.Options{})
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(10, 0, 20, 10)), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "hiding the zoomed container restores the original layout",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return twoWidgets(ft, widgetapi.Options{})
+			},
+			toggles:      []string{"left"},
+			afterVisible: []visibility{{id: "left", visible: false}},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "focus moves to the zoomed container",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							Border(linestyle.Light),
+						),
+						Right(),
+					),
+				)
+			},
+			toggles: []string{"left"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					ft.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "widgets outside of the zoomed container don't receive keyboard events",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return twoWidgets(ft, widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})
+			},
+			toggles: []string{"left"},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "mouse events in the space of a sibling go to the zoomed container",
+			termSize: image.Point{40, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return twoWidgets(ft, widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget})
+			},
+			toggles: []string{"left"},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{30, 5}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{30, 5}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&terminalapi.Mouse{Position: image.Point{30, 5}, Button: mouse.ButtonRelease},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			cont, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			eh := &errorHandler{}
+			// Subscribe to receive errors.
+			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
+				eh.handle(ev.(*terminalapi.Error).Error())
+			})
+			cont.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, v := range tc.beforeVisible {
+				if err := cont.SetVisible(v.id, v.visible); err != nil {
+					t.Fatalf("SetVisible(%q, %v) => unexpected error: %v", v.id, v.visible, err)
+				}
+			}
+			for _, id := range tc.toggles {
+				err := cont.ToggleZoom(id)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("ToggleZoom(%q) => unexpected error:%v, wantErr:%v", id, err, tc.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+			for _, v := range tc.afterVisible {
+				if err := cont.SetVisible(v.id, v.visible); err != nil {
+					t.Fatalf("SetVisible(%q, %v) => unexpected error: %v", v.id, v.visible, err)
+				}
+			}
+			// Draw to determine the new sizes of containers.
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+			if err := eh.get(); err != nil {
+				t.Fatalf("the event distribution system reported an error: %v", err)
+			}
+
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			var want *faketerm.Terminal
+			if tc.want != nil {
+				want = tc.want(tc.termSize)
+			} else {
+				want = faketerm.MustNew(tc.termSize)
+			}
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+// captureRecorder records the calls to capture hooks.
+type captureRecorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+// key returns a KeyCaptureFn that records its name and returns handled.
+func (cr *captureRecorder) key(name string, handled bool) KeyCaptureFn {
+	return func(*terminalapi.Keyboard) bool {
+		cr.record(name)
+		return handled
+	}
+}
+
+// mouse returns a MouseCaptureFn that records its name and returns handled.
+func (cr *captureRecorder) mouse(name string, handled bool) MouseCaptureFn {
+	return func(*terminalapi.Mouse) bool {
+		cr.record(name)
+		return handled
+	}
+}
+
+// record records a call to the named hook.
+func (cr *captureRecorder) record(name string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.calls = append(cr.calls, name)
+}
+
+// get returns the recorded calls.
+func (cr *captureRecorder) get() []string {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	return cr.calls
+}
+
+func TestCaptureHooks(t *testing.T) {
+	keyOpts := widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal}
+	mouseOpts := widgetapi.Options{WantMouse: widgetapi.MouseScopeGlobal}
+
+	tests := []struct {
+		desc string
+		// container returns the container, its capture hooks record their
+		// calls in the recorder.
+		container func(ft *faketerm.Terminal, cr *captureRecorder) (*Container, error)
+		events    []terminalapi.Event
+		want      func(size image.Point) *faketerm.Terminal
+		wantCalls []string
+	}{
+		{
+			desc: "handled keyboard event doesn't reach the widgets in the container",
+			container: func(ft *faketerm.Terminal, cr *captureRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							OnKeyCapture(cr.key("left", true)),
+							PlaceWidget(fakewidget.New(keyOpts)),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(keyOpts)),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 20, 10)), &widgetapi.Meta{}, keyOpts)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(20, 0, 40, 10)), &widgetapi.Meta{}, keyOpts,
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+			wantCalls: []string{"left"},
+		},
+		{
+			desc: "keyboard event that isn't handled propagates to the widgets",
+			container: func(ft *faketerm.Terminal, cr *captureRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							OnKeyCapture(cr.key("left", false)),
+							PlaceWidget(fakewidget.New(keyOpts)),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(keyOpts)),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 20, 10)), &widgetapi.Meta{}, keyOpts,
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(20, 0, 40, 10)), &widgetapi.Meta{}, keyOpts,
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+			wantCalls: []string{"left"},
+		},
+		{
+			desc: "keyboard event handled by a parent doesn't reach hooks of its sub containers",
+			container: func(ft *faketerm.Terminal, cr *captureRecorder) (*Container, error) {
+				return New(
+					ft,
+					OnKeyCapture(cr.key("root", true)),
+					SplitVertical(
+						Left(
+							OnKeyCapture(cr.key("left", false)),
+							PlaceWidget(fakewidget.New(keyOpts)),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(keyOpts)),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 20, 10)), &widgetapi.Meta{}, keyOpts)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(20, 0, 40, 10)), &widgetapi.Meta{}, keyOpts)
+				return ft
+			},
+			wantCalls: []string{"root"},
+		},
+		{
+			desc: "hooks are called from the parent down to the sub containers",
+			container: func(ft *faketerm.Terminal, cr *captureRecorder) (*Container, error) {
+				return New(
+					ft,
+					OnKeyCapture(cr.key("root", false)),
+					SplitVertical(
+						Left(
+							OnKeyCapture(cr.key("left", false)),
+							PlaceWidget(fakewidget.New(keyOpts)),
+						),
+						Right(
+							OnKeyCapture(cr.key("right", true)),
+							PlaceWidget(fakewidget.New(keyOpts)),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 20, 10)), &widgetapi.Meta{}, keyOpts,
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(20, 0, 40, 10)), &widgetapi.Meta{}, keyOpts)
+				return ft
+			},
+			wantCalls: []string{"root", "left", "right"},
+		},
+		{
+			desc: "handled mouse event doesn't reach the widgets in the container",
+			container: func(ft *faketerm.Terminal, cr *captureRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							OnMouseCapture(cr.mouse("left", true)),
+							PlaceWidget(fakewidget.New(mouseOpts)),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(mouseOpts)),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{5, 5}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 20, 10)), &widgetapi.Meta{}, mouseOpts)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(20, 0, 40, 10)), &widgetapi.Meta{}, mouseOpts,
+					&terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+			wantCalls: []string{"left"},
+		},
+		{
+			desc: "mouse event that isn't handled propagates to the widgets",
+			container: func(ft *faketerm.Terminal, cr *captureRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							OnMouseCapture(cr.mouse("left", false)),
+							PlaceWidget(fakewidget.New(mouseOpts)),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(mouseOpts)),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{5, 5}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 20, 10)), &widgetapi.Meta{}, mouseOpts,
+					&terminalapi.Mouse{Position: image.Point{5, 5}, Button: mouse.ButtonLeft},
+				)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(20, 0, 40, 10)), &widgetapi.Meta{}, mouseOpts,
+					&terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+			wantCalls: []string{"left"},
+		},
+		{
+			desc: "mouse hook isn't called for events outside of the container",
+			container: func(ft *faketerm.Terminal, cr *captureRecorder) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							OnMouseCapture(cr.mouse("left", true)),
+							PlaceWidget(fakewidget.New(mouseOpts)),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(mouseOpts)),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{25, 5}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 20, 10)), &widgetapi.Meta{}, mouseOpts,
+					&terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+				)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(20, 0, 40, 10)), &widgetapi.Meta{}, mouseOpts,
+					&terminalapi.Mouse{Position: image.Point{5, 5}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			size := image.Point{40, 10}
+			got, err := faketerm.New(size)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			cr := &captureRecorder{}
+			cont, err := tc.container(got, cr)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			eh := &errorHandler{}
+			// Subscribe to receive errors.
+			eds.Subscribe([]terminalapi.Event{terminalapi.NewError("")}, func(ev terminalapi.Event) {
+				eh.handle(ev.(*terminalapi.Error).Error())
+			})
+			cont.Subscribe(eds)
+			// Initial draw to determine sizes of containers.
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+			if err := eh.get(); err != nil {
+				t.Fatalf("the event distribution system reported an error: %v", err)
+			}
+
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(size), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+			if diff := pretty.Compare(tc.wantCalls, cr.get()); diff != "" {
+				t.Errorf("capture hook calls => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIDAt(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 10})
+	cont, err := New(
+		ft,
+		ID("root"),
+		SplitVertical(
+			Left(
+				ID("left"),
+			),
+			Right(
+				SplitHorizontal(
+					Top(
+						ID("top"),
+					),
+					Bottom(),
+				),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc  string
+		point image.Point
+		want  string
+	}{
+		{
+			desc:  "point in a container with ID",
+			point: image.Point{2, 2},
+			want:  "left",
+		},
+		{
+			desc:  "point in a nested container with ID",
+			point: image.Point{15, 2},
+			want:  "top",
+		},
+		{
+			desc:  "point in a container without ID reports the nearest ancestor with ID",
+			point: image.Point{15, 8},
+			want:  "root",
+		},
+		{
+			desc:  "point outside of the terminal",
+			point: image.Point{30, 30},
+			want:  "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := cont.IDAt(tc.point); got != tc.want {
+				t.Errorf("IDAt(%v) => %q, want %q", tc.point, got, tc.want)
+			}
+		})
+	}
+}
+
+// reportingWidget is a widget that reports changes as configured.
+type reportingWidget struct {
+	*fakewidget.Mirror
+
+	// mu protects changed.
+	mu sync.Mutex
+	// changed is the value returned by Changed.
+	changed bool
+}
+
+// set sets the value returned by Changed.
+func (rw *reportingWidget) set(changed bool) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.changed = changed
+}
+
+// Changed implements widgetapi.ChangeReporter.Changed.
+func (rw *reportingWidget) Changed() bool {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	return rw.changed
+}
+
+func TestChanged(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 10})
+	left := &reportingWidget{
+		Mirror: fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused}),
+	}
+	right := &reportingWidget{
+		Mirror: fakewidget.New(widgetapi.Options{}),
+	}
+	cont, err := New(
+		ft,
+		SplitVertical(
+			Left(
+				PlaceWidget(left),
+			),
+			Right(
+				ID("right"),
+				PlaceWidget(right),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	// mustDraw draws the container.
+	mustDraw := func() {
+		t.Helper()
+		if err := cont.Draw(); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+	}
+
+	if !cont.Changed() {
+		t.Errorf("Changed => false before the first Draw, want true")
+	}
+	mustDraw()
+	if cont.Changed() {
+		t.Errorf("Changed => true after Draw, want false")
+	}
+
+	right.set(true)
+	if !cont.Changed() {
+		t.Errorf("Changed => false when a widget changed, want true")
+	}
+	right.set(false)
+
+	if err := cont.ProcessEvent(&terminalapi.Keyboard{Key: keyboard.KeyEnter}); err != nil {
+		t.Fatalf("ProcessEvent => unexpected error: %v", err)
+	}
+	if !cont.Changed() {
+		t.Errorf("Changed => false after processing an event, want true")
+	}
+	mustDraw()
+
+	if err := cont.SetVisible("right", false); err != nil {
+		t.Fatalf("SetVisible => unexpected error: %v", err)
+	}
+	if !cont.Changed() {
+		t.Errorf("Changed => false after the layout changed, want true")
+	}
+	mustDraw()
+	right.set(true)
+	if cont.Changed() {
+		t.Errorf("Changed => true when only a hidden widget changed, want false")
+	}
+
+	if err := cont.SetVisible("right", true); err != nil {
+		t.Fatalf("SetVisible => unexpected error: %v", err)
+	}
+	right.set(false)
+	if err := cont.Update("right", PlaceWidget(fakewidget.New(widgetapi.Options{}))); err != nil {
+		t.Fatalf("Update => unexpected error: %v", err)
+	}
+	mustDraw()
+	if !cont.Changed() {
+		t.Errorf("Changed => false with a widget that doesn't implement widgetapi.ChangeReporter, want true")
+	}
+}
+
+// customWidget is a widget that records the custom events it receives.
+type customWidget struct {
+	*fakewidget.Mirror
+
+	// err is returned from Custom.
+	err error
+
+	// mu protects got.
+	mu sync.Mutex
+	// got are the names of the received events.
+	got []string
+}
+
+// Custom implements widgetapi.CustomHandler.Custom.
+func (cw *customWidget) Custom(ev *terminalapi.Custom) error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	cw.got = append(cw.got, ev.Name)
+	return cw.err
+}
+
+// received returns the names of the received events.
+func (cw *customWidget) received() []string {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	return cw.got
+}
+
+func TestCustomEvents(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 10})
+	left := &customWidget{
+		Mirror: fakewidget.New(widgetapi.Options{}),
+	}
+	hidden := &customWidget{
+		Mirror: fakewidget.New(widgetapi.Options{}),
+	}
+	other := fakewidget.New(widgetapi.Options{
+		WantKeyboard: widgetapi.KeyScopeGlobal,
+		WantMouse:    widgetapi.MouseScopeGlobal,
+	})
+	cont, err := New(
+		ft,
+		SplitVertical(
+			Left(
+				PlaceWidget(left),
+			),
+			Right(
+				SplitHorizontal(
+					Top(
+						ID("hidden"),
+						PlaceWidget(hidden),
+					),
+					Bottom(
+						PlaceWidget(other),
+					),
+				),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.SetVisible("hidden", false); err != nil {
+		t.Fatalf("SetVisible => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"refreshed", "reloaded"} {
+		if err := cont.ProcessEvent(&terminalapi.Custom{Name: name}); err != nil {
+			t.Fatalf("ProcessEvent => unexpected error: %v", err)
+		}
+	}
+
+	want := []string{"refreshed", "reloaded"}
+	if diff := pretty.Compare(want, left.received()); diff != "" {
+		t.Errorf("custom events received by the widget => unexpected diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare(want, hidden.received()); diff != "" {
+		t.Errorf("custom events received by the hidden widget => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	left.err = errors.New("custom error")
+	if err := cont.ProcessEvent(&terminalapi.Custom{Name: "failed"}); err == nil {
+		t.Errorf("ProcessEvent => got nil error, want the error returned by the widget")
+	}
+}
+
+func TestDimUnfocused(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 10})
+	dim := cell.ColorNumber(236)
+	cont, err := New(
+		ft,
+		DimUnfocused(dim),
+		SplitVertical(
+			Left(
+				PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			),
+			Right(
+				PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	// click focuses the container at the point.
+	click := func(p image.Point) {
+		t.Helper()
+		for _, ev := range []*terminalapi.Mouse{
+			{Position: p, Button: mouse.ButtonLeft},
+			{Position: p, Button: mouse.ButtonRelease},
+		} {
+			if err := cont.ProcessEvent(ev); err != nil {
+				t.Fatalf("ProcessEvent => unexpected error: %v", err)
+			}
+		}
+	}
+
+	steps := []struct {
+		desc string
+		// focus is the point clicked to move the focus, nil keeps the
+		// focus on the root container.
+		focus     *image.Point
+		wantLeft  bool // Whether the left half is dimmed.
+		wantRight bool // Whether the right half is dimmed.
+	}{
+		{
+			desc: "nothing dimmed when the root is focused",
+		},
+		{
+			desc:      "focusing the left widget dims the right one",
+			focus:     &image.Point{1, 1},
+			wantRight: true,
+		},
+		{
+			desc:     "focus moving to the right widget dims the left one",
+			focus:    &image.Point{15, 5},
+			wantLeft: true,
+		},
+	}
+
+	for _, step := range steps {
+		if step.focus != nil {
+			click(*step.focus)
+		}
+		if err := cont.Draw(); err != nil {
+			t.Fatalf("%s: Draw => unexpected error: %v", step.desc, err)
+		}
+
+		buf := ft.BackBuffer()
+		for x := range buf {
+			for y := range buf[x] {
+				want := step.wantLeft
+				if x >= 10 {
+					want = step.wantRight
+				}
+				if got := buf[x][y].Opts.BgColor == dim; got != want {
+					t.Errorf("%s: cell {%d,%d} dimmed => %v, want %v", step.desc, x, y, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDimUnfocusedKeepsWidgetBackground(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{20, 10})
+	dim := cell.ColorNumber(236)
+	g, err := gauge.New()
+	if err != nil {
+		t.Fatalf("gauge.New => unexpected error: %v", err)
+	}
+	if err := g.Percent(50); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	cont, err := New(
+		ft,
+		DimUnfocused(dim),
+		SplitVertical(
+			Left(
+				PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			),
+			Right(
+				PlaceWidget(g),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	// rightCells counts the cells in the right half that have the gauge
+	// color and the dim color as their background.
+	rightCells := func() (filled, dimmed int) {
+		buf := ft.BackBuffer()
+		for x := 10; x < len(buf); x++ {
+			for y := range buf[x] {
+				switch buf[x][y].Opts.BgColor {
+				case gauge.DefaultColor:
+					filled++
+				case dim:
+					dimmed++
+				}
+			}
+		}
+		return filled, dimmed
+	}
+
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	wantFilled, _ := rightCells()
+	if wantFilled == 0 {
+		t.Fatalf("the gauge drew no progress with the %v background", gauge.DefaultColor)
+	}
+
+	for _, ev := range []*terminalapi.Mouse{
+		{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+		{Position: image.Point{1, 1}, Button: mouse.ButtonRelease},
+	} {
+		if err := cont.ProcessEvent(ev); err != nil {
+			t.Fatalf("ProcessEvent => unexpected error: %v", err)
+		}
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	filled, dimmed := rightCells()
+	if filled != wantFilled {
+		t.Errorf("dimmed gauge has %d filled cells, want %d", filled, wantFilled)
+	}
+	if want := 10*10 - wantFilled; dimmed != want {
+		t.Errorf("dimmed gauge has %d dimmed cells, want %d", dimmed, want)
+	}
+}
+
+// measuredWidget is a fake widget that reports a fixed size of its content.
+type measuredWidget struct {
+	*fakewidget.Mirror
+	size image.Point
+	// constraint is the constraint of the last call to Measure.
+	constraint image.Point
+}
+
+// Measure implements widgetapi.Measurer.Measure.
+func (mw *measuredWidget) Measure(constraint image.Point) image.Point {
+	mw.constraint = constraint
+	return mw.size
+}
+
+func TestSplitFitFirst(t *testing.T) {
+	tests := []struct {
+		desc           string
+		size           image.Point
+		split          func(first widgetapi.Widget) Option
+		wantFirst      image.Rectangle
+		wantSecond     image.Rectangle
+		wantConstraint image.Point
+	}{
+		{
+			desc: "vertical split fits the width of the content",
+			size: image.Point{8, 2},
+			split: func(first widgetapi.Widget) Option {
+				return SplitVertical(
+					Left(PlaceWidget(first)),
+					Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					SplitFitFirst(),
+				)
+			},
+			wantFirst:      image.Rect(0, 0, 8, 10),
+			wantSecond:     image.Rect(8, 0, 40, 10),
+			wantConstraint: image.Point{0, 10},
+		},
+		{
+			desc: "horizontal split fits the height of the content",
+			size: image.Point{30, 4},
+			split: func(first widgetapi.Widget) Option {
+				return SplitHorizontal(
+					Top(PlaceWidget(first)),
+					Bottom(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					SplitFitFirst(),
+				)
+			},
+			wantFirst:      image.Rect(0, 0, 40, 4),
+			wantSecond:     image.Rect(0, 4, 40, 10),
+			wantConstraint: image.Point{40, 0},
+		},
+		{
+			desc: "adds the border and padding of the first container",
+			size: image.Point{30, 4},
+			split: func(first widgetapi.Widget) Option {
+				return SplitHorizontal(
+					Top(
+						PlaceWidget(first),
+						Border(linestyle.Light),
+						PaddingLeft(1),
+						PaddingTop(1),
+					),
+					Bottom(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					SplitFitFirst(),
+				)
+			},
+			wantFirst:      image.Rect(0, 0, 40, 7),
+			wantSecond:     image.Rect(0, 7, 40, 10),
+			wantConstraint: image.Point{37, 0},
+		},
+		{
+			desc: "content larger than the area leaves no space to the second container",
+			size: image.Point{30, 40},
+			split: func(first widgetapi.Widget) Option {
+				return SplitHorizontal(
+					Top(PlaceWidget(first)),
+					Bottom(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					SplitFitFirst(),
+				)
+			},
+			wantFirst:      image.Rect(0, 0, 40, 10),
+			wantSecond:     image.ZR,
+			wantConstraint: image.Point{40, 0},
+		},
+		{
+			desc: "uses the split percentage without a measured widget",
+			split: func(first widgetapi.Widget) Option {
+				return SplitVertical(
+					Left(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+					Right(PlaceWidget(first)),
+					SplitFitFirst(),
+					SplitPercent(30),
+				)
+			},
+			wantFirst:  image.Rect(0, 0, 12, 10),
+			wantSecond: image.Rect(12, 0, 40, 10),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(image.Point{40, 10})
+			mw := &measuredWidget{
+				Mirror: fakewidget.New(widgetapi.Options{}),
+				size:   tc.size,
+			}
+			cont, err := New(ft, tc.split(mw))
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if got := cont.first.area; !got.Eq(tc.wantFirst) {
+				t.Errorf("first area => %v, want %v", got, tc.wantFirst)
+			}
+			if got := cont.second.area; !got.Eq(tc.wantSecond) {
+				t.Errorf("second area => %v, want %v", got, tc.wantSecond)
+			}
+			if got := mw.constraint; got != tc.wantConstraint {
+				t.Errorf("Measure called with constraint %v, want %v", got, tc.wantConstraint)
+			}
+		})
+	}
+}
@@ -33,32 +33,25 @@ func drawTree(c *Container) error {
 	var errStr string
 
 	root := rootCont(c)
+	// When a container is zoomed, only its subtree is drawn and it occupies
+	// the entire terminal.
+	top := visibleRoot(root)
 	size := root.term.Size()
-	ar, err := root.opts.margin.apply(image.Rect(0, 0, size.X, size.Y))
+	ar, err := top.opts.margin.apply(image.Rect(0, 0, size.X, size.Y))
 	if err != nil {
 		return err
 	}
-	root.area = ar
+	top.area = ar
+	root.borderRunes = map[image.Point]rune{}
+	defer func() { root.borderRunes = nil }()
 
-	preOrder(root, &errStr, visitFunc(func(c *Container) error {
-		first, second, err := c.split()
+	preOrderVisible(top, &errStr, visitFunc(func(c *Container) error {
+		sep, err := layoutChildren(c)
 		if err != nil {
 			return err
 		}
-		if c.first != nil {
-			ar, err := c.first.opts.margin.apply(first)
-			if err != nil {
-				return err
-			}
-			c.first.area = ar
-		}
-
-		if c.second != nil {
-			ar, err := c.second.opts.margin.apply(second)
-			if err != nil {
-				return err
-			}
-			c.second.area = ar
+		if err := drawSeparator(c, sep); err != nil {
+			return err
 		}
 		return drawCont(c)
 	}))
@@ -68,6 +61,32 @@ func drawTree(c *Container) error {
 	return nil
 }
 
+// layoutChildren splits the area of the container and sets the areas of its
+// visible sub containers. Returns the area of the separator between them,
+// which is empty if there is no separator.
+func layoutChildren(c *Container) (image.Rectangle, error) {
+	first, second, sep, err := c.split()
+	if err != nil {
+		return image.ZR, err
+	}
+	if c.first != nil && !c.first.hidden {
+		ar, err := c.first.opts.margin.apply(first)
+		if err != nil {
+			return image.ZR, err
+		}
+		c.first.area = ar
+	}
+
+	if c.second != nil && !c.second.hidden {
+		ar, err := c.second.opts.margin.apply(second)
+		if err != nil {
+			return image.ZR, err
+		}
+		c.second.area = ar
+	}
+	return sep, nil
+}
+
 // drawBorder draws the border around the container if requested.
 func drawBorder(c *Container) error {
 	if !c.hasBorder() {
@@ -93,15 +112,97 @@ func drawBorder(c *Container) error {
 
 	if err := draw.Border(cvs, ar,
 		draw.BorderLineStyle(c.opts.border),
+		draw.BorderCornerStyle(c.opts.borderCorners),
+		draw.BorderSides(c.opts.borderSides.sidesSet()),
 		draw.BorderTitle(c.opts.borderTitle, draw.OverrunModeThreeDot, cOpts...),
 		draw.BorderTitleAlign(c.opts.borderTitleHAlign),
 		draw.BorderCellOpts(cOpts...),
 	); err != nil {
 		return err
 	}
+	if err := joinBorder(c, cvs, cOpts); err != nil {
+		return err
+	}
+	return cvs.Apply(c.term)
+}
+
+// drawSeparator draws the separator line between the sub containers in the
+// provided area. Does nothing if the area is empty or a single cell, which is
+// too short for a line.
+func drawSeparator(c *Container, sep image.Rectangle) error {
+	if sep.Empty() || (sep.Dx() == 1 && sep.Dy() == 1) {
+		return nil
+	}
+
+	cvs, err := canvas.New(sep)
+	if err != nil {
+		return err
+	}
+	size := cvs.Size()
+	line := draw.HVLine{
+		Start: image.Point{0, 0},
+		End:   image.Point{size.X - 1, size.Y - 1},
+	}
+	if err := draw.HVLines(cvs, []draw.HVLine{line},
+		draw.HVLineStyle(c.opts.separator),
+		draw.HVLineCellOpts(c.opts.separatorCellOpts...),
+	); err != nil {
+		return err
+	}
 	return cvs.Apply(c.term)
 }
 
+// sidesSet converts the sides to the representation used by draw.Border.
+func (bs BorderSide) sidesSet() draw.BorderSidesSet {
+	return draw.BorderSidesSet{
+		Top:    bs.has(BorderTop),
+		Right:  bs.has(BorderRight),
+		Bottom: bs.has(BorderBottom),
+		Left:   bs.has(BorderLeft),
+	}
+}
+
+// joinBorder joins the border drawn on the canvas with the borders of other
+// containers drawn earlier on the same cells, which happens when sub
+// containers share a border line due to SplitCompactBorders.
+func joinBorder(c *Container, cvs *canvas.Canvas, cOpts []cell.Option) error {
+	drawn := rootCont(c).borderRunes
+	if drawn == nil {
+		return nil
+	}
+
+	size := cvs.Size()
+	for x := 0; x < size.X; x++ {
+		for y := 0; y < size.Y; y++ {
+			if x != 0 && x != size.X-1 && y != 0 && y != size.Y-1 {
+				continue // Not on the border.
+			}
+
+			p := image.Point{x, y}
+			cl, err := cvs.Cell(p)
+			if err != nil {
+				return err
+			}
+			r := cl.Rune
+			tp := p.Add(c.area.Min)
+			if prev, ok := drawn[tp]; ok {
+				merged, ok, err := draw.MergeLineRunes(c.opts.border, prev, r)
+				if err != nil {
+					return err
+				}
+				if ok {
+					if _, err := cvs.SetCell(p, merged, cOpts...); err != nil {
+						return err
+					}
+					r = merged
+				}
+			}
+			drawn[tp] = r
+		}
+	}
+	return nil
+}
+
 // drawWidget requests the widget to draw on the canvas.
 func drawWidget(c *Container) error {
 	widgetArea, err := c.widgetArea()
@@ -132,15 +233,53 @@ func drawWidget(c *Container) error {
 	}
 
 	meta := &widgetapi.Meta{
-		Focused: c.focusTracker.isActive(c),
+		Focused:          c.focusTracker.isActive(c),
+		MouseUnsupported: !c.term.SupportsMouse(),
 	}
 
-	if err := c.opts.widget.Draw(cvs, meta); err != nil {
-		return err
+	for i, w := range c.layered() {
+		if i == 0 {
+			if err := w.Draw(cvs, meta); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := drawLayer(cvs, widgetArea, w, meta); err != nil {
+			return err
+		}
+	}
+
+	if inh := c.opts.inherited; inh.dimUnfocused && !isReachable(c.focusTracker.container, c) {
+		if err := dim(cvs, inh.dimColor); err != nil {
+			return err
+		}
 	}
 	return cvs.Apply(c.term)
 }
 
+// dim sets the provided background color on the cells of the canvas that
+// have the default background color. Cells the widget drew with a background
+// color, e.g. the fill of a Gauge, keep it.
+func dim(cvs *canvas.Canvas, color cell.Color) error {
+	ar := cvs.Area()
+	for col := ar.Min.X; col < ar.Max.X; col++ {
+		for row := ar.Min.Y; row < ar.Max.Y; row++ {
+			p := image.Point{col, row}
+			c, err := cvs.Cell(p)
+			if err != nil {
+				return err
+			}
+			if c.Opts.BgColor != cell.ColorDefault {
+				continue
+			}
+			if err := cvs.SetCellOpts(p, cell.BgColor(color)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // drawResize draws an unicode character indicating that the size is too small to draw this container.
 // Does nothing if the size is smaller than one cell, leaving no space for the character.
 func drawResize(c *Container, area image.Rectangle) error {
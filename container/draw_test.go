@@ -20,6 +20,7 @@ import (
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
 	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/draw/testdraw"
@@ -64,6 +65,92 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "draws widget with container border that has a different corner style",
+			termSize: image.Point{9, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Double),
+					BorderCornerStyle(linestyle.Round),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderLineStyle(linestyle.Double),
+					draw.BorderCornerStyle(linestyle.Round),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+
+				// Fake widget border.
+				testdraw.MustBorder(cvs, image.Rect(1, 1, 8, 4))
+				testdraw.MustText(cvs, "(7,3)", image.Point{2, 2})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "draws widget with container border only on the top and the bottom",
+			termSize: image.Point{9, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					BorderSides(BorderTop|BorderBottom),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderSides(draw.BorderSidesSet{Top: true, Bottom: true}),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+
+				// Fake widget border.
+				testdraw.MustBorder(cvs, image.Rect(0, 1, 9, 4))
+				testdraw.MustText(cvs, "(9,3)", image.Point{1, 2})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "draws widget with container border only on the left",
+			termSize: image.Point{9, 5},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					BorderCornerStyle(linestyle.Round),
+					BorderSides(BorderLeft),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border, a single edge without any corners.
+				for y := 0; y < 5; y++ {
+					testcanvas.MustSetCell(cvs, image.Point{0, y}, '│', cell.FgColor(cell.ColorYellow))
+				}
+
+				// Fake widget border.
+				testdraw.MustBorder(cvs, image.Rect(1, 0, 9, 5))
+				testdraw.MustText(cvs, "(8,5)", image.Point{2, 1})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "absolute margin on root container",
 			termSize: image.Point{20, 10},
@@ -168,6 +255,233 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "vertical sub-containers with compact borders share a single border column",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							Border(linestyle.Light),
+						),
+						SplitCompactBorders(),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 11, 10))
+				testdraw.MustBorder(cvs, image.Rect(10, 0, 20, 10))
+				testcanvas.MustSetCell(cvs, image.Point{10, 0}, '┬')
+				testcanvas.MustSetCell(cvs, image.Point{10, 9}, '┴')
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "horizontal sub-containers with compact borders share a single border row",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							Border(linestyle.Light),
+						),
+						Bottom(
+							Border(linestyle.Light),
+						),
+						SplitCompactBorders(),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 6))
+				testdraw.MustBorder(cvs, image.Rect(0, 5, 10, 10))
+				testcanvas.MustSetCell(cvs, image.Point{0, 5}, '├')
+				testcanvas.MustSetCell(cvs, image.Point{9, 5}, '┤')
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "horizontal split with a separator draws a single divider row",
+			termSize: image.Point{10, 11},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Bottom(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Separator(linestyle.Double, cell.FgColor(cell.ColorRed)),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 0, 10, 5)), &widgetapi.Meta{}, widgetapi.Options{})
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 6, 10, 11)), &widgetapi.Meta{}, widgetapi.Options{})
+
+				cvs := testcanvas.MustNew(image.Rect(0, 5, 10, 6))
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{0, 0}, End: image.Point{9, 0}},
+				}, draw.HVLineStyle(linestyle.Double), draw.HVLineCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "vertical split with a separator draws a single divider column",
+			termSize: image.Point{21, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(),
+						Right(),
+						Separator(linestyle.Light),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustHVLines(cvs, []draw.HVLine{
+					{Start: image.Point{10, 0}, End: image.Point{10, 9}},
+				})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "separator isn't drawn when one of the sub containers is hidden",
+			termSize: image.Point{10, 11},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				c, err := New(
+					ft,
+					SplitHorizontal(
+						Top(
+							ID("top"),
+						),
+						Bottom(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Separator(linestyle.Light),
+					),
+				)
+				if err != nil {
+					return nil, err
+				}
+				if err := c.SetVisible("top", false); err != nil {
+					return nil, err
+				}
+				return c, nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc:     "separator isn't drawn with compact borders",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							Border(linestyle.Light),
+						),
+						Bottom(
+							Border(linestyle.Light),
+						),
+						SplitCompactBorders(),
+						Separator(linestyle.Double),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 6))
+				testdraw.MustBorder(cvs, image.Rect(0, 5, 10, 10))
+				testcanvas.MustSetCell(cvs, image.Point{0, 5}, '├')
+				testcanvas.MustSetCell(cvs, image.Point{9, 5}, '┤')
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "nested compact borders are joined",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(
+							SplitHorizontal(
+								Top(
+									Border(linestyle.Light),
+								),
+								Bottom(
+									Border(linestyle.Light),
+								),
+								SplitCompactBorders(),
+							),
+						),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 10))
+				testdraw.MustBorder(cvs, image.Rect(10, 0, 20, 6))
+				testdraw.MustBorder(cvs, image.Rect(10, 5, 20, 10))
+				testcanvas.MustSetCell(cvs, image.Point{10, 5}, '├')
+				testcanvas.MustSetCell(cvs, image.Point{19, 5}, '┤')
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "compact borders have no effect when a sub-container has no border",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Border(linestyle.Light),
+						),
+						Right(),
+						SplitCompactBorders(),
+					),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(cvs, image.Rect(0, 0, 10, 10))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "draws horizontal sub-containers with margin",
 			termSize: image.Point{20, 20},
@@ -1145,3 +1459,57 @@ func TestDrawHandlesTerminalResize(t *testing.T) {
 		})
 	}
 }
+
+// metaRecorder is a widget that records the meta it was last drawn with.
+type metaRecorder struct {
+	*fakewidget.Mirror
+
+	// meta is the meta provided to the last call to Draw.
+	meta *widgetapi.Meta
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (mr *metaRecorder) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	mr.meta = meta
+	return mr.Mirror.Draw(cvs, meta)
+}
+
+func TestDrawReportsMouseSupport(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []faketerm.Option
+		want bool
+	}{
+		{
+			desc: "terminal supports the mouse",
+			want: false,
+		},
+		{
+			desc: "terminal doesn't support the mouse",
+			opts: []faketerm.Option{faketerm.WithoutMouse()},
+			want: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(image.Point{10, 10}, tc.opts...)
+			mr := &metaRecorder{
+				Mirror: fakewidget.New(widgetapi.Options{}),
+			}
+			cont, err := New(ft, PlaceWidget(mr))
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if mr.meta == nil {
+				t.Fatalf("Draw => the widget wasn't drawn")
+			}
+			if got := mr.meta.MouseUnsupported; got != tc.want {
+				t.Errorf("Draw => Meta.MouseUnsupported %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
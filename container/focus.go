@@ -32,7 +32,7 @@ func pointCont(c *Container, p image.Point) *Container {
 		errStr string
 		cont   *Container
 	)
-	postOrder(rootCont(c), &errStr, visitFunc(func(c *Container) error {
+	postOrderVisible(visibleRoot(c), &errStr, visitFunc(func(c *Container) error {
 		if p.In(c.area) && cont == nil {
 			cont = c
 		}
@@ -102,15 +102,5 @@ func (ft *focusTracker) updateArea(ar image.Rectangle) {
 // reachableFrom asserts whether the currently focused container is reachable
 // from the provided node in the tree.
 func (ft *focusTracker) reachableFrom(node *Container) bool {
-	var (
-		errStr    string
-		reachable bool
-	)
-	preOrder(node, &errStr, visitFunc(func(c *Container) error {
-		if c == ft.container {
-			reachable = true
-		}
-		return nil
-	}))
-	return reachable
+	return isReachable(node, ft.container)
 }
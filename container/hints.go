@@ -0,0 +1,133 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// hints.go contains code that implements the focus hint mode, see FocusHints.
+
+import (
+	"errors"
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// hintRunes are the runes that label the containers in the hint mode, in the
+// order in which they are assigned.
+const hintRunes = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// hintCellOpts are the cell options of the hint labels.
+var hintCellOpts = []cell.Option{
+	cell.FgColor(cell.ColorBlack),
+	cell.BgColor(cell.ColorYellow),
+}
+
+// assignHints labels the visible containers whose widgets want keyboard
+// events when focused. Returns the labeled containers keyed by their hints.
+// The toggle key is never used as a hint.
+// Caller must hold c.mu.
+func assignHints(c *Container, toggle keyboard.Key) map[keyboard.Key]*Container {
+	hints := map[keyboard.Key]*Container{}
+	runes := []rune(hintRunes)
+
+	var errStr string
+	preOrderVisible(visibleRoot(c), &errStr, visitFunc(func(cur *Container) error {
+		if !cur.hasWidget() || cur.opts.widget.Options().WantKeyboard != widgetapi.KeyScopeFocused {
+			return nil
+		}
+		for len(runes) > 0 {
+			k := keyboard.Key(runes[0])
+			runes = runes[1:]
+			if k != toggle {
+				hints[k] = cur
+				return nil
+			}
+		}
+		return nil // Out of hints, the rest of the containers isn't labeled.
+	}))
+	return hints
+}
+
+// hintEvent processes the keyboard event on behalf of the hint mode.
+// Returns true if the hint mode consumed the event, which then must not reach
+// the widgets.
+// Caller must hold c.mu.
+func (c *Container) hintEvent(k *terminalapi.Keyboard) bool {
+	root := rootCont(c)
+	toggle := root.opts.hintKey
+	if toggle == nil {
+		return false
+	}
+
+	if root.hints == nil {
+		if k.Key != *toggle {
+			return false
+		}
+		root.hints = assignHints(root, *toggle)
+		return true
+	}
+
+	if target, ok := root.hints[k.Key]; ok {
+		c.focusTracker.setActive(target)
+	}
+	root.hints = nil
+	return true
+}
+
+// drawHints draws the hint labels into the top left corner of the widgets in
+// the labeled containers if the hint mode is active.
+// Caller must hold c.mu.
+func drawHints(c *Container) error {
+	root := rootCont(c)
+	if root.hints == nil {
+		return nil
+	}
+
+	labels := map[*Container]rune{}
+	for k, cont := range root.hints {
+		labels[cont] = rune(k)
+	}
+
+	var errStr string
+	preOrderVisible(visibleRoot(root), &errStr, visitFunc(func(cur *Container) error {
+		r, ok := labels[cur]
+		if !ok {
+			return nil
+		}
+		wAr, err := cur.widgetArea()
+		if err != nil {
+			return err
+		}
+		if wAr.Empty() {
+			return nil
+		}
+
+		cvs, err := canvas.New(image.Rect(wAr.Min.X, wAr.Min.Y, wAr.Min.X+1, wAr.Min.Y+1))
+		if err != nil {
+			return err
+		}
+		if _, err := cvs.SetCell(image.Point{0, 0}, r, hintCellOpts...); err != nil {
+			return err
+		}
+		return cvs.Apply(cur.term)
+	}))
+	if errStr != "" {
+		return errors.New(errStr)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// layers.go contains code that draws multiple widgets in one container, see
+// Layer.
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// layer is a widget drawn over or under the widget placed in the container.
+type layer struct {
+	// widget is the widget drawn in the layer.
+	widget widgetapi.Widget
+	// z is the position of the layer, the widget placed in the container is at
+	// position zero.
+	z int
+}
+
+// Layer places an additional widget into the container at the provided
+// z-order position. The widget placed in the container with PlaceWidget is at
+// position zero, widgets with larger positions are drawn over widgets with
+// smaller ones. Cells that a widget doesn't draw into, i.e. cells without a
+// rune and with the default cell options, are transparent and let the widgets
+// under them show through.
+//
+// All the layers share the area of the widget placed with PlaceWidget, the
+// size limits and ratio in the options of the layered widgets are ignored.
+// Keyboard and mouse events are delivered to every layer that registered for
+// them, the topmost layer first.
+//
+// The position must not be zero and each position can only be used once in a
+// container. The container must also have a widget placed with PlaceWidget.
+// Splitting or clearing the container removes the layers.
+func Layer(w widgetapi.Widget, z int) Option {
+	return option(func(c *Container) error {
+		if z == 0 {
+			return errors.New("the position zero is reserved for the widget placed with PlaceWidget, Layer requires a non-zero position")
+		}
+		for _, l := range c.opts.layers {
+			if l.z == z {
+				return fmt.Errorf("the container already has a layer at position %d", z)
+			}
+		}
+		c.opts.layers = append(c.opts.layers, &layer{widget: w, z: z})
+		sort.Slice(c.opts.layers, func(i, j int) bool {
+			return c.opts.layers[i].z < c.opts.layers[j].z
+		})
+		return nil
+	})
+}
+
+// layered returns the widget placed in the container and the widgets in its
+// layers ordered bottom up. Returns nil if the container has no widget.
+func (c *Container) layered() []widgetapi.Widget {
+	if !c.hasWidget() {
+		return nil
+	}
+
+	var widgets []widgetapi.Widget
+	placed := false
+	for _, l := range c.opts.layers {
+		if l.z > 0 && !placed {
+			widgets = append(widgets, c.opts.widget)
+			placed = true
+		}
+		widgets = append(widgets, l.widget)
+	}
+	if !placed {
+		widgets = append(widgets, c.opts.widget)
+	}
+	return widgets
+}
+
+// topDown returns the widgets in the container ordered from the topmost layer.
+func (c *Container) topDown() []widgetapi.Widget {
+	widgets := c.layered()
+	for i, j := 0, len(widgets)-1; i < j; i, j = i+1, j-1 {
+		widgets[i], widgets[j] = widgets[j], widgets[i]
+	}
+	return widgets
+}
+
+// drawLayer draws the widget on a new canvas of the same area and copies the
+// cells the widget drew into onto the canvas, leaving the transparent cells
+// unchanged.
+func drawLayer(cvs *canvas.Canvas, ar image.Rectangle, w widgetapi.Widget, meta *widgetapi.Meta) error {
+	lcvs, err := canvas.New(ar)
+	if err != nil {
+		return err
+	}
+	if err := w.Draw(lcvs, meta); err != nil {
+		return fmt.Errorf("unable to draw layered widget %T: %v", w, err)
+	}
+
+	size := lcvs.Size()
+	for x := 0; x < size.X; x++ {
+		for y := 0; y < size.Y; y++ {
+			p := image.Point{x, y}
+			cl, err := lcvs.Cell(p)
+			if err != nil {
+				return err
+			}
+			if cl.Rune == 0 && *cl.Opts == (cell.Options{}) {
+				continue // Transparent.
+			}
+			if _, err := cvs.SetCell(p, cl.Rune, cl.Opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// layerWidget is a widget that draws the provided cells and logs the events it
+// receives.
+type layerWidget struct {
+	name  string
+	cells map[image.Point]rune
+	opts  widgetapi.Options
+	log   *[]string
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (lw *layerWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	for p, r := range lw.cells {
+		if _, err := cvs.SetCell(p, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (lw *layerWidget) Keyboard(k *terminalapi.Keyboard) error {
+	*lw.log = append(*lw.log, lw.name)
+	return nil
+}
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (lw *layerWidget) Mouse(m *terminalapi.Mouse) error {
+	*lw.log = append(*lw.log, lw.name)
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (lw *layerWidget) Options() widgetapi.Options {
+	return lw.opts
+}
+
+// rowCells returns cells that draw the text on the first row.
+func rowCells(text string) map[image.Point]rune {
+	cells := map[image.Point]rune{}
+	for i, r := range []rune(text) {
+		if r != ' ' {
+			cells[image.Point{i, 0}] = r
+		}
+	}
+	return cells
+}
+
+func TestLayer(t *testing.T) {
+	tests := []struct {
+		desc     string
+		opts     func(log *[]string) []Option
+		events   []terminalapi.Event
+		wantText string
+		wantLog  []string
+		wantErr  bool
+	}{
+		{
+			desc: "fails on a layer at position zero",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					PlaceWidget(&layerWidget{log: log}),
+					Layer(&layerWidget{log: log}, 0),
+				}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on two layers at the same position",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					PlaceWidget(&layerWidget{log: log}),
+					Layer(&layerWidget{log: log}, 1),
+					Layer(&layerWidget{log: log}, 1),
+				}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on a layer without a widget",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					Layer(&layerWidget{log: log}, 1),
+				}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "opaque cells of the upper layer cover the widget",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					PlaceWidget(&layerWidget{cells: rowCells("aaaa"), log: log}),
+					Layer(&layerWidget{cells: rowCells(" bb "), log: log}, 1),
+				}
+			},
+			wantText: "abba",
+		},
+		{
+			desc: "layers are drawn in the order of their positions",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					PlaceWidget(&layerWidget{cells: rowCells("aaaa"), log: log}),
+					Layer(&layerWidget{cells: rowCells("ccc "), log: log}, 2),
+					Layer(&layerWidget{cells: rowCells(" bbb"), log: log}, 1),
+				}
+			},
+			wantText: "cccb",
+		},
+		{
+			desc: "transparent cells of the widget reveal a lower layer",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					PlaceWidget(&layerWidget{cells: rowCells("a  a"), log: log}),
+					Layer(&layerWidget{cells: rowCells("cccc"), log: log}, -1),
+				}
+			},
+			wantText: "acca",
+		},
+		{
+			desc: "splitting the container removes the layers",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					PlaceWidget(&layerWidget{cells: rowCells("aaaa"), log: log}),
+					Layer(&layerWidget{cells: rowCells("bbbb"), log: log}, 1),
+					SplitVertical(Left(), Right()),
+				}
+			},
+			wantText: "    ",
+		},
+		{
+			desc: "mouse events reach the topmost layer first",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					PlaceWidget(&layerWidget{
+						name: "widget",
+						opts: widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+						log:  log,
+					}),
+					Layer(&layerWidget{
+						name: "bottom",
+						opts: widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+						log:  log,
+					}, -1),
+					Layer(&layerWidget{
+						name: "top",
+						opts: widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+						log:  log,
+					}, 1),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+			},
+			wantText: "    ",
+			wantLog:  []string{"top", "widget", "bottom"},
+		},
+		{
+			desc: "keyboard events reach the topmost layer first and skip layers that don't want them",
+			opts: func(log *[]string) []Option {
+				return []Option{
+					PlaceWidget(&layerWidget{
+						name: "widget",
+						opts: widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+						log:  log,
+					}),
+					Layer(&layerWidget{
+						name: "middle",
+						log:  log,
+					}, 1),
+					Layer(&layerWidget{
+						name: "top",
+						opts: widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+						log:  log,
+					}, 2),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			wantText: "    ",
+			wantLog:  []string{"top", "widget"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{4, 1})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			var log []string
+			c, err := New(ft, tc.opts(&log)...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			// Initial draw to determine sizes of containers.
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := c.ProcessEvent(ev); err != nil {
+					t.Fatalf("ProcessEvent(%v) => unexpected error: %v", ev, err)
+				}
+			}
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if got, want := ft.String(), tc.wantText+"\n"; got != want {
+				t.Errorf("Draw => %q, want %q", got, want)
+			}
+			if diff := pretty.Compare(tc.wantLog, log); diff != "" {
+				t.Errorf("delivered events in unexpected order, diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
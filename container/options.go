@@ -24,6 +24,7 @@ import (
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/area"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/widgetapi"
 )
@@ -57,6 +58,32 @@ func validateOptions(c *Container) error {
 	if errStr != "" {
 		return errors.New(errStr)
 	}
+
+	// ensure layers are only placed in containers with a widget.
+	preOrder(c, &errStr, func(c *Container) error {
+		if len(c.opts.layers) > 0 && !c.hasWidget() {
+			return errors.New("the Layer option requires a widget placed into the container with PlaceWidget")
+		}
+		return nil
+	})
+	if errStr != "" {
+		return errors.New(errStr)
+	}
+
+	// Ensure each secondary focus key is routed to at most one container.
+	seenKey := map[keyboard.Key]bool{}
+	preOrder(c, &errStr, func(c *Container) error {
+		for k := range c.opts.secondaryKeys {
+			if seenKey[k] {
+				return fmt.Errorf("key %v is provided to SecondaryFocusKeys of multiple containers", k)
+			}
+			seenKey[k] = true
+		}
+		return nil
+	})
+	if errStr != "" {
+		return errors.New(errStr)
+	}
 	return nil
 }
 
@@ -77,11 +104,23 @@ type options struct {
 	// split identifies how is this container split.
 	split        splitType
 	splitPercent int
+	// splitFitFirst indicates that the first sub container is sized to fit
+	// the content of its widget, see SplitFitFirst.
+	splitFitFirst bool
+	// compactBorders indicates that the borders of the sub containers should
+	// share the line along the split.
+	compactBorders bool
+	// separator is the style of the line drawn between the sub containers.
+	separator         linestyle.LineStyle
+	separatorCellOpts []cell.Option
 
 	// widget is the widget in the container.
 	// A container can have either two sub containers (left and right) or a
 	// widget. But not both.
 	widget widgetapi.Widget
+	// layers are additional widgets drawn over or under the widget, ordered
+	// by their position.
+	layers []*layer
 
 	// Alignment of the widget if present.
 	hAlign align.Horizontal
@@ -89,6 +128,8 @@ type options struct {
 
 	// border is the border around the container.
 	border            linestyle.LineStyle
+	borderCorners     linestyle.LineStyle
+	borderSides       BorderSide
 	borderTitle       string
 	borderTitleHAlign align.Horizontal
 
@@ -98,6 +139,19 @@ type options struct {
 
 	// margin is a space reserved on the outside of the container.
 	margin margin
+
+	// keyCapture and mouseCapture are the hooks that intercept events before
+	// they reach the widgets in this container and its sub containers.
+	keyCapture   KeyCaptureFn
+	mouseCapture MouseCaptureFn
+
+	// secondaryKeys are the keys routed to the widget in this container
+	// instead of the widget in the focused container.
+	secondaryKeys map[keyboard.Key]bool
+
+	// hintKey is the key that toggles the focus hint mode, nil if the mode is
+	// disabled. Only set on the root container.
+	hintKey *keyboard.Key
 }
 
 // margin stores the configured margin for the container.
@@ -154,6 +208,11 @@ type inherited struct {
 	borderColor cell.Color
 	// focusedColor is the color used for the border when focused.
 	focusedColor cell.Color
+	// dimUnfocused indicates that widgets outside of the focused container
+	// are drawn with the dimColor background where they have the default
+	// background, see DimUnfocused.
+	dimUnfocused bool
+	dimColor     cell.Color
 }
 
 // newOptions returns a new options instance with the default values.
@@ -167,6 +226,7 @@ func newOptions(parent *options) *options {
 		hAlign:       align.HorizontalCenter,
 		vAlign:       align.VerticalMiddle,
 		splitPercent: DefaultSplitPercent,
+		borderSides:  allBorderSides,
 	}
 	if parent != nil {
 		opts.inherited = parent.inherited
@@ -216,6 +276,71 @@ func SplitPercent(p int) SplitOption {
 	})
 }
 
+// SplitFitFirst sizes the first sub container to fit the content of its
+// widget, the second sub container gets the remainder of the space. The size
+// is measured by widgets that implement widgetapi.Measurer, e.g. the Text
+// widget, given the space available along the other axis. The cells taken by
+// the margin, border and padding of the first sub container are added to the
+// measured size.
+// When using SplitVertical, the width of the new left container fits the
+// content, when using SplitHorizontal, the height of the new top container
+// fits the content.
+// The SplitPercent option is used instead if the first sub container doesn't
+// have a widget or the widget doesn't implement widgetapi.Measurer.
+func SplitFitFirst() SplitOption {
+	return splitOption(func(opts *options) error {
+		opts.splitFitFirst = true
+		return nil
+	})
+}
+
+// FocusHints enables a hint mode that moves the keyboard focus by pressing a
+// single key. Pressing the toggle key labels each visible container whose
+// widget registered for keyboard events with the widgetapi.KeyScopeFocused
+// scope with a unique letter or digit drawn in the top left corner of the
+// widget. Pressing one of the labels focuses the labeled container, pressing
+// any other key, e.g. the toggle key, leaves the hint mode without changing
+// the focus. The toggle key and the key pressed in the hint mode aren't
+// delivered to the widgets.
+// This option can only be set on the root container.
+func FocusHints(toggle keyboard.Key) Option {
+	return option(func(c *Container) error {
+		if c.parent != nil {
+			return errors.New("the FocusHints option can only be set on the root container")
+		}
+		c.opts.hintKey = &toggle
+		return nil
+	})
+}
+
+// SplitCompactBorders makes the two sub containers share the border line
+// along the split instead of each drawing its own, saving one row or column.
+// Where the borders meet, the lines are joined with the appropriate junction
+// characters. The shared line is drawn with the cell options of the second
+// (right or bottom) sub container.
+// Only takes effect when both sub containers have a border with the side along
+// the split.
+func SplitCompactBorders() SplitOption {
+	return splitOption(func(opts *options) error {
+		opts.compactBorders = true
+		return nil
+	})
+}
+
+// Separator draws a single line between the two sub containers, separating
+// them without the need for borders around each of them. The line takes one
+// row or column of the space available to the sub containers. The cell
+// options are applied to the cells of the line.
+// Has no effect when the sub containers share their borders due to
+// SplitCompactBorders or when one of them is hidden.
+func Separator(ls linestyle.LineStyle, cOpts ...cell.Option) SplitOption {
+	return splitOption(func(opts *options) error {
+		opts.separator = ls
+		opts.separatorCellOpts = cOpts
+		return nil
+	})
+}
+
 // SplitVertical splits the container along the vertical axis into two sub
 // containers. The use of this option removes any widget placed at this
 // container, containers with sub containers cannot contain widgets.
@@ -223,6 +348,7 @@ func SplitVertical(l LeftOption, r RightOption, opts ...SplitOption) Option {
 	return option(func(c *Container) error {
 		c.opts.split = splitTypeVertical
 		c.opts.widget = nil
+		c.opts.layers = nil
 		for _, opt := range opts {
 			if err := opt.setSplit(c.opts); err != nil {
 				return err
@@ -243,6 +369,7 @@ func SplitHorizontal(t TopOption, b BottomOption, opts ...SplitOption) Option {
 	return option(func(c *Container) error {
 		c.opts.split = splitTypeHorizontal
 		c.opts.widget = nil
+		c.opts.layers = nil
 		for _, opt := range opts {
 			if err := opt.setSplit(c.opts); err != nil {
 				return err
@@ -272,11 +399,12 @@ func ID(id string) Option {
 }
 
 // Clear clears this container.
-// If the container contains a widget, the widget is removed.
+// If the container contains a widget, the widget and its layers are removed.
 // If the container had any sub containers or splits, they are removed.
 func Clear() Option {
 	return option(func(c *Container) error {
 		c.opts.widget = nil
+		c.opts.layers = nil
 		c.first = nil
 		c.second = nil
 		return nil
@@ -295,6 +423,58 @@ func PlaceWidget(w widgetapi.Widget) Option {
 	})
 }
 
+// OnKeyCapture sets a hook that intercepts keyboard events before they reach
+// the widgets in this container and its sub containers. The hook is called
+// for every keyboard event, hooks of parent containers are called first. If
+// the hook handles the event, none of the widgets in this container or its
+// sub containers receive it and the hooks of sub containers aren't called.
+// Providing a nil function removes the hook.
+func OnKeyCapture(fn KeyCaptureFn) Option {
+	return option(func(c *Container) error {
+		c.opts.keyCapture = fn
+		return nil
+	})
+}
+
+// SecondaryFocusKeys makes the widget in this container the secondary focus
+// for the provided keys. The container that has the keyboard focus is the
+// primary focus. The provided keys are delivered to the widget in this
+// container instead of the widget in the focused container, while all other
+// keys still reach the focused widget. This allows e.g. the list and the
+// detail pane of a master-detail layout to both respond to their keys without
+// switching the focus between them.
+// Only affects widgets that registered for keyboard events with the
+// widgetapi.KeyScopeFocused or widgetapi.KeyScopeGlobal scope, keys are only
+// routed while this container is visible. Each key can be provided to at most
+// one container. Calling this without any keys removes the secondary focus.
+func SecondaryFocusKeys(keys ...keyboard.Key) Option {
+	return option(func(c *Container) error {
+		if len(keys) == 0 {
+			c.opts.secondaryKeys = nil
+			return nil
+		}
+		c.opts.secondaryKeys = map[keyboard.Key]bool{}
+		for _, k := range keys {
+			c.opts.secondaryKeys[k] = true
+		}
+		return nil
+	})
+}
+
+// OnMouseCapture sets a hook that intercepts mouse events that fall within
+// the area of this container before they reach the widgets in this container
+// and its sub containers. Hooks of parent containers are called first. If the
+// hook handles the event, none of the widgets in this container or its sub
+// containers receive it and the hooks of sub containers aren't called. The
+// focus of the containers is still updated.
+// Providing a nil function removes the hook.
+func OnMouseCapture(fn MouseCaptureFn) Option {
+	return option(func(c *Container) error {
+		c.opts.mouseCapture = fn
+		return nil
+	})
+}
+
 // MarginTop sets reserved space outside of the container at its top.
 // The provided number is the absolute margin in cells and must be zero or a
 // positive integer. Only one of MarginTop or MarginTopPercent can be specified.
@@ -595,6 +775,54 @@ func Border(ls linestyle.LineStyle) Option {
 	})
 }
 
+// BorderCornerStyle configures the style of the corners of the border
+// independently of the style of its edges. E.g. Border(linestyle.Light)
+// together with BorderCornerStyle(linestyle.Round) results in a border with
+// rounded corners and straight edges.
+// Has no effect if the container has no border. If not provided or set to
+// linestyle.None, the corners use the style provided to the Border option.
+func BorderCornerStyle(ls linestyle.LineStyle) Option {
+	return option(func(c *Container) error {
+		c.opts.borderCorners = ls
+		return nil
+	})
+}
+
+// BorderSide identifies a side of the container border.
+// The sides can be combined using the bitwise OR operator.
+type BorderSide int
+
+// The sides of the border.
+const (
+	BorderTop BorderSide = 1 << iota
+	BorderRight
+	BorderBottom
+	BorderLeft
+)
+
+// allBorderSides combines all the sides of the border.
+const allBorderSides = BorderTop | BorderRight | BorderBottom | BorderLeft
+
+// has determines if the side s is among the sides.
+func (bs BorderSide) has(s BorderSide) bool {
+	return bs&s != 0
+}
+
+// BorderSides limits the border to the provided sides, e.g.
+// BorderSides(BorderTop|BorderBottom) draws lines only above and below the
+// content. The corners are only drawn where both of the adjacent sides are
+// present, the content extends into the space of the omitted sides.
+// Has no effect if the container has no border. Defaults to all four sides.
+func BorderSides(sides BorderSide) Option {
+	return option(func(c *Container) error {
+		if sides <= 0 || sides > allBorderSides {
+			return fmt.Errorf("invalid border sides %d, must be a combination of BorderTop, BorderRight, BorderBottom and BorderLeft", sides)
+		}
+		c.opts.borderSides = sides
+		return nil
+	})
+}
+
 // BorderTitle sets a text title within the border.
 func BorderTitle(title string) Option {
 	return option(func(c *Container) error {
@@ -646,6 +874,22 @@ func FocusedColor(color cell.Color) Option {
 	})
 }
 
+// DimUnfocused draws the widgets outside of the focused container with the
+// provided background color, which gives visual feedback of the focus, e.g.
+// with a dark gray like cell.ColorNumber(236). The widgets in the focused
+// container and in its sub containers keep their own colors, so when the root
+// container is focused no widget is dimmed. Only the cells drawn with the
+// default background color are dimmed, cells the widgets drew with their own
+// background color, e.g. the fill of a Gauge, keep it.
+// This option is inherited to sub containers created by container splits.
+func DimUnfocused(color cell.Color) Option {
+	return option(func(c *Container) error {
+		c.opts.inherited.dimUnfocused = true
+		c.opts.inherited.dimColor = color
+		return nil
+	})
+}
+
 // splitType identifies how a container is split.
 type splitType int
 
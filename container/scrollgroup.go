@@ -0,0 +1,99 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// scrollgroup.go contains code that links the scrolling positions of widgets.
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// ScrollGroup links the scrolling positions of multiple widgets, scrolling
+// any member of the group scrolls all the others by the same number of lines.
+// E.g. two side-by-side Text widgets showing the two sides of a diff.
+//
+// The members share a single scrolling position. Each member displays its
+// content from the shared position, clamped to the length of its own content.
+// The shared position itself can reach the end of the longest member, so
+// the members that reached their end stay aligned with the others when
+// scrolling back. The other members follow the scrolled one the next time
+// they are drawn.
+//
+// Implements widgetapi.ScrollLink. This object is thread-safe.
+type ScrollGroup struct {
+	// mu protects the ScrollGroup.
+	mu sync.Mutex
+
+	// first is the shared first line.
+	first int
+
+	// maxFirst is the highest first line each member can display as of the
+	// last time it was drawn.
+	maxFirst map[widgetapi.Scrollable]int
+}
+
+// NewScrollGroup returns a new empty ScrollGroup.
+func NewScrollGroup() *ScrollGroup {
+	return &ScrollGroup{
+		maxFirst: map[widgetapi.Scrollable]int{},
+	}
+}
+
+// Join adds the widget to the group. The widget must implement
+// widgetapi.Scrollable.
+func (sg *ScrollGroup) Join(w widgetapi.Widget) error {
+	s, ok := w.(widgetapi.Scrollable)
+	if !ok {
+		return fmt.Errorf("widget %T doesn't implement widgetapi.Scrollable and cannot join a ScrollGroup", w)
+	}
+
+	sg.mu.Lock()
+	if _, ok := sg.maxFirst[s]; ok {
+		sg.mu.Unlock()
+		return fmt.Errorf("widget %T is already a member of the ScrollGroup", w)
+	}
+	sg.maxFirst[s] = 0
+	sg.mu.Unlock()
+
+	// Not holding the lock, the widget might be drawing and calling Scroll.
+	s.LinkScroll(sg)
+	return nil
+}
+
+// Scroll implements widgetapi.ScrollLink.Scroll.
+func (sg *ScrollGroup) Scroll(w widgetapi.Scrollable, delta, maxFirst int) int {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+
+	sg.maxFirst[w] = maxFirst
+	max := 0
+	for _, m := range sg.maxFirst {
+		if m > max {
+			max = m
+		}
+	}
+
+	sg.first += delta
+	switch {
+	case sg.first > max:
+		sg.first = max
+	case sg.first < 0:
+		sg.first = 0
+	}
+	return sg.first
+}
@@ -0,0 +1,175 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/internal/fakewidget"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// scrollWidget is a fake widget that implements widgetapi.Scrollable.
+type scrollWidget struct {
+	*fakewidget.Mirror
+
+	link widgetapi.ScrollLink
+}
+
+// LinkScroll implements widgetapi.Scrollable.LinkScroll.
+func (sw *scrollWidget) LinkScroll(l widgetapi.ScrollLink) {
+	sw.link = l
+}
+
+// scroll simulates a draw of the widget after the user scrolled it by delta
+// lines. Returns the first line the widget displays.
+func (sw *scrollWidget) scroll(delta, maxFirst int) int {
+	first := sw.link.Scroll(sw, delta, maxFirst)
+	if first > maxFirst {
+		return maxFirst
+	}
+	return first
+}
+
+func newScrollWidget() *scrollWidget {
+	return &scrollWidget{
+		Mirror: fakewidget.New(widgetapi.Options{}),
+	}
+}
+
+func TestScrollGroupJoin(t *testing.T) {
+	sg := NewScrollGroup()
+	if err := sg.Join(fakewidget.New(widgetapi.Options{})); err == nil {
+		t.Errorf("Join => got nil error, want an error for a widget that isn't Scrollable")
+	}
+
+	sw := newScrollWidget()
+	if err := sg.Join(sw); err != nil {
+		t.Fatalf("Join => unexpected error: %v", err)
+	}
+	if sw.link != sg {
+		t.Errorf("Join => widget linked to %v, want the ScrollGroup", sw.link)
+	}
+	if err := sg.Join(sw); err == nil {
+		t.Errorf("Join => got nil error, want an error when joining twice")
+	}
+}
+
+func TestScrollGroup(t *testing.T) {
+	// scroll is a simulated draw of one member of the group.
+	type scroll struct {
+		// member is the index of the member.
+		member int
+		// delta is the number of lines the user scrolled the member by.
+		delta int
+		// maxFirst is the highest first line the member can display.
+		maxFirst int
+		// want is the first line the member displays.
+		want int
+	}
+
+	tests := []struct {
+		desc    string
+		members int
+		scrolls []scroll
+	}{
+		{
+			desc:    "scrolling one member advances the others by the same delta",
+			members: 2,
+			scrolls: []scroll{
+				{member: 0, delta: 0, maxFirst: 20, want: 0},
+				{member: 1, delta: 0, maxFirst: 20, want: 0},
+				{member: 0, delta: 3, maxFirst: 20, want: 3},
+				{member: 1, delta: 0, maxFirst: 20, want: 3},
+				{member: 1, delta: 2, maxFirst: 20, want: 5},
+				{member: 0, delta: 0, maxFirst: 20, want: 5},
+				{member: 0, delta: -4, maxFirst: 20, want: 1},
+				{member: 1, delta: 0, maxFirst: 20, want: 1},
+			},
+		},
+		{
+			desc:    "all three members follow",
+			members: 3,
+			scrolls: []scroll{
+				{member: 2, delta: 4, maxFirst: 10, want: 4},
+				{member: 0, delta: 0, maxFirst: 10, want: 4},
+				{member: 1, delta: 0, maxFirst: 10, want: 4},
+			},
+		},
+		{
+			desc:    "doesn't scroll above the first line",
+			members: 2,
+			scrolls: []scroll{
+				{member: 0, delta: -3, maxFirst: 10, want: 0},
+				{member: 1, delta: 2, maxFirst: 10, want: 2},
+				{member: 0, delta: 0, maxFirst: 10, want: 2},
+			},
+		},
+		{
+			desc:    "clamps to the end of the longest member",
+			members: 2,
+			scrolls: []scroll{
+				{member: 0, delta: 0, maxFirst: 10, want: 0},
+				{member: 1, delta: 25, maxFirst: 20, want: 20},
+				{member: 0, delta: 0, maxFirst: 10, want: 10},
+				{member: 1, delta: -5, maxFirst: 20, want: 15},
+				{member: 0, delta: 0, maxFirst: 10, want: 10},
+			},
+		},
+		{
+			desc:    "members stay aligned after one of them was clamped",
+			members: 2,
+			scrolls: []scroll{
+				{member: 0, delta: 0, maxFirst: 100, want: 0},
+				{member: 1, delta: 0, maxFirst: 10, want: 0},
+				{member: 0, delta: 50, maxFirst: 100, want: 50},
+				{member: 1, delta: 0, maxFirst: 10, want: 10},
+				{member: 0, delta: -45, maxFirst: 100, want: 5},
+				{member: 1, delta: 0, maxFirst: 10, want: 5},
+			},
+		},
+		{
+			desc:    "scrolling the shorter member past its end keeps the group consistent",
+			members: 2,
+			scrolls: []scroll{
+				{member: 0, delta: 0, maxFirst: 100, want: 0},
+				{member: 1, delta: 50, maxFirst: 10, want: 10},
+				{member: 0, delta: 0, maxFirst: 100, want: 50},
+				{member: 1, delta: -45, maxFirst: 10, want: 5},
+				{member: 0, delta: 0, maxFirst: 100, want: 5},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sg := NewScrollGroup()
+			var members []*scrollWidget
+			for i := 0; i < tc.members; i++ {
+				sw := newScrollWidget()
+				if err := sg.Join(sw); err != nil {
+					t.Fatalf("Join => unexpected error: %v", err)
+				}
+				members = append(members, sw)
+			}
+
+			for i, s := range tc.scrolls {
+				if got := members[s.member].scroll(s.delta, s.maxFirst); got != s.want {
+					t.Errorf("scroll #%d of member %d by %d => %d, want %d", i, s.member, s.delta, got, s.want)
+				}
+			}
+		})
+	}
+}
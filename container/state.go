@@ -0,0 +1,123 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// state.go contains code that saves and restores the state of the container tree.
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// treeState is the serialized state of the container tree.
+// Containers are identified by their path from the root container, see
+// contPaths.
+type treeState struct {
+	// Focused is the path to the focused container.
+	Focused string `json:"focused"`
+	// Widgets maps paths to containers to the state of their widgets.
+	Widgets map[string][]byte `json:"widgets,omitempty"`
+}
+
+// contPaths returns all the containers in the tree keyed by their path from
+// the provided container. The path of the provided container is an empty
+// string, the path of every sub container is the path of its parent followed
+// by "f" for the first or "s" for the second sub container.
+func contPaths(c *Container) map[string]*Container {
+	res := map[string]*Container{}
+	var add func(c *Container, path string)
+	add = func(c *Container, path string) {
+		if c == nil {
+			return
+		}
+		res[path] = c
+		add(c.first, path+"f")
+		add(c.second, path+"s")
+	}
+	add(c, "")
+	return res
+}
+
+// SaveState serializes the logical state of the container tree, i.e. which
+// container is focused and the state of all the widgets that implement the
+// widgetapi.Stateful interface. The returned data can be used to restore the
+// state by calling RestoreState on a container tree with the same layout.
+func (c *Container) SaveState() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	st := &treeState{
+		Widgets: map[string][]byte{},
+	}
+	for path, cont := range contPaths(c) {
+		if c.focusTracker.isActive(cont) {
+			st.Focused = path
+		}
+
+		sw, ok := cont.opts.widget.(widgetapi.Stateful)
+		if !ok {
+			continue
+		}
+		data, err := sw.SaveState()
+		if err != nil {
+			return nil, fmt.Errorf("unable to save the state of widget %T: %v", cont.opts.widget, err)
+		}
+		st.Widgets[path] = data
+	}
+	return json.Marshal(st)
+}
+
+// RestoreState restores the state of the container tree from data previously
+// returned by SaveState. Returns an error if the layout of the container tree
+// doesn't match the layout at the time the state was saved.
+func (c *Container) RestoreState(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	st := &treeState{}
+	if err := json.Unmarshal(data, st); err != nil {
+		return fmt.Errorf("unable to parse the saved state: %v", err)
+	}
+
+	paths := contPaths(c)
+	focused, ok := paths[st.Focused]
+	if !ok {
+		return fmt.Errorf("the saved state has the focus on container %q which doesn't exist in this layout", st.Focused)
+	}
+
+	widgets := map[string]widgetapi.Stateful{}
+	for path := range st.Widgets {
+		cont, ok := paths[path]
+		if !ok {
+			return fmt.Errorf("the saved state has a widget in container %q which doesn't exist in this layout", path)
+		}
+		sw, ok := cont.opts.widget.(widgetapi.Stateful)
+		if !ok {
+			return fmt.Errorf("the saved state has a widget in container %q, but the container has widget %T that doesn't implement widgetapi.Stateful", path, cont.opts.widget)
+		}
+		widgets[path] = sw
+	}
+
+	for path, sw := range widgets {
+		if err := sw.RestoreState(st.Widgets[path]); err != nil {
+			return fmt.Errorf("unable to restore the state of widget %T: %v", sw, err)
+		}
+	}
+	c.focusTracker.setActive(focused)
+	rootCont(c).changed = true
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/internal/fakewidget"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// stateTree creates a container tree with two text widgets for the state
+// tests. The returned text widget is placed in the right container.
+func stateTree(ft *faketerm.Terminal) (*Container, *text.Text, error) {
+	left, err := text.New()
+	if err != nil {
+		return nil, nil, err
+	}
+	right, err := text.New()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, fmt.Sprintf("line%d", i))
+	}
+	content := strings.Join(lines, "\n")
+	for _, t := range []*text.Text{left, right} {
+		if err := t.Write(content); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	c, err := New(
+		ft,
+		SplitVertical(
+			Left(
+				Border(linestyle.Light),
+				PlaceWidget(left),
+			),
+			Right(
+				Border(linestyle.Light),
+				PlaceWidget(right),
+			),
+		),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, right, nil
+}
+
+func TestSaveRestoreState(t *testing.T) {
+	size := image.Point{20, 5}
+
+	savedFt := faketerm.MustNew(size)
+	saved, savedText, err := stateTree(savedFt)
+	if err != nil {
+		t.Fatalf("stateTree => unexpected error: %v", err)
+	}
+	saved.focusTracker.setActive(saved.second)
+	for i := 0; i < 3; i++ {
+		if err := savedText.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowDown}); err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	}
+	if err := saved.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	data, err := saved.SaveState()
+	if err != nil {
+		t.Fatalf("SaveState => unexpected error: %v", err)
+	}
+
+	gotFt := faketerm.MustNew(size)
+	got, _, err := stateTree(gotFt)
+	if err != nil {
+		t.Fatalf("stateTree => unexpected error: %v", err)
+	}
+	if err := got.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if err := got.RestoreState(data); err != nil {
+		t.Fatalf("RestoreState => unexpected error: %v", err)
+	}
+	if !got.changed {
+		t.Errorf("RestoreState => the container isn't marked as changed, the restored focus wouldn't be redrawn")
+	}
+	if !got.focusTracker.isActive(got.second) {
+		t.Errorf("RestoreState => focused container %v, want the right container %v", got.focusTracker.container, got.second)
+	}
+	if err := got.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(savedFt, gotFt); diff != "" {
+		t.Errorf("Draw after RestoreState => %v", diff)
+	}
+}
+
+func TestRestoreStateFails(t *testing.T) {
+	tests := []struct {
+		desc string
+		data string
+	}{
+		{
+			desc: "fails on malformed data",
+			data: "{",
+		},
+		{
+			desc: "fails when the focused container doesn't exist",
+			data: `{"focused": "ff"}`,
+		},
+		{
+			desc: "fails when a container with widget state doesn't exist",
+			data: `{"focused": "", "widgets": {"sf": "e30="}}`,
+		},
+		{
+			desc: "fails when the widget doesn't support state",
+			data: `{"focused": "", "widgets": {"s": "e30="}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(image.Point{20, 5})
+			c, err := New(
+				ft,
+				SplitVertical(
+					Left(),
+					Right(PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+				),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := c.RestoreState([]byte(tc.data)); err == nil {
+				t.Errorf("RestoreState => got nil error, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// tooltips.go contains code that draws the tooltips of the widgets, see
+// widgetapi.Tooltipper.
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+	"unicode"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/area"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// tooltipCellOpts are the cell options of tooltips that don't specify any.
+var tooltipCellOpts = []cell.Option{
+	cell.FgColor(cell.ColorBlack),
+	cell.BgColor(cell.ColorWhite),
+}
+
+// drawTooltips draws the tooltips of the widgets in the visible containers
+// over all the other content on the terminal.
+// Cells under a tooltip that moved or disappeared might not be drawn by
+// anything else, e.g. when they fall onto the padding of a container, so the
+// terminal is cleared and the container tree drawn again whenever the areas
+// occupied by the tooltips change.
+// Caller must hold c.mu.
+func drawTooltips(c *Container) error {
+	root := rootCont(c)
+	termAr, err := area.FromSize(root.term.Size())
+	if err != nil {
+		return err
+	}
+
+	var (
+		errStr string
+		cvses  []*canvas.Canvas
+		areas  []image.Rectangle
+	)
+	preOrderVisible(visibleRoot(root), &errStr, visitFunc(func(cur *Container) error {
+		wAr, err := cur.widgetArea()
+		if err != nil {
+			return err
+		}
+		if wAr.Empty() {
+			return nil
+		}
+
+		for _, w := range cur.layered() {
+			tt, ok := w.(widgetapi.Tooltipper)
+			if !ok {
+				continue
+			}
+			cvs, ar, err := tooltipCanvas(tt.Tooltip(), wAr, termAr)
+			if err != nil {
+				return err
+			}
+			if cvs != nil {
+				cvses = append(cvses, cvs)
+				areas = append(areas, ar)
+			}
+		}
+		return nil
+	}))
+	if errStr != "" {
+		return errors.New(errStr)
+	}
+
+	if !sameAreas(root.tooltipAreas, areas) {
+		if len(root.tooltipAreas) > 0 {
+			if err := root.term.Clear(); err != nil {
+				return fmt.Errorf("term.Clear => error: %v", err)
+			}
+			if err := drawTree(root); err != nil {
+				return err
+			}
+			if err := drawHints(root); err != nil {
+				return err
+			}
+		}
+		root.tooltipAreas = areas
+	}
+
+	for _, cvs := range cvses {
+		if err := cvs.Apply(root.term); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameAreas asserts whether the two slices contain the same areas in the same
+// order.
+func sameAreas(a, b []image.Rectangle) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Eq(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeTooltip replaces characters that cannot be displayed on the single
+// line of a tooltip with spaces.
+func sanitizeTooltip(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r != ' ' && (unicode.IsControl(r) || unicode.IsSpace(r)) {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// tooltipCanvas returns a canvas with the tooltip of a widget placed in the
+// widget area and the area of the terminal the canvas occupies. Returns a nil
+// canvas if the widget doesn't display a tooltip. The tooltip is positioned one cell below and to the right of its position, it is moved to
+// the left or above the position as needed to fit the terminal.
+func tooltipCanvas(t *widgetapi.Tooltip, wAr, termAr image.Rectangle) (*canvas.Canvas, image.Rectangle, error) {
+	if t == nil || t.Text == "" {
+		return nil, image.ZR, nil
+	}
+
+	text, err := draw.TrimText(sanitizeTooltip(t.Text), termAr.Dx(), draw.OverrunModeThreeDot)
+	if err != nil {
+		return nil, image.ZR, err
+	}
+	width := runewidth.StringWidth(text)
+
+	pos := wAr.Min.Add(t.Position)
+	x := pos.X + 1
+	if x+width > termAr.Max.X {
+		x = termAr.Max.X - width
+	}
+	y := pos.Y + 1
+	if y >= termAr.Max.Y {
+		y = pos.Y - 1
+	}
+	if x < termAr.Min.X {
+		x = termAr.Min.X
+	}
+	if y < termAr.Min.Y {
+		y = termAr.Min.Y
+	}
+
+	ar := image.Rect(x, y, x+width, y+1)
+	cvs, err := canvas.New(ar)
+	if err != nil {
+		return nil, image.ZR, err
+	}
+	cOpts := t.CellOpts
+	if len(cOpts) == 0 {
+		cOpts = tooltipCellOpts
+	}
+	if err := draw.Text(cvs, text, image.Point{0, 0}, draw.TextCellOpts(cOpts...)); err != nil {
+		return nil, image.ZR, err
+	}
+	return cvs, ar, nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// tooltipWidget is a widget that fills its canvas with a rune and displays
+// the provided tooltip.
+type tooltipWidget struct {
+	fill    rune
+	tooltip *widgetapi.Tooltip
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (tw *tooltipWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	return cvs.SetAreaCells(cvs.Area(), tw.fill)
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (tw *tooltipWidget) Keyboard(k *terminalapi.Keyboard) error {
+	return nil
+}
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (tw *tooltipWidget) Mouse(m *terminalapi.Mouse) error {
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (tw *tooltipWidget) Options() widgetapi.Options {
+	return widgetapi.Options{}
+}
+
+// Tooltip implements widgetapi.Tooltipper.Tooltip.
+func (tw *tooltipWidget) Tooltip() *widgetapi.Tooltip {
+	return tw.tooltip
+}
+
+func TestTooltips(t *testing.T) {
+	tests := []struct {
+		desc         string
+		termSize     image.Point
+		leftTooltip  *widgetapi.Tooltip
+		rightTooltip *widgetapi.Tooltip
+		// want draws the expected tooltips over the widgets.
+		want func(c *canvas.Canvas)
+	}{
+		{
+			desc:     "draws nothing without tooltips",
+			termSize: image.Point{10, 4},
+			want:     func(c *canvas.Canvas) {},
+		},
+		{
+			desc:     "draws nothing for an empty tooltip",
+			termSize: image.Point{10, 4},
+			leftTooltip: &widgetapi.Tooltip{
+				Position: image.Point{1, 1},
+			},
+			want: func(c *canvas.Canvas) {},
+		},
+		{
+			desc:     "tooltip is drawn over the adjacent widget",
+			termSize: image.Point{10, 4},
+			leftTooltip: &widgetapi.Tooltip{
+				Text:     "tip",
+				Position: image.Point{3, 1},
+			},
+			want: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "tip", image.Point{4, 2}, draw.TextCellOpts(tooltipCellOpts...))
+			},
+		},
+		{
+			desc:     "tooltip of the right widget is positioned relative to its canvas",
+			termSize: image.Point{10, 4},
+			rightTooltip: &widgetapi.Tooltip{
+				Text:     "tip",
+				Position: image.Point{0, 0},
+			},
+			want: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "tip", image.Point{6, 1}, draw.TextCellOpts(tooltipCellOpts...))
+			},
+		},
+		{
+			desc:     "tooltip near the bottom right corner is clamped to the terminal",
+			termSize: image.Point{10, 4},
+			rightTooltip: &widgetapi.Tooltip{
+				Text:     "hello",
+				Position: image.Point{4, 3},
+			},
+			want: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "hello", image.Point{5, 2}, draw.TextCellOpts(tooltipCellOpts...))
+			},
+		},
+		{
+			desc:     "tooltip wider than the terminal is trimmed",
+			termSize: image.Point{10, 4},
+			leftTooltip: &widgetapi.Tooltip{
+				Text:     "long tooltip text",
+				Position: image.Point{2, 0},
+			},
+			want: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "long tool…", image.Point{0, 1}, draw.TextCellOpts(tooltipCellOpts...))
+			},
+		},
+		{
+			desc:     "control characters in the tooltip are drawn as spaces",
+			termSize: image.Point{10, 4},
+			leftTooltip: &widgetapi.Tooltip{
+				Text:     "a\nb\tc",
+				Position: image.Point{0, 0},
+			},
+			want: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "a b c", image.Point{1, 1}, draw.TextCellOpts(tooltipCellOpts...))
+			},
+		},
+		{
+			desc:     "tooltip with custom cell options",
+			termSize: image.Point{10, 4},
+			leftTooltip: &widgetapi.Tooltip{
+				Text:     "tip",
+				Position: image.Point{0, 0},
+				CellOpts: []cell.Option{cell.FgColor(cell.ColorRed)},
+			},
+			want: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "tip", image.Point{1, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			cont, err := New(
+				got,
+				SplitVertical(
+					Left(
+						PlaceWidget(&tooltipWidget{fill: 'a', tooltip: tc.leftTooltip}),
+					),
+					Right(
+						PlaceWidget(&tooltipWidget{fill: 'b', tooltip: tc.rightTooltip}),
+					),
+				),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			want := faketerm.MustNew(tc.termSize)
+			c := testcanvas.MustNew(want.Area())
+			half := tc.termSize.X / 2
+			testcanvas.MustSetAreaCells(c, image.Rect(0, 0, half, tc.termSize.Y), 'a')
+			testcanvas.MustSetAreaCells(c, image.Rect(half, 0, tc.termSize.X, tc.termSize.Y), 'b')
+			tc.want(c)
+			testcanvas.MustApply(c, want)
+
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestTooltipMoves(t *testing.T) {
+	size := image.Point{10, 4}
+	got := faketerm.MustNew(size)
+	tw := &tooltipWidget{
+		fill: 'a',
+		tooltip: &widgetapi.Tooltip{
+			Text:     "tip",
+			Position: image.Point{3, 1},
+		},
+	}
+	cont, err := New(
+		got,
+		SplitVertical(
+			Left(
+				PlaceWidget(tw),
+			),
+			// The right container has no widget, nothing draws over
+			// the cells left behind by the tooltip.
+			Right(),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	tw.tooltip = &widgetapi.Tooltip{
+		Text:     "tip",
+		Position: image.Point{0, 0},
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	want := faketerm.MustNew(size)
+	c := testcanvas.MustNew(want.Area())
+	testcanvas.MustSetAreaCells(c, image.Rect(0, 0, 5, 4), 'a')
+	testdraw.MustText(c, "tip", image.Point{1, 1}, draw.TextCellOpts(tooltipCellOpts...))
+	testcanvas.MustApply(c, want)
+
+	if diff := faketerm.Diff(want, got); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
@@ -29,6 +29,32 @@ func rootCont(c *Container) *Container {
 	return c
 }
 
+// visibleRoot returns the top-most container that is drawn on the terminal,
+// i.e. the container zoomed by ToggleZoom or the root container if none is.
+func visibleRoot(c *Container) *Container {
+	root := rootCont(c)
+	if root.zoomed != nil {
+		return root.zoomed
+	}
+	return root
+}
+
+// isReachable asserts whether the target container is reachable from the
+// provided node in the tree.
+func isReachable(node, target *Container) bool {
+	var (
+		errStr    string
+		reachable bool
+	)
+	preOrder(node, &errStr, visitFunc(func(c *Container) error {
+		if c == target {
+			reachable = true
+		}
+		return nil
+	}))
+	return reachable
+}
+
 // visitFunc is executed during traversals when node is visited.
 // If the visit function returns an error, the traversal terminates and the
 // errStr is set to the text of the returned error.
@@ -62,6 +88,36 @@ func postOrder(c *Container, errStr *string, visit visitFunc) {
 	}
 }
 
+// preOrderVisible is like preOrder, but skips containers hidden by
+// SetVisible along with all of their sub containers.
+func preOrderVisible(c *Container, errStr *string, visit visitFunc) {
+	if c == nil || c.hidden || *errStr != "" {
+		return
+	}
+
+	if err := visit(c); err != nil {
+		*errStr = err.Error()
+		return
+	}
+	preOrderVisible(c.first, errStr, visit)
+	preOrderVisible(c.second, errStr, visit)
+}
+
+// postOrderVisible is like postOrder, but skips containers hidden by
+// SetVisible along with all of their sub containers.
+func postOrderVisible(c *Container, errStr *string, visit visitFunc) {
+	if c == nil || c.hidden || *errStr != "" {
+		return
+	}
+
+	postOrderVisible(c.first, errStr, visit)
+	postOrderVisible(c.second, errStr, visit)
+	if err := visit(c); err != nil {
+		*errStr = err.Error()
+		return
+	}
+}
+
 // findID finds container with the provided ID.
 // Returns an error of there is no container with the specified ID.
 func findID(root *Container, id string) (*Container, error) {
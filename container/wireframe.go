@@ -0,0 +1,114 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// wireframe.go contains code that draws the layout of the container tree as
+// text.
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/terminal/headless"
+)
+
+// Wireframe returns a textual wireframe of the layout of the container tree
+// on a terminal of the provided size, i.e. the containers drawn as boxes
+// without any widget content. Useful for documenting and debugging layouts.
+//
+// Containers that have a border or don't have any sub containers are drawn as
+// boxes titled with their ID, or with their border title if they don't have
+// an ID, followed by their size in cells, e.g. "cpu 40x10". Like Draw, the
+// wireframe only contains the visible containers and follows ToggleZoom.
+// Containers smaller than 2x2 cells can't fit a box and are omitted.
+//
+// The layout of the container on its terminal isn't affected.
+func (c *Container) Wireframe(size image.Point) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, err := headless.New(size)
+	if err != nil {
+		return "", err
+	}
+	cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		return "", err
+	}
+
+	// The layout is computed by setting the areas of the containers, restore
+	// them for the terminal the container is drawn on.
+	root := rootCont(c)
+	var errStr string
+	saved := map[*Container]image.Rectangle{}
+	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+		saved[c] = c.area
+		return nil
+	}))
+	defer func() {
+		for cont, ar := range saved {
+			cont.area = ar
+		}
+	}()
+
+	top := visibleRoot(root)
+	ar, err := top.opts.margin.apply(cvs.Area())
+	if err != nil {
+		return "", err
+	}
+	top.area = ar
+	preOrderVisible(top, &errStr, visitFunc(func(c *Container) error {
+		if _, err := layoutChildren(c); err != nil {
+			return err
+		}
+		return drawWireframeBox(cvs, c)
+	}))
+	if errStr != "" {
+		return "", errors.New(errStr)
+	}
+
+	if err := cvs.Apply(t); err != nil {
+		return "", err
+	}
+	if err := t.Flush(); err != nil {
+		return "", err
+	}
+	return t.String(), nil
+}
+
+// drawWireframeBox draws the box of the container into the wireframe, see
+// Wireframe.
+func drawWireframeBox(cvs *canvas.Canvas, c *Container) error {
+	leaf := c.first == nil && c.second == nil
+	if !c.hasBorder() && !leaf {
+		return nil
+	}
+	if c.area.Dx() < 2 || c.area.Dy() < 2 {
+		return nil
+	}
+
+	name := c.opts.id
+	if name == "" {
+		name = c.opts.borderTitle
+	}
+	title := fmt.Sprintf("%dx%d", c.area.Dx(), c.area.Dy())
+	if name != "" {
+		title = fmt.Sprintf("%s %s", name, title)
+	}
+	return draw.Border(cvs, c.area, draw.BorderTitle(title, draw.OverrunModeThreeDot))
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/linestyle"
+)
+
+func TestWireframe(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		size    image.Point
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc: "single container",
+			size: image.Point{12, 3},
+			want: []string{
+				"┌12x3──────┐",
+				"│          │",
+				"└──────────┘",
+			},
+		},
+		{
+			desc: "two split layout",
+			opts: []Option{
+				SplitVertical(
+					Left(
+						ID("left"),
+					),
+					Right(
+						ID("right"),
+					),
+				),
+			},
+			size: image.Point{24, 4},
+			want: []string{
+				"┌left 12x4─┐┌right 12x4┐",
+				"│          ││          │",
+				"│          ││          │",
+				"└──────────┘└──────────┘",
+			},
+		},
+		{
+			desc: "nested boxes in a bordered container",
+			opts: []Option{
+				Border(linestyle.Light),
+				BorderTitle("root"),
+				SplitHorizontal(
+					Top(
+						ID("top"),
+					),
+					Bottom(
+						SplitVertical(
+							Left(
+								ID("bl"),
+							),
+							Right(
+								ID("br"),
+							),
+						),
+					),
+				),
+			},
+			size: image.Point{20, 8},
+			want: []string{
+				"┌root 20x8─────────┐",
+				"│┌top 18x3────────┐│",
+				"││                ││",
+				"│└────────────────┘│",
+				"│┌bl 9x3─┐┌br 9x3─┐│",
+				"││       ││       ││",
+				"│└───────┘└───────┘│",
+				"└──────────────────┘",
+			},
+		},
+		{
+			desc:    "fails on a size that doesn't fit the container",
+			size:    image.Point{0, 0},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(image.Point{10, 10})
+			cont, err := New(ft, tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			wantArea := cont.area
+
+			got, err := cont.Wireframe(tc.size)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Wireframe => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			want := strings.Join(tc.want, "\n") + "\n"
+			if diff := pretty.Compare(want, got); diff != "" {
+				t.Errorf("Wireframe => unexpected diff (-want, +got):\n%s\ngot:\n%s", diff, got)
+			}
+			if cont.area != wantArea {
+				t.Errorf("Wireframe => changed the container area to %v, want %v", cont.area, wantArea)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package braille
+
+// blocks.go contains code that renders the braille canvas using block
+// elements on terminals that cannot display braille patterns.
+
+import (
+	"os"
+	"sync"
+)
+
+// support tracks whether braille patterns are used when rendering.
+var support = struct {
+	mu      sync.RWMutex
+	enabled bool
+}{
+	enabled: true,
+}
+
+// DetectSupport determines whether the terminal is likely able to display the
+// braille patterns. The fonts of the Linux virtual console don't contain
+// them, everywhere else they are assumed to be available.
+func DetectSupport() bool {
+	return os.Getenv("TERM") != "linux"
+}
+
+// SetEnabled sets whether the braille canvases render braille patterns. When
+// disabled, each cell is rendered as a block element that combines the
+// pixels into quadrants instead, which halves the vertical resolution, but
+// works with fonts that lack the braille patterns. The pixels set on the
+// canvases aren't affected, so the setting can be changed at any time.
+//
+// The setting is global and applies to all braille canvases.
+// Defaults to enabled.
+func SetEnabled(enabled bool) {
+	support.mu.Lock()
+	defer support.mu.Unlock()
+	support.enabled = enabled
+}
+
+// Enabled returns true if the braille canvases render braille patterns, see
+// SetEnabled.
+func Enabled() bool {
+	support.mu.RLock()
+	defer support.mu.RUnlock()
+	return support.enabled
+}
+
+// quadrantRunes are the block elements indexed by a bit mask of the set
+// quadrants, the bits from the least significant are the top left, the top
+// right, the bottom left and the bottom right quadrant.
+var quadrantRunes = []rune{
+	' ', '▘', '▝', '▀',
+	'▖', '▌', '▞', '▛',
+	'▗', '▚', '▐', '▜',
+	'▄', '▙', '▟', '█',
+}
+
+// blockRune returns the block element that represents the pixels of the
+// braille pattern rune. Each quadrant of the block is set if any of the two
+// pixels of the braille cell that fall into it are set.
+func blockRune(r rune) rune {
+	var mask int
+	for p, pr := range pixelRunes {
+		if r&pr == 0 {
+			continue
+		}
+		quadrant := p.X
+		if p.Y >= RowMult/2 {
+			quadrant += 2
+		}
+		mask |= 1 << uint(quadrant)
+	}
+	return quadrantRunes[mask]
+}
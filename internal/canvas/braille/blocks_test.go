@@ -0,0 +1,105 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package braille
+
+import (
+	"os"
+	"testing"
+)
+
+func TestBlockRune(t *testing.T) {
+	tests := []struct {
+		desc string
+		r    rune
+		want rune
+	}{
+		{
+			desc: "no pixels",
+			r:    '⠀',
+			want: ' ',
+		},
+		{
+			desc: "either pixel in the top left quadrant",
+			r:    '⠂',
+			want: '▘',
+		},
+		{
+			desc: "both pixels in the bottom right quadrant",
+			r:    '⢠',
+			want: '▗',
+		},
+		{
+			desc: "diagonal",
+			r:    '⡈',
+			want: '▞',
+		},
+		{
+			desc: "left column",
+			r:    '⡇',
+			want: '▌',
+		},
+		{
+			desc: "all pixels",
+			r:    '⣿',
+			want: '█',
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := blockRune(tc.r); got != tc.want {
+				t.Errorf("blockRune(%q) => %q, want %q", tc.r, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDetectSupport(t *testing.T) {
+	orig, set := os.LookupEnv("TERM")
+	defer func() {
+		if set {
+			os.Setenv("TERM", orig)
+		} else {
+			os.Unsetenv("TERM")
+		}
+	}()
+
+	tests := []struct {
+		term string
+		want bool
+	}{
+		{term: "linux", want: false},
+		{term: "xterm-256color", want: true},
+		{term: "", want: true},
+	}
+	for _, tc := range tests {
+		os.Setenv("TERM", tc.term)
+		if got := DetectSupport(); got != tc.want {
+			t.Errorf("DetectSupport with TERM=%q => %v, want %v", tc.term, got, tc.want)
+		}
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	defer SetEnabled(true)
+
+	if !Enabled() {
+		t.Fatalf("Enabled => false by default, want true")
+	}
+	SetEnabled(false)
+	if Enabled() {
+		t.Errorf("Enabled => true after SetEnabled(false), want false")
+	}
+}
@@ -91,16 +91,60 @@ var pixelRunes = map[image.Point]rune{
 type Canvas struct {
 	// regular is the regular character canvas the braille canvas is based on.
 	regular *canvas.Canvas
+
+	// ar is the area the canvas was created for.
+	ar image.Rectangle
+
+	// opts are the provided options.
+	opts *options
+}
+
+// Option is used to provide options to New.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	debugGrid bool
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// DebugGrid when provided, the canvas overlays a grid marker onto every
+// character cell that has no pixels set when it is applied to a terminal or
+// copied to another canvas. This shows the cell boundaries of the braille dot
+// grid and is intended as an aid when developing braille visualizations. The
+// markers are only added to the rendered output, the pixels on the canvas
+// aren't modified.
+func DebugGrid() Option {
+	return option(func(opts *options) {
+		opts.debugGrid = true
+	})
 }
 
 // New returns a new braille canvas for the provided area.
-func New(ar image.Rectangle) (*Canvas, error) {
+func New(ar image.Rectangle, opts ...Option) (*Canvas, error) {
 	rc, err := canvas.New(ar)
 	if err != nil {
 		return nil, err
 	}
+
+	opt := &options{}
+	for _, o := range opts {
+		o.set(opt)
+	}
 	return &Canvas{
 		regular: rc,
+		ar:      ar,
+		opts:    opt,
 	}, nil
 }
 
@@ -245,14 +289,70 @@ func (c *Canvas) SetAreaCellOpts(cellArea image.Rectangle, opts ...cell.Option)
 // Apply applies the canvas to the corresponding area of the terminal.
 // Guarantees to stay within limits of the area the canvas was created with.
 func (c *Canvas) Apply(t terminalapi.Terminal) error {
-	return c.regular.Apply(t)
+	rc, err := c.rendered()
+	if err != nil {
+		return err
+	}
+	return rc.Apply(t)
 }
 
 // CopyTo copies the content of this canvas onto the destination canvas.
 // This canvas can have an offset when compared to the destination canvas, i.e.
 // the area of this canvas doesn't have to be zero-based.
 func (c *Canvas) CopyTo(dst *canvas.Canvas) error {
-	return c.regular.CopyTo(dst)
+	rc, err := c.rendered()
+	if err != nil {
+		return err
+	}
+	return rc.CopyTo(dst)
+}
+
+const (
+	// gridMarker is the rune placed into empty cells by the DebugGrid option.
+	gridMarker = '·'
+
+	// gridColorNumber is the color number of the grid markers.
+	gridColorNumber = 240
+)
+
+// rendered returns the regular canvas that should be rendered. This is the
+// underlying canvas itself unless the DebugGrid option was provided or the
+// braille patterns are disabled, in which case it is a copy with the grid
+// markers added or the braille patterns replaced by block elements.
+func (c *Canvas) rendered() (*canvas.Canvas, error) {
+	blocks := !Enabled()
+	if !c.opts.debugGrid && !blocks {
+		return c.regular, nil
+	}
+
+	rc, err := canvas.New(c.ar)
+	if err != nil {
+		return nil, err
+	}
+	ar := c.regular.Area()
+	for col := ar.Min.X; col < ar.Max.X; col++ {
+		for row := ar.Min.Y; row < ar.Max.Y; row++ {
+			p := image.Point{col, row}
+			cur, err := c.regular.Cell(p)
+			if err != nil {
+				return nil, err
+			}
+
+			r := cur.Rune
+			opts := []cell.Option{cur.Opts}
+			switch {
+			case c.opts.debugGrid && (r == 0 || r == ' ' || r == brailleCharOffset):
+				r = gridMarker
+				opts = append(opts, cell.FgColor(cell.ColorNumber(gridColorNumber)))
+			case blocks && isBraille(r):
+				r = blockRune(r)
+			}
+			if _, err := rc.SetCell(p, r, opts...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rc, nil
 }
 
 // cellPoint determines the point (coordinate) of the character cell given
@@ -22,6 +22,7 @@ import (
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/area"
 	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
 	"github.com/mum4k/termdash/internal/faketerm"
 )
@@ -153,11 +154,13 @@ func TestNew(t *testing.T) {
 
 func TestBraille(t *testing.T) {
 	tests := []struct {
-		desc     string
-		ar       image.Rectangle
-		pixelOps func(*Canvas) error
-		want     func(size image.Point) *faketerm.Terminal
-		wantErr  bool
+		desc      string
+		ar        image.Rectangle
+		opts      []Option
+		noBraille bool
+		pixelOps  func(*Canvas) error
+		want      func(size image.Point) *faketerm.Terminal
+		wantErr   bool
 	}{
 		{
 			desc: "fails on pixel with negative X",
@@ -937,11 +940,161 @@ func TestBraille(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc:      "renders block elements when braille is disabled",
+			ar:        image.Rect(0, 0, 3, 1),
+			noBraille: true,
+			pixelOps: func(c *Canvas) error {
+				for _, p := range []image.Point{
+					{0, 0}, {0, 3}, // Top left and bottom left quadrant.
+					{3, 1},                         // Top right quadrant.
+					{4, 0}, {5, 1}, {4, 2}, {5, 3}, // All quadrants.
+				} {
+					if err := c.SetPixel(p, cell.FgColor(cell.ColorRed)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▌', cell.FgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '▝', cell.FgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{2, 0}, '█', cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:      "renders cleared braille cells as empty when braille is disabled",
+			ar:        image.Rect(0, 0, 1, 1),
+			noBraille: true,
+			pixelOps: func(c *Canvas) error {
+				if err := c.SetPixel(image.Point{0, 0}); err != nil {
+					return err
+				}
+				return c.ClearPixel(image.Point{0, 0})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, ' ')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:      "DebugGrid marks empty cells when braille is disabled",
+			ar:        image.Rect(0, 0, 2, 1),
+			opts:      []Option{DebugGrid()},
+			noBraille: true,
+			pixelOps: func(c *Canvas) error {
+				return c.SetPixel(image.Point{1, 3})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▗')
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '·', cell.FgColor(cell.ColorNumber(gridColorNumber)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "without DebugGrid empty cells stay empty",
+			ar:   image.Rect(0, 0, 2, 1),
+			pixelOps: func(c *Canvas) error {
+				return c.SetPixel(image.Point{0, 0})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '⠁')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "DebugGrid marks cells without pixels",
+			ar:   image.Rect(0, 0, 3, 2),
+			opts: []Option{
+				DebugGrid(),
+			},
+			pixelOps: func(c *Canvas) error {
+				if err := c.SetPixel(image.Point{0, 0}); err != nil {
+					return err
+				}
+				return c.SetPixel(image.Point{5, 7})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				marker := cell.FgColor(cell.ColorNumber(gridColorNumber))
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '⠁')
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '·', marker)
+				testcanvas.MustSetCell(c, image.Point{2, 0}, '·', marker)
+				testcanvas.MustSetCell(c, image.Point{0, 1}, '·', marker)
+				testcanvas.MustSetCell(c, image.Point{1, 1}, '·', marker)
+				testcanvas.MustSetCell(c, image.Point{2, 1}, '⢀')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "DebugGrid marks cells whose pixels were all cleared",
+			ar:   image.Rect(0, 0, 1, 1),
+			opts: []Option{
+				DebugGrid(),
+			},
+			pixelOps: func(c *Canvas) error {
+				if err := c.SetPixel(image.Point{0, 0}); err != nil {
+					return err
+				}
+				return c.ClearPixel(image.Point{0, 0})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '·', cell.FgColor(cell.ColorNumber(gridColorNumber)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "DebugGrid keeps the options of cells with pixels and the background of empty cells",
+			ar:   image.Rect(0, 0, 2, 1),
+			opts: []Option{
+				DebugGrid(),
+			},
+			pixelOps: func(c *Canvas) error {
+				if err := c.SetPixel(image.Point{2, 0}, cell.FgColor(cell.ColorRed)); err != nil {
+					return err
+				}
+				return c.SetCellOpts(image.Point{0, 0}, cell.BgColor(cell.ColorBlue))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '·',
+					cell.FgColor(cell.ColorNumber(gridColorNumber)),
+					cell.BgColor(cell.ColorBlue),
+				)
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '⠁', cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			bc, err := New(tc.ar)
+			if tc.noBraille {
+				SetEnabled(false)
+				defer SetEnabled(true)
+			}
+
+			bc, err := New(tc.ar, tc.opts...)
 			if err != nil {
 				t.Fatalf("New => unexpected error: %v", err)
 			}
@@ -990,3 +1143,35 @@ func TestBraille(t *testing.T) {
 		})
 	}
 }
+
+func TestDebugGridLeavesCanvasUntouched(t *testing.T) {
+	ar := image.Rect(0, 0, 2, 1)
+	bc, err := New(ar, DebugGrid())
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := bc.SetPixel(image.Point{0, 0}); err != nil {
+		t.Fatalf("SetPixel => unexpected error: %v", err)
+	}
+
+	ft := faketerm.MustNew(area.Size(ar))
+	if err := bc.Apply(ft); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+
+	// Pixels set after rendering must not inherit the grid markers.
+	if err := bc.SetPixel(image.Point{2, 0}); err != nil {
+		t.Fatalf("SetPixel => unexpected error: %v", err)
+	}
+	got, err := bc.regular.Cell(image.Point{1, 0})
+	if err != nil {
+		t.Fatalf("Cell => unexpected error: %v", err)
+	}
+	want := &buffer.Cell{
+		Rune: '⠁',
+		Opts: cell.NewOptions(),
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Cell => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buffer
+
+// blank.go contains the setting of the rune used to display empty cells.
+
+import "sync"
+
+// DefaultBlankRune is the default value for SetBlankRune.
+const DefaultBlankRune = ' '
+
+// blank tracks the rune used to display empty cells.
+var blank = struct {
+	mu sync.RWMutex
+	r  rune
+}{
+	r: DefaultBlankRune,
+}
+
+// SetBlankRune sets the rune the terminals output for empty cells, i.e. cells
+// containing the rune 0, e.g. after the canvas or the terminal was cleared.
+// The buffers keep storing empty cells as the rune 0, the rune is only
+// substituted when the cells leave termdash.
+//
+// The setting is global and applies to all terminals.
+// Defaults to DefaultBlankRune.
+func SetBlankRune(r rune) {
+	blank.mu.Lock()
+	defer blank.mu.Unlock()
+	blank.r = r
+}
+
+// BlankRune returns the rune the terminals output for empty cells, see
+// SetBlankRune.
+func BlankRune() rune {
+	blank.mu.RLock()
+	defer blank.mu.RUnlock()
+	return blank.r
+}
+
+// Visible returns the rune the terminals output for the provided rune, i.e.
+// the rune itself or the blank rune if the provided rune is 0.
+func Visible(r rune) rune {
+	if r == 0 {
+		return BlankRune()
+	}
+	return r
+}
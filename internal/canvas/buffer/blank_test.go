@@ -0,0 +1,65 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buffer
+
+import "testing"
+
+func TestVisible(t *testing.T) {
+	defer SetBlankRune(DefaultBlankRune)
+
+	tests := []struct {
+		desc  string
+		blank rune
+		r     rune
+		want  rune
+	}{
+		{
+			desc:  "empty cell is displayed as the default blank rune",
+			blank: DefaultBlankRune,
+			r:     0,
+			want:  ' ',
+		},
+		{
+			desc:  "empty cell is displayed as the configured blank rune",
+			blank: '.',
+			r:     0,
+			want:  '.',
+		},
+		{
+			desc:  "other runes are displayed as is",
+			blank: '.',
+			r:     'a',
+			want:  'a',
+		},
+		{
+			desc:  "space isn't replaced with the blank rune",
+			blank: '.',
+			r:     ' ',
+			want:  ' ',
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			SetBlankRune(tc.blank)
+			if got := BlankRune(); got != tc.blank {
+				t.Errorf("BlankRune => %q, want %q", got, tc.blank)
+			}
+			if got := Visible(tc.r); got != tc.want {
+				t.Errorf("Visible(%q) => %q, want %q", tc.r, got, tc.want)
+			}
+		})
+	}
+}
@@ -101,7 +101,19 @@ func New(size image.Point) (Buffer, error) {
 // printed on the terminal. See http://www.unicode.org/reports/tr11/.
 // Use the options to specify which attributes to modify, if an attribute
 // option isn't specified, the attribute retains its previous value.
+// Cells that are protected, see cell.Protected, aren't modified.
 func (b Buffer) SetCell(p image.Point, r rune, opts ...cell.Option) (int, error) {
+	return b.setCell(p, r, false, opts...)
+}
+
+// OverwriteCell is like SetCell, but also modifies protected cells.
+func (b Buffer) OverwriteCell(p image.Point, r rune, opts ...cell.Option) (int, error) {
+	return b.setCell(p, r, true, opts...)
+}
+
+// setCell sets the rune of the specified cell in the buffer. Protected cells
+// are only modified if overwrite is true.
+func (b Buffer) setCell(p image.Point, r rune, overwrite bool, opts ...cell.Option) (int, error) {
 	partial, err := b.IsPartial(p)
 	if err != nil {
 		return -1, err
@@ -125,6 +137,9 @@ func (b Buffer) SetCell(p image.Point, r rune, opts ...cell.Option) (int, error)
 	}
 
 	c := b[p.X][p.Y]
+	if c.Opts.Protected && !overwrite {
+		return rw, nil
+	}
 	c.Rune = r
 	c.Apply(opts...)
 	return rw, nil
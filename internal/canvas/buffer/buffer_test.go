@@ -203,6 +203,18 @@ func TestCellApply(t *testing.T) {
 				cell.BgColor(cell.ColorBlack),
 			),
 		},
+		{
+			desc: "retains italic",
+			cell: NewCell(0, cell.Italic(true)),
+			opts: []cell.Option{
+				cell.FgColor(cell.ColorRed),
+			},
+			want: NewCell(
+				0,
+				cell.Italic(true),
+				cell.FgColor(cell.ColorRed),
+			),
+		},
 	}
 
 	for _, tc := range tests {
@@ -319,6 +331,7 @@ func TestSetCell(t *testing.T) {
 		point     image.Point
 		r         rune
 		opts      []cell.Option
+		overwrite bool
 		wantCells int
 		want      Buffer
 		wantErr   bool
@@ -463,11 +476,75 @@ func TestSetCell(t *testing.T) {
 				return b
 			}(),
 		},
+		{
+			desc: "doesn't modify a protected cell",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				c := b[1][2]
+				c.Rune = 'W'
+				c.Opts = cell.NewOptions(cell.FgColor(cell.ColorBlue), cell.Protected())
+				return b
+			}(),
+			point: image.Point{1, 2},
+			r:     'A',
+			opts: []cell.Option{
+				cell.FgColor(cell.ColorRed),
+			},
+			wantCells: 1,
+			want: func() Buffer {
+				b := mustNew(size)
+				c := b[1][2]
+				c.Rune = 'W'
+				c.Opts = cell.NewOptions(cell.FgColor(cell.ColorBlue), cell.Protected())
+				return b
+			}(),
+		},
+		{
+			desc: "overwrites a protected cell",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				c := b[1][2]
+				c.Rune = 'W'
+				c.Opts = cell.NewOptions(cell.FgColor(cell.ColorBlue), cell.Protected())
+				return b
+			}(),
+			point: image.Point{1, 2},
+			r:     'A',
+			opts: []cell.Option{
+				cell.FgColor(cell.ColorRed),
+			},
+			overwrite: true,
+			wantCells: 1,
+			want: func() Buffer {
+				b := mustNew(size)
+				c := b[1][2]
+				c.Rune = 'A'
+				c.Opts = cell.NewOptions(cell.FgColor(cell.ColorRed), cell.Protected())
+				return b
+			}(),
+		},
+		{
+			desc:      "overwrites an unprotected cell",
+			buffer:    mustNew(image.Point{3, 3}),
+			point:     image.Point{1, 1},
+			r:         'A',
+			overwrite: true,
+			wantCells: 1,
+			want: func() Buffer {
+				b := mustNew(size)
+				b[1][1].Rune = 'A'
+				return b
+			}(),
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			gotCells, err := tc.buffer.SetCell(tc.point, tc.r, tc.opts...)
+			setCell := tc.buffer.SetCell
+			if tc.overwrite {
+				setCell = tc.buffer.OverwriteCell
+			}
+			gotCells, err := setCell(tc.point, tc.r, tc.opts...)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("SetCell => unexpected error: %v, wantErr: %v", err, tc.wantErr)
 			}
@@ -53,6 +53,27 @@ func New(ar image.Rectangle) (*Canvas, error) {
 	}, nil
 }
 
+// SubCanvas returns a view of the specified area of this canvas. The point
+// image.Point{0, 0} of the returned canvas corresponds to r.Min on this
+// canvas. The returned canvas doesn't have its own buffer, any changes made
+// to it are immediately visible on this canvas and vice versa. Changes to the
+// returned canvas cannot reach cells of this canvas outside of r.
+// The area must fall within the area of this canvas and cannot be empty.
+func (c *Canvas) SubCanvas(r image.Rectangle) (*Canvas, error) {
+	if ar := c.Area(); !r.In(ar) || r.Empty() {
+		return nil, fmt.Errorf("unable to create a sub-canvas for area %v, it must be non-empty and fit inside the canvas area %v", r, ar)
+	}
+
+	b := make(buffer.Buffer, r.Dx())
+	for col := range b {
+		b[col] = c.buffer[r.Min.X+col][r.Min.Y:r.Max.Y:r.Max.Y]
+	}
+	return &Canvas{
+		area:   r.Add(c.area.Min),
+		buffer: b,
+	}, nil
+}
+
 // Size returns the size of the 2-D canvas.
 func (c *Canvas) Size() image.Point {
 	return c.buffer.Size()
@@ -66,11 +87,13 @@ func (c *Canvas) Area() image.Rectangle {
 
 // Clear clears all the content on the canvas.
 func (c *Canvas) Clear() error {
-	b, err := buffer.New(c.Size())
-	if err != nil {
-		return err
+	// Cells are cleared in place, since the buffer can be shared with a
+	// parent canvas, see SubCanvas.
+	for col := range c.buffer {
+		for row := range c.buffer[col] {
+			*c.buffer[col][row] = *buffer.NewCell(0)
+		}
 	}
-	c.buffer = b
 	return nil
 }
 
@@ -79,10 +102,16 @@ func (c *Canvas) Clear() error {
 // printed on the terminal. See http://www.unicode.org/reports/tr11/.
 // Use the options to specify which attributes to modify, if an attribute
 // option isn't specified, the attribute retains its previous value.
+// Cells that are protected, see cell.Protected, aren't modified.
 func (c *Canvas) SetCell(p image.Point, r rune, opts ...cell.Option) (int, error) {
 	return c.buffer.SetCell(p, r, opts...)
 }
 
+// OverwriteCell is like SetCell, but also modifies protected cells.
+func (c *Canvas) OverwriteCell(p image.Point, r rune, opts ...cell.Option) (int, error) {
+	return c.buffer.OverwriteCell(p, r, opts...)
+}
+
 // Cell returns a copy of the specified cell.
 func (c *Canvas) Cell(p image.Point) (*buffer.Cell, error) {
 	ar, err := area.FromSize(c.Size())
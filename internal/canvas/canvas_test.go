@@ -721,6 +721,26 @@ func TestSetCellAndApply(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:       "retains the underline options",
+			termSize:   image.Point{1, 1},
+			canvasArea: image.Rect(0, 0, 1, 1),
+			point:      image.Point{0, 0},
+			r:          'A',
+			opts: []cell.Option{
+				cell.UnderlineStyle(cell.UnderlineCurly),
+				cell.UnderlineColor(cell.ColorRed),
+			},
+			wantCells: 1,
+			want: buffer.Buffer{
+				{
+					buffer.NewCell('A',
+						cell.UnderlineStyle(cell.UnderlineCurly),
+						cell.UnderlineColor(cell.ColorRed),
+					),
+				},
+			},
+		},
 		{
 			desc:       "canvas size equals terminal size",
 			termSize:   image.Point{1, 1},
@@ -862,6 +882,251 @@ func TestClear(t *testing.T) {
 	}
 }
 
+func TestSubCanvas(t *testing.T) {
+	tests := []struct {
+		desc string
+		// parent is the area of the parent canvas.
+		parent image.Rectangle
+		// prepare, if not nil, is called to draw on the parent canvas before
+		// the sub-canvas is created.
+		prepare func(*Canvas) error
+		sub     image.Rectangle
+		// draw is called to draw on the sub-canvas.
+		draw         func(*Canvas) error
+		wantSubErr   bool
+		wantDrawErr  bool
+		wantSubArea  image.Rectangle
+		wantSubSize  image.Point
+		wantParent   buffer.Buffer
+		wantTerminal buffer.Buffer
+	}{
+		{
+			desc:       "fails when the area falls outside of the parent",
+			parent:     image.Rect(0, 0, 2, 2),
+			sub:        image.Rect(1, 1, 3, 3),
+			wantSubErr: true,
+		},
+		{
+			desc:       "fails when the area is empty",
+			parent:     image.Rect(0, 0, 2, 2),
+			sub:        image.Rect(1, 1, 1, 1),
+			wantSubErr: true,
+		},
+		{
+			desc:        "writes land at the correct parent coordinates",
+			parent:      image.Rect(0, 0, 3, 3),
+			sub:         image.Rect(1, 1, 3, 3),
+			wantSubArea: image.Rect(0, 0, 2, 2),
+			wantSubSize: image.Point{2, 2},
+			draw: func(c *Canvas) error {
+				if _, err := c.SetCell(image.Point{0, 0}, 'X'); err != nil {
+					return err
+				}
+				_, err := c.SetCell(image.Point{1, 1}, 'Y', cell.FgColor(cell.ColorRed))
+				return err
+			},
+			wantParent: buffer.Buffer{
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell('X'),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell('Y', cell.FgColor(cell.ColorRed)),
+				},
+			},
+			wantTerminal: buffer.Buffer{
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell('X'),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell('Y', cell.FgColor(cell.ColorRed)),
+				},
+			},
+		},
+		{
+			desc:        "sub-canvas of a parent with an offset applies to the correct terminal coordinates",
+			parent:      image.Rect(1, 0, 3, 3),
+			sub:         image.Rect(1, 2, 2, 3),
+			wantSubArea: image.Rect(0, 0, 1, 1),
+			wantSubSize: image.Point{1, 1},
+			draw: func(c *Canvas) error {
+				_, err := c.SetCell(image.Point{0, 0}, 'X')
+				return err
+			},
+			wantParent: buffer.Buffer{
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell('X'),
+				},
+			},
+			wantTerminal: buffer.Buffer{
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+					buffer.NewCell('X'),
+				},
+			},
+		},
+		{
+			desc:        "writes outside of the view are clipped",
+			parent:      image.Rect(0, 0, 3, 2),
+			sub:         image.Rect(1, 0, 2, 2),
+			wantSubArea: image.Rect(0, 0, 1, 2),
+			wantSubSize: image.Point{1, 2},
+			draw: func(c *Canvas) error {
+				_, err := c.SetCell(image.Point{1, 0}, 'X')
+				return err
+			},
+			wantDrawErr: true,
+			wantParent: buffer.Buffer{
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+					buffer.NewCell(0),
+				},
+			},
+		},
+		{
+			desc:        "full-width rune that doesn't fit the view is clipped",
+			parent:      image.Rect(0, 0, 3, 1),
+			sub:         image.Rect(0, 0, 1, 1),
+			wantSubArea: image.Rect(0, 0, 1, 1),
+			wantSubSize: image.Point{1, 1},
+			draw: func(c *Canvas) error {
+				_, err := c.SetCell(image.Point{0, 0}, '界')
+				return err
+			},
+			wantDrawErr: true,
+			wantParent: buffer.Buffer{
+				{
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell(0),
+				},
+			},
+		},
+		{
+			desc:   "clear only clears the view",
+			parent: image.Rect(0, 0, 2, 2),
+			prepare: func(c *Canvas) error {
+				return c.SetAreaCells(c.Area(), 'X')
+			},
+			sub:         image.Rect(0, 1, 2, 2),
+			wantSubArea: image.Rect(0, 0, 2, 1),
+			wantSubSize: image.Point{2, 1},
+			draw: func(c *Canvas) error {
+				return c.Clear()
+			},
+			wantParent: buffer.Buffer{
+				{
+					buffer.NewCell('X'),
+					buffer.NewCell(0),
+				},
+				{
+					buffer.NewCell('X'),
+					buffer.NewCell(0),
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			parent, err := New(tc.parent)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.prepare != nil {
+				if err := tc.prepare(parent); err != nil {
+					t.Fatalf("tc.prepare => unexpected error: %v", err)
+				}
+			}
+
+			sub, err := parent.SubCanvas(tc.sub)
+			if (err != nil) != tc.wantSubErr {
+				t.Errorf("SubCanvas => unexpected error: %v, wantSubErr: %v", err, tc.wantSubErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := sub.Area(); got != tc.wantSubArea {
+				t.Errorf("Area => got %v, want %v", got, tc.wantSubArea)
+			}
+			if got := sub.Size(); got != tc.wantSubSize {
+				t.Errorf("Size => got %v, want %v", got, tc.wantSubSize)
+			}
+
+			err = tc.draw(sub)
+			if (err != nil) != tc.wantDrawErr {
+				t.Errorf("tc.draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
+			}
+
+			if diff := pretty.Compare(tc.wantParent, parent.buffer); diff != "" {
+				t.Errorf("parent buffer => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			if tc.wantTerminal == nil {
+				return
+			}
+			ft, err := faketerm.New(area.Size(image.Rect(0, 0, tc.parent.Max.X, tc.parent.Max.Y)))
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := sub.Apply(ft); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.wantTerminal, ft.BackBuffer()); diff != "" {
+				t.Errorf("faketerm.BackBuffer => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // TestApplyFullWidthRunes verifies that when applying a full-width rune to the
 // terminal, canvas doesn't touch the neighbor cell that holds the remaining
 // part of the full-width rune.
@@ -37,10 +37,12 @@ type BorderOption interface {
 type borderOptions struct {
 	cellOpts      []cell.Option
 	lineStyle     linestyle.LineStyle
+	cornerStyle   linestyle.LineStyle
 	title         string
 	titleOM       OverrunMode
 	titleCellOpts []cell.Option
 	titleHAlign   align.Horizontal
+	sides         BorderSidesSet
 }
 
 // borderOption implements BorderOption.
@@ -61,6 +63,17 @@ func BorderLineStyle(ls linestyle.LineStyle) BorderOption {
 	})
 }
 
+// BorderCornerStyle sets the style of the line used to draw the four corners
+// of the border independently of the style used for its edges, e.g. rounded
+// corners with double edges.
+// If not provided or set to linestyle.None, the corners are drawn using the
+// style set by BorderLineStyle.
+func BorderCornerStyle(ls linestyle.LineStyle) BorderOption {
+	return borderOption(func(bOpts *borderOptions) {
+		bOpts.cornerStyle = ls
+	})
+}
+
 // BorderCellOpts sets options on the cells that create the border.
 func BorderCellOpts(opts ...cell.Option) BorderOption {
 	return borderOption(func(bOpts *borderOptions) {
@@ -77,6 +90,28 @@ func BorderTitle(title string, overrun OverrunMode, opts ...cell.Option) BorderO
 	})
 }
 
+// BorderSidesSet selects the sides of the border that are drawn.
+type BorderSidesSet struct {
+	Top    bool
+	Right  bool
+	Bottom bool
+	Left   bool
+}
+
+// AllBorderSides selects all four sides of the border.
+var AllBorderSides = BorderSidesSet{Top: true, Right: true, Bottom: true, Left: true}
+
+// BorderSides limits the border to the selected sides. The corners are only
+// drawn where both of the adjacent sides are selected, a side that has only
+// one of its neighbours continues with a straight line into the corner cell.
+// The title is only drawn when the top side is selected.
+// Defaults to AllBorderSides.
+func BorderSides(sides BorderSidesSet) BorderOption {
+	return borderOption(func(bOpts *borderOptions) {
+		bOpts.sides = sides
+	})
+}
+
 // BorderTitleAlign configures the horizontal alignment for the title.
 func BorderTitleAlign(h align.Horizontal) BorderOption {
 	return borderOption(func(bOpts *borderOptions) {
@@ -85,21 +120,25 @@ func BorderTitleAlign(h align.Horizontal) BorderOption {
 }
 
 // borderChar returns the correct border character from the parts for the use
-// at the specified point of the border. Returns -1 if no character should be at
-// this point.
-func borderChar(p image.Point, border image.Rectangle, parts map[linePart]rune) rune {
+// at the specified point of the border. The corner characters are taken from
+// the corners parts. Returns -1 if no character should be at this point.
+func borderChar(p image.Point, border image.Rectangle, sides BorderSidesSet, parts, corners map[linePart]rune) rune {
+	top := sides.Top && p.Y == border.Min.Y
+	bottom := sides.Bottom && p.Y == border.Max.Y-1
+	left := sides.Left && p.X == border.Min.X
+	right := sides.Right && p.X == border.Max.X-1
 	switch {
-	case p.X == border.Min.X && p.Y == border.Min.Y:
-		return parts[topLeftCorner]
-	case p.X == border.Max.X-1 && p.Y == border.Min.Y:
-		return parts[topRightCorner]
-	case p.X == border.Min.X && p.Y == border.Max.Y-1:
-		return parts[bottomLeftCorner]
-	case p.X == border.Max.X-1 && p.Y == border.Max.Y-1:
-		return parts[bottomRightCorner]
-	case p.X == border.Min.X || p.X == border.Max.X-1:
+	case top && left:
+		return corners[topLeftCorner]
+	case top && right:
+		return corners[topRightCorner]
+	case bottom && left:
+		return corners[bottomLeftCorner]
+	case bottom && right:
+		return corners[bottomRightCorner]
+	case left || right:
 		return parts[vLine]
-	case p.Y == border.Min.Y || p.Y == border.Max.Y-1:
+	case top || bottom:
 		return parts[hLine]
 	}
 	return -1
@@ -147,6 +186,7 @@ func Border(c *canvas.Canvas, border image.Rectangle, opts ...BorderOption) erro
 
 	opt := &borderOptions{
 		lineStyle: DefaultBorderLineStyle,
+		sides:     AllBorderSides,
 	}
 	for _, o := range opts {
 		o.set(opt)
@@ -156,11 +196,19 @@ func Border(c *canvas.Canvas, border image.Rectangle, opts ...BorderOption) erro
 	if err != nil {
 		return err
 	}
+	corners := parts
+	if opt.cornerStyle != linestyle.None {
+		cp, err := lineParts(opt.cornerStyle)
+		if err != nil {
+			return err
+		}
+		corners = cp
+	}
 
 	for col := border.Min.X; col < border.Max.X; col++ {
 		for row := border.Min.Y; row < border.Max.Y; row++ {
 			p := image.Point{col, row}
-			r := borderChar(p, border, parts)
+			r := borderChar(p, border, opt.sides, parts, corners)
 			if r == -1 {
 				continue
 			}
@@ -175,7 +223,7 @@ func Border(c *canvas.Canvas, border image.Rectangle, opts ...BorderOption) erro
 		}
 	}
 
-	if opt.title != "" {
+	if opt.title != "" && opt.sides.Top {
 		return drawTitle(c, border, opt)
 	}
 	return nil
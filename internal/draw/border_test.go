@@ -155,6 +155,166 @@ func TestBorder(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "unsupported corner style",
+			canvas: image.Rect(0, 0, 4, 4),
+			border: image.Rect(0, 0, 2, 2),
+			opts: []BorderOption{
+				BorderCornerStyle(linestyle.LineStyle(-1)),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws border with round corners and light edges",
+			canvas: image.Rect(0, 0, 4, 4),
+			border: image.Rect(0, 0, 4, 4),
+			opts: []BorderOption{
+				BorderLineStyle(linestyle.Light),
+				BorderCornerStyle(linestyle.Round),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '╭')
+				testcanvas.MustSetCell(c, image.Point{0, 1}, '│')
+				testcanvas.MustSetCell(c, image.Point{0, 2}, '│')
+				testcanvas.MustSetCell(c, image.Point{0, 3}, '╰')
+
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '─')
+				testcanvas.MustSetCell(c, image.Point{1, 3}, '─')
+
+				testcanvas.MustSetCell(c, image.Point{2, 0}, '─')
+				testcanvas.MustSetCell(c, image.Point{2, 3}, '─')
+
+				testcanvas.MustSetCell(c, image.Point{3, 0}, '╮')
+				testcanvas.MustSetCell(c, image.Point{3, 1}, '│')
+				testcanvas.MustSetCell(c, image.Point{3, 2}, '│')
+				testcanvas.MustSetCell(c, image.Point{3, 3}, '╯')
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws border with light corners and double edges",
+			canvas: image.Rect(0, 0, 4, 4),
+			border: image.Rect(0, 0, 4, 4),
+			opts: []BorderOption{
+				BorderLineStyle(linestyle.Double),
+				BorderCornerStyle(linestyle.Light),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, lineStyleChars[linestyle.Light][topLeftCorner])
+				testcanvas.MustSetCell(c, image.Point{0, 1}, lineStyleChars[linestyle.Double][vLine])
+				testcanvas.MustSetCell(c, image.Point{0, 2}, lineStyleChars[linestyle.Double][vLine])
+				testcanvas.MustSetCell(c, image.Point{0, 3}, lineStyleChars[linestyle.Light][bottomLeftCorner])
+
+				testcanvas.MustSetCell(c, image.Point{1, 0}, lineStyleChars[linestyle.Double][hLine])
+				testcanvas.MustSetCell(c, image.Point{1, 3}, lineStyleChars[linestyle.Double][hLine])
+
+				testcanvas.MustSetCell(c, image.Point{2, 0}, lineStyleChars[linestyle.Double][hLine])
+				testcanvas.MustSetCell(c, image.Point{2, 3}, lineStyleChars[linestyle.Double][hLine])
+
+				testcanvas.MustSetCell(c, image.Point{3, 0}, lineStyleChars[linestyle.Light][topRightCorner])
+				testcanvas.MustSetCell(c, image.Point{3, 1}, lineStyleChars[linestyle.Double][vLine])
+				testcanvas.MustSetCell(c, image.Point{3, 2}, lineStyleChars[linestyle.Double][vLine])
+				testcanvas.MustSetCell(c, image.Point{3, 3}, lineStyleChars[linestyle.Light][bottomRightCorner])
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws only the top and bottom sides",
+			canvas: image.Rect(0, 0, 4, 4),
+			border: image.Rect(0, 0, 4, 4),
+			opts: []BorderOption{
+				BorderSides(BorderSidesSet{Top: true, Bottom: true}),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for x := 0; x < 4; x++ {
+					testcanvas.MustSetCell(c, image.Point{x, 0}, lineStyleChars[linestyle.Light][hLine])
+					testcanvas.MustSetCell(c, image.Point{x, 3}, lineStyleChars[linestyle.Light][hLine])
+				}
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws only the left side",
+			canvas: image.Rect(0, 0, 4, 4),
+			border: image.Rect(0, 0, 4, 4),
+			opts: []BorderOption{
+				BorderSides(BorderSidesSet{Left: true}),
+				BorderCornerStyle(linestyle.Round),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for y := 0; y < 4; y++ {
+					testcanvas.MustSetCell(c, image.Point{0, y}, lineStyleChars[linestyle.Light][vLine])
+				}
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws corners only where both adjacent sides are drawn",
+			canvas: image.Rect(0, 0, 4, 4),
+			border: image.Rect(0, 0, 4, 4),
+			opts: []BorderOption{
+				BorderSides(BorderSidesSet{Top: true, Left: true}),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, lineStyleChars[linestyle.Light][topLeftCorner])
+				testcanvas.MustSetCell(c, image.Point{0, 1}, lineStyleChars[linestyle.Light][vLine])
+				testcanvas.MustSetCell(c, image.Point{0, 2}, lineStyleChars[linestyle.Light][vLine])
+				testcanvas.MustSetCell(c, image.Point{0, 3}, lineStyleChars[linestyle.Light][vLine])
+
+				testcanvas.MustSetCell(c, image.Point{1, 0}, lineStyleChars[linestyle.Light][hLine])
+				testcanvas.MustSetCell(c, image.Point{2, 0}, lineStyleChars[linestyle.Light][hLine])
+				testcanvas.MustSetCell(c, image.Point{3, 0}, lineStyleChars[linestyle.Light][hLine])
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "doesn't draw the title without the top side",
+			canvas: image.Rect(0, 0, 4, 2),
+			border: image.Rect(0, 0, 4, 2),
+			opts: []BorderOption{
+				BorderSides(BorderSidesSet{Bottom: true}),
+				BorderTitle("ab", OverrunModeStrict),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for x := 0; x < 4; x++ {
+					testcanvas.MustSetCell(c, image.Point{x, 1}, lineStyleChars[linestyle.Light][hLine])
+				}
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "draws border in the canvas",
 			canvas: image.Rect(0, 0, 4, 4),
@@ -0,0 +1,137 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package draw
+
+// braille_polygon.go implements the scanline algorithm for filling polygons on the braille canvas.
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	"github.com/mum4k/termdash/internal/canvas/braille"
+)
+
+// BrailleFillPolygon fills the polygon with the specified vertices on the
+// braille canvas. The polygon is closed automatically, i.e. the last vertex
+// is connected back to the first one. Vertices are pixel coordinates and the
+// pixels on the edges of the polygon are filled along with its interior.
+// Self-intersecting polygons are filled according to the even-odd rule.
+// The polygon can extend outside of the canvas, only the pixels that fall
+// within the canvas are filled.
+// Accepts the same options as BrailleFill.
+func BrailleFillPolygon(bc *braille.Canvas, points []image.Point, opts ...BrailleFillOption) error {
+	if min := 3; len(points) < min {
+		return fmt.Errorf("a polygon requires at least %d points, got %d", min, len(points))
+	}
+
+	opt := newBrailleFillOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+
+	ar := bc.Area()
+	filled := map[image.Point]bool{}
+	visit := func(p image.Point) error {
+		if !p.In(ar) || filled[p] {
+			return nil
+		}
+		filled[p] = true
+
+		switch opt.pixelChange {
+		case braillePixelChangeSet:
+			return bc.SetPixel(p, opt.cellOpts...)
+		case braillePixelChangeClear:
+			return bc.ClearPixel(p, opt.cellOpts...)
+		}
+		return nil
+	}
+
+	// Edges are drawn as lines so that pixels on the border are filled
+	// consistently with BrailleLine, including horizontal edges and vertices
+	// the scanlines only touch.
+	for i, start := range points {
+		end := points[(i+1)%len(points)]
+		for _, p := range brailleLinePoints(start, end) {
+			if err := visit(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	minY, maxY := points[0].Y, points[0].Y
+	for _, p := range points {
+		if p.Y < minY {
+			minY = p.Y
+		}
+		if p.Y > maxY {
+			maxY = p.Y
+		}
+	}
+	if minY < ar.Min.Y {
+		minY = ar.Min.Y
+	}
+	if maxY > ar.Max.Y-1 {
+		maxY = ar.Max.Y - 1
+	}
+
+	for y := minY; y <= maxY; y++ {
+		xs := scanlineCrossings(points, y)
+		for i := 0; i+1 < len(xs); i += 2 {
+			from := int(math.Ceil(xs[i]))
+			to := int(math.Floor(xs[i+1]))
+			if from < ar.Min.X {
+				from = ar.Min.X
+			}
+			if to > ar.Max.X-1 {
+				to = ar.Max.X - 1
+			}
+			for x := from; x <= to; x++ {
+				if err := visit(image.Point{x, y}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// scanlineCrossings returns the sorted X coordinates where the horizontal
+// scanline at the specified Y coordinate crosses the edges of the polygon.
+// Each edge includes its upper vertex and excludes the lower one, so vertices
+// shared by two edges aren't counted twice and horizontal edges are ignored.
+func scanlineCrossings(points []image.Point, y int) []float64 {
+	var xs []float64
+	for i, p1 := range points {
+		p2 := points[(i+1)%len(points)]
+		if p1.Y == p2.Y {
+			continue
+		}
+
+		top, bottom := p1, p2
+		if top.Y > bottom.Y {
+			top, bottom = bottom, top
+		}
+		if y < top.Y || y >= bottom.Y {
+			continue
+		}
+
+		x := float64(top.X) + float64(y-top.Y)*float64(bottom.X-top.X)/float64(bottom.Y-top.Y)
+		xs = append(xs, x)
+	}
+	sort.Float64s(xs)
+	return xs
+}
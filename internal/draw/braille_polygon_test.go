@@ -0,0 +1,201 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package draw
+
+import (
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/area"
+	"github.com/mum4k/termdash/internal/canvas/braille"
+	"github.com/mum4k/termdash/internal/canvas/braille/testbraille"
+	"github.com/mum4k/termdash/internal/faketerm"
+)
+
+func TestBrailleFillPolygon(t *testing.T) {
+	tests := []struct {
+		desc   string
+		canvas image.Rectangle
+		points []image.Point
+
+		// If not nil, called to prepare the braille canvas before running the test.
+		prepare func(*braille.Canvas) error
+
+		opts    []BrailleFillOption
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:    "fails with less than three points",
+			canvas:  image.Rect(0, 0, 3, 2),
+			points:  []image.Point{{0, 0}, {4, 0}},
+			wantErr: true,
+		},
+		{
+			desc:   "fills a triangle",
+			canvas: image.Rect(0, 0, 3, 2),
+			points: []image.Point{{0, 0}, {4, 0}, {0, 4}},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				for y := 0; y <= 4; y++ {
+					for x := 0; x+y <= 4; x++ {
+						testbraille.MustSetPixel(bc, image.Point{x, y})
+					}
+				}
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "fills a triangle and sets cell options",
+			canvas: image.Rect(0, 0, 3, 2),
+			points: []image.Point{{0, 0}, {4, 0}, {0, 4}},
+			opts: []BrailleFillOption{
+				BrailleFillCellOpts(cell.FgColor(cell.ColorRed)),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				for y := 0; y <= 4; y++ {
+					for x := 0; x+y <= 4; x++ {
+						testbraille.MustSetPixel(bc, image.Point{x, y}, cell.FgColor(cell.ColorRed))
+					}
+				}
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "doesn't fill points outside of a concave polygon",
+			canvas: image.Rect(0, 0, 3, 2),
+			points: []image.Point{{0, 0}, {2, 2}, {4, 0}, {4, 4}, {0, 4}},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testbraille.MustSetPixel(bc, image.Point{0, 0})
+				testbraille.MustSetPixel(bc, image.Point{4, 0})
+				testbraille.MustSetPixel(bc, image.Point{0, 1})
+				testbraille.MustSetPixel(bc, image.Point{1, 1})
+				testbraille.MustSetPixel(bc, image.Point{3, 1})
+				testbraille.MustSetPixel(bc, image.Point{4, 1})
+				for y := 2; y <= 4; y++ {
+					for x := 0; x <= 4; x++ {
+						testbraille.MustSetPixel(bc, image.Point{x, y})
+					}
+				}
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clips the polygon to the canvas",
+			canvas: image.Rect(0, 0, 3, 2),
+			points: []image.Point{{-2, -2}, {3, -2}, {3, 10}, {-2, 10}},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				for y := 0; y <= 7; y++ {
+					for x := 0; x <= 3; x++ {
+						testbraille.MustSetPixel(bc, image.Point{x, y})
+					}
+				}
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "polygon completely outside of the canvas",
+			canvas: image.Rect(0, 0, 3, 2),
+			points: []image.Point{{10, 10}, {20, 10}, {10, 20}},
+		},
+		{
+			desc:   "clears pixels instead of setting them",
+			canvas: image.Rect(0, 0, 3, 2),
+			points: []image.Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}},
+			opts: []BrailleFillOption{
+				BrailleFillClearPixels(),
+			},
+			prepare: func(bc *braille.Canvas) error {
+				for _, p := range []image.Point{{0, 0}, {1, 1}, {2, 2}} {
+					if err := bc.SetPixel(p); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testbraille.MustSetPixel(bc, image.Point{0, 0})
+				testbraille.MustClearPixel(bc, image.Point{0, 0})
+				testbraille.MustSetPixel(bc, image.Point{2, 2})
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			bc, err := braille.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("braille.New => unexpected error: %v", err)
+			}
+
+			if tc.prepare != nil {
+				if err := tc.prepare(bc); err != nil {
+					t.Fatalf("tc.prepare => unexpected error: %v", err)
+				}
+			}
+
+			err = BrailleFillPolygon(bc, tc.points, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("BrailleFillPolygon => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			size := area.Size(tc.canvas)
+			want := faketerm.MustNew(size)
+			if tc.want != nil {
+				want = tc.want(size)
+			}
+
+			got, err := faketerm.New(size)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := bc.Apply(got); err != nil {
+				t.Fatalf("bc.Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Fatalf("BrailleFillPolygon => %v", diff)
+			}
+		})
+	}
+}
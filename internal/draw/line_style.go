@@ -127,3 +127,65 @@ const (
 	vAndRight
 	vAndH
 )
+
+// Directions in which a line part extends from the center of its cell.
+const (
+	dirUp = 1 << iota
+	dirDown
+	dirLeft
+	dirRight
+)
+
+// linePartDirs maps line parts to the directions they extend to.
+var linePartDirs = map[linePart]int{
+	hLine:             dirLeft | dirRight,
+	vLine:             dirUp | dirDown,
+	topLeftCorner:     dirDown | dirRight,
+	topRightCorner:    dirDown | dirLeft,
+	bottomLeftCorner:  dirUp | dirRight,
+	bottomRightCorner: dirUp | dirLeft,
+	hAndUp:            dirLeft | dirRight | dirUp,
+	hAndDown:          dirLeft | dirRight | dirDown,
+	vAndLeft:          dirUp | dirDown | dirLeft,
+	vAndRight:         dirUp | dirDown | dirRight,
+	vAndH:             dirUp | dirDown | dirLeft | dirRight,
+}
+
+// runeDirs returns the directions the line part represented by the rune
+// extends to. Returns false if the rune isn't a line part of any of the
+// supported line styles.
+func runeDirs(r rune) (int, bool) {
+	for _, parts := range lineStyleChars {
+		for part, pr := range parts {
+			if pr == r {
+				return linePartDirs[part], true
+			}
+		}
+	}
+	return 0, false
+}
+
+// MergeLineRunes returns the line character that represents both of the
+// provided line characters drawn in the same cell, e.g. merging '┐' with '┌'
+// results in '┬'. The resulting character uses the provided line style.
+// Returns false if either of the runes isn't a line character.
+func MergeLineRunes(ls linestyle.LineStyle, a, b rune) (rune, bool, error) {
+	parts, err := lineParts(ls)
+	if err != nil {
+		return -1, false, err
+	}
+
+	aDirs, aOk := runeDirs(a)
+	bDirs, bOk := runeDirs(b)
+	if !aOk || !bOk {
+		return -1, false, nil
+	}
+
+	dirs := aDirs | bDirs
+	for part, pd := range linePartDirs {
+		if pd == dirs {
+			return parts[part], true, nil
+		}
+	}
+	return -1, false, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package draw
+
+import (
+	"testing"
+
+	"github.com/mum4k/termdash/linestyle"
+)
+
+func TestMergeLineRunes(t *testing.T) {
+	tests := []struct {
+		desc    string
+		ls      linestyle.LineStyle
+		a, b    rune
+		want    rune
+		wantOk  bool
+		wantErr bool
+	}{
+		{
+			desc:    "fails on unsupported line style",
+			ls:      linestyle.LineStyle(-1),
+			a:       '│',
+			b:       '│',
+			wantErr: true,
+		},
+		{
+			desc: "not ok when the first rune isn't a line part",
+			ls:   linestyle.Light,
+			a:    'a',
+			b:    '│',
+		},
+		{
+			desc: "not ok when the second rune isn't a line part",
+			ls:   linestyle.Light,
+			a:    '│',
+			b:    'a',
+		},
+		{
+			desc:   "merging identical lines",
+			ls:     linestyle.Light,
+			a:      '│',
+			b:      '│',
+			want:   '│',
+			wantOk: true,
+		},
+		{
+			desc:   "top corners merge into a T",
+			ls:     linestyle.Light,
+			a:      '┐',
+			b:      '┌',
+			want:   '┬',
+			wantOk: true,
+		},
+		{
+			desc:   "bottom corners merge into an inverted T",
+			ls:     linestyle.Light,
+			a:      '┘',
+			b:      '└',
+			want:   '┴',
+			wantOk: true,
+		},
+		{
+			desc:   "left corners merge into a T facing right",
+			ls:     linestyle.Light,
+			a:      '└',
+			b:      '┌',
+			want:   '├',
+			wantOk: true,
+		},
+		{
+			desc:   "horizontal and vertical lines cross",
+			ls:     linestyle.Light,
+			a:      '─',
+			b:      '│',
+			want:   '┼',
+			wantOk: true,
+		},
+		{
+			desc:   "result uses the requested line style",
+			ls:     linestyle.Double,
+			a:      '┐',
+			b:      '╔',
+			want:   '╦',
+			wantOk: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotOk, err := MergeLineRunes(tc.ls, tc.a, tc.b)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("MergeLineRunes => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if gotOk != tc.wantOk {
+				t.Errorf("MergeLineRunes => ok %v, want %v", gotOk, tc.wantOk)
+			}
+			if tc.wantOk && got != tc.want {
+				t.Errorf("MergeLineRunes => %c, want %c", got, tc.want)
+			}
+		})
+	}
+}
@@ -52,6 +52,14 @@ func WithEventQueue(eq *eventqueue.Unbound) Option {
 	})
 }
 
+// WithoutMouse makes the terminal report that it doesn't support the mouse.
+// The terminal still returns any mouse events in the provided event queue.
+func WithoutMouse() Option {
+	return option(func(t *Terminal) {
+		t.noMouse = true
+	})
+}
+
 // Terminal is a fake terminal.
 // This implementation is thread-safe.
 type Terminal struct {
@@ -61,6 +69,10 @@ type Terminal struct {
 	// events is a queue of input events.
 	events *eventqueue.Unbound
 
+	// noMouse is set if the terminal reports that it doesn't support the
+	// mouse.
+	noMouse bool
+
 	// mu protects the buffer.
 	mu sync.Mutex
 }
@@ -190,6 +202,15 @@ func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	return nil
 }
 
+// SupportsMouse implements terminalapi.Terminal.SupportsMouse.
+func (t *Terminal) SupportsMouse() bool {
+	return !t.noMouse
+}
+
+// SetClipboard implements terminalapi.Terminal.SetClipboard.
+// This is a no-op, the fake terminal has no clipboard.
+func (t *Terminal) SetClipboard(data string) {}
+
 // Event implements terminalapi.Terminal.Event.
 func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
 	if t.events == nil {
@@ -16,7 +16,12 @@
 // gives different treatment to certain runes with ambiguous width.
 package runewidth
 
-import runewidth "github.com/mattn/go-runewidth"
+import (
+	"sync"
+	"unicode/utf8"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
 
 // RuneWidth returns the number of cells needed to draw r.
 // Background in http://www.unicode.org/reports/tr11/.
@@ -29,13 +34,96 @@ import runewidth "github.com/mattn/go-runewidth"
 // This should be safe, since even in locales where these runes have ambiguous
 // width, we still place all the character content around them so they should
 // have be half-width.
+//
+// The widths are memoized, since they are looked up for each drawn cell on
+// each redraw.
 func RuneWidth(r rune) int {
+	if r >= 0 && r < utf8.RuneSelf {
+		return int(asciiWidths[r])
+	}
+	return cache.width(r)
+}
+
+// SetEastAsianWidth sets whether runes with ambiguous width are treated as
+// full-width (occupying two cells) like in the East Asian locales. Mirrors the
+// EastAsianWidth flag of github.com/mattn/go-runewidth, which determines the
+// default from the locale. The runes used internally by termdash remain
+// half-width regardless of this setting, see RuneWidth.
+func SetEastAsianWidth(wide bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	runewidth.DefaultCondition.EastAsianWidth = wide
+}
+
+// EastAsianWidth returns true if runes with ambiguous width are treated as
+// full-width, see SetEastAsianWidth.
+func EastAsianWidth() bool {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	return runewidth.DefaultCondition.EastAsianWidth
+}
+
+// runeWidth implements RuneWidth without memoization.
+func runeWidth(r rune) int {
 	if inTable(r, exceptions) {
 		return 1
 	}
 	return runewidth.RuneWidth(r)
 }
 
+// asciiWidths are the widths of all the ASCII runes. These don't depend on
+// the locale.
+var asciiWidths [utf8.RuneSelf]int8
+
+func init() {
+	for r := range asciiWidths {
+		asciiWidths[r] = int8(runeWidth(rune(r)))
+	}
+}
+
+// cache memoizes the widths of the non-ASCII runes.
+var cache = newWidthCache()
+
+// widthCache memoizes the widths of runes.
+// This object is thread-safe.
+type widthCache struct {
+	// mu protects the widthCache.
+	mu sync.RWMutex
+
+	// widths maps runes to their widths, indexed by the value of
+	// runewidth.DefaultCondition.EastAsianWidth, since it affects the width of
+	// ambiguous runes and can be changed at runtime.
+	widths map[bool]map[rune]int
+}
+
+// newWidthCache returns a new empty widthCache.
+func newWidthCache() *widthCache {
+	return &widthCache{
+		widths: map[bool]map[rune]int{
+			false: {},
+			true:  {},
+		},
+	}
+}
+
+// width returns the width of the rune, calculating it on the first lookup.
+func (wc *widthCache) width(r rune) int {
+	wc.mu.RLock()
+	eastAsian := runewidth.DefaultCondition.EastAsianWidth
+	w, ok := wc.widths[eastAsian][r]
+	wc.mu.RUnlock()
+	if ok {
+		return w
+	}
+
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	eastAsian = runewidth.DefaultCondition.EastAsianWidth
+	w = runeWidth(r)
+	wc.widths[eastAsian][r] = w
+	return w
+}
+
 // StringWidth is like RuneWidth, but returns the number of cells occupied by
 // all the runes in the string.
 func StringWidth(s string) int {
@@ -92,7 +180,7 @@ var exceptions = table{
 	// https://en.wikipedia.org/wiki/Box-drawing_character
 	{0x2500, 0x257F},
 
-	// Block elements used as sparks.
+	// Block elements used as sparks and instead of the braille patterns.
 	// https://en.wikipedia.org/wiki/Box-drawing_character
-	{0x2580, 0x258F},
+	{0x2580, 0x259F},
 }
@@ -15,6 +15,7 @@
 package runewidth
 
 import (
+	"strings"
 	"testing"
 
 	runewidth "github.com/mattn/go-runewidth"
@@ -164,3 +165,91 @@ func TestStringWidth(t *testing.T) {
 		})
 	}
 }
+
+func TestSetEastAsianWidth(t *testing.T) {
+	defer SetEastAsianWidth(false)
+
+	tests := []struct {
+		desc string
+		wide bool
+		want int
+	}{
+		{
+			desc: "ambiguous runes are half-width",
+			wide: false,
+			want: 1,
+		},
+		{
+			desc: "ambiguous runes are full-width",
+			wide: true,
+			want: 2,
+		},
+		{
+			desc: "ambiguous runes are half-width again",
+			wide: false,
+			want: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			SetEastAsianWidth(tc.wide)
+			if got := EastAsianWidth(); got != tc.wide {
+				t.Errorf("EastAsianWidth => %v, want %v", got, tc.wide)
+			}
+			for _, r := range []rune{'☆', '①', '■'} {
+				if got := RuneWidth(r); got != tc.want {
+					t.Errorf("RuneWidth(%c, %#x) => %v, want %v", r, r, got, tc.want)
+				}
+			}
+			// The runes used by termdash remain half-width.
+			if got := RuneWidth('…'); got != 1 {
+				t.Errorf("RuneWidth(…) => %v, want 1", got)
+			}
+		})
+	}
+}
+
+func TestRuneWidthMatchesUncached(t *testing.T) {
+	for _, eastAsian := range []bool{false, true} {
+		runewidth.DefaultCondition.EastAsianWidth = eastAsian
+		// Look up each rune twice, so that the second lookup hits the cache.
+		for i := 0; i < 2; i++ {
+			for r := rune(0); r < 0x20000; r++ {
+				if got, want := RuneWidth(r), runeWidth(r); got != want {
+					t.Fatalf("RuneWidth(%#x) with eastAsian:%v => %v, want %v", r, eastAsian, got, want)
+				}
+			}
+		}
+	}
+	runewidth.DefaultCondition.EastAsianWidth = false
+}
+
+// benchText is a large text buffer with a mix of ASCII and wide runes.
+var benchText = []rune(strings.Repeat("The quick brown fox jumps over the lazy dog. 世界の■㈱①…⇧⇩\n", 2000))
+
+func BenchmarkRuneWidth(b *testing.B) {
+	benchmarks := []struct {
+		desc    string
+		widthFn func(rune) int
+	}{
+		{
+			desc:    "cached",
+			widthFn: RuneWidth,
+		},
+		{
+			desc:    "uncached",
+			widthFn: runeWidth,
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.desc, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, r := range benchText {
+					bm.widthFn(r)
+				}
+			}
+		})
+	}
+}
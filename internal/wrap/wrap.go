@@ -58,6 +58,68 @@ const (
 	AtWords
 )
 
+// LongWord determines how the AtWords mode places words that are longer than
+// the width.
+type LongWord int
+
+// String implements fmt.Stringer()
+func (lw LongWord) String() string {
+	if n, ok := longWordNames[lw]; ok {
+		return n
+	}
+	return "LongWordUnknown"
+}
+
+// longWordNames maps LongWord values to human readable names.
+var longWordNames = map[LongWord]string{
+	LongWordHyphenate: "LongWordHyphenate",
+	LongWordBreak:     "LongWordBreak",
+	LongWordTruncate:  "LongWordTruncate",
+}
+
+const (
+	// LongWordHyphenate is the default, the word is broken at rune boundaries
+	// across multiple lines and the last rune on each broken line is replaced
+	// with a dash.
+	LongWordHyphenate LongWord = iota
+
+	// LongWordBreak breaks the word at rune boundaries across multiple lines
+	// without inserting any dashes.
+	LongWordBreak
+
+	// LongWordTruncate places the word on its own line and truncates it. The
+	// last rune that fits onto the line is replaced with the horizontal
+	// ellipsis '…'.
+	LongWordTruncate
+)
+
+// Option is used to provide options to Cells.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	longWord LongWord
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// LongWords sets how words longer than the width are placed when wrapping at
+// words. Defaults to LongWordHyphenate.
+func LongWords(lw LongWord) Option {
+	return option(func(opts *options) {
+		opts.longWord = lw
+	})
+}
+
 // ValidText validates the provided text for wrapping.
 // The text must not contain any control or space characters other
 // than '\n' and ' '.
@@ -97,8 +159,9 @@ func ValidCells(cells []*buffer.Cell) error {
 // them to start new lines.
 //
 // If the mode is AtWords, this function also drops cells with leading space
-// character before a word at which the wrap occurs.
-func Cells(cells []*buffer.Cell, width int, m Mode) ([][]*buffer.Cell, error) {
+// character before a word at which the wrap occurs. Words longer than the
+// width are placed according to the LongWords option.
+func Cells(cells []*buffer.Cell, width int, m Mode, opts ...Option) ([][]*buffer.Cell, error) {
 	if err := ValidCells(cells); err != nil {
 		return nil, err
 	}
@@ -109,11 +172,18 @@ func Cells(cells []*buffer.Cell, width int, m Mode) ([][]*buffer.Cell, error) {
 	default:
 		return nil, fmt.Errorf("unsupported wrapping mode %v(%d)", m, m)
 	}
+	opt := &options{}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if _, ok := longWordNames[opt.longWord]; !ok {
+		return nil, fmt.Errorf("unsupported long word mode %v(%d)", opt.longWord, opt.longWord)
+	}
 	if width <= 0 {
 		return nil, nil
 	}
 
-	cs := newCellScanner(cells, width, m)
+	cs := newCellScanner(cells, width, m, opt.longWord)
 	for state := scanCellRunes; state != nil; state = state(cs) {
 	}
 	return cs.lines, nil
@@ -153,6 +223,9 @@ type cellScanner struct {
 	// mode is the wrapping mode.
 	mode Mode
 
+	// longWord determines how words longer than the width are placed.
+	longWord LongWord
+
 	// atRunesInWord overrides the mode back to AtRunes.
 	atRunesInWord bool
 
@@ -164,11 +237,12 @@ type cellScanner struct {
 }
 
 // newCellScanner returns a scanner of the provided cells.
-func newCellScanner(cells []*buffer.Cell, width int, m Mode) *cellScanner {
+func newCellScanner(cells []*buffer.Cell, width int, m Mode, lw LongWord) *cellScanner {
 	return &cellScanner{
-		cells: cells,
-		width: width,
-		mode:  m,
+		cells:    cells,
+		width:    width,
+		mode:     m,
+		longWord: lw,
 	}
 }
 
@@ -365,18 +439,24 @@ func wrapWord(cs *cellScanner) cellScannerState {
 			continue
 		}
 
+		if cs.longWord == LongWordTruncate {
+			truncateWord(cs)
+			return scanCellRunes
+		}
+
 		// Replace the last placed rune with a dash indicating we wrapped the
 		// word. Only do this for half-width runes.
 		lastIdx := len(cs.line) - 1
 		last := cs.line[lastIdx]
 		lastRW := runewidth.RuneWidth(last.Rune)
-		if cs.width > 1 && lastRW == 1 {
+		if cs.longWord == LongWordHyphenate && cs.width > 1 && lastRW == 1 {
 			cs.line[lastIdx] = buffer.NewCell('-', last.Opts)
 			// Reset the scanner's position back to start scanning at the first
 			// rune of this word that wasn't placed.
 			cs.nextIdx = cs.wordStartIdx + i - 1
 		} else {
-			// Edge-case width is one, no space to put the dash rune.
+			// Edge-case width is one, no space to put the dash rune. Or the
+			// word is broken without the dash.
 			cs.nextIdx = cs.wordStartIdx + i
 		}
 		cs.atRunesInWord = true
@@ -387,6 +467,17 @@ func wrapWord(cs *cellScanner) cellScannerState {
 	return scanCellRunes
 }
 
+// truncateWord replaces the last placed rune of the word with the horizontal
+// ellipsis and skips over the rest of the word.
+func truncateWord(cs *cellScanner) {
+	if lastIdx := len(cs.line) - 1; lastIdx >= 0 {
+		last := cs.line[lastIdx]
+		cs.posX -= runewidth.RuneWidth(last.Rune) - runewidth.RuneWidth('…')
+		cs.line[lastIdx] = buffer.NewCell('…', last.Opts)
+	}
+	cs.nextIdx = cs.wordEndIdx
+}
+
 // isWordCell determines if the cell contains a rune that belongs to a word.
 func isWordCell(c *buffer.Cell) bool {
 	if c == nil {
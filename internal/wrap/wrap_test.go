@@ -23,6 +23,7 @@ import (
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/canvas/buffer"
+	"github.com/mum4k/termdash/internal/runewidth"
 )
 
 func TestValidTextAndCells(t *testing.T) {
@@ -130,10 +131,14 @@ func TestCells(t *testing.T) {
 		desc  string
 		cells []*buffer.Cell
 		// width is the width of the canvas.
-		width   int
-		mode    Mode
-		want    [][]*buffer.Cell
-		wantErr bool
+		width int
+		mode  Mode
+		// longWord is provided via the LongWords option.
+		longWord LongWord
+		// eastAsian when set treats runes with ambiguous width as full-width.
+		eastAsian bool
+		want      [][]*buffer.Cell
+		wantErr   bool
 	}{
 		{
 			desc:    "fails with zero text",
@@ -153,6 +158,14 @@ func TestCells(t *testing.T) {
 			mode:    Mode(-1),
 			wantErr: true,
 		},
+		{
+			desc:     "fails with unsupported long word mode",
+			cells:    buffer.NewCells("hello"),
+			width:    1,
+			mode:     AtWords,
+			longWord: LongWord(-1),
+			wantErr:  true,
+		},
 		{
 			desc:  "zero canvas width",
 			cells: buffer.NewCells("hello"),
@@ -650,6 +663,46 @@ func TestCells(t *testing.T) {
 				buffer.NewCells("世", cell.FgColor(cell.ColorRed), cell.BgColor(cell.ColorBlue)),
 			},
 		},
+		{
+			desc:  "wraps at runes, ambiguous runes are half-width",
+			cells: buffer.NewCells("☆☆☆☆"),
+			width: 4,
+			mode:  AtRunes,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("☆☆☆☆"),
+			},
+		},
+		{
+			desc:      "wraps at runes, ambiguous runes are full-width in East Asian width",
+			cells:     buffer.NewCells("☆☆☆☆"),
+			width:     4,
+			mode:      AtRunes,
+			eastAsian: true,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("☆☆"),
+				buffer.NewCells("☆☆"),
+			},
+		},
+		{
+			desc:  "wraps at words, ambiguous runes are half-width",
+			cells: buffer.NewCells("☆☆ ☆☆"),
+			width: 5,
+			mode:  AtWords,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("☆☆ ☆☆"),
+			},
+		},
+		{
+			desc:      "wraps at words, ambiguous runes are full-width in East Asian width",
+			cells:     buffer.NewCells("☆☆ ☆☆"),
+			width:     5,
+			mode:      AtWords,
+			eastAsian: true,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("☆☆"),
+				buffer.NewCells("☆☆"),
+			},
+		},
 		{
 			desc:  "inserted dash inherits cell options",
 			cells: buffer.NewCells("abc", cell.FgColor(cell.ColorRed), cell.BgColor(cell.ColorBlue)),
@@ -660,12 +713,104 @@ func TestCells(t *testing.T) {
 				buffer.NewCells("bc", cell.FgColor(cell.ColorRed), cell.BgColor(cell.ColorBlue)),
 			},
 		},
+		{
+			desc:     "breaks a long word without dashes",
+			cells:    buffer.NewCells("abcdefg"),
+			width:    3,
+			mode:     AtWords,
+			longWord: LongWordBreak,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("abc"),
+				buffer.NewCells("def"),
+				buffer.NewCells("g"),
+			},
+		},
+		{
+			desc:     "breaks a long word in the middle of the text without dashes",
+			cells:    buffer.NewCells("a bcdef g"),
+			width:    3,
+			mode:     AtWords,
+			longWord: LongWordBreak,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("a"),
+				buffer.NewCells("bcd"),
+				buffer.NewCells("ef"),
+				buffer.NewCells("g"),
+			},
+		},
+		{
+			desc:     "long word mode doesn't affect words that fit",
+			cells:    buffer.NewCells("ab cd"),
+			width:    2,
+			mode:     AtWords,
+			longWord: LongWordTruncate,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("ab"),
+				buffer.NewCells("cd"),
+			},
+		},
+		{
+			desc:     "truncates a long word",
+			cells:    buffer.NewCells("abcdefg"),
+			width:    3,
+			mode:     AtWords,
+			longWord: LongWordTruncate,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("ab…"),
+			},
+		},
+		{
+			desc:     "truncates a long word in the middle of the text",
+			cells:    buffer.NewCells("a bcdef g h"),
+			width:    3,
+			mode:     AtWords,
+			longWord: LongWordTruncate,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("a"),
+				buffer.NewCells("bc…"),
+				buffer.NewCells("g h"),
+			},
+		},
+		{
+			desc:     "truncates a long word of full-width runes",
+			cells:    buffer.NewCells("世世世"),
+			width:    4,
+			mode:     AtWords,
+			longWord: LongWordTruncate,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("世…"),
+			},
+		},
+		{
+			desc:     "truncation keeps cell options",
+			cells:    buffer.NewCells("abc", cell.FgColor(cell.ColorRed)),
+			width:    2,
+			mode:     AtWords,
+			longWord: LongWordTruncate,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("a…", cell.FgColor(cell.ColorRed)),
+			},
+		},
+		{
+			desc:     "long word mode doesn't affect wrapping at runes",
+			cells:    buffer.NewCells("abc"),
+			width:    2,
+			mode:     AtRunes,
+			longWord: LongWordTruncate,
+			want: [][]*buffer.Cell{
+				buffer.NewCells("ab"),
+				buffer.NewCells("c"),
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
+			runewidth.SetEastAsianWidth(tc.eastAsian)
+			defer runewidth.SetEastAsianWidth(false)
+
 			t.Logf(fmt.Sprintf("Mode: %v", tc.mode))
-			got, err := Cells(tc.cells, tc.width, tc.mode)
+			got, err := Cells(tc.cells, tc.width, tc.mode, LongWords(tc.longWord))
 			if (err != nil) != tc.wantErr {
 				t.Errorf("Cells => unexpected error %v, wantErr %v", err, tc.wantErr)
 			}
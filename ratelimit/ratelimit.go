@@ -0,0 +1,104 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit limits how often a function is called, e.g. to gate redraw
+// requests or updates of widgets with data that arrive faster than they can
+// be displayed.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// timer is a pending call of a function, implemented by *time.Timer.
+type timer interface {
+	// Stop prevents the function from being called.
+	Stop() bool
+}
+
+// Vars to be replaced from tests.
+var (
+	// timeNow returns the current time.
+	timeNow = time.Now
+
+	// afterFunc calls the function in its own goroutine once the duration
+	// elapses.
+	afterFunc = func(d time.Duration, f func()) timer {
+		return time.AfterFunc(d, f)
+	}
+)
+
+// Debounce returns a function that delays calls to fn until the returned
+// function stops being called for the duration d. Calling the returned
+// function repeatedly with less than d between the calls results in a single
+// call to fn, d after the last one.
+// The returned function is thread-safe, fn is called in its own goroutine.
+func Debounce(d time.Duration, fn func()) func() {
+	var (
+		mu      sync.Mutex
+		pending timer
+	)
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if pending != nil {
+			pending.Stop()
+		}
+		pending = afterFunc(d, fn)
+	}
+}
+
+// Throttle returns a function that calls fn at most once per duration d.
+// The first call of the returned function calls fn immediately. Calls made
+// within d of the last call of fn are merged into a single call made at the
+// end of the interval, so that the last call is never lost.
+// The returned function is thread-safe. The immediate call of fn happens in
+// the calling goroutine, the delayed one in its own goroutine.
+func Throttle(d time.Duration, fn func()) func() {
+	var (
+		mu sync.Mutex
+		// last is the time fn was last called.
+		last time.Time
+		// pending indicates that a delayed call of fn is scheduled.
+		pending bool
+	)
+
+	delayed := func() {
+		mu.Lock()
+		last = timeNow()
+		pending = false
+		mu.Unlock()
+		fn()
+	}
+
+	return func() {
+		mu.Lock()
+		if pending {
+			mu.Unlock()
+			return
+		}
+
+		now := timeNow()
+		if since := now.Sub(last); !last.IsZero() && since < d {
+			pending = true
+			afterFunc(d-since, delayed)
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+		fn()
+	}
+}
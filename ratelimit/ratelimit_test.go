@@ -0,0 +1,237 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+// fakeTimer is a function scheduled on the fakeClock.
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+// Stop implements timer.Stop.
+func (ft *fakeTimer) Stop() bool {
+	wasActive := !ft.stopped
+	ft.stopped = true
+	return wasActive
+}
+
+// fakeClock is a clock that only advances when requested.
+type fakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// install replaces the time functions of this package with the fake clock.
+// Returns a function that restores them.
+func (fc *fakeClock) install() func() {
+	timeNow = func() time.Time { return fc.now }
+	afterFunc = func(d time.Duration, f func()) timer {
+		ft := &fakeTimer{at: fc.now.Add(d), f: f}
+		fc.timers = append(fc.timers, ft)
+		return ft
+	}
+	return func() {
+		timeNow = time.Now
+		afterFunc = func(d time.Duration, f func()) timer {
+			return time.AfterFunc(d, f)
+		}
+	}
+}
+
+// advance moves the clock forward and synchronously calls the functions of
+// all the timers that expired in order of their expiry.
+func (fc *fakeClock) advance(d time.Duration) {
+	end := fc.now.Add(d)
+	for {
+		sort.SliceStable(fc.timers, func(i, j int) bool {
+			return fc.timers[i].at.Before(fc.timers[j].at)
+		})
+		if len(fc.timers) == 0 || fc.timers[0].at.After(end) {
+			break
+		}
+
+		ft := fc.timers[0]
+		fc.timers = fc.timers[1:]
+		if ft.stopped {
+			continue
+		}
+		fc.now = ft.at
+		ft.f()
+	}
+	fc.now = end
+}
+
+// step is a step in the tests, it either calls the rate limited function or
+// advances the fake clock.
+type step struct {
+	call    bool
+	advance time.Duration
+	// wantCalls is the number of calls of the wrapped function expected after
+	// this step.
+	wantCalls int
+}
+
+func TestDebounce(t *testing.T) {
+	tests := []struct {
+		desc  string
+		steps []step
+	}{
+		{
+			desc: "doesn't call before the quiet period elapses",
+			steps: []step{
+				{call: true, wantCalls: 0},
+				{advance: 99 * time.Millisecond, wantCalls: 0},
+			},
+		},
+		{
+			desc: "calls once after the quiet period",
+			steps: []step{
+				{call: true, wantCalls: 0},
+				{advance: 100 * time.Millisecond, wantCalls: 1},
+				{advance: time.Second, wantCalls: 1},
+			},
+		},
+		{
+			desc: "rapid calls result in a single call after the last one",
+			steps: []step{
+				{call: true},
+				{advance: 50 * time.Millisecond},
+				{call: true},
+				{advance: 50 * time.Millisecond},
+				{call: true},
+				{advance: 50 * time.Millisecond},
+				{call: true},
+				{advance: 99 * time.Millisecond, wantCalls: 0},
+				{advance: time.Millisecond, wantCalls: 1},
+				{advance: time.Second, wantCalls: 1},
+			},
+		},
+		{
+			desc: "calls again after another quiet period",
+			steps: []step{
+				{call: true},
+				{advance: 100 * time.Millisecond, wantCalls: 1},
+				{call: true, wantCalls: 1},
+				{advance: 100 * time.Millisecond, wantCalls: 2},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			fc := &fakeClock{now: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)}
+			defer fc.install()()
+
+			var calls int
+			debounced := Debounce(100*time.Millisecond, func() { calls++ })
+			for i, s := range tc.steps {
+				if s.call {
+					debounced()
+				}
+				fc.advance(s.advance)
+				if calls != s.wantCalls {
+					t.Fatalf("after step %d => got %d calls, want %d", i, calls, s.wantCalls)
+				}
+			}
+		})
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	tests := []struct {
+		desc  string
+		steps []step
+	}{
+		{
+			desc: "the first call is immediate",
+			steps: []step{
+				{call: true, wantCalls: 1},
+				{advance: time.Second, wantCalls: 1},
+			},
+		},
+		{
+			desc: "calls within the interval are merged into one at its end",
+			steps: []step{
+				{call: true, wantCalls: 1},
+				{advance: 10 * time.Millisecond, wantCalls: 1},
+				{call: true, wantCalls: 1},
+				{advance: 10 * time.Millisecond, wantCalls: 1},
+				{call: true, wantCalls: 1},
+				{advance: 79 * time.Millisecond, wantCalls: 1},
+				{advance: time.Millisecond, wantCalls: 2},
+				{advance: time.Second, wantCalls: 2},
+			},
+		},
+		{
+			desc: "calls after the interval are immediate",
+			steps: []step{
+				{call: true, wantCalls: 1},
+				{advance: 100 * time.Millisecond, wantCalls: 1},
+				{call: true, wantCalls: 2},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			fc := &fakeClock{now: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)}
+			defer fc.install()()
+
+			var calls int
+			throttled := Throttle(100*time.Millisecond, func() { calls++ })
+			for i, s := range tc.steps {
+				if s.call {
+					throttled()
+				}
+				fc.advance(s.advance)
+				if calls != s.wantCalls {
+					t.Fatalf("after step %d => got %d calls, want %d", i, calls, s.wantCalls)
+				}
+			}
+		})
+	}
+}
+
+func TestThrottleRapidCalls(t *testing.T) {
+	fc := &fakeClock{now: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)}
+	defer fc.install()()
+
+	const interval = 100 * time.Millisecond
+	var callTimes []time.Time
+	throttled := Throttle(interval, func() { callTimes = append(callTimes, fc.now) })
+
+	// One call every millisecond for one second.
+	for i := 0; i < 1000; i++ {
+		throttled()
+		fc.advance(time.Millisecond)
+	}
+	fc.advance(time.Second)
+
+	if got, want := len(callTimes), 11; got != want {
+		t.Errorf("Throttle => got %d calls, want %d", got, want)
+	}
+	for i := 1; i < len(callTimes); i++ {
+		if since := callTimes[i].Sub(callTimes[i-1]); since < interval {
+			t.Errorf("Throttle => calls %d and %d are only %v apart, want at least %v", i-1, i, since, interval)
+		}
+	}
+}
@@ -20,6 +20,9 @@ While running, the terminal dashboard performs the following:
   - Event based redrawing of the widgets (i.e. on Keyboard or Mouse events).
   - Forwards input events to widgets and optional subscribers.
   - Handles terminal resize events.
+
+Each frame is fully composed in the back buffer of the terminal before it is
+flushed, so a partially drawn frame never reaches the screen.
 */
 package termdash
 
@@ -27,11 +30,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"image"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/braille"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
+	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/event"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
 
@@ -54,7 +66,8 @@ func (o option) set(td *termdash) {
 
 // RedrawInterval sets how often termdash redraws the container and all the widgets.
 // Defaults to DefaultRedrawInterval. Use the controller to disable the
-// periodic redraw.
+// periodic redraw. The periodic redraw is skipped when nothing changed, see
+// widgetapi.ChangeReporter.
 func RedrawInterval(t time.Duration) Option {
 	return option(func(td *termdash) {
 		td.redrawInterval = t
@@ -89,6 +102,63 @@ func MouseSubscriber(f func(*terminalapi.Mouse)) Option {
 	})
 }
 
+// OnResize registers a function that is called with the new size of the
+// terminal each time the terminal is resized. Useful for applications that
+// need to react to a resize beyond the relayout of the container, e.g. by
+// changing the resolution of the displayed data. The function is called when
+// the resize event is processed, i.e. together with the request to clear and
+// relayout the terminal on the next redraw.
+// The provided function must be thread-safe.
+func OnResize(f func(size image.Point)) Option {
+	return option(func(td *termdash) {
+		td.resizeSubscriber = f
+	})
+}
+
+// QuitKeys configures keyboard keys that make Run return, as if the context
+// provided to Run expired. By default termdash doesn't quit on any key and
+// leaves the handling of quitting to the application, e.g. via a
+// KeyboardSubscriber that cancels the context. Providing no keys restores
+// this default. The quit keys are still forwarded to the container and to the
+// KeyboardSubscriber.
+// This option only affects Run, it is ignored by the controllers.
+func QuitKeys(keys ...keyboard.Key) Option {
+	return option(func(td *termdash) {
+		td.quitKeys = map[keyboard.Key]bool{}
+		for _, k := range keys {
+			td.quitKeys[k] = true
+		}
+	})
+}
+
+// DebugOverlay enables an overlay that helps debugging of layouts. Pressing the
+// provided key toggles the overlay, which displays a readout in the top right
+// corner of the terminal with the coordinates of the cell where the last mouse
+// event happened and the ID of the container at that cell, see
+// Container.IDAt. Terminals only report the mouse position with button
+// presses, releases, drags and wheel events, so the readout doesn't follow a
+// mouse that moves without pressing any buttons. The toggle key is still
+// forwarded to the container and to the KeyboardSubscriber.
+func DebugOverlay(toggle keyboard.Key) Option {
+	return option(func(td *termdash) {
+		td.debugKey = &toggle
+	})
+}
+
+// OnFrame registers a function that draws on top of each frame, e.g. a HUD
+// overlay like a FPS counter or a watermark. The function is called with a
+// canvas of the size of the terminal after all the widgets are drawn and
+// before the frame is flushed to the terminal, i.e. exactly once per flush.
+// Only the cells the function sets are drawn over the widgets, the remaining
+// cells of the canvas leave the content of the frame visible. Errors returned
+// by the function fail the redraw.
+// The provided function must be thread-safe.
+func OnFrame(f func(*canvas.Canvas) error) Option {
+	return option(func(td *termdash) {
+		td.frameCallback = f
+	})
+}
+
 // withEDS indicates that termdash should run with the provided event
 // distribution system instead of creating one.
 // Useful for tests.
@@ -101,7 +171,8 @@ func withEDS(eds *event.DistributionSystem) Option {
 // Run runs the terminal dashboard with the provided container on the terminal.
 // Redraws the terminal periodically. If you prefer a manual redraw, use the
 // Controller instead.
-// Blocks until the context expires.
+// Blocks until the context expires or until one of the keys provided via the
+// QuitKeys option is pressed.
 func Run(ctx context.Context, t terminalapi.Terminal, c *container.Container, opts ...Option) error {
 	td := newTermdash(t, c, opts...)
 
@@ -112,6 +183,82 @@ func Run(ctx context.Context, t terminalapi.Terminal, c *container.Container, op
 	return err
 }
 
+// Render draws the container and its widgets onto the terminal once and
+// flushes it, without reading any input events from the terminal. This is
+// useful in non-interactive environments, e.g. together with the headless
+// terminal to produce a snapshot of the dashboard as a string.
+func Render(t terminalapi.Terminal, c *container.Container) error {
+	td := &termdash{
+		term:      t,
+		container: c,
+	}
+	return td.redraw()
+}
+
+// SetAmbiguousWidthWide sets whether runes with ambiguous width are treated
+// as wide, i.e. occupying two cells. This is common in the CJK locales and
+// mirrors the EastAsianWidth flag of github.com/mattn/go-runewidth.
+//
+// The setting is global, since the layout of text and cells must be
+// consistent across all the widgets and the infrastructure. Call it before
+// creating any widgets, since some of them validate the width of their runes
+// when they are created. Runes used internally by termdash, e.g. the line
+// styles and the braille runes, are always treated as half-width.
+//
+// Defaults to the value determined by go-runewidth from the locale and the
+// RUNEWIDTH_EASTASIAN environment variable.
+func SetAmbiguousWidthWide(wide bool) {
+	runewidth.SetEastAsianWidth(wide)
+	settingsChanged()
+}
+
+// SetBlankRune sets the rune displayed in empty cells, i.e. cells that
+// nothing was drawn into since the canvas or the terminal was cleared. Useful
+// on terminals that display empty cells oddly, or to make empty cells visible
+// in snapshots produced by the headless terminal.
+//
+// The setting is global and applies to all terminals, it can be changed at any
+// time and takes effect on the next redraw.
+// Defaults to a space.
+func SetBlankRune(r rune) {
+	buffer.SetBlankRune(r)
+	settingsChanged()
+}
+
+// SetBrailleEnabled sets whether widgets draw with the braille patterns.
+// When disabled, the content the widgets draw with braille, e.g. the lines
+// of the LineChart or the Donut, is drawn with block elements instead. Use
+// this on terminals or with fonts that cannot display the braille patterns.
+// The block elements have half the vertical resolution of the braille
+// patterns.
+//
+// The setting is global and applies to all the widgets, it can be changed at
+// any time and takes effect on the next redraw.
+// Defaults to enabled, see BrailleSupported for a way to detect whether the
+// terminal supports the braille patterns.
+func SetBrailleEnabled(enabled bool) {
+	braille.SetEnabled(enabled)
+	settingsChanged()
+}
+
+// settingsVersion counts the changes of the global settings that affect how
+// everything is drawn, e.g. SetBlankRune. Accessed atomically.
+var settingsVersion uint64
+
+// settingsChanged records a change of the global settings, the next redraw
+// redraws the whole terminal even if the widgets didn't change.
+func settingsChanged() {
+	atomic.AddUint64(&settingsVersion, 1)
+}
+
+// BrailleSupported returns false if the terminal is known to lack the
+// braille patterns, e.g. the Linux virtual console. The detection is based on
+// the environment and can only recognize some terminals, the result can be
+// passed to SetBrailleEnabled.
+func BrailleSupported() bool {
+	return braille.DetectSupport()
+}
+
 // Controller controls a termdash instance.
 // The controller instance is only valid until Close() is called.
 // The controller is not thread-safe.
@@ -140,6 +287,9 @@ func NewController(t terminalapi.Terminal, c *container.Container, opts ...Optio
 }
 
 // Redraw triggers redraw of the terminal.
+// The redraw is skipped if nothing changed since the last one, i.e. if all
+// the widgets implement widgetapi.ChangeReporter and report no changes and
+// the container neither changed nor processed any events.
 func (c *Controller) Redraw() error {
 	if c.td == nil {
 		return errors.New("the termdash instance is no longer running, this controller is now invalid")
@@ -147,7 +297,18 @@ func (c *Controller) Redraw() error {
 
 	c.td.mu.Lock()
 	defer c.td.mu.Unlock()
-	return c.td.redraw()
+	return c.td.redrawChanged()
+}
+
+// Post posts the custom event, it is delivered to all the widgets that
+// implement widgetapi.CustomHandler. The event is delivered asynchronously,
+// errors returned by the widgets are passed to the ErrorHandler.
+func (c *Controller) Post(ev *terminalapi.Custom) error {
+	if c.td == nil {
+		return errors.New("the termdash instance is no longer running, this controller is now invalid")
+	}
+	c.td.eds.Event(ev)
+	return nil
 }
 
 // Close closes the Controller and its termdash instance.
@@ -157,6 +318,94 @@ func (c *Controller) Close() {
 	c.td = nil
 }
 
+// ExternalController controls a termdash instance that is driven by an
+// external event loop owned by the caller. Termdash doesn't start any
+// goroutines of its own, it doesn't read events from the terminal and doesn't
+// redraw periodically. The caller is responsible for reading events from the
+// terminal, passing them to ProcessEvent and calling Draw whenever the
+// terminal should be redrawn.
+// The controller is not thread-safe.
+type ExternalController struct {
+	td *termdash
+}
+
+// NewExternalController initializes termdash for use with an external event
+// loop, draws the container once and returns the controller.
+// The RedrawInterval and ErrorHandler options are ignored, errors are
+// returned to the caller instead.
+func NewExternalController(t terminalapi.Terminal, c *container.Container, opts ...Option) (*ExternalController, error) {
+	td := &termdash{
+		term:      t,
+		container: c,
+	}
+	for _, opt := range opts {
+		opt.set(td)
+	}
+
+	ec := &ExternalController{
+		td: td,
+	}
+	if err := ec.Draw(); err != nil {
+		return nil, err
+	}
+	return ec, nil
+}
+
+// ProcessEvent synchronously processes an event read from the terminal.
+// Keyboard and mouse events are delivered to the container, its widgets and
+// any subscribers provided via options before this method returns. Custom
+// events posted by the caller are delivered to the widgets that implement
+// widgetapi.CustomHandler. A resize event causes the terminal to be cleared on
+// the next call to Draw. Error events are returned as errors.
+// Processing events doesn't redraw the terminal, call Draw to make the effect
+// of the events visible.
+func (ec *ExternalController) ProcessEvent(ev terminalapi.Event) error {
+	td := ec.td
+	switch e := ev.(type) {
+	case *terminalapi.Keyboard:
+		if err := td.container.ProcessEvent(e); err != nil {
+			return fmt.Errorf("failed to process event %v: %v", e, err)
+		}
+		if td.keyboardSubscriber != nil {
+			td.keyboardSubscriber(e)
+		}
+		td.debugEvent(e)
+
+	case *terminalapi.Mouse:
+		if err := td.container.ProcessEvent(e); err != nil {
+			return fmt.Errorf("failed to process event %v: %v", e, err)
+		}
+		if td.mouseSubscriber != nil {
+			td.mouseSubscriber(e)
+		}
+		td.debugEvent(e)
+
+	case *terminalapi.Custom:
+		if err := td.container.ProcessEvent(e); err != nil {
+			return fmt.Errorf("failed to process event %v: %v", e, err)
+		}
+
+	case *terminalapi.Resize:
+		td.resize(e)
+
+	case *terminalapi.Error:
+		return e.Error()
+
+	default:
+		return fmt.Errorf("unsupported event type %T", ev)
+	}
+	return nil
+}
+
+// Draw redraws the terminal.
+// Like Controller.Redraw, this is skipped if nothing changed since the last
+// call.
+func (ec *ExternalController) Draw() error {
+	ec.td.mu.Lock()
+	defer ec.td.mu.Unlock()
+	return ec.td.redrawChanged()
+}
+
 // termdash is a terminal based dashboard.
 // This object is thread-safe.
 type termdash struct {
@@ -175,10 +424,18 @@ type termdash struct {
 	// exitCh gets closed when the event collecting goroutine actually exits.
 	exitCh chan struct{}
 
+	// quitCh gets closed when the user presses one of the quit keys.
+	quitCh chan struct{}
+	// quitOnce ensures quitCh is only closed once.
+	quitOnce sync.Once
+
 	// clearNeeded indicates if the terminal needs to be cleared next time
 	// we're drawing it. Terminal needs to be cleared if its sized changed.
 	clearNeeded bool
 
+	// drawnSettings is the settingsVersion of the last redraw.
+	drawnSettings uint64
+
 	// mu protects termdash.
 	mu sync.Mutex
 
@@ -187,6 +444,16 @@ type termdash struct {
 	errorHandler       func(error)
 	mouseSubscriber    func(*terminalapi.Mouse)
 	keyboardSubscriber func(*terminalapi.Keyboard)
+	resizeSubscriber   func(image.Point)
+	quitKeys           map[keyboard.Key]bool
+	debugKey           *keyboard.Key
+	frameCallback      func(*canvas.Canvas) error
+
+	// debugVisible indicates if the debug overlay is currently displayed.
+	debugVisible bool
+	// debugMouse is the position of the last mouse event displayed by the
+	// debug overlay, nil if there wasn't any mouse event yet.
+	debugMouse *image.Point
 }
 
 // newTermdash creates a new termdash.
@@ -197,6 +464,7 @@ func newTermdash(t terminalapi.Terminal, c *container.Container, opts ...Option)
 		eds:            event.NewDistributionSystem(),
 		closeCh:        make(chan struct{}),
 		exitCh:         make(chan struct{}),
+		quitCh:         make(chan struct{}),
 		redrawInterval: DefaultRedrawInterval,
 	}
 
@@ -216,16 +484,17 @@ func (td *termdash) subscribers() {
 	})
 
 	// Handles terminal resize events.
-	td.eds.Subscribe([]terminalapi.Event{&terminalapi.Resize{}}, func(terminalapi.Event) {
-		td.setClearNeeded()
+	td.eds.Subscribe([]terminalapi.Event{&terminalapi.Resize{}}, func(ev terminalapi.Event) {
+		td.resize(ev.(*terminalapi.Resize))
 	})
 
-	// Redraws the screen on Keyboard and Mouse events.
+	// Redraws the screen on Keyboard, Mouse and Custom events.
 	// These events very likely change the content of the widgets (e.g. zooming
 	// a LineChart) so a redraw is needed to make that visible.
 	td.eds.Subscribe([]terminalapi.Event{
 		&terminalapi.Keyboard{},
 		&terminalapi.Mouse{},
+		&terminalapi.Custom{},
 	}, func(terminalapi.Event) {
 		td.evRedraw()
 	}, event.MaxRepetitive(0)) // No repetitive events that cause terminal redraw.
@@ -241,6 +510,115 @@ func (td *termdash) subscribers() {
 			td.mouseSubscriber(ev.(*terminalapi.Mouse))
 		})
 	}
+
+	// Tracks the state of the debug overlay.
+	if td.debugKey != nil {
+		td.eds.Subscribe([]terminalapi.Event{
+			&terminalapi.Keyboard{},
+			&terminalapi.Mouse{},
+		}, func(ev terminalapi.Event) {
+			td.debugEvent(ev)
+		})
+	}
+
+	// Quits on the keys specified via options.
+	if len(td.quitKeys) > 0 {
+		td.eds.Subscribe([]terminalapi.Event{&terminalapi.Keyboard{}}, func(ev terminalapi.Event) {
+			if td.quitKeys[ev.(*terminalapi.Keyboard).Key] {
+				td.quitOnce.Do(func() { close(td.quitCh) })
+			}
+		})
+	}
+}
+
+// debugEvent updates the state of the debug overlay based on the event.
+func (td *termdash) debugEvent(ev terminalapi.Event) {
+	if td.debugKey == nil {
+		return
+	}
+
+	td.mu.Lock()
+	defer td.mu.Unlock()
+	switch e := ev.(type) {
+	case *terminalapi.Keyboard:
+		if e.Key == *td.debugKey {
+			td.debugVisible = !td.debugVisible
+			// Removes the overlay from the terminal when hidden.
+			td.clearNeeded = true
+		}
+	case *terminalapi.Mouse:
+		p := e.Position
+		td.debugMouse = &p
+	}
+}
+
+// drawDebug draws the debug overlay in the top right corner of the terminal
+// if it is visible.
+func (td *termdash) drawDebug() error {
+	if !td.debugVisible {
+		return nil
+	}
+
+	text := "mouse:none"
+	if p := td.debugMouse; p != nil {
+		id := td.container.IDAt(*p)
+		if id == "" {
+			id = "-"
+		}
+		text = fmt.Sprintf("x:%d y:%d id:%s", p.X, p.Y, id)
+	}
+
+	size := td.term.Size()
+	start := size.X - runewidth.StringWidth(text)
+	if start < 0 {
+		start = 0
+	}
+	cvs, err := canvas.New(image.Rect(start, 0, size.X, 1))
+	if err != nil {
+		return err
+	}
+	if err := draw.Text(cvs, text, image.Point{0, 0},
+		draw.TextCellOpts(cell.FgColor(cell.ColorBlack), cell.BgColor(cell.ColorYellow)),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	); err != nil {
+		return err
+	}
+	return cvs.Apply(td.term)
+}
+
+// drawFrame calls the function provided via the OnFrame option and draws the
+// cells it set over the frame.
+func (td *termdash) drawFrame() error {
+	if td.frameCallback == nil {
+		return nil
+	}
+
+	size := td.term.Size()
+	cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		return err
+	}
+	if err := td.frameCallback(cvs); err != nil {
+		return err
+	}
+
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			p := image.Point{x, y}
+			c, err := cvs.Cell(p)
+			if err != nil {
+				return err
+			}
+			if c.Rune == 0 && *c.Opts == (cell.Options{}) {
+				// Cells the function didn't set.
+				continue
+			}
+			if err := td.term.SetCell(p, c.Rune, c.Opts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // handleError forwards the error to the error handler if one was
@@ -261,9 +639,20 @@ func (td *termdash) setClearNeeded() {
 	td.clearNeeded = true
 }
 
+// resize handles a resize of the terminal.
+func (td *termdash) resize(e *terminalapi.Resize) {
+	td.setClearNeeded()
+	if td.resizeSubscriber != nil {
+		td.resizeSubscriber(e.Size)
+	}
+}
+
 // redraw redraws the container and its widgets.
+// The clearing and all the drawing only modify the back buffer of the terminal,
+// the frame becomes visible atomically when the terminal is flushed.
 // The caller must hold td.mu.
 func (td *termdash) redraw() error {
+	td.drawnSettings = atomic.LoadUint64(&settingsVersion)
 	if td.clearNeeded {
 		if err := td.term.Clear(); err != nil {
 			return fmt.Errorf("term.Clear => error: %v", err)
@@ -274,6 +663,12 @@ func (td *termdash) redraw() error {
 	if err := td.container.Draw(); err != nil {
 		return fmt.Errorf("container.Draw => error: %v", err)
 	}
+	if err := td.drawFrame(); err != nil {
+		return fmt.Errorf("drawFrame => error: %v", err)
+	}
+	if err := td.drawDebug(); err != nil {
+		return fmt.Errorf("drawDebug => error: %v", err)
+	}
 
 	if err := td.term.Flush(); err != nil {
 		return fmt.Errorf("term.Flush => error: %v", err)
@@ -281,6 +676,19 @@ func (td *termdash) redraw() error {
 	return nil
 }
 
+// redrawChanged is like redraw, but skips the redraw if neither the terminal,
+// the container nor the global settings changed since the last redraw.
+// The caller must hold td.mu.
+func (td *termdash) redrawChanged() error {
+	if atomic.LoadUint64(&settingsVersion) != td.drawnSettings {
+		td.clearNeeded = true // Empty cells might look different too.
+	}
+	if !td.clearNeeded && !td.container.Changed() {
+		return nil
+	}
+	return td.redraw()
+}
+
 // evRedraw redraws the container and its widgets.
 func (td *termdash) evRedraw() error {
 	td.mu.Lock()
@@ -298,7 +706,7 @@ func (td *termdash) evRedraw() error {
 func (td *termdash) periodicRedraw() error {
 	td.mu.Lock()
 	defer td.mu.Unlock()
-	return td.redraw()
+	return td.redrawChanged()
 }
 
 // processEvents processes terminal input events.
@@ -320,8 +728,8 @@ func (td *termdash) processEvents(ctx context.Context) {
 	}
 }
 
-// start starts the terminal dashboard. Blocks until the context expires or
-// until stop() is called.
+// start starts the terminal dashboard. Blocks until the context expires, until
+// one of the quit keys is pressed or until stop() is called.
 func (td *termdash) start(ctx context.Context) error {
 	// Redraw once to initialize the container sizes.
 	if err := td.periodicRedraw(); err != nil {
@@ -348,6 +756,9 @@ func (td *termdash) start(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 
+		case <-td.quitCh:
+			return nil
+
 		case <-td.closeCh:
 			return nil
 		}
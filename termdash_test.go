@@ -16,27 +16,35 @@ package termdash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"image"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/event"
 	"github.com/mum4k/termdash/internal/event/eventqueue"
 	"github.com/mum4k/termdash/internal/event/testevent"
 	"github.com/mum4k/termdash/internal/faketerm"
 	"github.com/mum4k/termdash/internal/fakewidget"
 	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/headless"
 	"github.com/mum4k/termdash/terminal/termbox"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/barchart"
 	"github.com/mum4k/termdash/widgets/gauge"
+	"github.com/mum4k/termdash/widgets/text"
 )
 
 // Example shows how to setup and run termdash with periodic redraw.
@@ -121,6 +129,136 @@ func Example_triggered() {
 	}
 }
 
+// Example shows how to drive termdash from an external event loop.
+func Example_externalLoop() {
+	// Create the terminal.
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	// Create a widget.
+	bc, err := barchart.New()
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the container with a widget.
+	c, err := container.New(
+		t,
+		container.PlaceWidget(bc),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	// Create the controller, termdash won't start any goroutines.
+	ctrl, err := NewExternalController(t, c)
+	if err != nil {
+		panic(err)
+	}
+
+	// The application owns the event loop.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for ctx.Err() == nil {
+		if ev := t.Event(ctx); ev != nil {
+			if err := ctrl.ProcessEvent(ev); err != nil {
+				panic(err)
+			}
+		}
+		if err := ctrl.Draw(); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Example_render shows how to render a snapshot of the dashboard without an
+// interactive terminal.
+func Example_render() {
+	t, err := headless.New(image.Point{12, 3})
+	if err != nil {
+		panic(err)
+	}
+
+	g, err := gauge.New(gauge.Char('#'), gauge.HideTextProgress())
+	if err != nil {
+		panic(err)
+	}
+	if err := g.Percent(50); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("CPU"),
+		container.PlaceWidget(g),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := Render(t, c); err != nil {
+		panic(err)
+	}
+	fmt.Print(t)
+	// Output:
+	// ┌CPU───────┐
+	// │#####     │
+	// └──────────┘
+}
+
+func TestSetAmbiguousWidthWide(t *testing.T) {
+	tests := []struct {
+		desc string
+		wide bool
+		want string
+	}{
+		{
+			desc: "ambiguous runes occupy one cell",
+			wide: false,
+			want: "☆☆☆☆\n    \n",
+		},
+		{
+			desc: "ambiguous runes occupy two cells and wrap",
+			wide: true,
+			want: "☆☆\n☆☆\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			SetAmbiguousWidthWide(tc.wide)
+			defer SetAmbiguousWidthWide(false)
+
+			ht, err := headless.New(image.Point{4, 2})
+			if err != nil {
+				t.Fatalf("headless.New => unexpected error: %v", err)
+			}
+			txt, err := text.New(text.WrapAtRunes())
+			if err != nil {
+				t.Fatalf("text.New => unexpected error: %v", err)
+			}
+			if err := txt.Write("☆☆☆☆"); err != nil {
+				t.Fatalf("Write => unexpected error: %v", err)
+			}
+			c, err := container.New(ht, container.PlaceWidget(txt))
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+
+			if err := Render(ht, c); err != nil {
+				t.Fatalf("Render => unexpected error: %v", err)
+			}
+			if got := ht.String(); got != tc.want {
+				t.Errorf("Render => got terminal content:\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
 // errorHandler just stores the last error received.
 type errorHandler struct {
 	err error
@@ -175,10 +313,29 @@ func (ms *mouseSubscriber) receive(m *terminalapi.Mouse) {
 	ms.received = *m
 }
 
+// resizeSubscriber stores all the sizes it was called with.
+type resizeSubscriber struct {
+	received []image.Point
+	mu       sync.Mutex
+}
+
+func (rs *resizeSubscriber) get() []image.Point {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.received
+}
+
+func (rs *resizeSubscriber) receive(size image.Point) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.received = append(rs.received, size)
+}
+
 type eventHandlers struct {
-	handler  errorHandler
-	keySub   keySubscriber
-	mouseSub mouseSubscriber
+	handler   errorHandler
+	keySub    keySubscriber
+	mouseSub  mouseSubscriber
+	resizeSub resizeSubscriber
 }
 
 func TestRun(t *testing.T) {
@@ -286,6 +443,40 @@ func TestRun(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "calls the resize subscriber with each new size",
+			size: image.Point{60, 10},
+			opts: func(eh *eventHandlers) []Option {
+				return []Option{
+					RedrawInterval(1),
+					OnResize(eh.resizeSub.receive),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Resize{Size: image.Point{70, 10}},
+				&terminalapi.Resize{Size: image.Point{80, 20}},
+			},
+			// Invoked from the same subscriber that triggers the relayout.
+			wantProcessed: 2,
+			after: func(eh *eventHandlers) error {
+				want := []image.Point{{70, 10}, {80, 20}}
+				if diff := pretty.Compare(want, eh.resizeSub.get()); diff != "" {
+					return fmt.Errorf("resize subscriber got unexpected sizes, diff (-want, +got):\n%s", diff)
+				}
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
 		{
 			desc: "forwards input errors to the error handler",
 			size: image.Point{60, 10},
@@ -457,142 +648,63 @@ func TestRun(t *testing.T) {
 	}
 }
 
-func TestController(t *testing.T) {
+func TestQuitKeys(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		desc   string
-		size   image.Point
 		opts   []Option
 		events []terminalapi.Event
-		// The number of expected processed events, used for synchronization.
-		// Equals len(events) * number of subscribers for the event type.
-		wantProcessed int
-		apiEvents     func(*fakewidget.Mirror) // Calls to the API of the widget.
-		controls      func(*Controller) error
-		want          func(size image.Point) *faketerm.Terminal
-		wantErr       bool
+		// wantQuit indicates whether Run is expected to return before the
+		// context expires.
+		wantQuit bool
 	}{
 		{
-			desc: "event triggers a redraw",
-			size: image.Point{60, 10},
+			desc: "doesn't quit on any key by default",
 			events: []terminalapi.Event{
-				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
-			},
-			wantProcessed: 2,
-			want: func(size image.Point) *faketerm.Terminal {
-				ft := faketerm.MustNew(size)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(ft.Area()),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{
-						WantKeyboard: widgetapi.KeyScopeFocused,
-						WantMouse:    widgetapi.MouseScopeWidget,
-					},
-					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
-				)
-				return ft
-
+				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+				&terminalapi.Keyboard{Key: 'q'},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlC},
 			},
 		},
 		{
-			desc: "controller triggers redraw",
-			size: image.Point{60, 10},
-			apiEvents: func(mi *fakewidget.Mirror) {
-				mi.Text("hello")
-			},
-			controls: func(ctrl *Controller) error {
-				return ctrl.Redraw()
+			desc: "custom quit key makes Run return",
+			opts: []Option{
+				QuitKeys('q'),
 			},
-			want: func(size image.Point) *faketerm.Terminal {
-				ft := faketerm.MustNew(size)
-
-				mirror := fakewidget.New(widgetapi.Options{})
-				mirror.Text("hello")
-				fakewidget.MustDrawWithMirror(
-					mirror,
-					ft,
-					testcanvas.MustNew(ft.Area()),
-					&widgetapi.Meta{Focused: true},
-				)
-				return ft
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'q'},
 			},
+			wantQuit: true,
 		},
 		{
-			desc: "ignores periodic redraw via the controller",
-			size: image.Point{60, 10},
+			desc: "any of the custom quit keys makes Run return",
 			opts: []Option{
-				RedrawInterval(1),
-			},
-			apiEvents: func(mi *fakewidget.Mirror) {
-				mi.Text("hello")
-			},
-			controls: func(ctrl *Controller) error {
-				return nil
+				QuitKeys('q', keyboard.KeyCtrlC),
 			},
-			want: func(size image.Point) *faketerm.Terminal {
-				ft := faketerm.MustNew(size)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(ft.Area()),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{},
-				)
-				return ft
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlC},
 			},
+			wantQuit: true,
 		},
 		{
-			desc: "does not redraw unless triggered when periodic disabled",
-			size: image.Point{60, 10},
-			apiEvents: func(mi *fakewidget.Mirror) {
-				mi.Text("hello")
-			},
-			controls: func(ctrl *Controller) error {
-				return nil
+			desc: "doesn't quit on keys other than the custom quit keys",
+			opts: []Option{
+				QuitKeys('q'),
 			},
-			want: func(size image.Point) *faketerm.Terminal {
-				ft := faketerm.MustNew(size)
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(ft.Area()),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{},
-				)
-				return ft
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+				&terminalapi.Keyboard{Key: 'x'},
 			},
 		},
 		{
-			desc: "fails when redraw fails",
-			size: image.Point{1, 1},
-			want: func(size image.Point) *faketerm.Terminal {
-				return faketerm.MustNew(size)
+			desc: "quit keys disabled when overridden with no keys",
+			opts: []Option{
+				QuitKeys('q'),
+				QuitKeys(),
 			},
-			wantErr: true,
-		},
-		{
-			desc: "resizes the terminal",
-			size: image.Point{60, 10},
 			events: []terminalapi.Event{
-				&terminalapi.Resize{Size: image.Point{70, 10}},
-			},
-			wantProcessed: 1,
-			controls: func(ctrl *Controller) error {
-				return ctrl.Redraw()
-			},
-			want: func(size image.Point) *faketerm.Terminal {
-				ft := faketerm.MustNew(image.Point{70, 10})
-
-				fakewidget.MustDraw(
-					ft,
-					testcanvas.MustNew(ft.Area()),
-					&widgetapi.Meta{Focused: true},
-					widgetapi.Options{},
-				)
-				return ft
+				&terminalapi.Keyboard{Key: 'q'},
 			},
 		},
 	}
@@ -607,57 +719,941 @@ func TestController(t *testing.T) {
 				eq.Push(ev)
 			}
 
-			got, err := faketerm.New(tc.size, faketerm.WithEventQueue(eq))
+			got, err := faketerm.New(image.Point{60, 10}, faketerm.WithEventQueue(eq))
 			if err != nil {
 				t.Fatalf("faketerm.New => unexpected error: %v", err)
 			}
 
-			mi := fakewidget.New(widgetapi.Options{
-				WantKeyboard: widgetapi.KeyScopeFocused,
-				WantMouse:    widgetapi.MouseScopeWidget,
-			})
 			cont, err := container.New(
 				got,
-				container.PlaceWidget(mi),
+				container.PlaceWidget(fakewidget.New(widgetapi.Options{})),
 			)
 			if err != nil {
 				t.Fatalf("container.New => unexpected error: %v", err)
 			}
 
-			eds := event.NewDistributionSystem()
-			opts := tc.opts
-			opts = append(opts, withEDS(eds))
-			ctrl, err := NewController(got, cont, opts...)
-			if (err != nil) != tc.wantErr {
-				t.Errorf("NewController => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			if err := Run(ctx, got, cont, tc.opts...); err != nil {
+				t.Fatalf("Run => unexpected error: %v", err)
 			}
-			if err != nil {
-				return
+			if gotQuit := ctx.Err() == nil; gotQuit != tc.wantQuit {
+				t.Errorf("Run returned before the context expired: %v, want %v", gotQuit, tc.wantQuit)
 			}
+		})
+	}
+}
 
-			if tc.apiEvents != nil {
-				tc.apiEvents(mi)
-			}
+// flushRecorder is a terminal that records the content of its back buffer
+// each time it is flushed.
+type flushRecorder struct {
+	*faketerm.Terminal
 
-			if err := testevent.WaitFor(5*time.Second, func() error {
-				if got, want := eds.Processed(), tc.wantProcessed; got != want {
-					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
-				}
-				return nil
-			}); err != nil {
-				t.Fatalf("testevent.WaitFor => %v", err)
+	// mu protects frames.
+	mu sync.Mutex
+	// frames are the contents of the back buffer at each flush.
+	frames []string
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+func (fr *flushRecorder) Flush() error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	fr.frames = append(fr.frames, fr.Terminal.String())
+	return fr.Terminal.Flush()
+}
+
+// get returns the recorded frames.
+func (fr *flushRecorder) get() []string {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	return append([]string(nil), fr.frames...)
+}
+
+// fillWidget is a widget that fills its entire canvas with a single rune that
+// changes on every call to Draw.
+type fillWidget struct {
+	// draws counts the calls to Draw.
+	draws int
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (fw *fillWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	r := rune('a' + fw.draws%26)
+	fw.draws++
+	return cvs.SetAreaCells(cvs.Area(), r)
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (*fillWidget) Keyboard(*terminalapi.Keyboard) error {
+	return nil
+}
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (*fillWidget) Mouse(*terminalapi.Mouse) error {
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*fillWidget) Options() widgetapi.Options {
+	return widgetapi.Options{}
+}
+
+func TestFlushesCompleteFrames(t *testing.T) {
+	t.Parallel()
+
+	eq := eventqueue.New()
+	for i := 0; i < 10; i++ {
+		// Resize events make termdash clear the terminal before the redraw.
+		eq.Push(&terminalapi.Resize{Size: image.Point{10, 3}})
+	}
+
+	ft, err := faketerm.New(image.Point{10, 3}, faketerm.WithEventQueue(eq))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	fr := &flushRecorder{Terminal: ft}
+
+	cont, err := container.New(
+		fr,
+		container.PlaceWidget(&fillWidget{}),
+	)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := Run(ctx, fr, cont, RedrawInterval(time.Millisecond)); err != nil {
+		t.Fatalf("Run => unexpected error: %v", err)
+	}
+
+	frames := fr.get()
+	if len(frames) < 2 {
+		t.Fatalf("got %d flushed frames, want at least 2", len(frames))
+	}
+	for i, frame := range frames {
+		// Every cell of a complete frame contains the same rune drawn by the
+		// widget.
+		content := strings.Replace(frame, "\n", "", -1)
+		if want := strings.Repeat(content[:1], 10*3); content[0] == ' ' || content != want {
+			t.Errorf("frame %d isn't complete, got:\n%s", i, frame)
+		}
+	}
+}
+
+func TestRedrawSkippedWhenNothingChanged(t *testing.T) {
+	t.Parallel()
+
+	ft, err := faketerm.New(image.Point{10, 3}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	fr := &flushRecorder{Terminal: ft}
+
+	g, err := gauge.New()
+	if err != nil {
+		t.Fatalf("gauge.New => unexpected error: %v", err)
+	}
+	cont, err := container.New(
+		fr,
+		container.ID("root"),
+		container.PlaceWidget(g),
+	)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctrl, err := NewController(fr, cont)
+	if err != nil {
+		t.Fatalf("NewController => unexpected error: %v", err)
+	}
+	defer ctrl.Close()
+
+	// wantFlushes asserts the number of flushes after the redraw.
+	wantFlushes := func(desc string, want int) {
+		t.Helper()
+		if err := ctrl.Redraw(); err != nil {
+			t.Fatalf("%s: Redraw => unexpected error: %v", desc, err)
+		}
+		if got := len(fr.get()); got != want {
+			t.Errorf("%s: got %d flushes, want %d", desc, got, want)
+		}
+	}
+
+	wantFlushes("without changes", 1)
+	if err := g.Percent(50); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	wantFlushes("after the widget changed", 2)
+	wantFlushes("without further changes", 2)
+
+	if err := cont.Update("root", container.Border(linestyle.Light)); err != nil {
+		t.Fatalf("Update => unexpected error: %v", err)
+	}
+	wantFlushes("after the container changed", 3)
+	wantFlushes("without further changes", 3)
+
+	if err := cont.Update("root", container.PlaceWidget(&fillWidget{})); err != nil {
+		t.Fatalf("Update => unexpected error: %v", err)
+	}
+	wantFlushes("with a widget that doesn't report changes", 4)
+	wantFlushes("with a widget that doesn't report changes", 5)
+}
+
+func TestRedrawAfterSettingsChanged(t *testing.T) {
+	ft, err := faketerm.New(image.Point{10, 3}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	fr := &flushRecorder{Terminal: ft}
+
+	g, err := gauge.New(gauge.Height(1))
+	if err != nil {
+		t.Fatalf("gauge.New => unexpected error: %v", err)
+	}
+	cont, err := container.New(fr, container.PlaceWidget(g))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctrl, err := NewController(fr, cont)
+	if err != nil {
+		t.Fatalf("NewController => unexpected error: %v", err)
+	}
+	defer ctrl.Close()
+
+	if err := ctrl.Redraw(); err != nil {
+		t.Fatalf("Redraw => unexpected error: %v", err)
+	}
+	if got := len(fr.get()); got != 1 {
+		t.Fatalf("got %d flushes before the setting changed, want 1", got)
+	}
+
+	SetBlankRune('.')
+	defer SetBlankRune(buffer.DefaultBlankRune)
+	if err := ctrl.Redraw(); err != nil {
+		t.Fatalf("Redraw => unexpected error: %v", err)
+	}
+	if got := len(fr.get()); got != 2 {
+		t.Errorf("got %d flushes after the setting changed, want 2", got)
+	}
+}
+
+func TestController(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc   string
+		size   image.Point
+		opts   []Option
+		events []terminalapi.Event
+		// The number of expected processed events, used for synchronization.
+		// Equals len(events) * number of subscribers for the event type.
+		wantProcessed int
+		apiEvents     func(*fakewidget.Mirror) // Calls to the API of the widget.
+		controls      func(*Controller) error
+		want          func(size image.Point) *faketerm.Terminal
+		wantErr       bool
+	}{
+		{
+			desc: "event triggers a redraw",
+			size: image.Point{60, 10},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			wantProcessed: 2,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{
+						WantKeyboard: widgetapi.KeyScopeFocused,
+						WantMouse:    widgetapi.MouseScopeWidget,
+					},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+
+			},
+		},
+		{
+			desc: "controller triggers redraw",
+			size: image.Point{60, 10},
+			apiEvents: func(mi *fakewidget.Mirror) {
+				mi.Text("hello")
+			},
+			controls: func(ctrl *Controller) error {
+				return ctrl.Redraw()
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				mirror := fakewidget.New(widgetapi.Options{})
+				mirror.Text("hello")
+				fakewidget.MustDrawWithMirror(
+					mirror,
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "ignores periodic redraw via the controller",
+			size: image.Point{60, 10},
+			opts: []Option{
+				RedrawInterval(1),
+			},
+			apiEvents: func(mi *fakewidget.Mirror) {
+				mi.Text("hello")
+			},
+			controls: func(ctrl *Controller) error {
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "does not redraw unless triggered when periodic disabled",
+			size: image.Point{60, 10},
+			apiEvents: func(mi *fakewidget.Mirror) {
+				mi.Text("hello")
+			},
+			controls: func(ctrl *Controller) error {
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "fails when redraw fails",
+			size: image.Point{1, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "resizes the terminal",
+			size: image.Point{60, 10},
+			events: []terminalapi.Event{
+				&terminalapi.Resize{Size: image.Point{70, 10}},
+			},
+			wantProcessed: 1,
+			controls: func(ctrl *Controller) error {
+				return ctrl.Redraw()
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(image.Point{70, 10})
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tc := tc
+			t.Parallel()
+
+			eq := eventqueue.New()
+			for _, ev := range tc.events {
+				eq.Push(ev)
+			}
+
+			got, err := faketerm.New(tc.size, faketerm.WithEventQueue(eq))
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			mi := fakewidget.New(widgetapi.Options{
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			})
+			cont, err := container.New(
+				got,
+				container.PlaceWidget(mi),
+			)
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			opts := tc.opts
+			opts = append(opts, withEDS(eds))
+			ctrl, err := NewController(got, cont, opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewController => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.apiEvents != nil {
+				tc.apiEvents(mi)
+			}
+
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), tc.wantProcessed; got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if tc.controls != nil {
+				if err := tc.controls(ctrl); err != nil {
+					t.Errorf("controls => unexpected error: %v", err)
+				}
+			}
+			ctrl.Close()
+
+			if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
+				t.Errorf("Run => %v", diff)
+			}
+		})
+	}
+}
+
+func TestExternalController(t *testing.T) {
+	tests := []struct {
+		desc     string
+		size     image.Point
+		opts     func(*eventHandlers) []Option
+		events   []terminalapi.Event
+		draw     bool // Whether to call Draw after processing the events.
+		after    func(*eventHandlers) error
+		want     func(size image.Point) *faketerm.Terminal
+		wantErr  bool
+		wantPErr bool // Whether to expect an error from ProcessEvent.
+	}{
+		{
+			desc: "draws the dashboard when created",
+			size: image.Point{60, 10},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "fails when the widget doesn't draw due to size too small",
+			size: image.Point{1, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "processed events aren't visible until drawn",
+			size: image.Point{60, 10},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "forwards keyboard events to container and subscriber",
+			size: image.Point{60, 10},
+			opts: func(eh *eventHandlers) []Option {
+				return []Option{
+					KeyboardSubscriber(eh.keySub.receive),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			draw: true,
+			after: func(eh *eventHandlers) error {
+				want := terminalapi.Keyboard{Key: keyboard.KeyEnter}
+				if diff := pretty.Compare(want, eh.keySub.get()); diff != "" {
+					return fmt.Errorf("keyboard subscriber got unexpected event, diff (-want, +got):\n%s", diff)
+				}
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{
+						WantKeyboard: widgetapi.KeyScopeFocused,
+					},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "calls the resize subscriber with the new size",
+			size: image.Point{60, 10},
+			opts: func(eh *eventHandlers) []Option {
+				return []Option{
+					OnResize(eh.resizeSub.receive),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Resize{Size: image.Point{70, 10}},
+			},
+			after: func(eh *eventHandlers) error {
+				want := []image.Point{{70, 10}}
+				if diff := pretty.Compare(want, eh.resizeSub.get()); diff != "" {
+					return fmt.Errorf("resize subscriber got unexpected sizes, diff (-want, +got):\n%s", diff)
+				}
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "forwards mouse events to container and subscriber",
+			size: image.Point{60, 10},
+			opts: func(eh *eventHandlers) []Option {
+				return []Option{
+					MouseSubscriber(eh.mouseSub.receive),
+				}
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonWheelUp},
+			},
+			draw: true,
+			after: func(eh *eventHandlers) error {
+				want := terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonWheelUp}
+				if diff := pretty.Compare(want, eh.mouseSub.get()); diff != "" {
+					return fmt.Errorf("mouse subscriber got unexpected event, diff (-want, +got):\n%s", diff)
+				}
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{
+						WantMouse: widgetapi.MouseScopeWidget,
+					},
+					&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonWheelUp},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "returns the error when a widget fails to process an event",
+			size: image.Point{60, 10},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+			wantPErr: true,
+		},
+		{
+			desc: "returns error events as errors",
+			size: image.Point{60, 10},
+			events: []terminalapi.Event{
+				terminalapi.NewError("error event"),
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+			wantPErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.size)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
 			}
 
-			if tc.controls != nil {
-				if err := tc.controls(ctrl); err != nil {
-					t.Errorf("controls => unexpected error: %v", err)
+			cont, err := container.New(
+				got,
+				container.PlaceWidget(fakewidget.New(widgetapi.Options{
+					WantKeyboard: widgetapi.KeyScopeFocused,
+					WantMouse:    widgetapi.MouseScopeWidget,
+				})),
+			)
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+
+			handlers := &eventHandlers{}
+			var opts []Option
+			if tc.opts != nil {
+				opts = tc.opts(handlers)
+			}
+
+			ctrl, err := NewExternalController(got, cont, opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewExternalController => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for i, ev := range tc.events {
+				err := ctrl.ProcessEvent(ev)
+				// Only the last event in test cases causes the error.
+				if i == len(tc.events)-1 {
+					if (err != nil) != tc.wantPErr {
+						t.Errorf("ProcessEvent => unexpected error: %v, wantPErr: %v", err, tc.wantPErr)
+					}
+				} else if err != nil {
+					t.Fatalf("ProcessEvent => unexpected error: %v", err)
+				}
+			}
+
+			if tc.draw {
+				if err := ctrl.Draw(); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			if tc.after != nil {
+				if err := tc.after(handlers); err != nil {
+					t.Errorf("after => unexpected error: %v", err)
 				}
 			}
-			ctrl.Close()
 
 			if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
-				t.Errorf("Run => %v", diff)
+				t.Errorf("Draw => %v", diff)
 			}
 		})
 	}
 }
+
+// topRow returns the content of the top row of the terminal.
+func topRow(ft *faketerm.Terminal) string {
+	bb := ft.BackBuffer()
+	var b strings.Builder
+	for x := 0; x < ft.Size().X; x++ {
+		r := bb[x][0].Rune
+		if r == 0 {
+			r = ' '
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func TestDebugOverlay(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{30, 5})
+	cont, err := container.New(
+		ft,
+		container.SplitVertical(
+			container.Left(container.ID("left")),
+			container.Right(container.ID("right")),
+		),
+	)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctrl, err := NewExternalController(ft, cont, DebugOverlay('d'))
+	if err != nil {
+		t.Fatalf("NewExternalController => unexpected error: %v", err)
+	}
+
+	steps := []struct {
+		desc  string
+		event terminalapi.Event
+		want  string
+	}{
+		{
+			desc:  "hidden until toggled",
+			event: &terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+			want:  "",
+		},
+		{
+			desc:  "toggled on shows the last mouse position",
+			event: &terminalapi.Keyboard{Key: 'd'},
+			want:  "x:1 y:1 id:left",
+		},
+		{
+			desc:  "mouse event in the left container",
+			event: &terminalapi.Mouse{Position: image.Point{3, 2}, Button: mouse.ButtonRelease},
+			want:  "x:3 y:2 id:left",
+		},
+		{
+			desc:  "mouse event in the right container",
+			event: &terminalapi.Mouse{Position: image.Point{20, 4}, Button: mouse.ButtonWheelUp},
+			want:  "x:20 y:4 id:right",
+		},
+		{
+			desc:  "toggled off removes the overlay",
+			event: &terminalapi.Keyboard{Key: 'd'},
+			want:  "",
+		},
+	}
+
+	for _, step := range steps {
+		if err := ctrl.ProcessEvent(step.event); err != nil {
+			t.Fatalf("%s: ProcessEvent => unexpected error: %v", step.desc, err)
+		}
+		if err := ctrl.Draw(); err != nil {
+			t.Fatalf("%s: Draw => unexpected error: %v", step.desc, err)
+		}
+
+		want := fmt.Sprintf("%*s", ft.Size().X, step.want)
+		if got := topRow(ft); got != want {
+			t.Errorf("%s: top row => %q, want %q", step.desc, got, want)
+		}
+	}
+}
+
+func TestDebugOverlayNoMouse(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{30, 5})
+	cont, err := container.New(ft)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctrl, err := NewExternalController(ft, cont, DebugOverlay('d'))
+	if err != nil {
+		t.Fatalf("NewExternalController => unexpected error: %v", err)
+	}
+	if err := ctrl.ProcessEvent(&terminalapi.Keyboard{Key: 'd'}); err != nil {
+		t.Fatalf("ProcessEvent => unexpected error: %v", err)
+	}
+	if err := ctrl.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	want := fmt.Sprintf("%*s", ft.Size().X, "mouse:none")
+	if got := topRow(ft); got != want {
+		t.Errorf("top row => %q, want %q", got, want)
+	}
+}
+
+// customWidget is a widget that forwards the custom events it receives.
+type customWidget struct {
+	*fakewidget.Mirror
+
+	events chan *terminalapi.Custom
+}
+
+// Custom implements widgetapi.CustomHandler.Custom.
+func (cw *customWidget) Custom(ev *terminalapi.Custom) error {
+	cw.events <- ev
+	return nil
+}
+
+func TestCustomEvents(t *testing.T) {
+	t.Run("Controller.Post", func(t *testing.T) {
+		ft, err := faketerm.New(image.Point{30, 5}, faketerm.WithEventQueue(eventqueue.New()))
+		if err != nil {
+			t.Fatalf("faketerm.New => unexpected error: %v", err)
+		}
+		cw := &customWidget{
+			Mirror: fakewidget.New(widgetapi.Options{}),
+			events: make(chan *terminalapi.Custom, 1),
+		}
+		cont, err := container.New(
+			ft,
+			container.SplitVertical(
+				container.Left(container.PlaceWidget(cw)),
+				container.Right(container.PlaceWidget(fakewidget.New(widgetapi.Options{}))),
+			),
+		)
+		if err != nil {
+			t.Fatalf("container.New => unexpected error: %v", err)
+		}
+
+		ctrl, err := NewController(ft, cont)
+		if err != nil {
+			t.Fatalf("NewController => unexpected error: %v", err)
+		}
+		defer ctrl.Close()
+
+		want := &terminalapi.Custom{Name: "refreshed", Data: 42}
+		if err := ctrl.Post(want); err != nil {
+			t.Fatalf("Post => unexpected error: %v", err)
+		}
+		select {
+		case got := <-cw.events:
+			if diff := pretty.Compare(want, got); diff != "" {
+				t.Errorf("Custom => unexpected diff (-want, +got):\n%s", diff)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the custom event")
+		}
+	})
+
+	t.Run("ExternalController.ProcessEvent", func(t *testing.T) {
+		ft := faketerm.MustNew(image.Point{30, 5})
+		cw := &customWidget{
+			Mirror: fakewidget.New(widgetapi.Options{}),
+			events: make(chan *terminalapi.Custom, 1),
+		}
+		cont, err := container.New(ft, container.PlaceWidget(cw))
+		if err != nil {
+			t.Fatalf("container.New => unexpected error: %v", err)
+		}
+
+		ctrl, err := NewExternalController(ft, cont)
+		if err != nil {
+			t.Fatalf("NewExternalController => unexpected error: %v", err)
+		}
+		want := &terminalapi.Custom{Name: "refreshed"}
+		if err := ctrl.ProcessEvent(want); err != nil {
+			t.Fatalf("ProcessEvent => unexpected error: %v", err)
+		}
+		select {
+		case got := <-cw.events:
+			if got != want {
+				t.Errorf("Custom => got event %v, want %v", got, want)
+			}
+		default:
+			t.Errorf("ProcessEvent => the custom event wasn't delivered before returning")
+		}
+	})
+}
+
+func TestOnFrame(t *testing.T) {
+	ft, err := faketerm.New(image.Point{10, 3}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	fr := &flushRecorder{Terminal: ft}
+
+	cont, err := container.New(
+		fr,
+		container.PlaceWidget(&fillWidget{}),
+	)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	var mu sync.Mutex
+	var calls int
+	onFrame := func(cvs *canvas.Canvas) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return draw.Text(cvs, "HUD", image.Point{0, 0})
+	}
+	ctrl, err := NewController(fr, cont, OnFrame(onFrame))
+	if err != nil {
+		t.Fatalf("NewController => unexpected error: %v", err)
+	}
+	defer ctrl.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := ctrl.Redraw(); err != nil {
+			t.Fatalf("Redraw => unexpected error: %v", err)
+		}
+	}
+
+	frames := fr.get()
+	mu.Lock()
+	gotCalls := calls
+	mu.Unlock()
+	if gotCalls != len(frames) {
+		t.Errorf("OnFrame called %d times, want once per each of the %d flushes", gotCalls, len(frames))
+	}
+
+	for i, frame := range frames {
+		// The widget fills each frame with a different rune.
+		fill := string(rune('a' + i))
+		want := "HUD" + strings.Repeat(fill, 7) + "\n" +
+			strings.Repeat(fill, 10) + "\n" +
+			strings.Repeat(fill, 10)
+		if got := strings.TrimSuffix(frame, "\n"); got != want {
+			t.Errorf("frame %d => got:\n%s\nwant:\n%s", i, got, want)
+		}
+	}
+}
+
+func TestOnFrameFails(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{10, 3})
+	cont, err := container.New(ft)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	onFrame := func(*canvas.Canvas) error {
+		return errors.New("frame error")
+	}
+	if _, err := NewExternalController(ft, cont, OnFrame(onFrame)); err == nil {
+		t.Errorf("NewExternalController => got nil error, want the error from the OnFrame function")
+	}
+}
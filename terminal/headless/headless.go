@@ -0,0 +1,231 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package headless implements a terminal that keeps its content in memory
+// instead of displaying it. This allows rendering dashboards in
+// non-interactive environments, e.g. to produce a snapshot of a dashboard for
+// a report.
+package headless
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Terminal is a terminal that keeps its content in memory.
+//
+// Like on other terminal implementations, cells are set in a back buffer and
+// become the content of the terminal when Flush is called. The content can
+// then be retrieved as plain text by calling String or with colors encoded as
+// ANSI escape sequences by calling ANSI.
+//
+// The terminal doesn't produce any input events.
+//
+// Implements terminalapi.Terminal. This object is thread-safe.
+type Terminal struct {
+	// back is the back buffer, modified by calls to Clear and SetCell.
+	back buffer.Buffer
+
+	// front is the content of the back buffer at the time of the last Flush.
+	front buffer.Buffer
+
+	// mu protects the Terminal.
+	mu sync.Mutex
+}
+
+// New returns a new headless Terminal of the specified size.
+func New(size image.Point) (*Terminal, error) {
+	back, err := buffer.New(size)
+	if err != nil {
+		return nil, err
+	}
+	front, err := buffer.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &Terminal{
+		back:  back,
+		front: front,
+	}, nil
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (t *Terminal) Size() image.Point {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.back.Size()
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, err := buffer.New(t.back.Size())
+	if err != nil {
+		return err
+	}
+	for _, col := range b {
+		for _, c := range col {
+			c.Opts = cell.NewOptions(opts...)
+		}
+	}
+	t.back = b
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+func (t *Terminal) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	front := make(buffer.Buffer, len(t.back))
+	for x, col := range t.back {
+		front[x] = make([]*buffer.Cell, len(col))
+		for y, c := range col {
+			opts := *c.Opts
+			front[x][y] = &buffer.Cell{
+				Rune: c.Rune,
+				Opts: &opts,
+			}
+		}
+	}
+	t.front = front
+	return nil
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+// The headless terminal has no cursor, this is a no-op.
+func (t *Terminal) SetCursor(p image.Point) {}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+// The headless terminal has no cursor, this is a no-op.
+func (t *Terminal) HideCursor() {}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.back.SetCell(p, r, opts...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SupportsMouse implements terminalapi.Terminal.SupportsMouse.
+// Always returns false, the terminal doesn't produce any input events.
+func (t *Terminal) SupportsMouse() bool {
+	return false
+}
+
+// SetClipboard implements terminalapi.Terminal.SetClipboard.
+// This is a no-op, the terminal has no clipboard.
+func (t *Terminal) SetClipboard(data string) {}
+
+// Event implements terminalapi.Terminal.Event.
+// The headless terminal doesn't produce any input events, this blocks until
+// the context expires and returns nil.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	<-ctx.Done()
+	return nil
+}
+
+// Close closes the terminal. This is a no-op on the headless terminal.
+func (t *Terminal) Close() {}
+
+// String returns the content of the terminal as of the last call to Flush.
+// Each line of the terminal is terminated by a newline character. Only the
+// runes are included, cell options are ignored.
+// Implements fmt.Stringer.
+func (t *Terminal) String() string {
+	return t.content(false)
+}
+
+// ANSI is like String, but encodes the colors of the cells as ANSI escape
+// sequences (SGR). Each line that changes colors ends with a reset of all the
+// attributes.
+func (t *Terminal) ANSI() string {
+	return t.content(true)
+}
+
+// content returns the content of the front buffer, optionally with the colors
+// encoded as ANSI escape sequences.
+func (t *Terminal) content(ansi bool) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	size := t.front.Size()
+	var b strings.Builder
+	for y := 0; y < size.Y; y++ {
+		cur := &cell.Options{}
+		for x := 0; x < size.X; x++ {
+			partial, err := t.front.IsPartial(image.Point{x, y})
+			if err != nil {
+				panic(fmt.Errorf("unable to determine if point %v is a partial rune: %v", image.Point{x, y}, err))
+			}
+			if partial {
+				continue // Occupied by the wide rune in the previous cell.
+			}
+
+			c := t.front[x][y]
+			if ansi && *c.Opts != *cur {
+				b.WriteString(sgr(c.Opts))
+				cur = c.Opts
+			}
+
+			b.WriteRune(buffer.Visible(c.Rune))
+		}
+		if ansi && *cur != (cell.Options{}) {
+			b.WriteString(sgr(&cell.Options{}))
+		}
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
+
+// sgr returns the ANSI escape sequence that resets all attributes and sets the
+// colors in the provided options.
+func sgr(opts *cell.Options) string {
+	var b strings.Builder
+	b.WriteString("\x1b[0")
+	if opts.FgColor != cell.ColorDefault {
+		b.WriteString(colorParam(opts.FgColor, 30))
+	}
+	if opts.BgColor != cell.ColorDefault {
+		b.WriteString(colorParam(opts.BgColor, 40))
+	}
+	b.WriteString("m")
+	return b.String()
+}
+
+// colorParam returns the SGR parameter that sets the color, the base is 30
+// for the foreground and 40 for the background color.
+func colorParam(c cell.Color, base int) string {
+	// Colors are off-by-one due to ColorDefault being zero, see
+	// cell.ColorNumber.
+	n := int(c) - 1
+	if n < 8 {
+		return fmt.Sprintf(";%d", base+n)
+	}
+	return fmt.Sprintf(";%d;5;%d", base+8, n)
+}
@@ -0,0 +1,230 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headless
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
+)
+
+func TestTerminal(t *testing.T) {
+	tests := []struct {
+		desc string
+		size image.Point
+		// draw draws on the terminal.
+		draw     func(*Terminal) error
+		wantText string
+		wantANSI string
+		wantErr  bool
+	}{
+		{
+			desc:    "fails on invalid size",
+			size:    image.Point{0, 1},
+			wantErr: true,
+		},
+		{
+			desc:     "empty terminal",
+			size:     image.Point{3, 2},
+			wantText: "   \n   \n",
+			wantANSI: "   \n   \n",
+		},
+		{
+			desc: "content isn't visible until flushed",
+			size: image.Point{3, 1},
+			draw: func(t *Terminal) error {
+				return t.SetCell(image.Point{0, 0}, 'a')
+			},
+			wantText: "   \n",
+			wantANSI: "   \n",
+		},
+		{
+			desc: "returns flushed content",
+			size: image.Point{3, 2},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{0, 0}, 'a'); err != nil {
+					return err
+				}
+				if err := t.SetCell(image.Point{2, 1}, 'b'); err != nil {
+					return err
+				}
+				return t.Flush()
+			},
+			wantText: "a  \n  b\n",
+			wantANSI: "a  \n  b\n",
+		},
+		{
+			desc: "changes after the flush aren't visible",
+			size: image.Point{3, 1},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{0, 0}, 'a'); err != nil {
+					return err
+				}
+				if err := t.Flush(); err != nil {
+					return err
+				}
+				return t.SetCell(image.Point{1, 0}, 'b')
+			},
+			wantText: "a  \n",
+			wantANSI: "a  \n",
+		},
+		{
+			desc: "wide runes occupy two cells",
+			size: image.Point{3, 1},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{0, 0}, '世'); err != nil {
+					return err
+				}
+				return t.Flush()
+			},
+			wantText: "世 \n",
+			wantANSI: "世 \n",
+		},
+		{
+			desc: "encodes system colors",
+			size: image.Point{3, 1},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{0, 0}, 'a', cell.FgColor(cell.ColorRed)); err != nil {
+					return err
+				}
+				if err := t.SetCell(image.Point{1, 0}, 'b', cell.FgColor(cell.ColorRed), cell.BgColor(cell.ColorBlue)); err != nil {
+					return err
+				}
+				return t.Flush()
+			},
+			wantText: "ab \n",
+			wantANSI: "\x1b[0;31ma\x1b[0;31;44mb\x1b[0m \n",
+		},
+		{
+			desc: "encodes numbered colors and resets at the end of the line",
+			size: image.Point{2, 2},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{1, 0}, 'a', cell.BgColor(cell.ColorNumber(200))); err != nil {
+					return err
+				}
+				return t.Flush()
+			},
+			wantText: " a\n  \n",
+			wantANSI: " \x1b[0;48;5;200ma\x1b[0m\n  \n",
+		},
+		{
+			desc: "clear sets the options on all the cells",
+			size: image.Point{2, 1},
+			draw: func(t *Terminal) error {
+				if err := t.SetCell(image.Point{0, 0}, 'a'); err != nil {
+					return err
+				}
+				if err := t.Clear(cell.BgColor(cell.ColorGreen)); err != nil {
+					return err
+				}
+				return t.Flush()
+			},
+			wantText: "  \n",
+			wantANSI: "\x1b[0;42m  \x1b[0m\n",
+		},
+		{
+			desc: "fails to set a cell outside of the terminal",
+			size: image.Point{2, 1},
+			draw: func(t *Terminal) error {
+				return t.SetCell(image.Point{2, 0}, 'a')
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			term, err := New(tc.size)
+			if err == nil && tc.draw != nil {
+				err = tc.draw(term)
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := term.String(); got != tc.wantText {
+				t.Errorf("String => %q, want %q", got, tc.wantText)
+			}
+			if got := term.ANSI(); got != tc.wantANSI {
+				t.Errorf("ANSI => %q, want %q", got, tc.wantANSI)
+			}
+		})
+	}
+}
+
+func TestEvent(t *testing.T) {
+	term, err := New(image.Point{1, 1})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if got := term.Event(ctx); got != nil {
+		t.Errorf("Event => %v, want nil", got)
+	}
+}
+
+func TestSupportsMouse(t *testing.T) {
+	term, err := New(image.Point{1, 1})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if got := term.SupportsMouse(); got {
+		t.Errorf("SupportsMouse => %v, want false", got)
+	}
+}
+
+func TestBlankRune(t *testing.T) {
+	buffer.SetBlankRune('.')
+	defer buffer.SetBlankRune(buffer.DefaultBlankRune)
+
+	term, err := New(image.Point{3, 2})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := term.SetCell(image.Point{0, 0}, 'a'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := term.SetCell(image.Point{1, 0}, ' '); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	if got, want := term.String(), "a .\n...\n"; got != want {
+		t.Errorf("String after Flush => %q, want %q", got, want)
+	}
+
+	if err := term.Clear(cell.BgColor(cell.ColorGreen)); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	if got, want := term.String(), "...\n...\n"; got != want {
+		t.Errorf("String after Clear => %q, want %q", got, want)
+	}
+	if got, want := term.ANSI(), "\x1b[0;42m...\x1b[0m\n\x1b[0;42m...\x1b[0m\n"; got != want {
+		t.Errorf("ANSI after Clear => %q, want %q", got, want)
+	}
+}
@@ -27,8 +27,15 @@ func cellColor(c cell.Color) tbx.Attribute {
 }
 
 // cellOptsToFg converts the cell options to the termbox foreground attribute.
+// Termbox only supports straight underlines without a color, so all the
+// underline styles are drawn as a straight underline and the underline color
+// is ignored. Termbox doesn't support italics, the Italic option is ignored.
 func cellOptsToFg(opts *cell.Options) tbx.Attribute {
-	return cellColor(opts.FgColor)
+	fg := cellColor(opts.FgColor)
+	if opts.Underline != cell.UnderlineNone {
+		fg |= tbx.AttrUnderline
+	}
+	return fg
 }
 
 // cellOptsToBg converts the cell options to the termbox background attribute.
@@ -48,3 +48,59 @@ func TestCellColor(t *testing.T) {
 		})
 	}
 }
+
+func TestCellOptsToFg(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts *cell.Options
+		want tbx.Attribute
+	}{
+		{
+			desc: "no underline",
+			opts: cell.NewOptions(cell.FgColor(cell.ColorRed)),
+			want: tbx.ColorRed,
+		},
+		{
+			desc: "straight underline",
+			opts: cell.NewOptions(
+				cell.FgColor(cell.ColorRed),
+				cell.UnderlineStyle(cell.UnderlineStraight),
+			),
+			want: tbx.ColorRed | tbx.AttrUnderline,
+		},
+		{
+			desc: "curly underline with a color is drawn as a straight underline",
+			opts: cell.NewOptions(
+				cell.FgColor(cell.ColorRed),
+				cell.UnderlineStyle(cell.UnderlineCurly),
+				cell.UnderlineColor(cell.ColorBlue),
+			),
+			want: tbx.ColorRed | tbx.AttrUnderline,
+		},
+		{
+			desc: "underline color without a style is ignored",
+			opts: cell.NewOptions(
+				cell.FgColor(cell.ColorRed),
+				cell.UnderlineColor(cell.ColorBlue),
+			),
+			want: tbx.ColorRed,
+		},
+		{
+			desc: "italic is ignored",
+			opts: cell.NewOptions(
+				cell.FgColor(cell.ColorRed),
+				cell.Italic(true),
+			),
+			want: tbx.ColorRed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := cellOptsToFg(tc.opts)
+			if got != tc.want {
+				t.Errorf("cellOptsToFg => got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -17,9 +17,14 @@ package termbox
 
 import (
 	"context"
+	"fmt"
 	"image"
+	"io"
+	"os"
+	"sync"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
 	"github.com/mum4k/termdash/internal/event/eventqueue"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	tbx "github.com/nsf/termbox-go"
@@ -50,6 +55,52 @@ func ColorMode(cm terminalapi.ColorMode) Option {
 	})
 }
 
+// MouseSupport overrides the detection of the mouse support. When set to
+// false, the terminal doesn't enable the reporting of mouse events. By
+// default the mouse is supported unless the TERM environment variable
+// indicates a terminal known to not report mouse events, e.g. the Linux
+// console.
+func MouseSupport(supported bool) Option {
+	return option(func(t *Terminal) {
+		t.mouse = supported
+	})
+}
+
+// noMouseTerms are values of the TERM environment variable of terminals that
+// don't report mouse events in the format used by termbox. The Linux console
+// only reports the mouse through the gpm daemon.
+var noMouseTerms = map[string]bool{
+	"":      true,
+	"dumb":  true,
+	"linux": true,
+	"vt100": true,
+	"vt102": true,
+	"vt220": true,
+}
+
+// mouseSupported determines if the terminal identified by the value of the
+// TERM environment variable reports mouse events.
+func mouseSupported(term string) bool {
+	return !noMouseTerms[term]
+}
+
+// clipboardSupported determines if the terminal identified by the value of the
+// TERM environment variable implements the OSC 52 escape sequence that sets
+// the clipboard. The same legacy terminals that don't report mouse events
+// don't implement it either.
+func clipboardSupported(term string) bool {
+	return !noMouseTerms[term]
+}
+
+// openTTY opens the terminal for writing the escape sequences that set the
+// clipboard. Termbox doesn't allow writing raw escape sequences, they are
+// written directly to the same terminal termbox draws to, which isn't
+// necessarily the standard output.
+// Changed from tests.
+var openTTY = func() (io.WriteCloser, error) {
+	return os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+}
+
 // Terminal provides input and output to a real terminal. Wraps the
 // nsf/termbox-go terminal implementation. This object is not thread-safe.
 // Implements terminalapi.Terminal.
@@ -62,6 +113,14 @@ type Terminal struct {
 
 	// Options.
 	colorMode terminalapi.ColorMode
+	mouse     bool
+	clipboard bool
+
+	// tty is where the escape sequences that set the clipboard are written.
+	// Nil if the terminal doesn't support the clipboard.
+	tty io.WriteCloser
+	// mu serializes writes to tty with flushing of the termbox output.
+	mu sync.Mutex
 }
 
 // newTerminal creates the terminal and applies the options.
@@ -70,6 +129,8 @@ func newTerminal(opts ...Option) *Terminal {
 		events:    eventqueue.New(),
 		done:      make(chan struct{}),
 		colorMode: DefaultColorMode,
+		mouse:     mouseSupported(os.Getenv("TERM")),
+		clipboard: clipboardSupported(os.Getenv("TERM")),
 	}
 	for _, opt := range opts {
 		opt.set(t)
@@ -83,15 +144,28 @@ func New(opts ...Option) (*Terminal, error) {
 	if err := tbx.Init(); err != nil {
 		return nil, err
 	}
-	tbx.SetInputMode(tbx.InputEsc | tbx.InputMouse)
-
 	t := newTerminal(opts...)
+	if t.mouse {
+		tbx.SetInputMode(tbx.InputEsc | tbx.InputMouse)
+	} else {
+		tbx.SetInputMode(tbx.InputEsc)
+	}
+
 	om, err := colorMode(t.colorMode)
 	if err != nil {
 		return nil, err
 	}
 	tbx.SetOutputMode(om)
 
+	if t.clipboard {
+		tty, err := openTTY()
+		if err != nil {
+			tbx.Close()
+			return nil, fmt.Errorf("unable to open the terminal for setting the clipboard: %v", err)
+		}
+		t.tty = tty
+	}
+
 	go t.pollEvents() // Stops when Close() is called.
 	return t, nil
 }
@@ -103,13 +177,30 @@ func (t *Terminal) Size() image.Point {
 }
 
 // Clear implements terminalapi.Terminal.Clear.
+// Termbox clears the cells to spaces, so cells are filled with the blank rune
+// when a different one is configured, see termdash.SetBlankRune.
 func (t *Terminal) Clear(opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	return tbx.Clear(cellOptsToFg(o), cellOptsToBg(o))
+	fg, bg := cellOptsToFg(o), cellOptsToBg(o)
+	if err := tbx.Clear(fg, bg); err != nil {
+		return err
+	}
+
+	if blank := buffer.BlankRune(); blank != ' ' {
+		w, h := tbx.Size()
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				tbx.SetCell(x, y, blank, fg, bg)
+			}
+		}
+	}
+	return nil
 }
 
 // Flush implements terminalapi.Terminal.Flush.
 func (t *Terminal) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 	return tbx.Flush()
 }
 
@@ -126,10 +217,28 @@ func (t *Terminal) HideCursor() {
 // SetCell implements terminalapi.Terminal.SetCell.
 func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	tbx.SetCell(p.X, p.Y, r, cellOptsToFg(o), cellOptsToBg(o))
+	tbx.SetCell(p.X, p.Y, buffer.Visible(r), cellOptsToFg(o), cellOptsToBg(o))
 	return nil
 }
 
+// SupportsMouse implements terminalapi.Terminal.SupportsMouse.
+func (t *Terminal) SupportsMouse() bool {
+	return t.mouse
+}
+
+// SetClipboard implements terminalapi.Terminal.SetClipboard.
+// This is a no-op on terminals that don't implement the OSC 52 escape
+// sequence. Setting the clipboard is best effort, the terminal doesn't
+// acknowledge the escape sequence, so errors writing it are ignored too.
+func (t *Terminal) SetClipboard(data string) {
+	if !t.clipboard || t.tty == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	io.WriteString(t.tty, terminalapi.ClipboardSequence(data))
+}
+
 // pollEvents polls and enqueues the input events.
 func (t *Terminal) pollEvents() {
 	for {
@@ -161,4 +270,7 @@ func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
 func (t *Terminal) Close() {
 	close(t.done)
 	tbx.Close()
+	if t.tty != nil {
+		t.tty.Close()
+	}
 }
@@ -15,6 +15,9 @@
 package termbox
 
 import (
+	"bytes"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -24,28 +27,75 @@ import (
 func TestNewTerminal(t *testing.T) {
 	tests := []struct {
 		desc string
+		// term is the value of the TERM environment variable.
+		term string
 		opts []Option
 		want *Terminal
 	}{
 		{
 			desc: "default options",
+			term: "xterm-256color",
 			want: &Terminal{
 				colorMode: terminalapi.ColorMode256,
+				mouse:     true,
+				clipboard: true,
 			},
 		},
 		{
 			desc: "sets color mode",
+			term: "xterm-256color",
 			opts: []Option{
 				ColorMode(terminalapi.ColorModeNormal),
 			},
 			want: &Terminal{
 				colorMode: terminalapi.ColorModeNormal,
+				mouse:     true,
+				clipboard: true,
+			},
+		},
+		{
+			desc: "detects terminal without mouse support",
+			term: "linux",
+			want: &Terminal{
+				colorMode: terminalapi.ColorMode256,
+			},
+		},
+		{
+			desc: "disables mouse support",
+			term: "xterm-256color",
+			opts: []Option{
+				MouseSupport(false),
+			},
+			want: &Terminal{
+				colorMode: terminalapi.ColorMode256,
+				clipboard: true,
+			},
+		},
+		{
+			desc: "enables mouse support the detection missed",
+			term: "linux",
+			opts: []Option{
+				MouseSupport(true),
+			},
+			want: &Terminal{
+				colorMode: terminalapi.ColorMode256,
+				mouse:     true,
 			},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
+			term, ok := os.LookupEnv("TERM")
+			if ok {
+				defer os.Setenv("TERM", term)
+			} else {
+				defer os.Unsetenv("TERM")
+			}
+			if err := os.Setenv("TERM", tc.term); err != nil {
+				t.Fatalf("os.Setenv => unexpected error: %v", err)
+			}
+
 			got := newTerminal(tc.opts...)
 
 			// Ignore these fields.
@@ -58,3 +108,83 @@ func TestNewTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestMouseSupported(t *testing.T) {
+	tests := []struct {
+		term string
+		want bool
+	}{
+		{term: "xterm", want: true},
+		{term: "xterm-256color", want: true},
+		{term: "screen", want: true},
+		{term: "rxvt-unicode", want: true},
+		{term: "", want: false},
+		{term: "dumb", want: false},
+		{term: "linux", want: false},
+		{term: "vt100", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.term, func(t *testing.T) {
+			if got := mouseSupported(tc.term); got != tc.want {
+				t.Errorf("mouseSupported(%q) => %v, want %v", tc.term, got, tc.want)
+			}
+		})
+	}
+}
+
+// nopCloser is an io.WriteCloser with a no-op Close.
+type nopCloser struct {
+	io.Writer
+}
+
+// Close implements io.Closer.Close.
+func (nopCloser) Close() error {
+	return nil
+}
+
+func TestSetClipboard(t *testing.T) {
+	tests := []struct {
+		desc string
+		// term is the value of the TERM environment variable.
+		term string
+		data string
+		want string
+	}{
+		{
+			desc: "emits base64 encoded data",
+			term: "xterm-256color",
+			data: "hello",
+			want: "\x1b]52;c;aGVsbG8=\a",
+		},
+		{
+			desc: "no-op on terminal without clipboard support",
+			term: "linux",
+			data: "hello",
+			want: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			term, ok := os.LookupEnv("TERM")
+			if ok {
+				defer os.Setenv("TERM", term)
+			} else {
+				defer os.Unsetenv("TERM")
+			}
+			if err := os.Setenv("TERM", tc.term); err != nil {
+				t.Fatalf("os.Setenv => unexpected error: %v", err)
+			}
+
+			var b bytes.Buffer
+			tb := newTerminal()
+			tb.tty = nopCloser{&b}
+
+			tb.SetClipboard(tc.data)
+			if got := b.String(); got != tc.want {
+				t.Errorf("SetClipboard(%q) => wrote %q, want %q", tc.data, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminalapi
+
+// clipboard.go contains code that sets the system clipboard.
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// ClipboardSequence returns the OSC 52 escape sequence that instructs the
+// terminal to set the system clipboard to the data. The terminal receives the
+// data base64 encoded in the output stream, so this also works over SSH.
+// Terminal implementations that support the clipboard emit this sequence
+// from SetClipboard.
+func ClipboardSequence(data string) string {
+	return fmt.Sprintf("\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(data)))
+}
@@ -0,0 +1,49 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminalapi
+
+import "testing"
+
+func TestClipboardSequence(t *testing.T) {
+	tests := []struct {
+		desc string
+		data string
+		want string
+	}{
+		{
+			desc: "empty data clears the clipboard",
+			data: "",
+			want: "\x1b]52;c;\a",
+		},
+		{
+			desc: "encodes the data",
+			data: "hello",
+			want: "\x1b]52;c;aGVsbG8=\a",
+		},
+		{
+			desc: "encodes full-width runes and newlines",
+			data: "世界\n",
+			want: "\x1b]52;c;5LiW55WMCg==\a",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := ClipboardSequence(tc.data); got != tc.want {
+				t.Errorf("ClipboardSequence(%q) => %q, want %q", tc.data, got, tc.want)
+			}
+		})
+	}
+}
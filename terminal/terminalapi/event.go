@@ -75,6 +75,26 @@ func (m Mouse) String() string {
 	return fmt.Sprintf("Mouse{Position: %v, Button: %v}", m.Position, m.Button)
 }
 
+// Custom is an application specific event, e.g. an indication that new data
+// are available. Custom events aren't produced by terminals, the application
+// posts them, see termdash.Controller.Post and
+// termdash.ExternalController.ProcessEvent. They are delivered to all the
+// widgets that implement widgetapi.CustomHandler.
+// Implements terminalapi.Event.
+type Custom struct {
+	// Name identifies the kind of the event.
+	Name string
+	// Data are optional data that accompany the event.
+	Data interface{}
+}
+
+func (*Custom) isEvent() {}
+
+// String implements fmt.Stringer.
+func (c Custom) String() string {
+	return fmt.Sprintf("Custom{Name: %q, Data: %v}", c.Name, c.Data)
+}
+
 // Error is an event indicating an error while processing input.
 type Error string
 
@@ -45,6 +45,16 @@ type Terminal interface {
 	// option isn't specified, the attribute retains its previous value.
 	SetCell(p image.Point, r rune, opts ...cell.Option) error
 
+	// SupportsMouse asserts whether the terminal reports mouse events.
+	// Terminals that don't support the mouse only report keyboard and resize
+	// events.
+	SupportsMouse() bool
+
+	// SetClipboard sets the system clipboard to the data, see
+	// ClipboardSequence. Terminals that don't support the clipboard ignore
+	// the call.
+	SetClipboard(data string)
+
 	// Event waits for the next event and returns it.
 	// This call blocks until the next event or cancellation of the context.
 	// Returns nil when the context gets canceled.
@@ -0,0 +1,229 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package viewport implements a terminal that occupies a rectangle of another
+// terminal. This allows embedding termdash into a larger application that
+// draws on the rest of the terminal, or rendering the dashboard at a fixed
+// size centered in a larger terminal.
+package viewport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Terminal is a terminal that occupies a rectangle of the underlying
+// terminal.
+//
+// All coordinates are relative to the top left corner of the rectangle, the
+// cells outside of the rectangle are never modified. The rectangle is clipped
+// to the size of the underlying terminal, so the size of the viewport can
+// change when the underlying terminal gets resized.
+//
+// Mouse events that happen outside of the rectangle are dropped. Resize events
+// report the new size of the viewport.
+//
+// Implements terminalapi.Terminal. This object is thread-safe.
+type Terminal struct {
+	// term is the underlying terminal.
+	term terminalapi.Terminal
+
+	// ar is the rectangle of the underlying terminal the viewport occupies.
+	// When centered is true, ar starts at the origin and only its size is
+	// used.
+	ar image.Rectangle
+
+	// centered indicates that the viewport is centered in the underlying
+	// terminal, see NewCentered.
+	centered bool
+
+	// mu protects the Terminal.
+	mu sync.Mutex
+}
+
+// New returns a new viewport on the terminal that occupies the provided
+// rectangle, which must not be empty and must not start at negative
+// coordinates.
+func New(t terminalapi.Terminal, ar image.Rectangle) (*Terminal, error) {
+	if t == nil {
+		return nil, errors.New("the terminal cannot be nil")
+	}
+	if ar.Empty() {
+		return nil, fmt.Errorf("the viewport area %v cannot be empty", ar)
+	}
+	if ar.Min.X < 0 || ar.Min.Y < 0 {
+		return nil, fmt.Errorf("the viewport area %v cannot start at negative coordinates", ar)
+	}
+	return &Terminal{
+		term: t,
+		ar:   ar,
+	}, nil
+}
+
+// NewCentered returns a new viewport of a fixed size centered in the
+// terminal. Dashboards drawn on the viewport lay out against the fixed size
+// regardless of the size of the terminal, which is useful e.g. when recording
+// a dashboard. The viewport follows the center of the terminal when it gets
+// resized and is clipped when the terminal is smaller than the viewport.
+//
+// The viewport letterboxes the dashboard, i.e. Clear clears the entire
+// terminal including the blank margins around the viewport.
+// The size must be positive in both dimensions.
+func NewCentered(t terminalapi.Terminal, size image.Point) (*Terminal, error) {
+	if t == nil {
+		return nil, errors.New("the terminal cannot be nil")
+	}
+	if size.X <= 0 || size.Y <= 0 {
+		return nil, fmt.Errorf("the viewport size %v must be positive", size)
+	}
+	return &Terminal{
+		term:     t,
+		ar:       image.Rectangle{Max: size},
+		centered: true,
+	}, nil
+}
+
+// area returns the rectangle of the underlying terminal currently occupied by
+// the viewport.
+func (t *Terminal) area() image.Rectangle {
+	size := t.term.Size()
+	ar := t.ar
+	if t.centered {
+		offset := image.Point{(size.X - ar.Dx()) / 2, (size.Y - ar.Dy()) / 2}
+		if offset.X < 0 {
+			offset.X = 0
+		}
+		if offset.Y < 0 {
+			offset.Y = 0
+		}
+		ar = ar.Add(offset)
+	}
+	return ar.Intersect(image.Rect(0, 0, size.X, size.Y))
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (t *Terminal) Size() image.Point {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.area().Size()
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+// Only clears the cells within the viewport, unless the viewport was created
+// by NewCentered in which case the entire underlying terminal is cleared.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.centered {
+		return t.term.Clear(opts...)
+	}
+
+	ar := t.area()
+	blank := buffer.BlankRune()
+	for y := ar.Min.Y; y < ar.Max.Y; y++ {
+		for x := ar.Min.X; x < ar.Max.X; x++ {
+			if err := t.term.SetCell(image.Point{x, y}, blank, opts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+func (t *Terminal) Flush() error {
+	return t.term.Flush()
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+func (t *Terminal) SetCursor(p image.Point) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.term.SetCursor(p.Add(t.area().Min))
+}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+func (t *Terminal) HideCursor() {
+	t.term.HideCursor()
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+// Returns an error if the point falls outside of the viewport.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ar := t.area()
+	tp := p.Add(ar.Min)
+	if !tp.In(ar) {
+		return fmt.Errorf("point %v falls outside of the viewport of size %v", p, ar.Size())
+	}
+	return t.term.SetCell(tp, r, opts...)
+}
+
+// SupportsMouse implements terminalapi.Terminal.SupportsMouse.
+// Reports the support of the underlying terminal.
+func (t *Terminal) SupportsMouse() bool {
+	return t.term.SupportsMouse()
+}
+
+// SetClipboard implements terminalapi.Terminal.SetClipboard.
+// Sets the clipboard of the underlying terminal.
+func (t *Terminal) SetClipboard(data string) {
+	t.term.SetClipboard(data)
+}
+
+// Event implements terminalapi.Terminal.Event.
+// The positions of mouse events are relative to the viewport.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	for {
+		ev := t.term.Event(ctx)
+		switch e := ev.(type) {
+		case *terminalapi.Mouse:
+			t.mu.Lock()
+			ar := t.area()
+			t.mu.Unlock()
+
+			if !e.Position.In(ar) {
+				continue
+			}
+			return &terminalapi.Mouse{
+				Position: e.Position.Sub(ar.Min),
+				Button:   e.Button,
+			}
+
+		case *terminalapi.Resize:
+			return &terminalapi.Resize{
+				Size: t.Size(),
+			}
+
+		default:
+			return ev
+		}
+	}
+}
+
+// Close closes the viewport. This is a no-op, the underlying terminal remains
+// owned by the caller and must be closed separately.
+func (t *Terminal) Close() {}
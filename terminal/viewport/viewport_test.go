@@ -0,0 +1,534 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package viewport
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/internal/event/eventqueue"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/headless"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// mustFill fills all the cells of the terminal with the rune.
+func mustFill(ft *faketerm.Terminal, r rune) {
+	size := ft.Size()
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			if err := ft.SetCell(image.Point{x, y}, r); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		term    terminalapi.Terminal
+		ar      image.Rectangle
+		wantErr bool
+	}{
+		{
+			desc:    "fails on nil terminal",
+			ar:      image.Rect(0, 0, 1, 1),
+			wantErr: true,
+		},
+		{
+			desc:    "fails on empty area",
+			term:    faketerm.MustNew(image.Point{3, 3}),
+			ar:      image.Rect(1, 1, 1, 2),
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative coordinates",
+			term:    faketerm.MustNew(image.Point{3, 3}),
+			ar:      image.Rect(-1, 0, 2, 2),
+			wantErr: true,
+		},
+		{
+			desc: "succeeds",
+			term: faketerm.MustNew(image.Point{3, 3}),
+			ar:   image.Rect(1, 1, 2, 2),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := New(tc.term, tc.ar)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	tests := []struct {
+		desc     string
+		termSize image.Point
+		ar       image.Rectangle
+		// draw draws on the viewport.
+		draw     func(*Terminal) error
+		wantSize image.Point
+		want     func(size image.Point) *faketerm.Terminal
+		wantErr  bool
+	}{
+		{
+			desc:     "sets cells relative to the viewport",
+			termSize: image.Point{5, 4},
+			ar:       image.Rect(1, 1, 4, 3),
+			draw: func(vp *Terminal) error {
+				if err := vp.SetCell(image.Point{0, 0}, 'a'); err != nil {
+					return err
+				}
+				return vp.SetCell(image.Point{2, 1}, 'b', cell.FgColor(cell.ColorRed))
+			},
+			wantSize: image.Point{3, 2},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFill(ft, 'x')
+				ft.SetCell(image.Point{1, 1}, 'a')
+				ft.SetCell(image.Point{3, 2}, 'b', cell.FgColor(cell.ColorRed))
+				return ft
+			},
+		},
+		{
+			desc:     "fails to set cells outside of the viewport",
+			termSize: image.Point{5, 4},
+			ar:       image.Rect(1, 1, 4, 3),
+			draw: func(vp *Terminal) error {
+				return vp.SetCell(image.Point{3, 0}, 'a')
+			},
+			wantSize: image.Point{3, 2},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFill(ft, 'x')
+				return ft
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "clears only the viewport",
+			termSize: image.Point{5, 4},
+			ar:       image.Rect(1, 1, 4, 3),
+			draw: func(vp *Terminal) error {
+				return vp.Clear()
+			},
+			wantSize: image.Point{3, 2},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFill(ft, 'x')
+				for y := 1; y < 3; y++ {
+					for x := 1; x < 4; x++ {
+						ft.SetCell(image.Point{x, y}, ' ')
+					}
+				}
+				return ft
+			},
+		},
+		{
+			desc:     "clips the viewport to the terminal",
+			termSize: image.Point{5, 4},
+			ar:       image.Rect(3, 2, 10, 10),
+			draw: func(vp *Terminal) error {
+				return vp.SetCell(image.Point{1, 1}, 'a')
+			},
+			wantSize: image.Point{2, 2},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFill(ft, 'x')
+				ft.SetCell(image.Point{4, 3}, 'a')
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := faketerm.MustNew(tc.termSize)
+			mustFill(got, 'x')
+
+			vp, err := New(got, tc.ar)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if gotSize := vp.Size(); gotSize != tc.wantSize {
+				t.Errorf("Size => %v, want %v", gotSize, tc.wantSize)
+			}
+
+			err = tc.draw(vp)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("draw => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+
+			if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
+				t.Errorf("draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestDrawsOnlyWithinViewport(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{12, 6})
+	mustFill(ft, 'x')
+	ar := image.Rect(2, 1, 10, 5)
+	vp, err := New(ft, ar)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	c, err := container.New(
+		vp,
+		container.Border(linestyle.Light),
+		container.BorderTitle("A"),
+	)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+	if err := termdash.Render(vp, c); err != nil {
+		t.Fatalf("Render => unexpected error: %v", err)
+	}
+
+	// The same container drawn on a terminal of the size of the viewport.
+	wantVP := faketerm.MustNew(ar.Size())
+	wantC, err := container.New(
+		wantVP,
+		container.Border(linestyle.Light),
+		container.BorderTitle("A"),
+	)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+	if err := termdash.Render(wantVP, wantC); err != nil {
+		t.Fatalf("Render => unexpected error: %v", err)
+	}
+
+	gotBuf, wantBuf := ft.BackBuffer(), wantVP.BackBuffer()
+	for x := range gotBuf {
+		for y := range gotBuf[x] {
+			p := image.Point{x, y}
+			got := gotBuf[x][y].Rune
+			if !p.In(ar) {
+				if got != 'x' {
+					t.Errorf("cell %v outside of the viewport => %q, want it untouched", p, got)
+				}
+				continue
+			}
+			vpP := p.Sub(ar.Min)
+			if want := wantBuf[vpP.X][vpP.Y].Rune; got != want {
+				t.Errorf("cell %v inside of the viewport => %q, want %q", p, got, want)
+			}
+		}
+	}
+}
+
+func TestNewCentered(t *testing.T) {
+	tests := []struct {
+		desc    string
+		term    terminalapi.Terminal
+		size    image.Point
+		wantErr bool
+	}{
+		{
+			desc:    "fails on nil terminal",
+			size:    image.Point{1, 1},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on zero width",
+			term:    faketerm.MustNew(image.Point{3, 3}),
+			size:    image.Point{0, 1},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative height",
+			term:    faketerm.MustNew(image.Point{3, 3}),
+			size:    image.Point{1, -1},
+			wantErr: true,
+		},
+		{
+			desc: "succeeds",
+			term: faketerm.MustNew(image.Point{3, 3}),
+			size: image.Point{1, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := NewCentered(tc.term, tc.size)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewCentered => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestCentered(t *testing.T) {
+	tests := []struct {
+		desc     string
+		termSize image.Point
+		size     image.Point
+		// draw draws on the viewport.
+		draw     func(*Terminal) error
+		wantSize image.Point
+		want     func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:     "sets cells relative to the centered viewport",
+			termSize: image.Point{10, 6},
+			size:     image.Point{4, 2},
+			draw: func(vp *Terminal) error {
+				if err := vp.SetCell(image.Point{0, 0}, 'a'); err != nil {
+					return err
+				}
+				return vp.SetCell(image.Point{3, 1}, 'b')
+			},
+			wantSize: image.Point{4, 2},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFill(ft, 'x')
+				ft.SetCell(image.Point{3, 2}, 'a')
+				ft.SetCell(image.Point{6, 3}, 'b')
+				return ft
+			},
+		},
+		{
+			desc:     "rounds the margins down on odd differences",
+			termSize: image.Point{5, 4},
+			size:     image.Point{2, 1},
+			draw: func(vp *Terminal) error {
+				return vp.SetCell(image.Point{0, 0}, 'a')
+			},
+			wantSize: image.Point{2, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFill(ft, 'x')
+				ft.SetCell(image.Point{1, 1}, 'a')
+				return ft
+			},
+		},
+		{
+			desc:     "clears the margins",
+			termSize: image.Point{5, 4},
+			size:     image.Point{2, 1},
+			draw: func(vp *Terminal) error {
+				return vp.Clear()
+			},
+			wantSize: image.Point{2, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "clips the viewport to a smaller terminal",
+			termSize: image.Point{3, 2},
+			size:     image.Point{4, 3},
+			draw: func(vp *Terminal) error {
+				return vp.SetCell(image.Point{2, 1}, 'a')
+			},
+			wantSize: image.Point{3, 2},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustFill(ft, 'x')
+				ft.SetCell(image.Point{2, 1}, 'a')
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := faketerm.MustNew(tc.termSize)
+			mustFill(got, 'x')
+
+			vp, err := NewCentered(got, tc.size)
+			if err != nil {
+				t.Fatalf("NewCentered => unexpected error: %v", err)
+			}
+			if gotSize := vp.Size(); gotSize != tc.wantSize {
+				t.Errorf("Size => %v, want %v", gotSize, tc.wantSize)
+			}
+
+			if err := tc.draw(vp); err != nil {
+				t.Fatalf("draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(got.Size()), got); diff != "" {
+				t.Errorf("draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestCenteredLayout(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{12, 6})
+	size := image.Point{8, 4}
+	vp, err := NewCentered(ft, size)
+	if err != nil {
+		t.Fatalf("NewCentered => unexpected error: %v", err)
+	}
+
+	newContainer := func(t terminalapi.Terminal) (*container.Container, error) {
+		return container.New(
+			t,
+			container.Border(linestyle.Light),
+			container.SplitVertical(
+				container.Left(container.Border(linestyle.Light)),
+				container.Right(container.Border(linestyle.Light)),
+			),
+		)
+	}
+	c, err := newContainer(vp)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+	if err := termdash.Render(vp, c); err != nil {
+		t.Fatalf("Render => unexpected error: %v", err)
+	}
+
+	// The same container drawn on a terminal of the virtual size and placed
+	// in the middle of blank margins.
+	wantVP := faketerm.MustNew(size)
+	wantC, err := newContainer(wantVP)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+	if err := termdash.Render(wantVP, wantC); err != nil {
+		t.Fatalf("Render => unexpected error: %v", err)
+	}
+	want := faketerm.MustNew(ft.Size())
+	offset := image.Point{2, 1}
+	wantBuf := wantVP.BackBuffer()
+	for x := range wantBuf {
+		for y := range wantBuf[x] {
+			c := wantBuf[x][y]
+			if err := want.SetCell(image.Point{x, y}.Add(offset), c.Rune, c.Opts); err != nil {
+				t.Fatalf("SetCell => unexpected error: %v", err)
+			}
+		}
+	}
+
+	if diff := faketerm.Diff(want, ft); diff != "" {
+		t.Errorf("Render => %v", diff)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	eq := eventqueue.New()
+	for _, ev := range []terminalapi.Event{
+		&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+		&terminalapi.Mouse{Position: image.Point{3, 2}, Button: mouse.ButtonLeft},
+		&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+		&terminalapi.Resize{Size: image.Point{4, 3}},
+	} {
+		eq.Push(ev)
+	}
+	ft := faketerm.MustNew(image.Point{5, 4}, faketerm.WithEventQueue(eq))
+	vp, err := New(ft, image.Rect(1, 1, 4, 3))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var got []terminalapi.Event
+	for i := 0; i < 3; i++ {
+		got = append(got, vp.Event(ctx))
+	}
+
+	want := []terminalapi.Event{
+		// The mouse event outside of the viewport is dropped.
+		&terminalapi.Mouse{Position: image.Point{2, 1}, Button: mouse.ButtonLeft},
+		&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+		// The viewport is clipped to the resized terminal.
+		&terminalapi.Resize{Size: image.Point{3, 2}},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Event => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSupportsMouse(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []faketerm.Option
+		want bool
+	}{
+		{
+			desc: "supported by the underlying terminal",
+			want: true,
+		},
+		{
+			desc: "unsupported by the underlying terminal",
+			opts: []faketerm.Option{faketerm.WithoutMouse()},
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			vp, err := New(faketerm.MustNew(image.Point{3, 3}, tc.opts...), image.Rect(0, 0, 1, 1))
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if got := vp.SupportsMouse(); got != tc.want {
+				t.Errorf("SupportsMouse => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// Example draws a dashboard into a rectangle of a larger terminal.
+func Example() {
+	t, err := headless.New(image.Point{12, 5})
+	if err != nil {
+		panic(err)
+	}
+	vp, err := New(t, image.Rect(2, 1, 12, 4))
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		vp,
+		container.Border(linestyle.Light),
+		container.BorderTitle("CPU"),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := termdash.Render(vp, c); err != nil {
+		panic(err)
+	}
+	fmt.Print(t)
+	// Output:
+	//
+	//   ┌CPU─────┐
+	//   │        │
+	//   └────────┘
+	//
+}
@@ -16,8 +16,10 @@
 package widgetapi
 
 import (
+	"fmt"
 	"image"
 
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
@@ -143,6 +145,11 @@ type Options struct {
 type Meta struct {
 	// Focused asserts whether the widget's container is focused.
 	Focused bool
+
+	// MouseUnsupported asserts that the terminal doesn't report mouse events.
+	// Widgets that can be operated with both the mouse and the keyboard
+	// should omit elements that only serve the mouse, e.g. buttons to click.
+	MouseUnsupported bool
 }
 
 // Widget is a single widget on the dashboard.
@@ -183,3 +190,130 @@ type Widget interface {
 	// Draw.
 	Options() Options
 }
+
+// Measurer is an optional interface widgets can implement in order to report
+// the size they would like to occupy. This allows layouts to adapt to the
+// content of the widgets.
+// Implementations must be thread safe.
+type Measurer interface {
+	// Measure returns the size of the canvas the widget needs in order to
+	// display all of its content when given at most the size of the
+	// constraint. Setting any of the two coordinates of the constraint to zero
+	// indicates unlimited space in that direction. The returned size never
+	// exceeds a non-zero coordinate of the constraint.
+	//
+	// E.g. a widget that wraps text would return the number of lines its
+	// content occupies when wrapped to the width of the constraint.
+	Measure(constraint image.Point) image.Point
+}
+
+// Stateful is an optional interface widgets can implement in order to have
+// their logical state (e.g. the scrolling position or the entered value)
+// saved and restored along with the state of the container they are placed
+// in, see Container.SaveState and Container.RestoreState.
+// Implementations must be thread safe.
+type Stateful interface {
+	// SaveState returns the serialized state of the widget.
+	SaveState() ([]byte, error)
+
+	// RestoreState restores the widget state from the data previously
+	// returned by SaveState.
+	RestoreState(data []byte) error
+}
+
+// CustomHandler is an optional interface widgets can implement in order to
+// receive the application specific terminalapi.Custom events. The events are
+// delivered to all the widgets in the container tree that implement it,
+// regardless of their focus or visibility.
+type CustomHandler interface {
+	// Custom is called with the posted custom event.
+	// This method is called from a separate goroutine, it must be thread
+	// safe. Returning an error causes termdash to handle it like errors
+	// returned from Keyboard or Mouse.
+	Custom(ev *terminalapi.Custom) error
+}
+
+// Scrollable is an optional interface widgets with vertically scrolling content
+// can implement in order to have their scrolling position linked with other
+// widgets, see container.ScrollGroup.
+// Implementations must be thread safe.
+type Scrollable interface {
+	// LinkScroll links the scrolling position of the widget to the provided
+	// shared position. From now on the widget reports all the scrolling
+	// requests from the user to the link and displays the content from the
+	// position the link returns.
+	LinkScroll(l ScrollLink)
+}
+
+// ScrollLink is a scrolling position shared by multiple Scrollable widgets.
+// Implementations must be thread safe.
+type ScrollLink interface {
+	// Scroll is called by the widget every time it draws. Delta is the number
+	// of lines the user scrolled the widget by since the last call, negative
+	// when scrolling up. MaxFirst is the highest first line the widget can
+	// display given its content and size. Returns the shared first line, the
+	// widget clamps it to the range of its own content.
+	Scroll(w Scrollable, delta, maxFirst int) int
+}
+
+// ChangeReporter is an optional interface widgets can implement in order to
+// report whether their content changed since they were last drawn. This
+// allows termdash to skip redrawing the terminal when nothing changed.
+// Widgets that don't implement it are assumed to change all the time.
+// Implementations must be thread safe.
+type ChangeReporter interface {
+	// Changed returns true if the widget would draw something different than
+	// on the last call to Draw, e.g. because its data were updated. Must
+	// return true if Draw wasn't called yet.
+	Changed() bool
+}
+
+// Tooltip is a short text displayed over the other widgets on the dashboard,
+// e.g. the details of the data point under the mouse cursor.
+type Tooltip struct {
+	// Text is the text of the tooltip, displayed on a single line. Control
+	// characters like newlines are displayed as spaces.
+	Text string
+
+	// Position is the point the tooltip belongs to, relative to the canvas of
+	// the widget. Usually the last position of the mouse cursor. The tooltip
+	// is displayed next to it.
+	Position image.Point
+
+	// CellOpts are the cell options of the tooltip text. If empty, the
+	// tooltip is displayed as black text on a white background.
+	CellOpts []cell.Option
+}
+
+// Tooltipper is an optional interface widgets can implement in order to
+// display a tooltip that isn't limited to their canvas. The tooltips are
+// drawn after all the widgets, over any widgets next to the one that displays
+// them. Widgets that implement ChangeReporter should report a change when
+// their tooltip changes.
+// Implementations must be thread safe.
+type Tooltipper interface {
+	// Tooltip returns the tooltip the widget currently displays or nil if it
+	// doesn't display any. Called after the widget is drawn.
+	Tooltip() *Tooltip
+}
+
+// RenderToBuffer draws the widget once onto a fresh canvas of the specified
+// size and returns the canvas holding the resulting buffer of cells. This
+// allows rendering a widget outside of a container, e.g. to compose a preview
+// thumbnail. The returned canvas is zero-based, use Canvas.CopyTo to blit it
+// onto another canvas, or onto a sub-canvas in order to place it elsewhere.
+// The widget is drawn as unfocused.
+func RenderToBuffer(w Widget, size image.Point) (*canvas.Canvas, error) {
+	if size.X <= 0 || size.Y <= 0 {
+		return nil, fmt.Errorf("invalid size %v, both dimensions must be positive", size)
+	}
+
+	cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Draw(cvs, &Meta{}); err != nil {
+		return nil, fmt.Errorf("widget.Draw => %v", err)
+	}
+	return cvs, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widgetapi
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// testWidget is a widget that fills its canvas with a rune or fails to draw.
+type testWidget struct {
+	r       rune
+	drawErr bool
+}
+
+func (tw *testWidget) Draw(cvs *canvas.Canvas, meta *Meta) error {
+	if tw.drawErr {
+		return errors.New("draw failed")
+	}
+	return cvs.SetAreaCells(cvs.Area(), tw.r)
+}
+
+func (tw *testWidget) Keyboard(k *terminalapi.Keyboard) error { return nil }
+
+func (tw *testWidget) Mouse(m *terminalapi.Mouse) error { return nil }
+
+func (tw *testWidget) Options() Options { return Options{} }
+
+func TestRenderToBuffer(t *testing.T) {
+	tests := []struct {
+		desc    string
+		widget  *testWidget
+		size    image.Point
+		wantErr bool
+	}{
+		{
+			desc:    "fails on zero size",
+			widget:  &testWidget{r: 'x'},
+			size:    image.Point{0, 1},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative size",
+			widget:  &testWidget{r: 'x'},
+			size:    image.Point{1, -1},
+			wantErr: true,
+		},
+		{
+			desc:    "fails when the widget fails to draw",
+			widget:  &testWidget{drawErr: true},
+			size:    image.Point{2, 2},
+			wantErr: true,
+		},
+		{
+			desc:   "draws the widget onto a buffer of the requested size",
+			widget: &testWidget{r: 'x'},
+			size:   image.Point{3, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := RenderToBuffer(tc.widget, tc.size)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("RenderToBuffer => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Size() != tc.size {
+				t.Errorf("RenderToBuffer => size %v, want %v", got.Size(), tc.size)
+			}
+			for col := 0; col < tc.size.X; col++ {
+				for row := 0; row < tc.size.Y; row++ {
+					p := image.Point{col, row}
+					c, err := got.Cell(p)
+					if err != nil {
+						t.Fatalf("Cell(%v) => unexpected error: %v", p, err)
+					}
+					if c.Rune != tc.widget.r {
+						t.Errorf("Cell(%v) => rune %q, want %q", p, c.Rune, tc.widget.r)
+					}
+				}
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"image"
 	"math"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
@@ -29,6 +30,7 @@ import (
 	"github.com/mum4k/termdash/internal/area"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 )
@@ -87,6 +89,10 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	for i, v := range bc.values {
+		clamped := v > bc.max // Only possible with OverflowClamp.
+		if clamped {
+			v = bc.max
+		}
 		r, err := bc.barRect(cvs, i, v)
 		if err != nil {
 			return err
@@ -100,6 +106,18 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 				return err
 			}
 		}
+		if err := bc.drawPartialTop(cvs, i, v, r); err != nil {
+			return err
+		}
+		if clamped && r.Dy() > 0 {
+			top := image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+1)
+			if err := draw.Rectangle(cvs, top,
+				draw.RectCellOpts(cell.FgColor(bc.valColor(i)), cell.BgColor(bc.barColor(i))),
+				draw.RectChar(OverflowChar),
+			); err != nil {
+				return err
+			}
+		}
 
 		if bc.opts.showValues {
 			if err := bc.drawText(cvs, i, fmt.Sprint(bc.values[i]), bc.valColor(i), insideBar); err != nil {
@@ -109,7 +127,7 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 
 		l, c := bc.label(i)
 		if l != "" {
-			if err := bc.drawText(cvs, i, l, c, underBar); err != nil {
+			if err := bc.drawLabel(cvs, i, l, c); err != nil {
 				return err
 			}
 		}
@@ -157,6 +175,100 @@ func (bc *BarChart) drawText(cvs *canvas.Canvas, i int, text string, color cell.
 	)
 }
 
+// drawLabel draws the provided label under the i-th bar according to the
+// configured label rotation.
+func (bc *BarChart) drawLabel(cvs *canvas.Canvas, i int, label string, color cell.Color) error {
+	if bc.opts.labelRot == LabelRotationNone {
+		return bc.drawText(cvs, i, label, color, underBar)
+	}
+
+	start, err := bc.labelStart(cvs, i)
+	if err != nil {
+		return err
+	}
+	if !start.In(cvs.Area()) {
+		return nil // No space for the label.
+	}
+
+	switch bc.opts.labelRot {
+	case LabelRotationVertical:
+		return draw.VerticalText(cvs, label, start,
+			draw.VerticalTextCellOpts(cell.FgColor(color)),
+			draw.VerticalTextOverrunMode(draw.OverrunModeThreeDot),
+		)
+
+	case LabelRotationDiagonal:
+		maxCells := cvs.Area().Max.X - start.X
+		if rows := cvs.Area().Max.Y - start.Y; rows < maxCells {
+			maxCells = rows
+		}
+		// The label must end before the columns where the label of the next
+		// bar starts, otherwise the two labels run into each other.
+		if next, _ := bc.label(i + 1); i+1 < len(bc.values) && next != "" {
+			nextStart, err := bc.labelStart(cvs, i+1)
+			if err != nil {
+				return err
+			}
+			if free := nextStart.X - start.X; free < maxCells {
+				maxCells = free
+			}
+		}
+		trimmed, err := draw.TrimText(label, maxCells, draw.OverrunModeThreeDot)
+		if err != nil {
+			return err
+		}
+
+		cur := start
+		for _, ru := range trimmed {
+			cells, err := cvs.SetCell(cur, ru, cell.FgColor(color))
+			if err != nil {
+				return err
+			}
+			cur = image.Point{cur.X + cells, cur.Y + 1}
+		}
+	}
+	return nil
+}
+
+// labelStart returns the point where the rotated label of the i-th bar
+// starts, which is under the center of the bar.
+func (bc *BarChart) labelStart(cvs *canvas.Canvas, i int) (image.Point, error) {
+	r, err := bc.barRect(cvs, i, bc.max)
+	if err != nil {
+		return image.Point{}, err
+	}
+	return image.Point{r.Min.X + (r.Dx()-1)/2, r.Max.Y}, nil
+}
+
+// labelHeight determines the number of lines used by the labels under the
+// bars on a canvas of the specified height.
+func (bc *BarChart) labelHeight(cvsHeight int) int {
+	if len(bc.opts.labels) == 0 {
+		return 0
+	}
+	if bc.opts.labelRot == LabelRotationNone {
+		return 1 // One line for the bar labels.
+	}
+
+	height := 1
+	for _, l := range bc.opts.labels {
+		// Diagonal labels advance one line per rune, vertical labels are
+		// drawn by draw.VerticalText which advances by the width of the rune.
+		lines := utf8.RuneCountInString(l)
+		if bc.opts.labelRot == LabelRotationVertical {
+			lines = runewidth.StringWidth(l)
+		}
+		if lines > height {
+			height = lines
+		}
+	}
+	// Leave at least one line for the bars.
+	if max := cvsHeight - 1; height > max {
+		height = max
+	}
+	return height
+}
+
 // barWidth determines the width of a single bar based on options and the canvas.
 func (bc *BarChart) barWidth(cvs *canvas.Canvas) int {
 	if len(bc.values) == 0 {
@@ -174,16 +286,56 @@ func (bc *BarChart) barWidth(cvs *canvas.Canvas) int {
 	return rem / len(bc.values)
 }
 
-// barHeight determines the height of the i-th bar based on the value it is displaying.
+// partialBlocks are the characters used to draw the fractional top cell of a
+// bar when the PartialTopCells option is set, the i-th block fills (i+1)/8
+// of the cell.
+var partialBlocks = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// barHeight determines the height of the i-th bar based on the value it is
+// displaying. This is the number of cells fully filled by the bar.
 func (bc *BarChart) barHeight(cvs *canvas.Canvas, i, value int) int {
-	available := cvs.Area().Dy()
-	if len(bc.opts.labels) > 0 {
-		// One line for the bar labels.
-		available--
+	if bc.opts.partialTop {
+		return bc.barEighths(cvs, value) / len(partialBlocks)
 	}
 
+	available := cvs.Area().Dy() - bc.labelHeight(cvs.Area().Dy())
+
 	ratio := float32(value) / float32(bc.max)
-	return int(float32(available) * ratio)
+	height := int(float32(available) * ratio)
+	if height == 0 && value > 0 && bc.opts.showSmall {
+		return 1
+	}
+	return height
+}
+
+// barEighths determines the height of a bar that displays the value in
+// eighths of a cell, rounded to the nearest eighth.
+func (bc *BarChart) barEighths(cvs *canvas.Canvas, value int) int {
+	available := cvs.Area().Dy() - bc.labelHeight(cvs.Area().Dy())
+	ratio := float64(value) / float64(bc.max)
+	eighths := int(math.Round(float64(available*len(partialBlocks)) * ratio))
+	if eighths == 0 && value > 0 && bc.opts.showSmall {
+		return 1
+	}
+	return eighths
+}
+
+// drawPartialTop draws the fractional top cell of the i-th bar above the fully
+// filled cells in the rectangle r if the PartialTopCells option is set.
+func (bc *BarChart) drawPartialTop(cvs *canvas.Canvas, i, value int, r image.Rectangle) error {
+	if !bc.opts.partialTop {
+		return nil
+	}
+	part := bc.barEighths(cvs, value) % len(partialBlocks)
+	if part == 0 || r.Dx() <= 0 {
+		return nil
+	}
+
+	top := image.Rect(r.Min.X, r.Min.Y-1, r.Max.X, r.Min.Y)
+	return draw.Rectangle(cvs, top,
+		draw.RectCellOpts(cell.FgColor(bc.barColor(i))),
+		draw.RectChar(partialBlocks[part-1]),
+	)
 }
 
 // barRect returns a rectangle that represents the i-th bar on the canvas that
@@ -197,18 +349,18 @@ func (bc *BarChart) barRect(cvs *canvas.Canvas, i, value int) (image.Rectangle,
 	maxX := minX + bw
 
 	bh := bc.barHeight(cvs, i, value)
-	maxY := cvs.Area().Max.Y
-	if len(bc.opts.labels) > 0 {
-		// One line for the bar labels.
-		maxY--
-	}
+	maxY := cvs.Area().Max.Y - bc.labelHeight(cvs.Area().Dy())
 	minY := maxY - bh
 	return image.Rect(minX, minY, maxX, maxY), nil
 }
 
 // barColor safely determines the color for the i-th bar.
 // Colors are optional and don't have to be specified for all the bars.
+// The threshold color, if configured, takes precedence over the bar colors.
 func (bc *BarChart) barColor(i int) cell.Color {
+	if t := bc.opts.threshold; t != nil && bc.values[i] >= t.value {
+		return t.color
+	}
 	if len(bc.opts.barColors) > i {
 		return bc.opts.barColors[i]
 	}
@@ -257,8 +409,9 @@ func (bc *BarChart) ValueCapacity() int {
 
 // Values sets the values to be displayed by the BarChart.
 // Each value ends up in its own bar. The values must not be negative and must
-// be less or equal the maximum value. A bar displaying the maximum value is a
-// full bar, taking all available vertical space.
+// be less or equal the maximum value unless allowed by the Overflow option. A
+// bar displaying the maximum value is a full bar, taking all available
+// vertical space.
 // Provided options override values set when New() was called.
 func (bc *BarChart) Values(values []int, max int, opts ...Option) error {
 	bc.mu.Lock()
@@ -267,13 +420,28 @@ func (bc *BarChart) Values(values []int, max int, opts ...Option) error {
 	// Copy to avoid external modifications. See #174.
 	v := make([]int, len(values))
 	copy(v, values)
-	if err := validateValues(v, max); err != nil {
+
+	// The options can change the overflow mode, so apply them to a copy that
+	// is only kept if the values are valid.
+	o := *bc.opts
+	for _, opt := range opts {
+		opt.set(&o)
+	}
+	if err := o.validate(); err != nil {
+		return err
+	}
+	if err := validateValues(v, max, o.overflow); err != nil {
 		return err
 	}
 
-	for _, opt := range opts {
-		opt.set(bc.opts)
+	if o.overflow == OverflowRescale {
+		for _, val := range v {
+			if val > max {
+				max = val
+			}
+		}
 	}
+	bc.opts = &o
 	bc.values = v
 	bc.max = max
 	return nil
@@ -337,15 +505,19 @@ func (bc *BarChart) minSize() image.Point {
 	return image.Point{minWidth, minHeight}
 }
 
-// validateValues validates the provided values and maximum.
-func validateValues(values []int, max int) error {
+// validateValues validates the provided values and maximum. Values larger
+// than the maximum are only valid if the overflow mode handles them.
+func validateValues(values []int, max int, overflow OverflowMode) error {
 	if max < 1 {
 		return fmt.Errorf("invalid maximum value %d, must be at least 1", max)
 	}
 
 	for i, v := range values {
-		if v < 0 || v > max {
-			return fmt.Errorf("invalid values[%d]: %d, each value must be 0 <= value <= max", i, v)
+		if v < 0 {
+			return fmt.Errorf("invalid values[%d]: %d, each value must be 0 <= value", i, v)
+		}
+		if v > max && overflow == OverflowError {
+			return fmt.Errorf("invalid values[%d]: %d, each value must be 0 <= value <= max, see the Overflow option", i, v)
 		}
 	}
 	return nil
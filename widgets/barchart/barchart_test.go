@@ -139,6 +139,171 @@ func TestBarChart(t *testing.T) {
 			},
 			wantUpdateErr: true,
 		},
+		{
+			desc: "fails on invalid overflow mode",
+			opts: []Option{
+				Overflow(OverflowMode(-1)),
+			},
+			update: func(bc *BarChart) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on invalid overflow mode provided to Values",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1}, 10, Overflow(OverflowMode(-1)))
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "still fails for negative value when clamping overflows",
+			opts: []Option{
+				Char('o'),
+				Overflow(OverflowClamp),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{-1, 11}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "clamps value larger than max and draws the overflow indicator",
+			opts: []Option{
+				Char('o'),
+				Overflow(OverflowClamp),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 15}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 5, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 1),
+					draw.RectChar(OverflowChar),
+					draw.RectCellOpts(
+						cell.FgColor(DefaultValueColor),
+						cell.BgColor(DefaultBarColor),
+					),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "overflow mode can be set when providing values",
+			opts: []Option{
+				Char('o'),
+				ShowValues(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{15}, 10, Overflow(OverflowClamp))
+			},
+			canvas: image.Rect(0, 0, 2, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 1),
+					draw.RectChar(OverflowChar),
+					draw.RectCellOpts(
+						cell.FgColor(DefaultValueColor),
+						cell.BgColor(DefaultBarColor),
+					),
+				)
+				testdraw.MustText(c, "15", image.Point{0, 2}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
+		{
+			desc: "rescales the max to the largest value so that no bar overflows",
+			opts: []Option{
+				Char('o'),
+				Overflow(OverflowRescale),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 20}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 8, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "doesn't rescale the max when no value exceeds it",
+			opts: []Option{
+				Char('o'),
+				Overflow(OverflowRescale),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 10}, 20)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 8, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 5, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
 		{
 			desc: "draws resize needed character when canvas is smaller than requested",
 			opts: []Option{
@@ -188,6 +353,140 @@ func TestBarChart(t *testing.T) {
 			},
 			wantCapacity: 4,
 		},
+		{
+			desc: "displays the fractional top cells of the bars",
+			opts: []Option{
+				PartialTopCells(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{20, 3, 32, 0}, 32)
+			},
+			canvas: image.Rect(0, 0, 7, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// 2.5 cells.
+				testdraw.MustRectangle(c, image.Rect(0, 2, 1, 4),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "▄", image.Point{0, 1},
+					draw.TextCellOpts(cell.FgColor(DefaultBarColor)),
+				)
+				// 3/8 of a cell.
+				testdraw.MustText(c, "▃", image.Point{2, 3},
+					draw.TextCellOpts(cell.FgColor(DefaultBarColor)),
+				)
+				// Full height.
+				testdraw.MustRectangle(c, image.Rect(4, 0, 5, 4),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 4,
+		},
+		{
+			desc: "small values disappear by default",
+			opts: []Option{
+				Char('o'),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 0, 100}, 100)
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(4, 0, 5, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "ShowSmallValues draws small non-zero values as one cell",
+			opts: []Option{
+				Char('o'),
+				ShowSmallValues(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 0, 100}, 100)
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 3, 1, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 0, 5, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "ShowSmallValues draws small non-zero values as one eighth of a cell",
+			opts: []Option{
+				PartialTopCells(),
+				ShowSmallValues(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 0, 100}, 100)
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "▁", image.Point{0, 3},
+					draw.TextCellOpts(cell.FgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 0, 5, 4),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "fractional top cell rounds to the nearest eighth and spans the bar width",
+			opts: []Option{
+				PartialTopCells(),
+				BarWidth(2),
+				BarColors([]cell.Color{cell.ColorBlue}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{3}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// 1.2 cells, rounds up to 1 + 2/8 cells.
+				testdraw.MustRectangle(c, image.Rect(0, 3, 2, 4),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustText(c, "▂▂", image.Point{0, 2},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
 		{
 			desc: "displays bars with labels",
 			opts: []Option{
@@ -238,6 +537,212 @@ func TestBarChart(t *testing.T) {
 			},
 			wantCapacity: 4,
 		},
+		{
+			desc: "fails on invalid label rotation",
+			opts: []Option{
+				LabelRotation(LabelRotationMode(-1)),
+			},
+			update: func(bc *BarChart) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "displays vertical labels in columns under the bars",
+			opts: []Option{
+				Char('o'),
+				BarWidth(3),
+				LabelRotation(LabelRotationVertical),
+				Labels([]string{
+					"abc",
+					"de",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 7, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 4, 3, 7),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 0, 7, 7),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+
+				// Labels.
+				testdraw.MustVerticalText(c, "abc", image.Point{1, 7}, draw.VerticalTextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testdraw.MustVerticalText(c, "de", image.Point{5, 7}, draw.VerticalTextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "trims vertical labels that don't fit",
+			opts: []Option{
+				Char('o'),
+				BarWidth(3),
+				LabelRotation(LabelRotationVertical),
+				Labels([]string{
+					"abcdef",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{10}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+
+				// Labels.
+				testdraw.MustVerticalText(c, "ab…", image.Point{1, 1}, draw.VerticalTextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
+		{
+			desc: "displays diagonal labels under the bars",
+			opts: []Option{
+				Char('o'),
+				BarWidth(3),
+				LabelRotation(LabelRotationDiagonal),
+				Labels([]string{
+					"abc",
+					"de",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{5, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 7, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 4, 3, 7),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 0, 7, 7),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+
+				// Labels.
+				opts := []draw.TextOption{
+					draw.TextCellOpts(cell.FgColor(DefaultLabelColor)),
+				}
+				testdraw.MustText(c, "a", image.Point{1, 7}, opts...)
+				testdraw.MustText(c, "b", image.Point{2, 8}, opts...)
+				testdraw.MustText(c, "c", image.Point{3, 9}, opts...)
+				testdraw.MustText(c, "d", image.Point{5, 7}, opts...)
+				testdraw.MustText(c, "e", image.Point{6, 8}, opts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "trims diagonal labels before the label of the next bar",
+			opts: []Option{
+				Char('o'),
+				BarWidth(3),
+				LabelRotation(LabelRotationDiagonal),
+				Labels([]string{
+					"abcdef",
+					"ghi",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{10, 10}, 10)
+			},
+			canvas: image.Rect(0, 0, 9, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 0, 7, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+
+				// Labels.
+				opts := []draw.TextOption{
+					draw.TextCellOpts(cell.FgColor(DefaultLabelColor)),
+				}
+				testdraw.MustText(c, "a", image.Point{1, 4}, opts...)
+				testdraw.MustText(c, "b", image.Point{2, 5}, opts...)
+				testdraw.MustText(c, "c", image.Point{3, 6}, opts...)
+				testdraw.MustText(c, "…", image.Point{4, 7}, opts...)
+				testdraw.MustText(c, "g", image.Point{5, 4}, opts...)
+				testdraw.MustText(c, "h", image.Point{6, 5}, opts...)
+				testdraw.MustText(c, "i", image.Point{7, 6}, opts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "trims diagonal labels at the edge of the canvas",
+			opts: []Option{
+				Char('o'),
+				BarWidth(3),
+				LabelRotation(LabelRotationDiagonal),
+				Labels([]string{
+					"abcdef",
+				}),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{10}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 4),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+
+				// Labels.
+				opts := []draw.TextOption{
+					draw.TextCellOpts(cell.FgColor(DefaultLabelColor)),
+				}
+				testdraw.MustText(c, "a", image.Point{1, 4}, opts...)
+				testdraw.MustText(c, "…", image.Point{2, 5}, opts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
 		{
 			desc: "trims too long labels",
 			opts: []Option{
@@ -580,6 +1085,83 @@ func TestBarChart(t *testing.T) {
 			},
 			wantCapacity: 3,
 		},
+		{
+			desc: "bars at or above the threshold use the alert color",
+			opts: []Option{
+				Char('o'),
+				ThresholdColor(5, cell.ColorMagenta),
+				ShowValues(),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{4, 5, 6}, 10)
+			},
+			canvas: image.Rect(0, 0, 5, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 6, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(DefaultBarColor)),
+				)
+				testdraw.MustText(c, "4", image.Point{0, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(DefaultBarColor),
+				))
+
+				testdraw.MustRectangle(c, image.Rect(2, 5, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustText(c, "5", image.Point{2, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(cell.ColorMagenta),
+				))
+
+				testdraw.MustRectangle(c, image.Rect(4, 4, 5, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustText(c, "6", image.Point{4, 9}, draw.TextCellOpts(
+					cell.FgColor(DefaultValueColor),
+					cell.BgColor(cell.ColorMagenta),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "threshold color takes precedence over bar colors",
+			opts: []Option{
+				Char('o'),
+				BarColors([]cell.Color{
+					cell.ColorBlue,
+					cell.ColorYellow,
+				}),
+				ThresholdColor(3, cell.ColorMagenta),
+			},
+			update: func(bc *BarChart) error {
+				return bc.Values([]int{1, 3}, 10)
+			},
+			canvas: image.Rect(0, 0, 3, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 9, 1, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 7, 3, 10),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
 		{
 			desc: "regression for #174, protects against external data mutation",
 			opts: []Option{
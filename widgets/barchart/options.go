@@ -43,10 +43,21 @@ type options struct {
 	barWidth    int
 	barGap      int
 	showValues  bool
+	partialTop  bool
+	showSmall   bool
 	barColors   []cell.Color
 	labelColors []cell.Color
 	valueColors []cell.Color
 	labels      []string
+	threshold   *threshold
+	labelRot    LabelRotationMode
+	overflow    OverflowMode
+}
+
+// threshold is a value at or above which bars use an alert color.
+type threshold struct {
+	value int
+	color cell.Color
 }
 
 // validate validates the provided options.
@@ -57,6 +68,12 @@ func (o *options) validate() error {
 	if got, min := o.barGap, 0; got < min {
 		return fmt.Errorf("invalid BarGap %d, must be %d <= BarGap", got, min)
 	}
+	if _, ok := labelRotationModeNames[o.labelRot]; !ok {
+		return fmt.Errorf("invalid LabelRotation %v", o.labelRot)
+	}
+	if _, ok := overflowModeNames[o.overflow]; !ok {
+		return fmt.Errorf("invalid Overflow %v", o.overflow)
+	}
 	return nil
 }
 
@@ -107,6 +124,27 @@ func ShowValues() Option {
 	})
 }
 
+// PartialTopCells tells the bar chart to represent the fractional part of the
+// height of each bar in its top cell using the eighth-block characters, e.g.
+// '▄' for a half of a cell. This makes the heights of the bars smoother, since
+// they are no longer rounded down to whole cells. The top cell is drawn in
+// the color of the bar on the default background.
+func PartialTopCells() Option {
+	return option(func(opts *options) {
+		opts.partialTop = true
+	})
+}
+
+// ShowSmallValues tells the bar chart to draw bars of non-zero values that are
+// too small to fill any part of the bar at the smallest visible height, so they
+// don't disappear. That is one cell, or one eighth of a cell if the
+// PartialTopCells option is set. Bars of zero values are never drawn.
+func ShowSmallValues() Option {
+	return option(func(opts *options) {
+		opts.showSmall = true
+	})
+}
+
 // DefaultBarColor is the default color of a bar, unless specified otherwise
 // via the BarColors option.
 const DefaultBarColor = cell.ColorRed
@@ -121,6 +159,18 @@ func BarColors(colors []cell.Color) Option {
 	})
 }
 
+// ThresholdColor sets an alert color used for any bar whose value is at or
+// above the provided value. Bars displaying smaller values use the colors
+// configured via the BarColors option or the DefaultBarColor.
+func ThresholdColor(value int, color cell.Color) Option {
+	return option(func(opts *options) {
+		opts.threshold = &threshold{
+			value: value,
+			color: color,
+		}
+	})
+}
+
 // DefaultLabelColor is the default color of a bar label, unless specified
 // otherwise via the LabelColors option.
 const DefaultLabelColor = cell.ColorGreen
@@ -149,6 +199,97 @@ func Labels(labels []string) Option {
 	})
 }
 
+// LabelRotationMode determines how the labels under the bars are drawn.
+type LabelRotationMode int
+
+// String implements fmt.Stringer()
+func (lrm LabelRotationMode) String() string {
+	if n, ok := labelRotationModeNames[lrm]; ok {
+		return n
+	}
+	return "LabelRotationModeUnknown"
+}
+
+// labelRotationModeNames maps LabelRotationMode values to human readable names.
+var labelRotationModeNames = map[LabelRotationMode]string{
+	LabelRotationNone:     "LabelRotationNone",
+	LabelRotationVertical: "LabelRotationVertical",
+	LabelRotationDiagonal: "LabelRotationDiagonal",
+}
+
+const (
+	// LabelRotationNone draws the labels horizontally on a single line under
+	// the bars. Labels longer than the width of the bar are trimmed.
+	LabelRotationNone LabelRotationMode = iota
+
+	// LabelRotationVertical draws the labels vertically, one rune per line in
+	// a column under the center of each bar.
+	LabelRotationVertical
+
+	// LabelRotationDiagonal draws the labels diagonally, each rune is one line
+	// lower and to the right of the previous one, starting under the center
+	// of each bar. Labels are trimmed before the column where the label of
+	// the next bar starts.
+	LabelRotationDiagonal
+)
+
+// LabelRotation sets how the labels under the bars are drawn. Rotated labels
+// occupy as many lines under the bars as is the length of the longest label,
+// but at most all the lines except one which is reserved for the bars.
+// Rotated labels that don't fit are trimmed.
+// Defaults to LabelRotationNone.
+func LabelRotation(mode LabelRotationMode) Option {
+	return option(func(opts *options) {
+		opts.labelRot = mode
+	})
+}
+
+// OverflowMode determines how the bar chart handles values larger than the
+// maximum.
+type OverflowMode int
+
+// String implements fmt.Stringer()
+func (om OverflowMode) String() string {
+	if n, ok := overflowModeNames[om]; ok {
+		return n
+	}
+	return "OverflowModeUnknown"
+}
+
+// overflowModeNames maps OverflowMode values to human readable names.
+var overflowModeNames = map[OverflowMode]string{
+	OverflowError:   "OverflowError",
+	OverflowClamp:   "OverflowClamp",
+	OverflowRescale: "OverflowRescale",
+}
+
+const (
+	// OverflowError makes Values return an error for values larger than the
+	// maximum.
+	OverflowError OverflowMode = iota
+
+	// OverflowClamp draws values larger than the maximum as full bars and
+	// marks them with the OverflowChar in the top cell.
+	OverflowClamp
+
+	// OverflowRescale raises the maximum to the largest of the values, so
+	// that no bar overflows.
+	OverflowRescale
+)
+
+// OverflowChar is the rune drawn in the top cell of bars clamped by the
+// OverflowClamp mode.
+const OverflowChar = '▲'
+
+// Overflow sets how the bar chart handles values larger than the maximum
+// provided to Values.
+// Defaults to OverflowError.
+func Overflow(mode OverflowMode) Option {
+	return option(func(opts *options) {
+		opts.overflow = mode
+	})
+}
+
 // DefaultValueColor is the default color of a bar value, unless specified
 // otherwise via the ValueColors option.
 const DefaultValueColor = cell.ColorYellow
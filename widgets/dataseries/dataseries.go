@@ -0,0 +1,202 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dataseries implements a series of values that can be shared by
+// multiple chart widgets.
+package dataseries
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/widgets/linechart"
+	"github.com/mum4k/termdash/widgets/sparkline"
+)
+
+// UpdateFn is called with the values appended to the series by a call to
+// Append and with all the values currently held by the series.
+// The function must be thread-safe, since Append can be called from any
+// goroutine. The functions returned by LineChart and SparkLine update the
+// widgets which are all thread-safe.
+type UpdateFn func(appended, all []float64) error
+
+// Series holds a series of values and updates all the bound widgets each time
+// new values are appended.
+//
+// This object is thread-safe.
+type Series struct {
+	// updateMu serializes the calls to Append, so that the bound widgets
+	// receive the updates in the same order.
+	updateMu sync.Mutex
+
+	// mu protects the values and the bindings.
+	mu sync.Mutex
+
+	// values are the values currently held by the series.
+	values []float64
+	// times are the times when the values were appended, times[i] is the time
+	// of values[i].
+	times []time.Time
+
+	// bindings are the functions that update the bound widgets.
+	bindings []UpdateFn
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new empty Series.
+func New(opts ...Option) (*Series, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Series{
+		opts: opt,
+	}, nil
+}
+
+// Vars to be replaced from tests.
+var (
+	// timeNow returns the current time.
+	timeNow = time.Now
+)
+
+// Bind binds a widget to the series. The provided function is called on each
+// call to Append. Use the LineChart and SparkLine functions to bind the
+// respective widgets.
+// If the series already contains values, the function is called immediately
+// with all of them as the appended values.
+func (s *Series) Bind(fn UpdateFn) error {
+	if fn == nil {
+		return errors.New("the UpdateFn cannot be nil")
+	}
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	s.mu.Lock()
+	s.bindings = append(s.bindings, fn)
+	all := s.copyValues()
+	s.mu.Unlock()
+
+	if len(all) == 0 {
+		return nil
+	}
+	return fn(all, all)
+}
+
+// Append appends the values to the series, updates all the bound widgets and
+// then calls the function provided via the OnAppend option once, so that a
+// single redraw can be requested for all the widgets.
+// If the series holds more values than specified by the Capacity option or
+// values older than specified by the RetainFor option, these values are
+// dropped.
+func (s *Series) Append(values ...float64) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	s.updateMu.Lock()
+	s.mu.Lock()
+	now := timeNow()
+	s.values = append(s.values, values...)
+	for range values {
+		s.times = append(s.times, now)
+	}
+	if c := s.opts.capacity; c > 0 && len(s.values) > c {
+		s.drop(len(s.values) - c)
+	}
+	if d := s.opts.retainFor; d > 0 {
+		cutoff := now.Add(-d)
+		var old int
+		for old < len(s.times) && s.times[old].Before(cutoff) {
+			old++
+		}
+		s.drop(old)
+	}
+	appended := make([]float64, len(values))
+	copy(appended, values)
+	all := s.copyValues()
+	bindings := make([]UpdateFn, len(s.bindings))
+	copy(bindings, s.bindings)
+	s.mu.Unlock()
+
+	for i, fn := range bindings {
+		if err := fn(appended, all); err != nil {
+			s.updateMu.Unlock()
+			return fmt.Errorf("binding[%d] failed to update: %v", i, err)
+		}
+	}
+	s.updateMu.Unlock()
+
+	// Mutex must be released when calling the callback. Users might request
+	// a redraw which draws the bound widgets.
+	if s.opts.onAppend != nil {
+		return s.opts.onAppend()
+	}
+	return nil
+}
+
+// drop drops the specified number of the oldest values.
+// Caller must hold mu.
+func (s *Series) drop(n int) {
+	s.values = s.values[n:]
+	s.times = s.times[n:]
+}
+
+// Values returns a copy of the values currently held by the series.
+func (s *Series) Values() []float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.copyValues()
+}
+
+// copyValues returns a copy of the values.
+// Caller must hold mu.
+func (s *Series) copyValues() []float64 {
+	values := make([]float64, len(s.values))
+	copy(values, s.values)
+	return values
+}
+
+// LineChart returns an UpdateFn that displays all the values in the series
+// as the series of the LineChart with the provided label.
+func LineChart(lc *linechart.LineChart, label string, opts ...linechart.SeriesOption) UpdateFn {
+	return func(appended, all []float64) error {
+		return lc.Series(label, all, opts...)
+	}
+}
+
+// SparkLine returns an UpdateFn that adds the appended values to the
+// SparkLine. The values are rounded to the nearest integer, the SparkLine
+// only accepts values that are zero or positive.
+func SparkLine(sl *sparkline.SparkLine) UpdateFn {
+	return func(appended, all []float64) error {
+		data := make([]int, len(appended))
+		for i, v := range appended {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("value[%d]: %v cannot be displayed on the SparkLine", i, v)
+			}
+			data[i] = int(math.Round(v))
+		}
+		return sl.Add(data)
+	}
+}
@@ -0,0 +1,420 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataseries
+
+import (
+	"errors"
+	"image"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/linechart"
+	"github.com/mum4k/termdash/widgets/sparkline"
+)
+
+// update is a single call to an UpdateFn.
+type update struct {
+	appended []float64
+	all      []float64
+}
+
+// recorder records the calls to its UpdateFn.
+type recorder struct {
+	// wantErr when set to true, makes the UpdateFn return an error.
+	wantErr bool
+
+	mu      sync.Mutex
+	updates []update
+}
+
+// update implements UpdateFn.
+func (r *recorder) update(appended, all []float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.wantErr {
+		return errors.New("r.wantErr set to true")
+	}
+	r.updates = append(r.updates, update{appended: appended, all: all})
+	return nil
+}
+
+// counter counts the calls to its AppendFn.
+type counter struct {
+	mu    sync.Mutex
+	calls int
+}
+
+// onAppend implements AppendFn.
+func (c *counter) onAppend() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return nil
+}
+
+func TestSeries(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		// before are values appended before the recorder is bound.
+		before []float64
+		// appends are the calls to Append done after the recorder is bound.
+		appends     [][]float64
+		wantValues  []float64
+		wantUpdates []update
+	}{
+		{
+			desc:       "no values",
+			wantValues: []float64{},
+		},
+		{
+			desc:       "appending no values doesn't update",
+			appends:    [][]float64{{}},
+			wantValues: []float64{},
+		},
+		{
+			desc:       "each append updates the binding",
+			appends:    [][]float64{{1, 2}, {3}},
+			wantValues: []float64{1, 2, 3},
+			wantUpdates: []update{
+				{appended: []float64{1, 2}, all: []float64{1, 2}},
+				{appended: []float64{3}, all: []float64{1, 2, 3}},
+			},
+		},
+		{
+			desc:       "binding receives existing values",
+			before:     []float64{1, 2},
+			appends:    [][]float64{{3}},
+			wantValues: []float64{1, 2, 3},
+			wantUpdates: []update{
+				{appended: []float64{1, 2}, all: []float64{1, 2}},
+				{appended: []float64{3}, all: []float64{1, 2, 3}},
+			},
+		},
+		{
+			desc:       "capacity drops the oldest values",
+			opts:       []Option{Capacity(2)},
+			appends:    [][]float64{{1, 2}, {3}, {4, 5, 6}},
+			wantValues: []float64{5, 6},
+			wantUpdates: []update{
+				{appended: []float64{1, 2}, all: []float64{1, 2}},
+				{appended: []float64{3}, all: []float64{2, 3}},
+				{appended: []float64{4, 5, 6}, all: []float64{5, 6}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := s.Append(tc.before...); err != nil {
+				t.Fatalf("Append => unexpected error: %v", err)
+			}
+
+			r := &recorder{}
+			if err := s.Bind(r.update); err != nil {
+				t.Fatalf("Bind => unexpected error: %v", err)
+			}
+			for _, values := range tc.appends {
+				if err := s.Append(values...); err != nil {
+					t.Fatalf("Append => unexpected error: %v", err)
+				}
+			}
+
+			if diff := pretty.Compare(tc.wantValues, s.Values()); diff != "" {
+				t.Errorf("Values => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantUpdates, r.updates); diff != "" {
+				t.Errorf("UpdateFn => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// timedAppend is a call to Append at the specified offset from the start
+// time.
+type timedAppend struct {
+	at     time.Duration
+	values []float64
+}
+
+func TestRetainFor(t *testing.T) {
+	start := time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc        string
+		opts        []Option
+		appends     []timedAppend
+		wantValues  []float64
+		wantUpdates []update
+	}{
+		{
+			desc: "retains values regardless of their age by default",
+			appends: []timedAppend{
+				{at: 0, values: []float64{1}},
+				{at: time.Hour, values: []float64{2}},
+			},
+			wantValues: []float64{1, 2},
+			wantUpdates: []update{
+				{appended: []float64{1}, all: []float64{1}},
+				{appended: []float64{2}, all: []float64{1, 2}},
+			},
+		},
+		{
+			desc: "retains recent values",
+			opts: []Option{RetainFor(10 * time.Second)},
+			appends: []timedAppend{
+				{at: 0, values: []float64{1, 2}},
+				{at: 5 * time.Second, values: []float64{3}},
+				{at: 10 * time.Second, values: []float64{4}},
+			},
+			wantValues: []float64{1, 2, 3, 4},
+			wantUpdates: []update{
+				{appended: []float64{1, 2}, all: []float64{1, 2}},
+				{appended: []float64{3}, all: []float64{1, 2, 3}},
+				{appended: []float64{4}, all: []float64{1, 2, 3, 4}},
+			},
+		},
+		{
+			desc: "advancing time prunes aged out values",
+			opts: []Option{RetainFor(10 * time.Second)},
+			appends: []timedAppend{
+				{at: 0, values: []float64{1, 2}},
+				{at: 5 * time.Second, values: []float64{3}},
+				{at: 11 * time.Second, values: []float64{4}},
+				{at: 16 * time.Second, values: []float64{5}},
+			},
+			wantValues: []float64{4, 5},
+			wantUpdates: []update{
+				{appended: []float64{1, 2}, all: []float64{1, 2}},
+				{appended: []float64{3}, all: []float64{1, 2, 3}},
+				{appended: []float64{4}, all: []float64{3, 4}},
+				{appended: []float64{5}, all: []float64{4, 5}},
+			},
+		},
+		{
+			desc: "values appended after a long pause are retained",
+			opts: []Option{RetainFor(time.Second)},
+			appends: []timedAppend{
+				{at: 0, values: []float64{1}},
+				{at: time.Hour, values: []float64{2, 3}},
+			},
+			wantValues: []float64{2, 3},
+			wantUpdates: []update{
+				{appended: []float64{1}, all: []float64{1}},
+				{appended: []float64{2, 3}, all: []float64{2, 3}},
+			},
+		},
+		{
+			desc: "combined with capacity",
+			opts: []Option{
+				Capacity(2),
+				RetainFor(10 * time.Second),
+			},
+			appends: []timedAppend{
+				{at: 0, values: []float64{1, 2, 3}},
+				{at: 20 * time.Second, values: []float64{4}},
+			},
+			wantValues: []float64{4},
+			wantUpdates: []update{
+				{appended: []float64{1, 2, 3}, all: []float64{2, 3}},
+				{appended: []float64{4}, all: []float64{4}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			now := start
+			timeNow = func() time.Time { return now }
+			defer func() { timeNow = time.Now }()
+
+			s, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			r := &recorder{}
+			if err := s.Bind(r.update); err != nil {
+				t.Fatalf("Bind => unexpected error: %v", err)
+			}
+			for _, ta := range tc.appends {
+				now = start.Add(ta.at)
+				if err := s.Append(ta.values...); err != nil {
+					t.Fatalf("Append => unexpected error: %v", err)
+				}
+			}
+
+			if diff := pretty.Compare(tc.wantValues, s.Values()); diff != "" {
+				t.Errorf("Values => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if diff := pretty.Compare(tc.wantUpdates, r.updates); diff != "" {
+				t.Errorf("UpdateFn => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// mustDraw draws the widget on a canvas of the specified size and returns the
+// resulting terminal.
+func mustDraw(t *testing.T, w widgetapi.Widget, size image.Point) *faketerm.Terminal {
+	t.Helper()
+
+	c, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := w.Draw(c, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	ft := faketerm.MustNew(c.Size())
+	if err := c.Apply(ft); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+	return ft
+}
+
+func TestAppendUpdatesBoundWidgets(t *testing.T) {
+	cnt := &counter{}
+	s, err := New(OnAppend(cnt.onAppend))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	lc, err := linechart.New()
+	if err != nil {
+		t.Fatalf("linechart.New => unexpected error: %v", err)
+	}
+	sl, err := sparkline.New()
+	if err != nil {
+		t.Fatalf("sparkline.New => unexpected error: %v", err)
+	}
+	if err := s.Bind(LineChart(lc, "metric")); err != nil {
+		t.Fatalf("Bind => unexpected error: %v", err)
+	}
+	if err := s.Bind(SparkLine(sl)); err != nil {
+		t.Fatalf("Bind => unexpected error: %v", err)
+	}
+
+	appends := [][]float64{{1, 5}, {2.4, 8}, {3}}
+	for i, values := range appends {
+		if err := s.Append(values...); err != nil {
+			t.Fatalf("Append => unexpected error: %v", err)
+		}
+		if got, want := cnt.calls, i+1; got != want {
+			t.Errorf("after Append #%d, OnAppend was called %d times, want %d", i, got, want)
+		}
+	}
+
+	// The bound widgets must draw the same as widgets updated directly.
+	wantLC, err := linechart.New()
+	if err != nil {
+		t.Fatalf("linechart.New => unexpected error: %v", err)
+	}
+	if err := wantLC.Series("metric", []float64{1, 5, 2.4, 8, 3}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	wantSL, err := sparkline.New()
+	if err != nil {
+		t.Fatalf("sparkline.New => unexpected error: %v", err)
+	}
+	if err := wantSL.Add([]int{1, 5, 2, 8, 3}); err != nil {
+		t.Fatalf("Add => unexpected error: %v", err)
+	}
+
+	size := image.Point{20, 10}
+	if diff := faketerm.Diff(mustDraw(t, wantLC, size), mustDraw(t, lc, size)); diff != "" {
+		t.Errorf("LineChart.Draw => %v", diff)
+	}
+	if diff := faketerm.Diff(mustDraw(t, wantSL, size), mustDraw(t, sl, size)); diff != "" {
+		t.Errorf("SparkLine.Draw => %v", diff)
+	}
+}
+
+func TestNewFails(t *testing.T) {
+	if _, err := New(Capacity(-1)); err == nil {
+		t.Errorf("New(Capacity(-1)) => got nil err, wanted one")
+	}
+	if _, err := New(RetainFor(-time.Second)); err == nil {
+		t.Errorf("New(RetainFor(-1s)) => got nil err, wanted one")
+	}
+}
+
+func TestBindFails(t *testing.T) {
+	s, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := s.Bind(nil); err == nil {
+		t.Errorf("Bind => got nil err, wanted one")
+	}
+}
+
+func TestAppendFails(t *testing.T) {
+	sl, err := sparkline.New()
+	if err != nil {
+		t.Fatalf("sparkline.New => unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc   string
+		bind   UpdateFn
+		values []float64
+	}{
+		{
+			desc:   "binding returns an error",
+			bind:   (&recorder{wantErr: true}).update,
+			values: []float64{1},
+		},
+		{
+			desc:   "SparkLine cannot display NaN",
+			bind:   SparkLine(sl),
+			values: []float64{math.NaN()},
+		},
+		{
+			desc:   "SparkLine cannot display negative values",
+			bind:   SparkLine(sl),
+			values: []float64{-1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cnt := &counter{}
+			s, err := New(OnAppend(cnt.onAppend))
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := s.Bind(tc.bind); err != nil {
+				t.Fatalf("Bind => unexpected error: %v", err)
+			}
+			if err := s.Append(tc.values...); err == nil {
+				t.Errorf("Append => got nil err, wanted one")
+			}
+			if cnt.calls != 0 {
+				t.Errorf("OnAppend was called %d times after a failed Append, want 0", cnt.calls)
+			}
+		})
+	}
+}
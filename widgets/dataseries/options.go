@@ -0,0 +1,99 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataseries
+
+// options.go contains configurable options for Series.
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	capacity  int
+	retainFor time.Duration
+	onAppend  AppendFn
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got, min := o.capacity, 0; got < min {
+		return fmt.Errorf("invalid Capacity %d, must be %d <= Capacity", got, min)
+	}
+	if got, min := o.retainFor, time.Duration(0); got < min {
+		return fmt.Errorf("invalid RetainFor %v, must be %v <= RetainFor", got, min)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{}
+}
+
+// Capacity sets the maximum number of values held by the series. Once the
+// capacity is reached, appending values drops the oldest ones.
+// Defaults to zero which means the series holds all the appended values.
+func Capacity(n int) Option {
+	return option(func(opts *options) {
+		opts.capacity = n
+	})
+}
+
+// RetainFor sets the maximum age of the values held by the series. Each value
+// is time-stamped when it is appended and every call to Append drops the
+// values that are older than the provided duration. Can be combined with the
+// Capacity option, in which case values are dropped when either limit is
+// reached.
+// Note that only widgets that display all the values of the series, like
+// those bound using the LineChart function, stop displaying the dropped
+// values. The SparkLine function only adds the appended values to the
+// SparkLine.
+// Defaults to zero which means the values are retained regardless of their
+// age.
+func RetainFor(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.retainFor = d
+	})
+}
+
+// AppendFn is the function called once after each call to Append.
+//
+// If the function returns an error, it is returned from the call to Append.
+type AppendFn func() error
+
+// OnAppend sets the function that is called once after each call to Append,
+// when all the bound widgets were updated. Use it to request a single
+// redraw for all of them, e.g. by calling termdash.Controller.Redraw.
+func OnAppend(fn AppendFn) Option {
+	return option(func(opts *options) {
+		opts.onAppend = fn
+	})
+}
@@ -0,0 +1,297 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datepicker implements a widget that allows the user to select a
+// date from a month calendar.
+package datepicker
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/alignfor"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// DatePicker displays a month calendar and allows the user to select a date.
+//
+// The cursor is moved between days using the arrow keys, the PgUp and PgDn
+// keys move it to the previous or the next month. Pressing Enter selects the
+// date under the cursor.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type DatePicker struct {
+	// mu protects the widget.
+	mu sync.Mutex
+
+	// cursor is the date under the cursor.
+	cursor time.Time
+
+	// selected is the date selected by the user or nil if no date was
+	// selected yet.
+	selected *time.Time
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new DatePicker.
+func New(opts ...Option) (*DatePicker, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+
+	initial := timeNow()
+	if opt.initialDate != nil {
+		initial = *opt.initialDate
+	}
+	return &DatePicker{
+		cursor: truncateDay(initial),
+		opts:   opt,
+	}, nil
+}
+
+// Vars to be replaced from tests.
+var (
+	// timeNow returns the current time.
+	timeNow = time.Now
+)
+
+// Cursor returns the date currently under the cursor.
+func (dp *DatePicker) Cursor() time.Time {
+	dp.mu.Lock()
+	defer dp.mu.Unlock()
+
+	return dp.cursor
+}
+
+// Selected returns the date selected by the user. The returned bool is false
+// if the user didn't select any date yet.
+func (dp *DatePicker) Selected() (time.Time, bool) {
+	dp.mu.Lock()
+	defer dp.mu.Unlock()
+
+	if dp.selected == nil {
+		return time.Time{}, false
+	}
+	return *dp.selected, true
+}
+
+// truncateDay returns midnight of the day of the provided time.
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysIn returns the number of days in the month.
+func daysIn(year int, month time.Month, loc *time.Location) int {
+	// Day zero of the following month is the last day of this month.
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+}
+
+// addMonths returns the date that is the specified number of months away
+// from t. The day is clamped to the last day of the resulting month, i.e.
+// adding one month to January 31st results in the last day of February.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	day := t.Day()
+	if last := daysIn(first.Year(), first.Month(), first.Location()); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, t.Location())
+}
+
+// sameDay asserts whether the two times fall onto the same calendar day.
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+const (
+	// dayWidth is the width of a day column in cells, excluding the space
+	// between columns.
+	dayWidth = 2
+
+	// daysInWeek is the number of columns in the grid.
+	daysInWeek = 7
+
+	// maxWeeks is the maximum number of weeks a month can span.
+	maxWeeks = 6
+
+	// headerHeight is the number of rows used by the month name and weekday
+	// names.
+	headerHeight = 2
+)
+
+// minWidth is the width in cells needed to display the month grid.
+const minWidth = daysInWeek*(dayWidth+1) - 1
+
+// minHeight is the height in cells needed to display the month grid.
+const minHeight = headerHeight + maxWeeks
+
+// column returns the column of the grid the weekday is displayed in.
+func (dp *DatePicker) column(wd time.Weekday) int {
+	return (int(wd) - int(dp.opts.firstWeekday) + daysInWeek) % daysInWeek
+}
+
+// drawHeader draws the month name and the names of the weekdays.
+func (dp *DatePicker) drawHeader(cvs *canvas.Canvas) error {
+	monthAr := image.Rect(0, 0, minWidth, 1)
+	month := fmt.Sprintf("%s %d", dp.cursor.Month(), dp.cursor.Year())
+	start, err := alignfor.Text(monthAr, month, align.HorizontalCenter, align.VerticalTop)
+	if err != nil {
+		return err
+	}
+	if err := draw.Text(cvs, month, start,
+		draw.TextMaxX(monthAr.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cell.FgColor(dp.opts.headerColor)),
+	); err != nil {
+		return err
+	}
+
+	for i := 0; i < daysInWeek; i++ {
+		wd := time.Weekday((int(dp.opts.firstWeekday) + i) % daysInWeek)
+		name := wd.String()[:dayWidth]
+		start := image.Point{i * (dayWidth + 1), 1}
+		if err := draw.Text(cvs, name, start, draw.TextCellOpts(cell.FgColor(dp.opts.headerColor))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dayCellOpts returns the cell options for the specified day.
+func (dp *DatePicker) dayCellOpts(day time.Time, today time.Time, focused bool) []cell.Option {
+	opts := []cell.Option{cell.FgColor(dp.opts.textColor)}
+	if sameDay(day, today) {
+		opts = append(opts, cell.FgColor(dp.opts.todayColor))
+	}
+	if dp.selected != nil && sameDay(day, *dp.selected) {
+		opts = append(opts, cell.BgColor(dp.opts.selectedColor))
+	}
+	if focused && sameDay(day, dp.cursor) {
+		opts = append(opts, cell.BgColor(dp.opts.cursorColor))
+	}
+	return opts
+}
+
+// Draw draws the DatePicker widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (dp *DatePicker) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	dp.mu.Lock()
+	defer dp.mu.Unlock()
+
+	if size := cvs.Size(); size.X < minWidth || size.Y < minHeight {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	if err := dp.drawHeader(cvs); err != nil {
+		return err
+	}
+
+	today := timeNow()
+	loc := dp.cursor.Location()
+	year, month := dp.cursor.Year(), dp.cursor.Month()
+	offset := dp.column(time.Date(year, month, 1, 0, 0, 0, 0, loc).Weekday())
+	for d := 1; d <= daysIn(year, month, loc); d++ {
+		day := time.Date(year, month, d, 0, 0, 0, 0, loc)
+		pos := offset + d - 1
+		start := image.Point{
+			(pos % daysInWeek) * (dayWidth + 1),
+			headerHeight + pos/daysInWeek,
+		}
+		if err := draw.Text(cvs, fmt.Sprintf("%*d", dayWidth, d), start,
+			draw.TextCellOpts(dp.dayCellOpts(day, today, meta.Focused)...),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyboard processes keyboard events.
+// Returns a bool indicating if a date was selected and the selected date.
+func (dp *DatePicker) keyboard(k *terminalapi.Keyboard) (bool, time.Time) {
+	dp.mu.Lock()
+	defer dp.mu.Unlock()
+
+	switch k.Key {
+	case keyboard.KeyArrowLeft:
+		dp.cursor = dp.cursor.AddDate(0, 0, -1)
+
+	case keyboard.KeyArrowRight:
+		dp.cursor = dp.cursor.AddDate(0, 0, 1)
+
+	case keyboard.KeyArrowUp:
+		dp.cursor = dp.cursor.AddDate(0, 0, -daysInWeek)
+
+	case keyboard.KeyArrowDown:
+		dp.cursor = dp.cursor.AddDate(0, 0, daysInWeek)
+
+	case keyboard.KeyPgUp:
+		dp.cursor = addMonths(dp.cursor, -1)
+
+	case keyboard.KeyPgDn:
+		dp.cursor = addMonths(dp.cursor, 1)
+
+	case keyboard.KeyEnter:
+		sel := dp.cursor
+		dp.selected = &sel
+		if dp.opts.onSelect != nil {
+			return true, sel
+		}
+	}
+	return false, time.Time{}
+}
+
+// Keyboard processes keyboard events.
+// Implements widgetapi.Widget.Keyboard.
+func (dp *DatePicker) Keyboard(k *terminalapi.Keyboard) error {
+	if selected, date := dp.keyboard(k); selected {
+		// Mutex must be released when calling the callback.
+		// Users might call container methods from the callback like the
+		// Container.Update, see #205.
+		return dp.opts.onSelect(date)
+	}
+	return nil
+}
+
+// Mouse input isn't supported on the DatePicker widget.
+func (*DatePicker) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the DatePicker widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*DatePicker) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{minWidth, minHeight},
+		MaximumSize:  image.Point{minWidth, minHeight},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
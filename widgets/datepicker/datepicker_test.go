@@ -0,0 +1,536 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datepicker
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// callbackTracker tracks whether callback was called.
+type callbackTracker struct {
+	// wantErr when set to true, makes callback return an error.
+	wantErr bool
+
+	// called asserts whether the callback was called.
+	called bool
+
+	// date is the date the callback was called with.
+	date time.Time
+
+	// mu protects the tracker.
+	mu sync.Mutex
+}
+
+// callback is the callback function.
+func (ct *callbackTracker) callback(date time.Time) error {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+
+	ct.called = true
+	ct.date = date
+	return nil
+}
+
+// date returns midnight UTC of the specified date.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// keys returns keyboard events for the provided keys.
+func keys(ks ...keyboard.Key) []*terminalapi.Keyboard {
+	var res []*terminalapi.Keyboard
+	for _, k := range ks {
+		res = append(res, &terminalapi.Keyboard{Key: k})
+	}
+	return res
+}
+
+func TestKeyboard(t *testing.T) {
+	tests := []struct {
+		desc     string
+		callback *callbackTracker
+		opts     []Option
+		events   []*terminalapi.Keyboard
+
+		wantCursor      time.Time
+		wantSelected    *time.Time
+		wantCallback    *callbackTracker
+		wantCallbackErr bool
+	}{
+		{
+			desc: "cursor starts on the initial date and the time of day is ignored",
+			opts: []Option{
+				InitialDate(time.Date(2026, time.February, 10, 13, 14, 15, 0, time.UTC)),
+			},
+			wantCursor: date(2026, time.February, 10),
+		},
+		{
+			desc:       "cursor starts on today by default",
+			wantCursor: date(2026, time.October, 14),
+		},
+		{
+			desc: "arrow right moves into the next month",
+			opts: []Option{
+				InitialDate(date(2026, time.January, 31)),
+			},
+			events:     keys(keyboard.KeyArrowRight),
+			wantCursor: date(2026, time.February, 1),
+		},
+		{
+			desc: "arrow left moves into the previous month",
+			opts: []Option{
+				InitialDate(date(2026, time.March, 1)),
+			},
+			events:     keys(keyboard.KeyArrowLeft),
+			wantCursor: date(2026, time.February, 28),
+		},
+		{
+			desc: "arrow left moves into the previous year",
+			opts: []Option{
+				InitialDate(date(2026, time.January, 1)),
+			},
+			events:     keys(keyboard.KeyArrowLeft),
+			wantCursor: date(2025, time.December, 31),
+		},
+		{
+			desc: "arrow down moves a week forward across the month boundary",
+			opts: []Option{
+				InitialDate(date(2026, time.January, 28)),
+			},
+			events:     keys(keyboard.KeyArrowDown),
+			wantCursor: date(2026, time.February, 4),
+		},
+		{
+			desc: "arrow up moves a week back across the month boundary",
+			opts: []Option{
+				InitialDate(date(2024, time.March, 3)),
+			},
+			events:     keys(keyboard.KeyArrowUp),
+			wantCursor: date(2024, time.February, 25),
+		},
+		{
+			desc: "page down moves to the same day of the next month",
+			opts: []Option{
+				InitialDate(date(2026, time.October, 14)),
+			},
+			events:     keys(keyboard.KeyPgDn),
+			wantCursor: date(2026, time.November, 14),
+		},
+		{
+			desc: "page up moves to the same day of the previous month",
+			opts: []Option{
+				InitialDate(date(2026, time.January, 14)),
+			},
+			events:     keys(keyboard.KeyPgUp),
+			wantCursor: date(2025, time.December, 14),
+		},
+		{
+			desc: "page down clamps the day to the last day of a shorter month",
+			opts: []Option{
+				InitialDate(date(2026, time.January, 31)),
+			},
+			events:     keys(keyboard.KeyPgDn),
+			wantCursor: date(2026, time.February, 28),
+		},
+		{
+			desc: "page down clamps the day in a leap year",
+			opts: []Option{
+				InitialDate(date(2024, time.January, 31)),
+			},
+			events:     keys(keyboard.KeyPgDn),
+			wantCursor: date(2024, time.February, 29),
+		},
+		{
+			desc: "page up clamps the day to the last day of a shorter month",
+			opts: []Option{
+				InitialDate(date(2026, time.March, 31)),
+			},
+			events:     keys(keyboard.KeyPgUp, keyboard.KeyPgUp),
+			wantCursor: date(2026, time.January, 28),
+		},
+		{
+			desc: "ignores unsupported keys",
+			opts: []Option{
+				InitialDate(date(2026, time.March, 31)),
+			},
+			events:     keys('a', keyboard.KeyHome),
+			wantCursor: date(2026, time.March, 31),
+		},
+		{
+			desc: "enter selects the date without a callback",
+			opts: []Option{
+				InitialDate(date(2026, time.March, 31)),
+			},
+			events:       keys(keyboard.KeyArrowRight, keyboard.KeyEnter),
+			wantCursor:   date(2026, time.April, 1),
+			wantSelected: func() *time.Time { d := date(2026, time.April, 1); return &d }(),
+		},
+		{
+			desc:     "enter calls the callback with the selected date",
+			callback: &callbackTracker{},
+			opts: []Option{
+				InitialDate(date(2026, time.January, 31)),
+			},
+			events:       keys(keyboard.KeyPgDn, keyboard.KeyArrowLeft, keyboard.KeyEnter),
+			wantCursor:   date(2026, time.February, 27),
+			wantSelected: func() *time.Time { d := date(2026, time.February, 27); return &d }(),
+			wantCallback: &callbackTracker{
+				called: true,
+				date:   date(2026, time.February, 27),
+			},
+		},
+		{
+			desc:     "moving the cursor after selection keeps the selected date",
+			callback: &callbackTracker{},
+			opts: []Option{
+				InitialDate(date(2026, time.February, 27)),
+			},
+			events:       keys(keyboard.KeyEnter, keyboard.KeyPgDn),
+			wantCursor:   date(2026, time.March, 27),
+			wantSelected: func() *time.Time { d := date(2026, time.February, 27); return &d }(),
+			wantCallback: &callbackTracker{
+				called: true,
+				date:   date(2026, time.February, 27),
+			},
+		},
+		{
+			desc: "forwards the error returned by the callback",
+			callback: &callbackTracker{
+				wantErr: true,
+			},
+			opts: []Option{
+				InitialDate(date(2026, time.February, 27)),
+			},
+			events:       keys(keyboard.KeyEnter),
+			wantCursor:   date(2026, time.February, 27),
+			wantSelected: func() *time.Time { d := date(2026, time.February, 27); return &d }(),
+			wantCallback: &callbackTracker{
+				wantErr: true,
+			},
+			wantCallbackErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			timeNow = func() time.Time {
+				return time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC)
+			}
+			defer func() {
+				timeNow = time.Now
+			}()
+
+			opts := tc.opts
+			if tc.callback != nil {
+				opts = append(opts, OnSelect(tc.callback.callback))
+			}
+			dp, err := New(opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			for i, ev := range tc.events {
+				err := dp.Keyboard(ev)
+				// Only the last event in test cases is the one that triggers the callback.
+				if i == len(tc.events)-1 {
+					if (err != nil) != tc.wantCallbackErr {
+						t.Errorf("Keyboard => unexpected error: %v, wantCallbackErr: %v", err, tc.wantCallbackErr)
+					}
+				} else if err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+			}
+
+			if got := dp.Cursor(); !got.Equal(tc.wantCursor) {
+				t.Errorf("Cursor => %v, want %v", got, tc.wantCursor)
+			}
+
+			gotSel, gotOk := dp.Selected()
+			if tc.wantSelected == nil {
+				if gotOk {
+					t.Errorf("Selected => %v, %v, want no selection", gotSel, gotOk)
+				}
+			} else if !gotOk || !gotSel.Equal(*tc.wantSelected) {
+				t.Errorf("Selected => %v, %v, want %v, true", gotSel, gotOk, *tc.wantSelected)
+			}
+
+			if diff := pretty.Compare(tc.wantCallback, tc.callback); diff != "" {
+				t.Errorf("CallbackFn => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// mustDrawWeekdays draws the space separated weekday names on the second row.
+func mustDrawWeekdays(cvs *canvas.Canvas, names string, opts ...draw.TextOption) {
+	for i, n := range strings.Fields(names) {
+		testdraw.MustText(cvs, n, image.Point{i * 3, 1}, opts...)
+	}
+}
+
+// mustDrawFebruary2026 draws the days of February 2026, a month that starts
+// on a Sunday, starting in the specified column.
+func mustDrawFebruary2026(cvs *canvas.Canvas, startCol int) {
+	for d := 1; d <= 28; d++ {
+		pos := startCol + d - 1
+		testdraw.MustText(cvs, fmt.Sprintf("%2d", d), image.Point{(pos % 7) * 3, 2 + pos/7})
+	}
+}
+
+func TestDatePicker(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		events  []*terminalapi.Keyboard
+		canvas  image.Rectangle
+		meta    *widgetapi.Meta
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:   "displays resize needed when the canvas is too small",
+			canvas: image.Rect(0, 0, 19, 8),
+			meta:   &widgetapi.Meta{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the month and highlights today",
+			opts: []Option{
+				InitialDate(date(2026, time.February, 14)),
+			},
+			canvas: image.Rect(0, 0, 20, 8),
+			meta:   &widgetapi.Meta{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "February 2026", image.Point{3, 0})
+				mustDrawWeekdays(cvs, "Su Mo Tu We Th Fr Sa")
+				mustDrawFebruary2026(cvs, 0)
+				testdraw.MustText(cvs, "10", image.Point{6, 3}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorNumber(DefaultTodayColorNumber)),
+				))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the cursor when focused",
+			opts: []Option{
+				InitialDate(date(2026, time.February, 14)),
+			},
+			canvas: image.Rect(0, 0, 20, 8),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "February 2026", image.Point{3, 0})
+				mustDrawWeekdays(cvs, "Su Mo Tu We Th Fr Sa")
+				mustDrawFebruary2026(cvs, 0)
+				testdraw.MustText(cvs, "10", image.Point{6, 3}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorNumber(DefaultTodayColorNumber)),
+				))
+				testdraw.MustText(cvs, "14", image.Point{18, 3}, draw.TextCellOpts(
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+				))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the selected date",
+			opts: []Option{
+				InitialDate(date(2026, time.February, 2)),
+			},
+			events: keys(keyboard.KeyEnter, keyboard.KeyArrowDown),
+			canvas: image.Rect(0, 0, 20, 8),
+			meta: &widgetapi.Meta{
+				Focused: true,
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "February 2026", image.Point{3, 0})
+				mustDrawWeekdays(cvs, "Su Mo Tu We Th Fr Sa")
+				mustDrawFebruary2026(cvs, 0)
+				testdraw.MustText(cvs, " 2", image.Point{3, 2}, draw.TextCellOpts(
+					cell.BgColor(cell.ColorNumber(DefaultSelectedColorNumber)),
+				))
+				testdraw.MustText(cvs, " 9", image.Point{3, 3}, draw.TextCellOpts(
+					cell.BgColor(cell.ColorNumber(DefaultCursorColorNumber)),
+				))
+				testdraw.MustText(cvs, "10", image.Point{6, 3}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorNumber(DefaultTodayColorNumber)),
+				))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the next month after paging",
+			opts: []Option{
+				InitialDate(date(2026, time.January, 31)),
+				TodayColor(cell.ColorRed),
+			},
+			events: keys(keyboard.KeyPgDn),
+			canvas: image.Rect(0, 0, 20, 8),
+			meta:   &widgetapi.Meta{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "February 2026", image.Point{3, 0})
+				mustDrawWeekdays(cvs, "Su Mo Tu We Th Fr Sa")
+				mustDrawFebruary2026(cvs, 0)
+				testdraw.MustText(cvs, "10", image.Point{6, 3}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "weeks can start on Monday",
+			opts: []Option{
+				InitialDate(date(2026, time.February, 14)),
+				FirstWeekday(time.Monday),
+				HeaderColor(cell.ColorBlue),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			meta:   &widgetapi.Meta{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "February 2026", image.Point{3, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+				mustDrawWeekdays(cvs, "Mo Tu We Th Fr Sa Su", draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlue),
+				))
+				mustDrawFebruary2026(cvs, 6)
+				testdraw.MustText(cvs, "10", image.Point{3, 4}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorNumber(DefaultTodayColorNumber)),
+				))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			timeNow = func() time.Time {
+				return time.Date(2026, time.February, 10, 10, 0, 0, 0, time.UTC)
+			}
+			defer func() {
+				timeNow = time.Now
+			}()
+
+			dp, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := dp.Keyboard(ev); err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			err = dp.Draw(c, tc.meta)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Draw => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestNewFails(t *testing.T) {
+	if _, err := New(FirstWeekday(time.Weekday(7))); err == nil {
+		t.Errorf("New => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	dp, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := dp.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	dp, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := dp.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{20, 8},
+		MaximumSize:  image.Point{20, 8},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
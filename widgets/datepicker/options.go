@@ -0,0 +1,158 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datepicker
+
+// options.go contains configurable options for DatePicker.
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	initialDate   *time.Time
+	firstWeekday  time.Weekday
+	textColor     cell.Color
+	headerColor   cell.Color
+	todayColor    cell.Color
+	selectedColor cell.Color
+	cursorColor   cell.Color
+	onSelect      SelectFn
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if min, max := time.Sunday, time.Saturday; o.firstWeekday < min || o.firstWeekday > max {
+		return fmt.Errorf("invalid FirstWeekday(%d), must be in range %d <= value <= %d", o.firstWeekday, min, max)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		firstWeekday:  DefaultFirstWeekday,
+		todayColor:    cell.ColorNumber(DefaultTodayColorNumber),
+		selectedColor: cell.ColorNumber(DefaultSelectedColorNumber),
+		cursorColor:   cell.ColorNumber(DefaultCursorColorNumber),
+	}
+}
+
+// InitialDate sets the date the cursor is placed on when the widget is
+// created. The time of the day is ignored.
+// Defaults to the current date.
+func InitialDate(t time.Time) Option {
+	return option(func(opts *options) {
+		opts.initialDate = &t
+	})
+}
+
+// DefaultFirstWeekday is the default value for the FirstWeekday option.
+const DefaultFirstWeekday = time.Sunday
+
+// FirstWeekday sets the day that is displayed in the first column of the
+// month grid.
+// Defaults to DefaultFirstWeekday.
+func FirstWeekday(wd time.Weekday) Option {
+	return option(func(opts *options) {
+		opts.firstWeekday = wd
+	})
+}
+
+// TextColor sets the color of the day numbers.
+// Defaults to the default terminal color.
+func TextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.textColor = c
+	})
+}
+
+// HeaderColor sets the color of the month name and the weekday names.
+// Defaults to the default terminal color.
+func HeaderColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.headerColor = c
+	})
+}
+
+// DefaultTodayColorNumber is the default color number for the TodayColor
+// option.
+const DefaultTodayColorNumber = 220
+
+// TodayColor sets the color of the text of the current date.
+// Defaults to DefaultTodayColorNumber.
+func TodayColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.todayColor = c
+	})
+}
+
+// DefaultSelectedColorNumber is the default color number for the
+// SelectedColor option.
+const DefaultSelectedColorNumber = 33
+
+// SelectedColor sets the background color of the selected date.
+// Defaults to DefaultSelectedColorNumber.
+func SelectedColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.selectedColor = c
+	})
+}
+
+// DefaultCursorColorNumber is the default color number for the CursorColor
+// option.
+const DefaultCursorColorNumber = 250
+
+// CursorColor sets the background color of the date under the cursor.
+// The cursor is only displayed when the widget's container is focused.
+// Defaults to DefaultCursorColorNumber.
+func CursorColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.cursorColor = c
+	})
+}
+
+// SelectFn if provided is called when the user selects a date by pressing the
+// Enter key. The argument date is the selected date at midnight in the
+// location of the initial date.
+//
+// The callback function must be thread-safe as the keyboard event that
+// triggers the selection comes from a separate goroutine.
+type SelectFn func(date time.Time) error
+
+// OnSelect sets a function that will be called with the date under the cursor
+// when the user presses the Enter key.
+func OnSelect(fn SelectFn) Option {
+	return option(func(opts *options) {
+		opts.onSelect = fn
+	})
+}
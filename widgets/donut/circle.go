@@ -57,6 +57,74 @@ func startEndAngles(current, total, startAngle, direction int) (start, end int)
 	return startAngle, end
 }
 
+// arcAngles is like startEndAngles, but for an arc of the specified span in
+// degrees that is symmetrical around the angle of 90 degrees. The progress
+// starts at the end of the arc given by the direction.
+func arcAngles(current, total, span, direction int) (start, end int) {
+	const fullCircle = 360
+	first := 90 + span/2
+	if direction > 0 {
+		first = 90 - span/2
+	}
+	if first < 0 {
+		first += fullCircle
+	}
+	if total == 0 {
+		return first, first
+	}
+
+	size := int(math.Round(float64(span) * float64(current) / float64(total)))
+	if size == 0 {
+		return first, first
+	}
+	if direction > 0 {
+		start, end = first, first+size
+		if end > fullCircle {
+			end -= fullCircle
+		}
+		return start, end
+	}
+	start, end = first-size, first
+	if start < 0 {
+		start += fullCircle
+	}
+	return start, end
+}
+
+// needleAngle returns the angle in degrees in range 0 <= angle < 360 at which
+// the needle points for the provided progress. The span is the size of the
+// arc in degrees that represents 100% of the progress, the start angle only
+// applies to a full circle, see arcAngles.
+func needleAngle(current, total, startAngle, span, direction int) float64 {
+	const fullCircle = 360
+	first := float64(startAngle)
+	if span < fullCircle {
+		first = 90 - float64(direction*span)/2
+	}
+
+	angle := first
+	if total > 0 {
+		angle += float64(direction*span) * float64(current) / float64(total)
+	}
+	angle = math.Mod(angle, fullCircle)
+	if angle < 0 {
+		angle += fullCircle
+	}
+	return angle
+}
+
+// needleEnd returns the pixel on the circle with the provided mid point and
+// radius at the specified angle in degrees.
+// Angles start at the X axis and grow counter-clockwise, the Y axis of the
+// canvas grows down.
+func needleEnd(mid image.Point, radius int, angle float64) image.Point {
+	rad := angle * math.Pi / 180
+	return image.Point{
+		mid.X + int(math.Round(float64(radius)*math.Cos(rad))),
+		mid.Y - int(math.Round(float64(radius)*math.Sin(rad))),
+	}
+}
+
 // midAndRadius given an area of a braille canvas, determines the mid point in
 // pixels and radius to draw the largest circle that fits.
 // The circle's mid point is always positioned on the {0,1} pixel in the chosen
@@ -116,3 +184,21 @@ func availableCells(mid image.Point, radius int) (int, image.Point) {
 	}
 	return pixels / braille.ColMult, startCell
 }
+
+// topMidAndRadius is like midAndRadius, but for a circle of which only the
+// top half gets drawn. The mid point is positioned in the last row of cells.
+func topMidAndRadius(ar image.Rectangle) (image.Point, int) {
+	mid := image.Point{ar.Dx() / 2, ar.Dy() - braille.RowMult + 1}
+	if mid.X%2 != 0 {
+		mid.X--
+	}
+
+	radius := mid.Y
+	if r := mid.X; r < radius {
+		radius = r
+	}
+	if r := ar.Dx() - mid.X - 1; r < radius {
+		radius = r
+	}
+	return mid, radius
+}
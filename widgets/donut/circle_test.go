@@ -347,6 +347,276 @@ func TestStartEndAngles(t *testing.T) {
 	}
 }
 
+func TestArcAngles(t *testing.T) {
+	tests := []struct {
+		desc      string
+		current   int
+		total     int
+		span      int
+		direction int
+		wantStart int
+		wantEnd   int
+	}{
+		{
+			desc:      "zero angle without current or total",
+			span:      180,
+			direction: -1,
+			wantStart: 180,
+			wantEnd:   180,
+		},
+		{
+			desc:      "zero angle without current",
+			total:     100,
+			span:      180,
+			direction: -1,
+			wantStart: 180,
+			wantEnd:   180,
+		},
+		{
+			desc:      "50% of a half circle, clockwise",
+			current:   50,
+			total:     100,
+			span:      180,
+			direction: -1,
+			wantStart: 90,
+			wantEnd:   180,
+		},
+		{
+			desc:      "100% of a half circle, clockwise",
+			current:   100,
+			total:     100,
+			span:      180,
+			direction: -1,
+			wantStart: 0,
+			wantEnd:   180,
+		},
+		{
+			desc:      "25% of a half circle, counter-clockwise",
+			current:   25,
+			total:     100,
+			span:      180,
+			direction: 1,
+			wantStart: 0,
+			wantEnd:   45,
+		},
+		{
+			desc:      "50% of a 270 degree arc, clockwise",
+			current:   50,
+			total:     100,
+			span:      270,
+			direction: -1,
+			wantStart: 90,
+			wantEnd:   225,
+		},
+		{
+			desc:      "100% of a 270 degree arc, clockwise wraps over zero",
+			current:   100,
+			total:     100,
+			span:      270,
+			direction: -1,
+			wantStart: 315,
+			wantEnd:   225,
+		},
+		{
+			desc:      "10% of a 270 degree arc, counter-clockwise",
+			current:   1,
+			total:     10,
+			span:      270,
+			direction: 1,
+			wantStart: 315,
+			wantEnd:   342,
+		},
+		{
+			desc:      "100% of a 270 degree arc, counter-clockwise wraps over zero",
+			current:   10,
+			total:     10,
+			span:      270,
+			direction: 1,
+			wantStart: 315,
+			wantEnd:   225,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotStart, gotEnd := arcAngles(tc.current, tc.total, tc.span, tc.direction)
+			if gotStart != tc.wantStart || gotEnd != tc.wantEnd {
+				t.Errorf("arcAngles => %v, %v, want %v, %v", gotStart, gotEnd, tc.wantStart, tc.wantEnd)
+			}
+		})
+	}
+}
+
+func TestNeedleAngle(t *testing.T) {
+	tests := []struct {
+		desc       string
+		current    int
+		total      int
+		startAngle int
+		span       int
+		direction  int
+		want       float64
+	}{
+		{
+			desc:       "points to the start angle without total",
+			startAngle: 90,
+			span:       360,
+			direction:  -1,
+			want:       90,
+		},
+		{
+			desc:       "points to the start angle at 0%",
+			total:      100,
+			startAngle: 90,
+			span:       360,
+			direction:  -1,
+			want:       90,
+		},
+		{
+			desc:       "25% of a full circle, clockwise",
+			current:    25,
+			total:      100,
+			startAngle: 90,
+			span:       360,
+			direction:  -1,
+			want:       0,
+		},
+		{
+			desc:       "50% of a full circle, clockwise wraps below zero",
+			current:    50,
+			total:      100,
+			startAngle: 90,
+			span:       360,
+			direction:  -1,
+			want:       270,
+		},
+		{
+			desc:       "25% of a full circle, counter-clockwise",
+			current:    25,
+			total:      100,
+			startAngle: 0,
+			span:       360,
+			direction:  1,
+			want:       90,
+		},
+		{
+			desc:       "100% of a full circle points back to the start",
+			current:    100,
+			total:      100,
+			startAngle: 90,
+			span:       360,
+			direction:  -1,
+			want:       90,
+		},
+		{
+			desc:       "arcs ignore the start angle, clockwise starts on the left",
+			total:      100,
+			startAngle: 0,
+			span:       180,
+			direction:  -1,
+			want:       180,
+		},
+		{
+			desc:       "50% of a half circle, clockwise",
+			current:    50,
+			total:      100,
+			startAngle: 90,
+			span:       180,
+			direction:  -1,
+			want:       90,
+		},
+		{
+			desc:       "75% of a half circle, counter-clockwise",
+			current:    75,
+			total:      100,
+			startAngle: 90,
+			span:       180,
+			direction:  1,
+			want:       135,
+		},
+		{
+			desc:       "100% of a 270 degree arc, clockwise wraps below zero",
+			current:    10,
+			total:      10,
+			startAngle: 90,
+			span:       270,
+			direction:  -1,
+			want:       315,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := needleAngle(tc.current, tc.total, tc.startAngle, tc.span, tc.direction)
+			if got != tc.want {
+				t.Errorf("needleAngle => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNeedleEnd(t *testing.T) {
+	tests := []struct {
+		desc   string
+		mid    image.Point
+		radius int
+		angle  float64
+		want   image.Point
+	}{
+		{
+			desc:   "zero radius is anchored at the mid point",
+			mid:    image.Point{10, 9},
+			radius: 0,
+			angle:  45,
+			want:   image.Point{10, 9},
+		},
+		{
+			desc:   "zero degrees points right",
+			mid:    image.Point{10, 9},
+			radius: 5,
+			angle:  0,
+			want:   image.Point{15, 9},
+		},
+		{
+			desc:   "90 degrees points up",
+			mid:    image.Point{10, 9},
+			radius: 5,
+			angle:  90,
+			want:   image.Point{10, 4},
+		},
+		{
+			desc:   "180 degrees points left",
+			mid:    image.Point{10, 9},
+			radius: 5,
+			angle:  180,
+			want:   image.Point{5, 9},
+		},
+		{
+			desc:   "270 degrees points down",
+			mid:    image.Point{10, 9},
+			radius: 5,
+			angle:  270,
+			want:   image.Point{10, 14},
+		},
+		{
+			desc:   "45 degrees points up and right",
+			mid:    image.Point{10, 9},
+			radius: 10,
+			angle:  45,
+			want:   image.Point{17, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := needleEnd(tc.mid, tc.radius, tc.angle)
+			if got != tc.want {
+				t.Errorf("needleEnd => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestMidAndRadius(t *testing.T) {
 	tests := []struct {
 		desc      string
@@ -426,6 +696,43 @@ func TestMidAndRadius(t *testing.T) {
 	}
 }
 
+func TestTopMidAndRadius(t *testing.T) {
+	tests := []struct {
+		desc      string
+		pixelArea image.Rectangle
+		wantMid   image.Point
+		wantR     int
+	}{
+		{
+			desc:      "mid point in the last row of cells",
+			pixelArea: image.Rect(0, 0, 28, 16),
+			wantMid:   image.Point{14, 13},
+			wantR:     13,
+		},
+		{
+			desc:      "middle on X falls on end of cell and is adjusted",
+			pixelArea: image.Rect(0, 0, 6, 8),
+			wantMid:   image.Point{2, 5},
+			wantR:     2,
+		},
+		{
+			desc:      "radius limited by the width",
+			pixelArea: image.Rect(0, 0, 10, 32),
+			wantMid:   image.Point{4, 29},
+			wantR:     4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			gotMid, gotR := topMidAndRadius(tc.pixelArea)
+			if gotMid != tc.wantMid || gotR != tc.wantR {
+				t.Errorf("topMidAndRadius => %v, %v, want %v, %v", gotMid, gotR, tc.wantMid, tc.wantR)
+			}
+		})
+	}
+}
+
 func TestAvailableCells(t *testing.T) {
 	tests := []struct {
 		desc      string
@@ -218,7 +218,10 @@ func (d *Donut) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	defer d.mu.Unlock()
 
 	startA, endA := startEndAngles(d.current, d.total, d.opts.startAngle, d.opts.direction)
-	if startA == endA {
+	if d.opts.arcSpan < DefaultArcSpan {
+		startA, endA = arcAngles(d.current, d.total, d.opts.arcSpan, d.opts.direction)
+	}
+	if startA == endA && !d.opts.needle {
 		// No progress recorded, so nothing to do.
 		return nil
 	}
@@ -248,6 +251,25 @@ func (d *Donut) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	mid, r := midAndRadius(bc.Area())
+	if d.topHalf() {
+		mid, r = topMidAndRadius(bc.Area())
+	}
+	if min := 2; r < min {
+		return draw.ResizeNeeded(cvs)
+	}
+	if d.opts.needle {
+		if err := d.drawNeedle(bc, mid, r); err != nil {
+			return err
+		}
+		if err := bc.CopyTo(cvs); err != nil {
+			return err
+		}
+		if !labelAr.Empty() {
+			return d.drawLabel(cvs, labelAr)
+		}
+		return nil
+	}
+
 	if err := draw.BrailleCircle(bc, mid, r,
 		draw.BrailleCircleFilled(),
 		draw.BrailleCircleArcOnly(startA, endA),
@@ -258,10 +280,15 @@ func (d *Donut) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 
 	holeR := d.holeRadius(r)
 	if holeR != 0 {
-		if err := draw.BrailleCircle(bc, mid, holeR,
+		holeOpts := []draw.BrailleCircleOption{
 			draw.BrailleCircleFilled(),
 			draw.BrailleCircleClearPixels(),
-		); err != nil {
+		}
+		if d.topHalf() {
+			// The bottom half of the hole falls outside of the canvas.
+			holeOpts = append(holeOpts, draw.BrailleCircleArcOnly(0, 180))
+		}
+		if err := draw.BrailleCircle(bc, mid, holeR, holeOpts...); err != nil {
 			return fmt.Errorf("failed to draw the outer circle: %v", err)
 		}
 	}
@@ -283,6 +310,35 @@ func (d *Donut) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	return nil
 }
 
+// drawNeedle draws the outline of the circle or the arc and the needle
+// pointing from its mid point to the current progress.
+func (d *Donut) drawNeedle(bc *braille.Canvas, mid image.Point, r int) error {
+	outlineOpts := []draw.BrailleCircleOption{
+		draw.BrailleCircleCellOpts(d.opts.cellOpts...),
+	}
+	if d.opts.arcSpan < DefaultArcSpan {
+		startA, endA := arcAngles(1, 1, d.opts.arcSpan, d.opts.direction)
+		outlineOpts = append(outlineOpts, draw.BrailleCircleArcOnly(startA, endA))
+	}
+	if err := draw.BrailleCircle(bc, mid, r, outlineOpts...); err != nil {
+		return fmt.Errorf("failed to draw the outline: %v", err)
+	}
+
+	angle := needleAngle(d.current, d.total, d.opts.startAngle, d.opts.arcSpan, d.opts.direction)
+	if err := draw.BrailleLine(bc, mid, needleEnd(mid, r, angle),
+		draw.BrailleLineCellOpts(d.opts.needleCellOpts...),
+	); err != nil {
+		return fmt.Errorf("failed to draw the needle: %v", err)
+	}
+	return nil
+}
+
+// topHalf asserts whether the donut is an arc that only occupies the top
+// half of the circle.
+func (d *Donut) topHalf() bool {
+	return d.opts.arcSpan <= 180
+}
+
 // Keyboard input isn't supported on the Donut widget.
 func (*Donut) Keyboard(k *terminalapi.Keyboard) error {
 	return errors.New("the Donut widget doesn't support keyboard events")
@@ -298,10 +354,18 @@ var minSize = image.Point{3, 3}
 
 // Options implements widgetapi.Widget.Options.
 func (d *Donut) Options() widgetapi.Options {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// We are drawing a circle, ensure equal ratio of rows and columns.
+	// This is adjusted for the inequality of the braille canvas.
+	ratio := image.Point{braille.RowMult, braille.ColMult}
+	if d.topHalf() {
+		// Only the top half of the circle, so half of the rows.
+		ratio.X *= 2
+	}
 	return widgetapi.Options{
-		// We are drawing a circle, ensure equal ratio of rows and columns.
-		// This is adjusted for the inequality of the braille canvas.
-		Ratio: image.Point{braille.RowMult, braille.ColMult},
+		Ratio: ratio,
 
 		// The smallest circle that "looks" like a circle on the canvas.
 		MinimumSize:  minSize,
@@ -75,6 +75,22 @@ func TestDonut(t *testing.T) {
 			canvas:     image.Rect(0, 0, 3, 3),
 			wantNewErr: true,
 		},
+		{
+			desc: "New fails on too small arc span",
+			opts: []Option{
+				ArcSpan(0),
+			},
+			canvas:     image.Rect(0, 0, 3, 3),
+			wantNewErr: true,
+		},
+		{
+			desc: "New fails on too large arc span",
+			opts: []Option{
+				ArcSpan(361),
+			},
+			canvas:     image.Rect(0, 0, 3, 3),
+			wantNewErr: true,
+		},
 		{
 			desc:   "Percent fails on too small start angle",
 			canvas: image.Rect(0, 0, 3, 3),
@@ -577,6 +593,164 @@ func TestDonut(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "half circle arc fills to 50%",
+			opts: []Option{
+				ArcSpan(180),
+				HolePercent(80),
+			},
+			canvas: image.Rect(0, 0, 14, 4),
+			update: func(d *Donut) error {
+				return d.Percent(50)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(c.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{14, 13}, 13,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(90, 180),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{14, 13}, 10,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+					draw.BrailleCircleArcOnly(0, 180),
+				)
+				testbraille.MustCopyTo(bc, c)
+
+				testdraw.MustText(c, "50%", image.Point{6, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "needle points to the progress on a full circle",
+			opts: []Option{
+				Needle(cell.FgColor(cell.ColorRed)),
+			},
+			canvas: image.Rect(0, 0, 10, 5),
+			update: func(d *Donut) error {
+				return d.Percent(25)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{10, 9}, 9)
+				testdraw.MustBrailleLine(bc, image.Point{10, 9}, image.Point{19, 9},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc: "needle points to the start angle without progress",
+			opts: []Option{
+				Needle(),
+			},
+			canvas: image.Rect(0, 0, 10, 5),
+			update: func(d *Donut) error {
+				return d.Percent(0)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{10, 9}, 9)
+				testdraw.MustBrailleLine(bc, image.Point{10, 9}, image.Point{10, 0})
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc: "needle on a half circle arc is anchored at the bottom",
+			opts: []Option{
+				ArcSpan(180),
+				Needle(),
+			},
+			canvas: image.Rect(0, 0, 14, 4),
+			update: func(d *Donut) error {
+				return d.Percent(50)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{14, 13}, 13,
+					draw.BrailleCircleArcOnly(0, 180),
+				)
+				testdraw.MustBrailleLine(bc, image.Point{14, 13}, image.Point{14, 0})
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc: "half circle arc fills counter-clockwise from the right",
+			opts: []Option{
+				ArcSpan(180),
+				HolePercent(80),
+				CounterClockwise(),
+				HideTextProgress(),
+			},
+			canvas: image.Rect(0, 0, 14, 4),
+			update: func(d *Donut) error {
+				return d.Percent(25)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{14, 13}, 13,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(0, 45),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{14, 13}, 10,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+					draw.BrailleCircleArcOnly(0, 180),
+				)
+
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc: "speedometer arc fills to 100%",
+			opts: []Option{
+				ArcSpan(270),
+				HolePercent(80),
+			},
+			canvas: image.Rect(0, 0, 7, 7),
+			update: func(d *Donut) error {
+				return d.Percent(100)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(c.Area())
+
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 6,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(315, 225),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{6, 13}, 5,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+				testbraille.MustCopyTo(bc, c)
+
+				testdraw.MustText(c, "100%", image.Point{2, 3})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "displays 10/10 absolute progress",
 			canvas: image.Rect(0, 0, 8, 8),
@@ -914,5 +1088,22 @@ func TestOptions(t *testing.T) {
 	if diff := pretty.Compare(want, got); diff != "" {
 		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
 	}
+}
 
+func TestOptionsHalfCircleArc(t *testing.T) {
+	d, err := New(ArcSpan(180))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := d.Options()
+	want := widgetapi.Options{
+		Ratio:        image.Point{8, 2},
+		MinimumSize:  image.Point{3, 3},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
 }
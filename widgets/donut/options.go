@@ -54,6 +54,14 @@ type options struct {
 	// The direction in which the donut completes as progress increases.
 	// Positive for counter-clockwise, negative for clockwise.
 	direction int
+	// arcSpan is the size in degrees of the arc that represents 100% of the
+	// progress.
+	arcSpan int
+
+	// needle indicates that the progress is displayed as a needle instead of
+	// a filled circle.
+	needle         bool
+	needleCellOpts []cell.Option
 }
 
 // validate validates the provided options.
@@ -66,6 +74,9 @@ func (o *options) validate() error {
 		return fmt.Errorf("invalid start angle %d, must be in range %d <= angle < %d", o.startAngle, min, max)
 	}
 
+	if min, max := 1, 360; o.arcSpan < min || o.arcSpan > max {
+		return fmt.Errorf("invalid arc span %d, must be in range %d <= span <= %d", o.arcSpan, min, max)
+	}
 	return nil
 }
 
@@ -74,6 +85,7 @@ func newOptions() *options {
 	return &options{
 		donutHolePercent: DefaultHolePercent,
 		startAngle:       DefaultStartAngle,
+		arcSpan:          DefaultArcSpan,
 		direction:        -1,
 		textCellOpts: []cell.Option{
 			cell.FgColor(cell.ColorDefault),
@@ -181,3 +193,39 @@ func LabelAlign(la align.Horizontal) Option {
 		opts.labelAlign = la
 	})
 }
+
+// DefaultArcSpan is the default value for the ArcSpan option.
+const DefaultArcSpan = 360
+
+// ArcSpan turns the donut into an arc gauge, e.g. a half circle or a
+// speedometer. The argument is the size of the arc in degrees that
+// represents 100% of the progress, the arc is symmetrical around the top of
+// the circle. E.g. 180 draws a half circle that fills from the left to the
+// right when progressing clockwise.
+//
+// Spans smaller than 360 ignore the StartAngle option, the progress starts at
+// the end of the arc given by the direction. Arcs that span 180 degrees or
+// less only occupy the top half of the circle, so the widget only needs half
+// of the rows.
+// Valid values are in range 1 <= span <= 360, defaults to DefaultArcSpan,
+// i.e. a full donut.
+func ArcSpan(degrees int) Option {
+	return option(func(opts *options) {
+		opts.arcSpan = degrees
+	})
+}
+
+// Needle turns the donut into a dial with a needle, like an analog gauge.
+// Instead of filling the circle or the arc, the widget draws its outline and
+// a line from the middle of the circle to the point on the outline that
+// represents the progress. The cell options are applied to the cells that
+// contain the needle, the outline uses the options provided via CellOpts.
+//
+// The needle passes through the middle of the circle, so the hole and the
+// text progress aren't drawn, see HolePercent and ShowTextProgress.
+func Needle(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.needle = true
+		opts.needleCellOpts = cOpts
+	})
+}
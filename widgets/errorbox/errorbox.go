@@ -0,0 +1,304 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errorbox implements a widget that displays an error, e.g. a failure
+// of the data source of another widget.
+package errorbox
+
+import (
+	"errors"
+	"image"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/area"
+	"github.com/mum4k/termdash/internal/button"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/internal/wrap"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// ErrorBox displays an error icon and a title on the first line followed by
+// the error message wrapped at word boundaries to the width of the widget.
+//
+// Optionally displays a retry button on the last line, see the OnRetry
+// option. The widget is empty until an error is provided by calling Error.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type ErrorBox struct {
+	// mu protects the widget.
+	mu sync.Mutex
+
+	// message is the displayed error message or an empty string if no error
+	// is displayed.
+	message string
+
+	// mouseFSM tracks left mouse clicks on the retry button.
+	mouseFSM *button.FSM
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new ErrorBox.
+func New(opts ...Option) (*ErrorBox, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &ErrorBox{
+		mouseFSM: button.NewFSM(mouse.ButtonLeft, image.ZR),
+		opts:     opt,
+	}, nil
+}
+
+// Error sets the error displayed by the widget, replacing any previously
+// displayed error. Providing a nil error clears the widget.
+func (eb *ErrorBox) Error(err error) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if err == nil {
+		eb.message = ""
+		return
+	}
+	eb.message = sanitize(err.Error())
+}
+
+// sanitize replaces characters that cannot be displayed with spaces, so that
+// any error message can be wrapped.
+func sanitize(msg string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == ' ' {
+			return r
+		}
+		if unicode.IsControl(r) || unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, msg)
+}
+
+// Draw draws the ErrorBox widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (eb *ErrorBox) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	needAr, err := area.FromSize(eb.minSize())
+	if err != nil {
+		return err
+	}
+	if !needAr.In(cvs.Area()) {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	if eb.message == "" {
+		eb.mouseFSM.UpdateArea(image.ZR)
+		return nil
+	}
+
+	ar := cvs.Area()
+	if err := eb.drawTitle(cvs); err != nil {
+		return err
+	}
+
+	msgAr := image.Rect(ar.Min.X, ar.Min.Y+1, ar.Max.X, ar.Max.Y)
+	if eb.opts.onRetry != nil {
+		btnAr := eb.buttonArea(ar)
+		if err := eb.drawButton(cvs, btnAr); err != nil {
+			return err
+		}
+		eb.mouseFSM.UpdateArea(btnAr)
+		msgAr.Max.Y--
+	}
+	return eb.drawMessage(cvs, msgAr)
+}
+
+// drawTitle draws the icon and the title on the first line.
+func (eb *ErrorBox) drawTitle(cvs *canvas.Canvas) error {
+	ar := cvs.Area()
+	if _, err := cvs.SetCell(ar.Min, eb.opts.icon, cell.FgColor(eb.opts.alertColor)); err != nil {
+		return err
+	}
+
+	titleStart := image.Point{ar.Min.X + 2, ar.Min.Y}
+	if eb.opts.title == "" || !titleStart.In(ar) {
+		return nil
+	}
+	return draw.Text(cvs, eb.opts.title, titleStart,
+		draw.TextCellOpts(cell.FgColor(eb.opts.alertColor)),
+		draw.TextMaxX(ar.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// drawMessage draws the message wrapped at word boundaries within the area.
+// If the message doesn't fit, the last visible line ends with a horizontal
+// ellipsis.
+func (eb *ErrorBox) drawMessage(cvs *canvas.Canvas, msgAr image.Rectangle) error {
+	lines, err := wrap.Cells(buffer.NewCells(eb.message), msgAr.Dx(), wrap.AtWords)
+	if err != nil {
+		return err
+	}
+
+	for i, line := range lines {
+		if i >= msgAr.Dy() {
+			break
+		}
+
+		var b strings.Builder
+		for _, c := range line {
+			b.WriteRune(c.Rune)
+		}
+		text := b.String()
+		if i == msgAr.Dy()-1 && len(lines) > msgAr.Dy() {
+			// Indicate that the message continues but doesn't fit.
+			text += "…"
+		}
+		if text == "" {
+			continue
+		}
+
+		start := image.Point{msgAr.Min.X, msgAr.Min.Y + i}
+		if err := draw.Text(cvs, text, start,
+			draw.TextCellOpts(cell.FgColor(eb.opts.alertColor)),
+			draw.TextMaxX(msgAr.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buttonWidth returns the width of the retry button in cells.
+func (eb *ErrorBox) buttonWidth() int {
+	// One cell of padding on each side of the text.
+	return runewidth.StringWidth(eb.opts.retryText) + 2
+}
+
+// buttonArea returns the area of the retry button, horizontally centered on
+// the last line of the provided area.
+func (eb *ErrorBox) buttonArea(ar image.Rectangle) image.Rectangle {
+	width := eb.buttonWidth()
+	if width > ar.Dx() {
+		width = ar.Dx()
+	}
+	minX := ar.Min.X + (ar.Dx()-width)/2
+	return image.Rect(minX, ar.Max.Y-1, minX+width, ar.Max.Y)
+}
+
+// drawButton draws the retry button in the provided area.
+func (eb *ErrorBox) drawButton(cvs *canvas.Canvas, btnAr image.Rectangle) error {
+	if err := cvs.SetAreaCells(btnAr, ' ', cell.BgColor(eb.opts.buttonColor)); err != nil {
+		return err
+	}
+	return draw.Text(cvs, eb.opts.retryText, image.Point{btnAr.Min.X + 1, btnAr.Min.Y},
+		draw.TextCellOpts(cell.BgColor(eb.opts.buttonColor)),
+		draw.TextMaxX(btnAr.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// retryKeyActivated asserts whether the keyboard event activated the retry
+// button.
+func (eb *ErrorBox) retryKeyActivated(k *terminalapi.Keyboard) bool {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	return eb.message != "" && k.Key == eb.opts.retryKey
+}
+
+// Keyboard activates the retry button when the RetryKey is pressed.
+// Implements widgetapi.Widget.Keyboard.
+func (eb *ErrorBox) Keyboard(k *terminalapi.Keyboard) error {
+	if eb.opts.onRetry == nil {
+		return errors.New("the ErrorBox widget only supports keyboard events when the OnRetry option is provided")
+	}
+
+	if eb.retryKeyActivated(k) {
+		// Mutex must be released when calling the callback.
+		// Users might call container methods from the callback like the
+		// Container.Update, see #205.
+		return eb.opts.onRetry()
+	}
+	return nil
+}
+
+// retryMouseActivated asserts whether the mouse event activated the retry
+// button.
+func (eb *ErrorBox) retryMouseActivated(m *terminalapi.Mouse) bool {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	clicked, _ := eb.mouseFSM.Event(m)
+	return clicked && eb.message != ""
+}
+
+// Mouse activates the retry button if both the press and the release of the
+// left mouse button happen inside the button.
+// Implements widgetapi.Widget.Mouse.
+func (eb *ErrorBox) Mouse(m *terminalapi.Mouse) error {
+	if eb.opts.onRetry == nil {
+		return errors.New("the ErrorBox widget only supports mouse events when the OnRetry option is provided")
+	}
+
+	if eb.retryMouseActivated(m) {
+		// Mutex must be released when calling the callback.
+		// Users might call container methods from the callback like the
+		// Container.Update, see #205.
+		return eb.opts.onRetry()
+	}
+	return nil
+}
+
+// minSize returns the minimum size required by the widget.
+func (eb *ErrorBox) minSize() image.Point {
+	// The icon, a space and at least one cell of the title.
+	// One line for the title and at least one line for the message.
+	size := image.Point{3, 2}
+	if eb.opts.onRetry != nil {
+		size.Y++ // One line for the retry button.
+		if w := eb.buttonWidth(); w > size.X {
+			size.X = w
+		}
+	}
+	return size
+}
+
+// Options implements widgetapi.Widget.Options.
+func (eb *ErrorBox) Options() widgetapi.Options {
+	// No need to lock, the options are fixed when New is called.
+	opts := widgetapi.Options{
+		MinimumSize:  eb.minSize(),
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if eb.opts.onRetry != nil {
+		opts.WantKeyboard = widgetapi.KeyScopeFocused
+		opts.WantMouse = widgetapi.MouseScopeWidget
+	}
+	return opts
+}
@@ -0,0 +1,498 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorbox
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// callbackTracker tracks whether callback was called.
+type callbackTracker struct {
+	// wantErr when set to true, makes callback return an error.
+	wantErr bool
+
+	// count is the number of times the callback was called.
+	count int
+
+	// mu protects the tracker.
+	mu sync.Mutex
+}
+
+// callback is the callback function.
+func (ct *callbackTracker) callback() error {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.count++
+	return nil
+}
+
+// mustDrawTitle draws the default icon and the title.
+func mustDrawTitle(cvs *canvas.Canvas, title string) {
+	alert := draw.TextCellOpts(cell.FgColor(DefaultAlertColor))
+	testdraw.MustText(cvs, string(DefaultIcon), image.Point{0, 0}, alert)
+	testdraw.MustText(cvs, title, image.Point{2, 0}, alert)
+}
+
+// mustDrawLines draws the lines of the message starting on the second line.
+func mustDrawLines(cvs *canvas.Canvas, lines ...string) {
+	for i, line := range lines {
+		testdraw.MustText(cvs, line, image.Point{0, i + 1}, draw.TextCellOpts(cell.FgColor(DefaultAlertColor)))
+	}
+}
+
+// mustDrawButton draws the retry button with the provided text.
+func mustDrawButton(cvs *canvas.Canvas, text string, start image.Point) {
+	testdraw.MustText(cvs, " "+text+" ", start, draw.TextCellOpts(
+		cell.BgColor(cell.ColorNumber(DefaultButtonColorNumber)),
+	))
+}
+
+func TestErrorBox(t *testing.T) {
+	ct := &callbackTracker{}
+	tests := []struct {
+		desc    string
+		opts    []Option
+		err     error
+		canvas  image.Rectangle
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:   "displays resize needed when the canvas is too small",
+			err:    errors.New("failed"),
+			canvas: image.Rect(0, 0, 2, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "displays resize needed when the canvas is too small for the retry button",
+			opts:   []Option{OnRetry(ct.callback)},
+			err:    errors.New("failed"),
+			canvas: image.Rect(0, 0, 12, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws nothing without an error",
+			canvas: image.Rect(0, 0, 12, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws nothing when the error was cleared",
+			err:    nil,
+			canvas: image.Rect(0, 0, 12, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "wraps the message at word boundaries",
+			err:    errors.New("connection refused by the server"),
+			canvas: image.Rect(0, 0, 12, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(cvs, "Error")
+				mustDrawLines(cvs, "connection", "refused by", "the server")
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "respects newlines and replaces other control characters",
+			err:    errors.New("first\tline\nsecond"),
+			canvas: image.Rect(0, 0, 12, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(cvs, "Error")
+				mustDrawLines(cvs, "first line", "second")
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "indicates that the message doesn't fit",
+			err:    errors.New("connection refused by the server"),
+			canvas: image.Rect(0, 0, 12, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(cvs, "Error")
+				mustDrawLines(cvs, "connection", "refused by…")
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws custom title, icon and color",
+			opts: []Option{
+				Title("Failed"),
+				Icon('!'),
+				AlertColor(cell.ColorYellow),
+			},
+			err:    errors.New("timeout"),
+			canvas: image.Rect(0, 0, 12, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				yellow := draw.TextCellOpts(cell.FgColor(cell.ColorYellow))
+				testdraw.MustText(cvs, "!", image.Point{0, 0}, yellow)
+				testdraw.MustText(cvs, "Failed", image.Point{2, 0}, yellow)
+				testdraw.MustText(cvs, "timeout", image.Point{0, 1}, yellow)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims a title that doesn't fit",
+			err:    errors.New("failed"),
+			canvas: image.Rect(0, 0, 5, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(cvs, "Er…")
+				mustDrawLines(cvs, "fail…")
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the retry button on the last line",
+			opts:   []Option{OnRetry(ct.callback)},
+			err:    errors.New("connection refused by the server"),
+			canvas: image.Rect(0, 0, 12, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(cvs, "Error")
+				mustDrawLines(cvs, "connection", "refused by…")
+				mustDrawButton(cvs, "Retry", image.Point{2, 3})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the retry button with custom text and color",
+			opts: []Option{
+				OnRetry(ct.callback),
+				RetryText("Again"),
+				ButtonColor(cell.ColorBlue),
+			},
+			err:    errors.New("failed"),
+			canvas: image.Rect(0, 0, 9, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(cvs, "Error")
+				mustDrawLines(cvs, "failed")
+				testdraw.MustText(cvs, " Again ", image.Point{1, 2}, draw.TextCellOpts(
+					cell.BgColor(cell.ColorBlue),
+				))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			eb, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			eb.Error(tc.err)
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			err = eb.Draw(c, &widgetapi.Meta{})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Draw => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		desc      string
+		callback  *callbackTracker
+		opts      []Option
+		err       error
+		keyboard  []*terminalapi.Keyboard
+		mouse     []*terminalapi.Mouse
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			desc:     "the retry key calls the callback",
+			callback: &callbackTracker{},
+			err:      errors.New("failed"),
+			keyboard: []*terminalapi.Keyboard{
+				{Key: keyboard.KeyEnter},
+				{Key: keyboard.KeyEnter},
+			},
+			wantCount: 2,
+		},
+		{
+			desc:     "other keys are ignored",
+			callback: &callbackTracker{},
+			err:      errors.New("failed"),
+			keyboard: []*terminalapi.Keyboard{
+				{Key: keyboard.KeyTab},
+			},
+			wantCount: 0,
+		},
+		{
+			desc:     "custom retry key",
+			callback: &callbackTracker{},
+			opts:     []Option{RetryKey('r')},
+			err:      errors.New("failed"),
+			keyboard: []*terminalapi.Keyboard{
+				{Key: keyboard.KeyEnter},
+				{Key: 'r'},
+			},
+			wantCount: 1,
+		},
+		{
+			desc:     "the retry key is ignored without an error",
+			callback: &callbackTracker{},
+			keyboard: []*terminalapi.Keyboard{
+				{Key: keyboard.KeyEnter},
+			},
+			wantCount: 0,
+		},
+		{
+			desc:     "a click on the button calls the callback",
+			callback: &callbackTracker{},
+			err:      errors.New("failed"),
+			mouse: []*terminalapi.Mouse{
+				{Position: image.Point{2, 3}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 3}, Button: mouse.ButtonRelease},
+			},
+			wantCount: 1,
+		},
+		{
+			desc:     "a click outside of the button is ignored",
+			callback: &callbackTracker{},
+			err:      errors.New("failed"),
+			mouse: []*terminalapi.Mouse{
+				{Position: image.Point{1, 3}, Button: mouse.ButtonLeft},
+				{Position: image.Point{1, 3}, Button: mouse.ButtonRelease},
+			},
+			wantCount: 0,
+		},
+		{
+			desc:     "release outside of the button is ignored",
+			callback: &callbackTracker{},
+			err:      errors.New("failed"),
+			mouse: []*terminalapi.Mouse{
+				{Position: image.Point{2, 3}, Button: mouse.ButtonLeft},
+				{Position: image.Point{2, 2}, Button: mouse.ButtonRelease},
+			},
+			wantCount: 0,
+		},
+		{
+			desc:     "a click is ignored without an error",
+			callback: &callbackTracker{},
+			mouse: []*terminalapi.Mouse{
+				{Position: image.Point{2, 3}, Button: mouse.ButtonLeft},
+				{Position: image.Point{2, 3}, Button: mouse.ButtonRelease},
+			},
+			wantCount: 0,
+		},
+		{
+			desc: "forwards the error returned by the callback",
+			callback: &callbackTracker{
+				wantErr: true,
+			},
+			err: errors.New("failed"),
+			keyboard: []*terminalapi.Keyboard{
+				{Key: keyboard.KeyEnter},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			eb, err := New(append(tc.opts, OnRetry(tc.callback.callback))...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			eb.Error(tc.err)
+
+			c, err := canvas.New(image.Rect(0, 0, 12, 4))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := eb.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for i, k := range tc.keyboard {
+				err := eb.Keyboard(k)
+				// Only the last event in test cases is the one that triggers the callback.
+				if i == len(tc.keyboard)-1 {
+					if (err != nil) != tc.wantErr {
+						t.Errorf("Keyboard => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+			}
+			for _, m := range tc.mouse {
+				if err := eb.Mouse(m); err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+
+			if got := tc.callback.count; got != tc.wantCount {
+				t.Errorf("callback called %d times, want %d", got, tc.wantCount)
+			}
+		})
+	}
+}
+
+func TestNewFails(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+	}{
+		{
+			desc: "icon occupies more than one cell",
+			opts: []Option{Icon('世')},
+		},
+		{
+			desc: "empty retry text with OnRetry",
+			opts: []Option{
+				OnRetry(func() error { return nil }),
+				RetryText(""),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := New(tc.opts...); err == nil {
+				t.Errorf("New => got nil err, wanted one")
+			}
+		})
+	}
+}
+
+func TestEventsWithoutRetry(t *testing.T) {
+	eb, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := eb.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+	if err := eb.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "without the retry button",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 2},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc: "with the retry button",
+			opts: []Option{
+				OnRetry(func() error { return nil }),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{7, 3},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			eb, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			got := eb.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
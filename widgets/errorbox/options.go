@@ -0,0 +1,167 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorbox
+
+// options.go contains configurable options for ErrorBox.
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	title       string
+	icon        rune
+	alertColor  cell.Color
+	onRetry     RetryFn
+	retryText   string
+	retryKey    keyboard.Key
+	buttonColor cell.Color
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got := runewidth.RuneWidth(o.icon); got != 1 {
+		return fmt.Errorf("invalid Icon %q, must be a rune that occupies exactly one cell, this one occupies %d", o.icon, got)
+	}
+	if o.onRetry != nil && o.retryText == "" {
+		return errors.New("invalid RetryText, cannot be empty when OnRetry is provided")
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		title:       DefaultTitle,
+		icon:        DefaultIcon,
+		alertColor:  DefaultAlertColor,
+		retryText:   DefaultRetryText,
+		retryKey:    DefaultRetryKey,
+		buttonColor: cell.ColorNumber(DefaultButtonColorNumber),
+	}
+}
+
+// DefaultTitle is the default value for the Title option.
+const DefaultTitle = "Error"
+
+// Title sets the title displayed next to the icon on the first line of the
+// widget.
+// Defaults to DefaultTitle.
+func Title(title string) Option {
+	return option(func(opts *options) {
+		opts.title = title
+	})
+}
+
+// DefaultIcon is the default value for the Icon option.
+const DefaultIcon = '✘'
+
+// Icon sets the rune displayed in front of the title. The rune must occupy
+// exactly one cell on the terminal.
+// Defaults to DefaultIcon.
+func Icon(icon rune) Option {
+	return option(func(opts *options) {
+		opts.icon = icon
+	})
+}
+
+// DefaultAlertColor is the default value for the AlertColor option.
+const DefaultAlertColor = cell.ColorRed
+
+// AlertColor sets the color of the icon, the title and the message.
+// Defaults to DefaultAlertColor.
+func AlertColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.alertColor = c
+	})
+}
+
+// RetryFn is the function called when the user activates the retry button.
+// The function must be light-weight, ideally just signaling the data source
+// and returning.
+//
+// The function must be thread-safe as the mouse or keyboard events that
+// activate the button are processed in a separate goroutine.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type RetryFn func() error
+
+// OnRetry displays a retry button on the last line of the widget. The
+// provided function is called each time the button is activated, either by a
+// click of the left mouse button or by pressing the RetryKey while the widget
+// is focused.
+// If not provided, the widget doesn't display the retry button.
+func OnRetry(fn RetryFn) Option {
+	return option(func(opts *options) {
+		opts.onRetry = fn
+	})
+}
+
+// DefaultRetryText is the default value for the RetryText option.
+const DefaultRetryText = "Retry"
+
+// RetryText sets the text displayed on the retry button.
+// Defaults to DefaultRetryText.
+func RetryText(text string) Option {
+	return option(func(opts *options) {
+		opts.retryText = text
+	})
+}
+
+// DefaultRetryKey is the default value for the RetryKey option.
+const DefaultRetryKey = keyboard.KeyEnter
+
+// RetryKey sets the keyboard key that activates the retry button when the
+// widget is focused.
+// Defaults to DefaultRetryKey.
+func RetryKey(k keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.retryKey = k
+	})
+}
+
+// DefaultButtonColorNumber is the default color number for the ButtonColor
+// option.
+const DefaultButtonColorNumber = 238
+
+// ButtonColor sets the background color of the retry button.
+// Defaults to DefaultButtonColorNumber.
+func ButtonColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.buttonColor = c
+	})
+}
@@ -19,6 +19,8 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"math"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/mum4k/termdash/internal/alignfor"
 	"github.com/mum4k/termdash/internal/area"
 	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/braille"
 	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/runewidth"
 	"github.com/mum4k/termdash/linestyle"
@@ -60,7 +63,8 @@ const (
 // Draws a rectangle, a progress bar with optional display of percentage and /
 // or text label.
 //
-// Implements widgetapi.Widget. This object is thread-safe.
+// Implements widgetapi.Widget and widgetapi.ChangeReporter. This object is
+// thread-safe.
 type Gauge struct {
 	// pt indicates how current and total are interpreted.
 	pt progressType
@@ -70,6 +74,8 @@ type Gauge struct {
 	// For progressTypePercent, this is 100, for progressTypeAbsolute this is
 	// the total provided by the caller.
 	total int
+	// drawn indicates that Draw was called since the progress was last set.
+	drawn bool
 	// mu protects the Gauge.
 	mu sync.Mutex
 
@@ -110,6 +116,7 @@ func (g *Gauge) Absolute(done, total int, opts ...Option) error {
 	}
 
 	g.pt = progressTypeAbsolute
+	g.drawn = false
 	g.current = done
 	g.total = total
 	return nil
@@ -131,6 +138,7 @@ func (g *Gauge) Percent(p int, opts ...Option) error {
 	}
 
 	g.pt = progressTypePercent
+	g.drawn = false
 	g.current = p
 	g.total = 100
 	return nil
@@ -144,6 +152,86 @@ func (g *Gauge) width(ar image.Rectangle) int {
 	return int(width)
 }
 
+// brailleWidth determines the required width in braille pixels of the gauge
+// drawn on the provided area in order to represent the current progress, see
+// BrailleFill.
+func (g *Gauge) brailleWidth(ar image.Rectangle) int {
+	if g.total == 0 {
+		return 0 // No progress was set yet.
+	}
+	return g.current * ar.Dx() * braille.ColMult / g.total
+}
+
+// segments splits the area into the number of segments configured via the
+// Segmented option, separated by gaps of one cell. Any cells that don't divide
+// evenly are distributed to the leftmost segments.
+func (g *Gauge) segments(ar image.Rectangle) []image.Rectangle {
+	count := g.opts.segments
+	width := ar.Dx() - (count - 1) // Width without the gaps.
+	base, extra := width/count, width%count
+
+	var segs []image.Rectangle
+	x := ar.Min.X
+	for i := 0; i < count; i++ {
+		w := base
+		if i < extra {
+			w++
+		}
+		segs = append(segs, image.Rect(x, ar.Min.Y, x+w, ar.Max.Y))
+		x += w + 1 // Skip over the gap.
+	}
+	return segs
+}
+
+// progress returns the areas that should be filled in order to represent the
+// current progress on the provided area.
+func (g *Gauge) progress(ar image.Rectangle) []image.Rectangle {
+	if g.total == 0 {
+		return nil // No progress was set yet.
+	}
+	if g.opts.segments == 0 {
+		progress := image.Rect(
+			ar.Min.X,
+			ar.Min.Y,
+			ar.Min.X+g.width(ar),
+			ar.Max.Y,
+		)
+		if progress.Dx() <= 0 {
+			return nil
+		}
+		return []image.Rectangle{progress}
+	}
+
+	segs := g.segments(ar)
+	lit := g.current * len(segs) / g.total
+	filled := segs[:lit]
+	if lit < len(segs) {
+		// The segment in which the progress ends is filled partially.
+		partial := segs[lit]
+		remainder := g.current*len(segs) - lit*g.total
+		if w := remainder * partial.Dx() / g.total; w > 0 {
+			filled = append(filled, image.Rect(
+				partial.Min.X,
+				partial.Min.Y,
+				partial.Min.X+w,
+				partial.Max.Y,
+			))
+		}
+	}
+	return filled
+}
+
+// inProgress determines if the point falls within any of the areas that
+// represent the current progress.
+func inProgress(p image.Point, progress []image.Rectangle) bool {
+	for _, ar := range progress {
+		if p.In(ar) {
+			return true
+		}
+	}
+	return false
+}
+
 // hasBorder determines of the gauge has a border.
 func (g *Gauge) hasBorder() bool {
 	return g.opts.border != linestyle.None
@@ -167,12 +255,26 @@ func (g *Gauge) progressText() string {
 	case progressTypePercent:
 		return fmt.Sprintf("%d%%", g.current)
 	case progressTypeAbsolute:
-		return fmt.Sprintf("%d/%d", g.current, g.total)
+		text := fmt.Sprintf("%s/%s", fixedPoint(g.current, g.opts.decimals), fixedPoint(g.total, g.opts.decimals))
+		if g.opts.unit != "" {
+			text = fmt.Sprintf("%s %s", text, g.opts.unit)
+		}
+		return text
 	default:
 		return ""
 	}
 }
 
+// fixedPoint formats the non-negative value as a fixed-point number with the
+// specified number of decimal places.
+func fixedPoint(v, decimals int) string {
+	if decimals == 0 {
+		return strconv.Itoa(v)
+	}
+	div := int(math.Pow10(decimals))
+	return fmt.Sprintf("%d.%0*d", v/div, decimals, v%div)
+}
+
 // gaugeText returns full text to be displayed within the gauge, i.e. the
 // progress text and the optional label.
 func (g *Gauge) gaugeText() string {
@@ -188,14 +290,21 @@ func (g *Gauge) gaugeText() string {
 }
 
 // drawText draws the text enumerating the progress and the text label.
-func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
+func (g *Gauge) drawText(cvs *canvas.Canvas, progress []image.Rectangle) error {
 	text := g.gaugeText()
 	if text == "" {
 		return nil
 	}
 
 	ar := g.usable(cvs)
-	trimmed, err := draw.TrimText(text, ar.Dx(), draw.OverrunModeThreeDot)
+	if ar.Dx() < g.opts.hideTextBelow {
+		return nil
+	}
+	width := ar.Dx()
+	if max := g.opts.textMaxWidth; max > 0 && max < width {
+		width = max
+	}
+	trimmed, err := draw.TrimText(text, width, draw.OverrunModeThreeDot)
 	if err != nil {
 		return err
 	}
@@ -215,7 +324,7 @@ func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 		// If the current rune is full-width and only one of its cells falls
 		// within the filled area of the gauge, extend the gauge by one cell to
 		// fully cover the full-width rune.
-		if rw == 2 && next.In(ar) && cur.In(progress) && !next.In(progress) {
+		if rw == 2 && next.In(ar) && inProgress(cur, progress) && !inProgress(next, progress) {
 			fixup := image.Rect(
 				next.X,
 				ar.Min.Y,
@@ -232,7 +341,7 @@ func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 		}
 
 		var cellOpts []cell.Option
-		if cur.In(progress) {
+		if inProgress(cur, progress) {
 			cellOpts = append(cellOpts, cell.FgColor(g.opts.filledTextColor))
 		} else {
 			cellOpts = append(cellOpts, cell.FgColor(g.opts.emptyTextColor))
@@ -248,12 +357,88 @@ func (g *Gauge) drawText(cvs *canvas.Canvas, progress image.Rectangle) error {
 	return nil
 }
 
+// drawTrack fills the area of the gauge with the color set via the
+// EmptyColor option. The filled part of the gauge is drawn over it.
+func (g *Gauge) drawTrack(cvs *canvas.Canvas) error {
+	if g.opts.emptyColor == cell.ColorDefault {
+		return nil
+	}
+
+	ar := g.usable(cvs)
+	track := []image.Rectangle{ar}
+	if g.opts.segments > 0 {
+		track = g.segments(ar)
+	}
+	for _, t := range track {
+		if err := draw.Rectangle(cvs, t,
+			draw.RectCellOpts(cell.BgColor(g.opts.emptyColor)),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawBrailleFill draws the track and the progress on a braille canvas, see
+// BrailleFill.
+func (g *Gauge) drawBrailleFill(cvs *canvas.Canvas) error {
+	ar := g.usable(cvs)
+	bc, err := braille.New(ar)
+	if err != nil {
+		return err
+	}
+
+	if g.opts.emptyColor != cell.ColorDefault {
+		if err := bc.SetAreaCellOpts(bc.CellArea(), cell.BgColor(g.opts.emptyColor)); err != nil {
+			return err
+		}
+	}
+
+	width := g.brailleWidth(ar)
+	for x := 0; x < width; x++ {
+		for y := 0; y < bc.Area().Dy(); y++ {
+			if err := bc.SetPixel(image.Point{x, y}, cell.FgColor(g.opts.color)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Cells fully within the progress look the same as without braille.
+	if full := image.Rect(0, 0, width/braille.ColMult, ar.Dy()); !full.Empty() {
+		if err := bc.SetAreaCellOpts(full, cell.BgColor(g.opts.color)); err != nil {
+			return err
+		}
+	}
+	return bc.CopyTo(cvs)
+}
+
+// drawTarget draws the marker set via the Target option.
+func (g *Gauge) drawTarget(cvs *canvas.Canvas) error {
+	if g.opts.target == nil {
+		return nil
+	}
+
+	ar := g.usable(cvs)
+	x := ar.Min.X + *g.opts.target*ar.Dx()/100
+	if x >= ar.Max.X {
+		x = ar.Max.X - 1 // A target of 100% is drawn on the last cell.
+	}
+	for y := ar.Min.Y; y < ar.Max.Y; y++ {
+		if _, err := cvs.SetCell(image.Point{x, y}, g.opts.targetChar, g.opts.targetCellOpts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Draw draws the Gauge widget onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (g *Gauge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.drawn = true
+
 	needAr, err := area.FromSize(g.minSize())
 	if err != nil {
 		return err
@@ -273,24 +458,37 @@ func (g *Gauge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		}
 	}
 
-	usable := g.usable(cvs)
-	progress := image.Rect(
-		usable.Min.X,
-		usable.Min.Y,
-		usable.Min.X+g.width(usable),
-		usable.Max.Y,
-	)
-	if progress.Dx() > 0 {
-		if err := draw.Rectangle(cvs, progress,
-			draw.RectChar(g.opts.gaugeChar),
-			draw.RectCellOpts(cell.BgColor(g.opts.color)),
-		); err != nil {
+	progress := g.progress(g.usable(cvs))
+	if g.opts.brailleFill {
+		if err := g.drawBrailleFill(cvs); err != nil {
 			return err
 		}
+	} else {
+		if err := g.drawTrack(cvs); err != nil {
+			return err
+		}
+		for _, ar := range progress {
+			if err := draw.Rectangle(cvs, ar,
+				draw.RectChar(g.opts.gaugeChar),
+				draw.RectCellOpts(cell.BgColor(g.opts.color)),
+			); err != nil {
+				return err
+			}
+		}
+	}
+	if err := g.drawTarget(cvs); err != nil {
+		return err
 	}
 	return g.drawText(cvs, progress)
 }
 
+// Changed implements widgetapi.ChangeReporter.Changed.
+func (g *Gauge) Changed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return !g.drawn
+}
+
 // Keyboard input isn't supported on the Gauge widget.
 func (g *Gauge) Keyboard(k *terminalapi.Keyboard) error {
 	return errors.New("the Gauge widget doesn't support keyboard events")
@@ -315,6 +513,10 @@ func (g *Gauge) maxSize() image.Point {
 func (g *Gauge) minSize() image.Point {
 	minWidth := 1  // Shorter gauge than this cannot display anything.
 	minHeight := 1 // At least one line for the gauge itself.
+	if g.opts.segments > 0 {
+		// At least one cell for each segment and each gap.
+		minWidth = 2*g.opts.segments - 1
+	}
 	if g.hasBorder() {
 		// Add the required space for the border.
 		minWidth += 2
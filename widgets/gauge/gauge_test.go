@@ -67,6 +67,304 @@ func TestGauge(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "fails when BrailleFill is combined with Segmented",
+			opts: []Option{
+				BrailleFill(),
+				Segmented(2),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative decimals",
+			opts: []Option{
+				Decimals(-1),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on too many decimals",
+			opts: []Option{
+				Decimals(10),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative TextMaxWidth",
+			opts: []Option{
+				TextMaxWidth(-1),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative HideTextBelow",
+			opts: []Option{
+				HideTextBelow(-1),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative Target",
+			opts: []Option{
+				Target(-1),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on Target above 100",
+			opts: []Option{
+				Target(101),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative segment count",
+			opts: []Option{
+				Segmented(-1),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "segmented gauge lights whole segments and leaves the gaps empty",
+			opts: []Option{
+				Char('o'),
+				Segmented(4),
+				HideTextProgress(),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, r := range []image.Rectangle{
+					image.Rect(0, 0, 2, 1),
+					image.Rect(3, 0, 5, 1),
+				} {
+					testdraw.MustRectangle(c, r,
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+					)
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "braille fill draws no progress before it is set",
+			opts: []Option{
+				BrailleFill(),
+				HideTextProgress(),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "braille fill on a cell boundary",
+			opts: []Option{
+				BrailleFill(),
+				HideTextProgress(),
+			},
+			percent: &percentCall{p: 20},
+			canvas:  image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⣿⣿", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorGreen),
+					cell.BgColor(cell.ColorGreen),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "braille fill advances by a braille column within a cell",
+			opts: []Option{
+				BrailleFill(),
+				HideTextProgress(),
+			},
+			percent: &percentCall{p: 25},
+			canvas:  image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for y := 0; y < 2; y++ {
+					testdraw.MustText(c, "⣿⣿", image.Point{0, y}, draw.TextCellOpts(
+						cell.FgColor(cell.ColorGreen),
+						cell.BgColor(cell.ColorGreen),
+					))
+					testdraw.MustText(c, "⡇", image.Point{2, y}, draw.TextCellOpts(
+						cell.FgColor(cell.ColorGreen),
+					))
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "braille fill draws the track in the empty color",
+			opts: []Option{
+				BrailleFill(),
+				HideTextProgress(),
+				EmptyColor(cell.ColorBlue),
+			},
+			percent: &percentCall{p: 5},
+			canvas:  image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetAreaCells(c, c.Area(), 0, cell.BgColor(cell.ColorBlue))
+				testdraw.MustText(c, "⡇", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorGreen),
+					cell.BgColor(cell.ColorBlue),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "segmented gauge draws no progress before it is set",
+			opts: []Option{
+				Char('o'),
+				Segmented(3),
+				HideTextProgress(),
+			},
+			canvas: image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "segmented gauge partially lights the segment where the progress ends",
+			opts: []Option{
+				Char('o'),
+				Segmented(3),
+				HideTextProgress(),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 14, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, r := range []image.Rectangle{
+					image.Rect(0, 0, 4, 1),
+					image.Rect(5, 0, 7, 1),
+				} {
+					testdraw.MustRectangle(c, r,
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+					)
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "segmented gauge distributes the remaining cells to the leftmost segments",
+			opts: []Option{
+				Char('o'),
+				Segmented(3),
+				HideTextProgress(),
+			},
+			absolute: &absoluteCall{done: 3, total: 3},
+			canvas:   image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, r := range []image.Rectangle{
+					image.Rect(0, 0, 3, 1),
+					image.Rect(4, 0, 7, 1),
+					image.Rect(8, 0, 10, 1),
+				} {
+					testdraw.MustRectangle(c, r,
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+					)
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "segmented gauge draws text in gaps with the empty text color",
+			opts: []Option{
+				Char('o'),
+				Segmented(2),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 9, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 4, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "5", image.Point{3, 0},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testdraw.MustText(c, "0%", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "segmented gauge draws resize needed when the canvas is narrower than the segments",
+			opts: []Option{
+				Segmented(4),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "gauge showing percentage",
 			opts: []Option{
@@ -315,6 +613,169 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "gauge showing absolute progress with a unit",
+			opts: []Option{
+				Char('o'),
+				Unit("GB"),
+			},
+			absolute: &absoluteCall{done: 20, total: 100},
+			canvas:   image.Rect(0, 0, 12, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "2", image.Point{1, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testdraw.MustText(c, "0/100 GB", image.Point{2, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "wide gauge shows the full text centered",
+			opts: []Option{
+				Unit("GB"),
+				TextMaxWidth(10),
+				HideTextBelow(10),
+			},
+			absolute: &absoluteCall{done: 0, total: 100},
+			canvas:   image.Rect(0, 0, 20, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0/100 GB", image.Point{6, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "text is trimmed to TextMaxWidth",
+			opts: []Option{
+				Unit("GB"),
+				TextMaxWidth(5),
+			},
+			absolute: &absoluteCall{done: 0, total: 100},
+			canvas:   image.Rect(0, 0, 20, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0/10…", image.Point{7, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "text is trimmed to the width of the gauge when narrower than TextMaxWidth",
+			opts: []Option{
+				Unit("GB"),
+				TextMaxWidth(10),
+			},
+			absolute: &absoluteCall{done: 0, total: 100},
+			canvas:   image.Rect(0, 0, 6, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0/100…", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "text is hidden when the gauge is narrower than HideTextBelow",
+			opts: []Option{
+				Unit("GB"),
+				HideTextBelow(10),
+			},
+			absolute: &absoluteCall{done: 0, total: 100},
+			canvas:   image.Rect(0, 0, 9, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "text is shown when the gauge is as wide as HideTextBelow",
+			opts: []Option{
+				Unit("GB"),
+				HideTextBelow(10),
+			},
+			absolute: &absoluteCall{done: 0, total: 100},
+			canvas:   image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0/100 GB", image.Point{1, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "gauge showing absolute progress with decimals and a unit",
+			opts: []Option{
+				Char('o'),
+				Unit("GB"),
+				Decimals(2),
+			},
+			absolute: &absoluteCall{done: 50, total: 1000},
+			canvas:   image.Rect(0, 0, 20, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "0.50/10.00 GB", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "passing Decimals to Absolute() pads the fraction",
+			opts: []Option{
+				Char('o'),
+			},
+			absolute: &absoluteCall{done: 5, total: 10, opts: []Option{Decimals(3)}},
+			canvas:   image.Rect(0, 0, 20, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 10, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "0.005/", image.Point{4, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack)),
+				)
+				testdraw.MustText(c, "0.010", image.Point{10, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "unit and decimals don't apply to percentage",
+			opts: []Option{
+				Char('o'),
+				Unit("GB"),
+				Decimals(2),
+			},
+			percent: &percentCall{p: 0},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "0%", image.Point{4, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "gauge without text progress",
 			opts: []Option{
@@ -522,6 +983,203 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "EmptyColor fills the whole gauge at zero percent",
+			opts: []Option{
+				Char('o'),
+				EmptyColor(cell.ColorRed),
+			},
+			percent: &percentCall{},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 10, 3),
+					draw.RectCellOpts(cell.BgColor(cell.ColorRed)),
+				)
+				testdraw.MustText(c, "0%", image.Point{4, 1},
+					draw.TextCellOpts(cell.BgColor(cell.ColorRed)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "EmptyColor isn't visible at 100 percent",
+			opts: []Option{
+				Char('o'),
+				EmptyColor(cell.ColorRed),
+			},
+			percent: &percentCall{p: 100},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 10, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "100%", image.Point{3, 1},
+					draw.TextCellOpts(cell.FgColor(cell.ColorBlack), cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "EmptyColor fills only the unfilled cells of a partial gauge",
+			opts: []Option{
+				Char('o'),
+				Color(cell.ColorBlue),
+				EmptyColor(cell.ColorRed),
+				HideTextProgress(),
+			},
+			percent: &percentCall{p: 30},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorBlue)),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 0, 10, 3),
+					draw.RectCellOpts(cell.BgColor(cell.ColorRed)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "Target marker over the empty part of the gauge",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Target(95, cell.FgColor(cell.ColorRed)),
+			},
+			percent: &percentCall{p: 30},
+			canvas:  image.Rect(0, 0, 20, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 6, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				for y := 0; y < 3; y++ {
+					testcanvas.MustSetCell(c, image.Point{19, y}, '│', cell.FgColor(cell.ColorRed))
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "Target marker over the filled part of the gauge",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Target(20),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 5, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				for y := 0; y < 3; y++ {
+					testcanvas.MustSetCell(c, image.Point{2, y}, '│', cell.BgColor(cell.ColorGreen))
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "Target marker of 100 percent on the last cell with a custom rune",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Target(100),
+				TargetChar('|'),
+				EmptyColor(cell.ColorRed),
+			},
+			percent: &percentCall{p: 0},
+			canvas:  image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 10, 2),
+					draw.RectCellOpts(cell.BgColor(cell.ColorRed)),
+				)
+				testcanvas.MustSetCell(c, image.Point{9, 0}, '|', cell.BgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{9, 1}, '|', cell.BgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "Target marker within a border",
+			opts: []Option{
+				Char('o'),
+				HideTextProgress(),
+				Target(50),
+				Border(linestyle.Light),
+			},
+			percent: &percentCall{p: 0},
+			canvas:  image.Rect(0, 0, 12, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustBorder(c, c.Area())
+				testcanvas.MustSetCell(c, image.Point{6, 1}, '│')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "EmptyColor leaves the gaps of a segmented gauge empty",
+			opts: []Option{
+				Char('o'),
+				Segmented(4),
+				EmptyColor(cell.ColorRed),
+				HideTextProgress(),
+			},
+			percent: &percentCall{p: 50},
+			canvas:  image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				for _, r := range []image.Rectangle{
+					image.Rect(0, 0, 2, 1),
+					image.Rect(3, 0, 5, 1),
+				} {
+					testdraw.MustRectangle(c, r,
+						draw.RectChar('o'),
+						draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+					)
+				}
+				for _, r := range []image.Rectangle{
+					image.Rect(6, 0, 8, 1),
+					image.Rect(9, 0, 11, 1),
+				} {
+					testdraw.MustRectangle(c, r,
+						draw.RectCellOpts(cell.BgColor(cell.ColorRed)),
+					)
+				}
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "text fully outside of gauge respects EmptyTextColor",
 			opts: []Option{
@@ -831,6 +1489,19 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "minimum size accounts for segments and gaps",
+			opts: []Option{
+				Border(linestyle.Light),
+				Segmented(4),
+			},
+			want: widgetapi.Options{
+				MaximumSize:  image.Point{0, 2},
+				MinimumSize:  image.Point{9, 3},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
 		{
 			desc: "border is accounted for in maximum and minimum size",
 			opts: []Option{
@@ -861,3 +1532,84 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderToBuffer(t *testing.T) {
+	g, err := New(Char('o'))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := g.Percent(40); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+
+	// The gauge drawn normally onto a canvas at an offset.
+	at := image.Rect(2, 1, 12, 4)
+	want := faketerm.MustNew(image.Point{14, 5})
+	wantCvs := testcanvas.MustNew(at)
+	if err := g.Draw(wantCvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	testcanvas.MustApply(wantCvs, want)
+
+	rendered, err := widgetapi.RenderToBuffer(g, at.Size())
+	if err != nil {
+		t.Fatalf("RenderToBuffer => unexpected error: %v", err)
+	}
+	if got, want := rendered.Size(), at.Size(); got != want {
+		t.Errorf("RenderToBuffer => size %v, want %v", got, want)
+	}
+
+	// Blit the rendered buffer onto the same position of a larger canvas.
+	dst := testcanvas.MustNew(image.Rect(0, 0, 14, 5))
+	sub, err := dst.SubCanvas(at)
+	if err != nil {
+		t.Fatalf("SubCanvas => unexpected error: %v", err)
+	}
+	if err := rendered.CopyTo(sub); err != nil {
+		t.Fatalf("CopyTo => unexpected error: %v", err)
+	}
+	got := faketerm.MustNew(image.Point{14, 5})
+	testcanvas.MustApply(dst, got)
+
+	if diff := faketerm.Diff(want, got); diff != "" {
+		t.Errorf("RenderToBuffer => %v", diff)
+	}
+}
+
+func TestChanged(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	// mustDraw draws the gauge.
+	mustDraw := func() {
+		t.Helper()
+		c := testcanvas.MustNew(image.Rect(0, 0, 10, 3))
+		if err := g.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+	}
+
+	if !g.Changed() {
+		t.Errorf("Changed => false before the first Draw, want true")
+	}
+	mustDraw()
+	if g.Changed() {
+		t.Errorf("Changed => true after Draw, want false")
+	}
+
+	if err := g.Percent(10); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	if !g.Changed() {
+		t.Errorf("Changed => false after Percent, want true")
+	}
+	mustDraw()
+
+	if err := g.Absolute(1, 2); err != nil {
+		t.Fatalf("Absolute => unexpected error: %v", err)
+	}
+	if !g.Changed() {
+		t.Errorf("Changed => false after Absolute, want true")
+	}
+}
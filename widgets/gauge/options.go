@@ -17,6 +17,7 @@ package gauge
 // options.go contains configurable options for Gauge.
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/mum4k/termdash/align"
@@ -40,8 +41,18 @@ type options struct {
 	hTextAlign       align.Horizontal
 	vTextAlign       align.Vertical
 	color            cell.Color
+	emptyColor       cell.Color
 	filledTextColor  cell.Color
 	emptyTextColor   cell.Color
+	segments         int
+	brailleFill      bool
+	unit             string
+	decimals         int
+	textMaxWidth     int
+	hideTextBelow    int
+	target           *int
+	targetChar       rune
+	targetCellOpts   []cell.Option
 	// If set, draws a border around the gauge.
 	border            linestyle.LineStyle
 	borderCellOpts    []cell.Option
@@ -56,8 +67,10 @@ func newOptions() *options {
 		hTextAlign:      DefaultHorizontalTextAlign,
 		vTextAlign:      DefaultVerticalTextAlign,
 		color:           DefaultColor,
+		emptyColor:      DefaultEmptyColor,
 		filledTextColor: DefaultFilledTextColor,
 		emptyTextColor:  DefaultEmptyTextColor,
+		targetChar:      DefaultTargetChar,
 	}
 }
 
@@ -66,6 +79,26 @@ func (o *options) validate() error {
 	if got, min := o.height, 0; got < min {
 		return fmt.Errorf("invalid Height %d, must be %d <= Height", got, min)
 	}
+	if got, min := o.segments, 0; got < min {
+		return fmt.Errorf("invalid Segmented %d, must be %d <= Segmented", got, min)
+	}
+	if o.brailleFill && o.segments > 0 {
+		return errors.New("the BrailleFill and Segmented options cannot be provided at the same time")
+	}
+	if got, min, max := o.decimals, 0, maxDecimals; got < min || got > max {
+		return fmt.Errorf("invalid Decimals %d, must be %d <= Decimals <= %d", got, min, max)
+	}
+	if got, min := o.textMaxWidth, 0; got < min {
+		return fmt.Errorf("invalid TextMaxWidth %d, must be %d <= TextMaxWidth", got, min)
+	}
+	if got, min := o.hideTextBelow, 0; got < min {
+		return fmt.Errorf("invalid HideTextBelow %d, must be %d <= HideTextBelow", got, min)
+	}
+	if t := o.target; t != nil {
+		if min, max := 0, 100; *t < min || *t > max {
+			return fmt.Errorf("invalid Target %d, must be %d <= Target <= %d", *t, min, max)
+		}
+	}
 	return nil
 }
 
@@ -114,6 +147,56 @@ func Height(height int) Option {
 	})
 }
 
+// Segmented configures the Gauge to draw the progress as the specified number
+// of discrete segments separated by gaps of one empty cell instead of a
+// continuous bar. The segments are filled one by one as the progress
+// increases, the segment in which the progress currently ends is filled
+// partially. The Gauge requires at least one cell for each segment and each
+// gap.
+// Defaults to zero which means the progress is drawn as a continuous bar.
+func Segmented(count int) Option {
+	return option(func(opts *options) {
+		opts.segments = count
+	})
+}
+
+// BrailleFill configures the Gauge to draw the progress using braille
+// patterns. Each cell is two braille dots wide, so the edge of the progress
+// advances by half a cell instead of a whole cell. The cells fully within the
+// progress are drawn as full braille patterns with the background set to the
+// Color, the cell with the edge of the progress only has its left column of
+// dots set. The Char option has no effect in this mode. Cannot be combined
+// with the Segmented option.
+func BrailleFill() Option {
+	return option(func(opts *options) {
+		opts.brailleFill = true
+	})
+}
+
+// Unit configures the Gauge to display the provided unit after the absolute
+// progress text, e.g. "7/10 GB". Only applies when the progress is set by a
+// call to Absolute().
+func Unit(unit string) Option {
+	return option(func(opts *options) {
+		opts.unit = unit
+	})
+}
+
+// maxDecimals is the maximum value for the Decimals option.
+const maxDecimals = 9
+
+// Decimals configures the Gauge to interpret the values provided to
+// Absolute() as fixed-point numbers with the specified number of decimal
+// places. E.g. with Decimals(2), a call to Absolute(750, 1000) displays
+// "7.50/10.00". Must be in range 0 <= decimals <= 9. Only applies when the
+// progress is set by a call to Absolute().
+// Defaults to zero which displays the values as integers.
+func Decimals(decimals int) Option {
+	return option(func(opts *options) {
+		opts.decimals = decimals
+	})
+}
+
 // TextLabel configures the Gauge to display the provided text.
 // If the ShowTextProgress() option is also provided, this label is drawn right
 // after the progress text.
@@ -123,6 +206,50 @@ func TextLabel(text string) Option {
 	})
 }
 
+// TextMaxWidth limits the width of the text progress and text label to the
+// provided number of cells. Longer text is trimmed and ends with a horizontal
+// ellipsis. Text that doesn't fit into the width of the Gauge is always
+// trimmed this way.
+// Defaults to zero which means the text can use the full width of the Gauge.
+func TextMaxWidth(cells int) Option {
+	return option(func(opts *options) {
+		opts.textMaxWidth = cells
+	})
+}
+
+// HideTextBelow hides the text progress and text label when the width of the
+// Gauge is less than the provided number of cells. Useful to prevent a narrow
+// Gauge from displaying text that is trimmed down to a few unreadable cells.
+// Defaults to zero which means the text is never hidden.
+func HideTextBelow(cells int) Option {
+	return option(func(opts *options) {
+		opts.hideTextBelow = cells
+	})
+}
+
+// Target draws a marker at the provided percentage of the width of the Gauge,
+// e.g. at the 95% target of an SLA. The marker is independent of the current
+// progress and is drawn over both the filled and the empty part of the Gauge.
+// Must be in range 0 <= percent <= 100. The cell options apply to the marker,
+// see TargetChar for the rune used to draw it.
+func Target(percent int, cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.target = &percent
+		opts.targetCellOpts = cOpts
+	})
+}
+
+// DefaultTargetChar is the default value for the TargetChar option.
+const DefaultTargetChar = '│'
+
+// TargetChar sets the rune that is used to draw the marker set by the Target
+// option.
+func TargetChar(ch rune) Option {
+	return option(func(opts *options) {
+		opts.targetChar = ch
+	})
+}
+
 // DefaultColor is the default value for the Color option.
 const DefaultColor = cell.ColorGreen
 
@@ -133,6 +260,17 @@ func Color(c cell.Color) Option {
 	})
 }
 
+// DefaultEmptyColor is the default value for the EmptyColor option.
+const DefaultEmptyColor = cell.ColorDefault
+
+// EmptyColor sets the background color of the cells in the empty area the
+// Gauge didn't fill yet. Useful to make the track of the gauge visible.
+func EmptyColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.emptyColor = c
+	})
+}
+
 // DefaultFilledTextColor is the default value for the FilledTextColor option.
 const DefaultFilledTextColor = cell.ColorBlack
 
@@ -0,0 +1,245 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package histogram implements a widget that sorts values into bins and
+// displays the number of values in each bin as bars.
+package histogram
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/barchart"
+)
+
+// maxBins is the maximum number of bins the values can be sorted into.
+// Protects against a BinWidth that is very small relative to the range of the
+// values.
+const maxBins = 1000
+
+// Bin is a single bin of the histogram.
+type Bin struct {
+	// Min is the inclusive lower bound of the bin.
+	Min float64
+	// Max is the exclusive upper bound of the bin. The last bin also includes
+	// values equal to its upper bound.
+	Max float64
+	// Count is the number of values in the bin.
+	Count int
+}
+
+// Histogram sorts raw values into bins and displays the number of values in
+// each bin as a bar. Each bar has a label under it with the range of the bin.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Histogram struct {
+	// bins are the bins computed on the last call to Values.
+	bins []Bin
+
+	// bc draws the bars.
+	bc *barchart.BarChart
+
+	// mu protects the Histogram.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Histogram.
+func New(opts ...Option) (*Histogram, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+
+	bc, err := barchart.New()
+	if err != nil {
+		return nil, err
+	}
+	return &Histogram{
+		bc:   bc,
+		opts: opt,
+	}, nil
+}
+
+// Values sets the raw values to be displayed by the Histogram. The values are
+// sorted into bins according to the BinCount or BinWidth options. The values
+// must not be NaN or infinite.
+// Provided options override values set when New() was called.
+func (h *Histogram) Values(values []float64, opts ...Option) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	opt := *h.opts
+	for _, o := range opts {
+		o.set(&opt)
+	}
+	if err := opt.validate(); err != nil {
+		return err
+	}
+
+	bins, err := binValues(values, &opt)
+	if err != nil {
+		return err
+	}
+
+	var (
+		counts      []int
+		labels      []string
+		barColors   []cell.Color
+		labelColors []cell.Color
+	)
+	max := 1
+	for _, b := range bins {
+		counts = append(counts, b.Count)
+		labels = append(labels, fmt.Sprintf("%s..%s", formatBound(b.Min), formatBound(b.Max)))
+		barColors = append(barColors, opt.barColor)
+		labelColors = append(labelColors, opt.labelColor)
+		if b.Count > max {
+			max = b.Count
+		}
+	}
+
+	bcOpts := []barchart.Option{
+		barchart.BarGap(opt.barGap),
+		barchart.BarColors(barColors),
+		barchart.LabelColors(labelColors),
+		barchart.Labels(labels),
+	}
+	if opt.showCounts {
+		bcOpts = append(bcOpts, barchart.ShowValues())
+	}
+	if err := h.bc.Values(counts, max, bcOpts...); err != nil {
+		return err
+	}
+
+	h.bins = bins
+	*h.opts = opt
+	return nil
+}
+
+// Bins returns the bins computed on the last call to Values.
+func (h *Histogram) Bins() []Bin {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	res := make([]Bin, len(h.bins))
+	copy(res, h.bins)
+	return res
+}
+
+// Draw draws the Histogram widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (h *Histogram) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.bc.Draw(cvs, meta)
+}
+
+// Keyboard input isn't supported on the Histogram widget.
+func (*Histogram) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Histogram widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the Histogram widget.
+func (*Histogram) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the Histogram widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (h *Histogram) Options() widgetapi.Options {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.bc.Options()
+}
+
+// binValues sorts the values into bins according to the options.
+func binValues(values []float64, opts *options) ([]Bin, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("invalid values[%d]: %v, values must not be NaN or infinite", i, v)
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+
+	var (
+		start, width float64
+		n            int
+	)
+	if opts.binWidth > 0 {
+		width = opts.binWidth
+		start = math.Floor(min/width) * width
+		bins := math.Floor((max-start)/width) + 1
+		if bins > maxBins {
+			return nil, fmt.Errorf("BinWidth %v results in %v bins for the values in range %v..%v, at most %d bins are supported", width, bins, min, max, maxBins)
+		}
+		n = int(bins)
+	} else {
+		if min == max {
+			// All the values are the same, use a range of one around them.
+			min, max = min-0.5, max+0.5
+		}
+		n = opts.binCount
+		start = min
+		width = (max - min) / float64(n)
+	}
+
+	bins := make([]Bin, n)
+	for i := range bins {
+		bins[i] = Bin{
+			Min: start + float64(i)*width,
+			Max: start + float64(i+1)*width,
+		}
+	}
+	if opts.binWidth <= 0 {
+		// Avoid rounding errors on the upper bound of the range.
+		bins[n-1].Max = max
+	}
+
+	for _, v := range values {
+		i := int((v - start) / width)
+		switch {
+		case i < 0:
+			i = 0 // Rounding error on the lower bound of the first bin.
+		case i >= n:
+			i = n - 1 // The upper bound of the last bin is inclusive.
+		}
+		bins[i].Count++
+	}
+	return bins, nil
+}
+
+// formatBound formats the bound of a bin for display in the label.
+func formatBound(v float64) string {
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
@@ -0,0 +1,322 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          []Option
+		values        []float64
+		valuesOpts    []Option
+		want          []Bin
+		wantErr       bool
+		wantValuesErr bool
+	}{
+		{
+			desc: "fails on zero BinCount",
+			opts: []Option{
+				BinCount(0),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative BinWidth",
+			opts: []Option{
+				BinWidth(-1),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on NaN BinWidth",
+			opts: []Option{
+				BinWidth(math.NaN()),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative BarGap",
+			opts: []Option{
+				BarGap(-1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:          "fails on NaN value",
+			values:        []float64{1, math.NaN()},
+			wantValuesErr: true,
+		},
+		{
+			desc:          "fails on infinite value",
+			values:        []float64{math.Inf(1)},
+			wantValuesErr: true,
+		},
+		{
+			desc:          "fails on invalid option provided to Values",
+			values:        []float64{1},
+			valuesOpts:    []Option{BinCount(-1)},
+			wantValuesErr: true,
+		},
+		{
+			desc: "fails when BinWidth results in too many bins",
+			opts: []Option{
+				BinWidth(0.001),
+			},
+			values:        []float64{0, 10},
+			wantValuesErr: true,
+		},
+		{
+			desc: "no bins without values",
+			want: []Bin{},
+		},
+		{
+			desc: "fixed bin count",
+			opts: []Option{
+				BinCount(4),
+			},
+			values: []float64{0, 0.5, 1, 1.5, 2, 2.5, 3, 4, 4},
+			want: []Bin{
+				{Min: 0, Max: 1, Count: 2},
+				{Min: 1, Max: 2, Count: 2},
+				{Min: 2, Max: 3, Count: 2},
+				{Min: 3, Max: 4, Count: 3},
+			},
+		},
+		{
+			desc: "single bin holds all the values",
+			opts: []Option{
+				BinCount(1),
+			},
+			values: []float64{-1, 0, 1},
+			want: []Bin{
+				{Min: -1, Max: 1, Count: 3},
+			},
+		},
+		{
+			desc: "identical values",
+			opts: []Option{
+				BinCount(2),
+			},
+			values: []float64{5, 5, 5},
+			want: []Bin{
+				{Min: 4.5, Max: 5, Count: 0},
+				{Min: 5, Max: 5.5, Count: 3},
+			},
+		},
+		{
+			desc: "fixed bin width aligned to multiples of the width",
+			opts: []Option{
+				BinWidth(10),
+			},
+			values: []float64{-5, 3, 12, 19, 20, 33},
+			want: []Bin{
+				{Min: -10, Max: 0, Count: 1},
+				{Min: 0, Max: 10, Count: 1},
+				{Min: 10, Max: 20, Count: 2},
+				{Min: 20, Max: 30, Count: 1},
+				{Min: 30, Max: 40, Count: 1},
+			},
+		},
+		{
+			desc: "BinWidth takes precedence over BinCount",
+			opts: []Option{
+				BinCount(5),
+				BinWidth(2),
+			},
+			values: []float64{0, 1, 2, 3},
+			want: []Bin{
+				{Min: 0, Max: 2, Count: 2},
+				{Min: 2, Max: 4, Count: 2},
+			},
+		},
+		{
+			desc: "options provided to Values override those from New",
+			opts: []Option{
+				BinCount(5),
+			},
+			values:     []float64{0, 1, 2},
+			valuesOpts: []Option{BinCount(2)},
+			want: []Bin{
+				{Min: 0, Max: 1, Count: 1},
+				{Min: 1, Max: 2, Count: 2},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			h, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = h.Values(tc.values, tc.valuesOpts...)
+			if (err != nil) != tc.wantValuesErr {
+				t.Errorf("Values => unexpected error: %v, wantValuesErr: %v", err, tc.wantValuesErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := h.Bins()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Bins => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		values []float64
+		canvas image.Rectangle
+		want   func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:   "draws empty for no values",
+			canvas: image.Rect(0, 0, 14, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "tallest bar corresponds to the mode bin",
+			opts: []Option{
+				BinCount(3),
+			},
+			values: []float64{0, 1, 1, 3},
+			canvas: image.Rect(0, 0, 14, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				barOpts := draw.RectCellOpts(cell.BgColor(DefaultBarColor))
+				testdraw.MustRectangle(c, image.Rect(0, 2, 4, 4), barOpts)
+				testdraw.MustRectangle(c, image.Rect(5, 0, 9, 4), barOpts)
+				testdraw.MustRectangle(c, image.Rect(10, 2, 14, 4), barOpts)
+
+				labelOpts := draw.TextCellOpts(cell.FgColor(DefaultLabelColor))
+				testdraw.MustText(c, "0..1", image.Point{0, 4}, labelOpts)
+				testdraw.MustText(c, "1..2", image.Point{5, 4}, labelOpts)
+				testdraw.MustText(c, "2..3", image.Point{10, 4}, labelOpts)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom colors and counts inside bars",
+			opts: []Option{
+				BinCount(2),
+				BarGap(0),
+				BarColor(cell.ColorBlue),
+				LabelColor(cell.ColorWhite),
+				ShowCounts(),
+			},
+			values: []float64{0, 2, 2},
+			canvas: image.Rect(0, 0, 8, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				barOpts := draw.RectCellOpts(cell.BgColor(cell.ColorBlue))
+				testdraw.MustRectangle(c, image.Rect(0, 2, 4, 4), barOpts)
+				testdraw.MustRectangle(c, image.Rect(4, 0, 8, 4), barOpts)
+
+				valueOpts := draw.TextCellOpts(cell.FgColor(cell.ColorYellow))
+				testdraw.MustText(c, "1", image.Point{1, 3}, valueOpts)
+				testdraw.MustText(c, "2", image.Point{5, 3}, valueOpts)
+
+				labelOpts := draw.TextCellOpts(cell.FgColor(cell.ColorWhite))
+				testdraw.MustText(c, "0..1", image.Point{0, 4}, labelOpts)
+				testdraw.MustText(c, "1..2", image.Point{4, 4}, labelOpts)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			h, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.values != nil {
+				if err := h.Values(tc.values); err != nil {
+					t.Fatalf("Values => unexpected error: %v", err)
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := h.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	h, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := h.Values([]float64{1, 2, 3}, BinCount(3)); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+
+	got := h.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 2},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+// options.go contains configurable options for Histogram.
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/widgets/barchart"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	binCount   int
+	binWidth   float64
+	barGap     int
+	barColor   cell.Color
+	labelColor cell.Color
+	showCounts bool
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got, min := o.binCount, 1; got < min {
+		return fmt.Errorf("invalid BinCount %d, must be %d <= BinCount", got, min)
+	}
+	if got := o.binWidth; math.IsNaN(got) || math.IsInf(got, 0) || got < 0 {
+		return fmt.Errorf("invalid BinWidth %v, must be a positive number", got)
+	}
+	if got, min := o.barGap, 0; got < min {
+		return fmt.Errorf("invalid BarGap %d, must be %d <= BarGap", got, min)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		binCount:   DefaultBinCount,
+		barGap:     DefaultBarGap,
+		barColor:   DefaultBarColor,
+		labelColor: DefaultLabelColor,
+	}
+}
+
+// DefaultBinCount is the default value for the BinCount option.
+const DefaultBinCount = 10
+
+// BinCount sets the number of equally sized bins the range of the values is
+// divided into. Must be a positive integer. Ignored if BinWidth is set.
+// Defaults to DefaultBinCount.
+func BinCount(n int) Option {
+	return option(func(opts *options) {
+		opts.binCount = n
+	})
+}
+
+// BinWidth sets a fixed width of each bin. The bins are aligned to multiples
+// of the width and as many bins are created as needed to cover all the
+// values. Must be a positive number. Takes precedence over BinCount.
+func BinWidth(w float64) Option {
+	return option(func(opts *options) {
+		opts.binWidth = w
+	})
+}
+
+// DefaultBarGap is the default value for the BarGap option.
+const DefaultBarGap = barchart.DefaultBarGap
+
+// BarGap sets the width of the space between the bars.
+// Must be a positive or zero integer.
+// Defaults to DefaultBarGap.
+func BarGap(width int) Option {
+	return option(func(opts *options) {
+		opts.barGap = width
+	})
+}
+
+// DefaultBarColor is the default value for the BarColor option.
+const DefaultBarColor = barchart.DefaultBarColor
+
+// BarColor sets the color of the bars.
+// Defaults to DefaultBarColor.
+func BarColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.barColor = c
+	})
+}
+
+// DefaultLabelColor is the default value for the LabelColor option.
+const DefaultLabelColor = barchart.DefaultLabelColor
+
+// LabelColor sets the color of the labels with the bin ranges displayed under
+// the bars.
+// Defaults to DefaultLabelColor.
+func LabelColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.labelColor = c
+	})
+}
+
+// ShowCounts tells the histogram to display the number of values in each bin
+// inside of the bars.
+func ShowCounts() Option {
+	return option(func(opts *options) {
+		opts.showCounts = true
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// average.go contains code that computes the moving average overlay of a
+// series.
+
+import (
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// DefaultMovingAverageColor is the default color of the line plotted by the
+// SeriesMovingAverage option.
+const DefaultMovingAverageColor = cell.ColorYellow
+
+// movingAverage returns the simple moving average of the values over the
+// window, i.e. each value is replaced with the mean of the window of values
+// that ends with it. Values that don't have a full window of values before
+// them are NaN. Missing (NaN) values inside a window are excluded from its
+// mean, a window without any present values has a NaN mean.
+func movingAverage(values []float64, window int) []float64 {
+	res := make([]float64, len(values))
+	for i := range values {
+		if i < window-1 {
+			res[i] = math.NaN()
+			continue
+		}
+
+		var sum float64
+		var count int
+		for _, v := range values[i-window+1 : i+1] {
+			if math.IsNaN(v) {
+				continue
+			}
+			sum += v
+			count++
+		}
+		if count == 0 {
+			res[i] = math.NaN()
+			continue
+		}
+		res[i] = sum / float64(count)
+	}
+	return res
+}
+
+// averageSeries returns the series plotted as the moving average overlay of
+// this series, see SeriesMovingAverage.
+func (sv *seriesValues) averageSeries() *seriesValues {
+	avg := newSeriesValues(movingAverage(sv.values, sv.maWindow))
+	avg.xs = sv.xs
+	avg.interpolation = sv.interpolation
+	avg.plotAll = sv.plotAll
+	avg.seriesCellOpts = append([]cell.Option{cell.FgColor(DefaultMovingAverageColor)}, sv.maCellOpts...)
+	return avg
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestMovingAverage(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		desc   string
+		values []float64
+		window int
+		want   []float64
+	}{
+		{
+			desc:   "no values",
+			window: 2,
+			want:   []float64{},
+		},
+		{
+			desc:   "window of one keeps the values",
+			values: []float64{1, 2, 3},
+			window: 1,
+			want:   []float64{1, 2, 3},
+		},
+		{
+			desc:   "values without a full window are missing",
+			values: []float64{1, 3, 5, 7},
+			window: 3,
+			want:   []float64{nan, nan, 3, 5},
+		},
+		{
+			desc:   "window longer than the values",
+			values: []float64{1, 3},
+			window: 3,
+			want:   []float64{nan, nan},
+		},
+		{
+			desc:   "smooths alternating values",
+			values: []float64{0, 8, 0, 8},
+			window: 2,
+			want:   []float64{nan, 4, 4, 4},
+		},
+		{
+			desc:   "excludes missing values from the mean",
+			values: []float64{2, nan, 4, 6},
+			window: 2,
+			want:   []float64{nan, 2, 4, 5},
+		},
+		{
+			desc:   "window with only missing values is missing",
+			values: []float64{2, nan, nan, 6},
+			window: 2,
+			want:   []float64{nan, 2, nan, 6},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := movingAverage(tc.values, tc.window)
+			// NaN values don't compare equal, compare their string form.
+			if diff := pretty.Compare(fmtFloats(tc.want), fmtFloats(got)); diff != "" {
+				t.Errorf("movingAverage => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// fmtFloats formats the values so that NaN values compare equal.
+func fmtFloats(values []float64) []string {
+	res := []string{}
+	for _, v := range values {
+		res = append(res, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return res
+}
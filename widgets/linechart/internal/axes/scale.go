@@ -272,9 +272,14 @@ func (xs *XScale) PixelToValue(x int) (float64, error) {
 // The value must be within the bounds provided to NewXScale. X coordinates
 // grow right.
 func (xs *XScale) ValueToPixel(v int) (int, error) {
-	fv := float64(v)
+	return xs.FloatValueToPixel(float64(v))
+}
+
+// FloatValueToPixel is like ValueToPixel, but accepts values that fall
+// between the integer positions on the X axis.
+func (xs *XScale) FloatValueToPixel(fv float64) (int, error) {
 	if min, max := xs.Min.Value, xs.Max.Rounded; fv < min || fv > max {
-		return 0, fmt.Errorf("invalid value %v, must be in range %v <= v <= %v", v, min, max)
+		return 0, fmt.Errorf("invalid value %v, must be in range %v <= v <= %v", fv, min, max)
 	}
 	if xs.Step.Rounded == 0 {
 		return 0, nil
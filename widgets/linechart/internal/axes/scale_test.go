@@ -1206,3 +1206,35 @@ func TestXScale(t *testing.T) {
 		})
 	}
 }
+
+func TestXScaleFloatValueToPixel(t *testing.T) {
+	scale, err := NewXScale(0, 4, 5, 2)
+	if err != nil {
+		t.Fatalf("NewXScale => unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		value   float64
+		want    int
+		wantErr bool
+	}{
+		{value: -0.5, wantErr: true},
+		{value: 0, want: 0},
+		{value: 1.5, want: 3},
+		{value: 2, want: 4},
+		{value: 4, want: 9},
+		{value: 4.5, wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := scale.FloatValueToPixel(tc.value)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("FloatValueToPixel(%v) => unexpected error: %v, wantErr: %v", tc.value, err, tc.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("FloatValueToPixel(%v) => %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
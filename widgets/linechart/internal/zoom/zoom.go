@@ -36,6 +36,7 @@ type Option interface {
 // options stores the provided options.
 type options struct {
 	scrollStepPerc int
+	brush          bool
 }
 
 // newOptions creates new options instance and applies the provided options.
@@ -78,6 +79,15 @@ func ScrollStep(perc int) Option {
 	})
 }
 
+// Brush changes the behavior of the tracker so that a range selected by
+// dragging the mouse doesn't zoom the X axis. The selected range is reported
+// via Brushed instead. Zooming with the mouse scroll button isn't affected.
+func Brush() Option {
+	return option(func(opts *options) {
+		opts.brush = true
+	})
+}
+
 // Tracker tracks the state of mouse selection on the linechart and stores
 // requests for zoom.
 // This object is not thread-safe.
@@ -100,6 +110,10 @@ type Tracker struct {
 	// highlight is the currently highlighted area.
 	highlight *Range
 
+	// brushed are the X axis values at the start and the end of the last
+	// range selected with the Brush option or nil if there isn't any.
+	brushed []*axes.Value
+
 	// opts are the provided options.
 	opts *options
 }
@@ -189,6 +203,12 @@ func (t *Tracker) baseForZoom() *axes.XDetails {
 	return t.zoomX
 }
 
+// Reset removes any zoom, so that the base X axis is displayed again.
+func (t *Tracker) Reset() {
+	t.zoomX = nil
+	t.highlight.reset()
+}
+
 // Mouse is used to forward mouse events to the zoom tracker.
 func (t *Tracker) Mouse(m *terminalapi.Mouse) error {
 	if m.Position.In(t.graphAr) {
@@ -208,6 +228,14 @@ func (t *Tracker) Mouse(m *terminalapi.Mouse) error {
 		cellX := m.Position.X - t.graphAr.Min.X
 		t.highlight.addX(cellX)
 
+	case clicked && bs == button.Up && t.opts.brush:
+		if !t.highlight.empty() {
+			if err := t.brush(); err != nil {
+				return err
+			}
+		}
+		t.highlight.reset()
+
 	case clicked && bs == button.Up:
 		if t.highlight.length() >= 2 {
 			zoom, err := zoomToHighlight(t.baseForZoom(), t.highlight, t.cvsAr)
@@ -224,6 +252,34 @@ func (t *Tracker) Mouse(m *terminalapi.Mouse) error {
 	return nil
 }
 
+// brush records the values at the start and the end of the highlighted range.
+func (t *Tracker) brush() error {
+	base := t.baseForZoom()
+	start, err := base.Scale.CellLabel(t.highlight.Start)
+	if err != nil {
+		return fmt.Errorf("unable to determine value at the start of the brushed range: %v", err)
+	}
+	end, err := base.Scale.CellLabel(t.highlight.End - 1)
+	if err != nil {
+		return fmt.Errorf("unable to determine value at the end of the brushed range: %v", err)
+	}
+	t.brushed = []*axes.Value{start, end}
+	return nil
+}
+
+// Brushed returns true if the user selected a range on the graph area since
+// the last call to Brushed. Only reports ranges when the tracker was created
+// with the Brush option. The returned values are the values on the X axis at
+// the first and the last selected column.
+func (t *Tracker) Brushed() (bool, float64, float64) {
+	if t.brushed == nil {
+		return false, 0, 0
+	}
+	start, end := t.brushed[0].Value, t.brushed[1].Value
+	t.brushed = nil
+	return true, start, end
+}
+
 // Range represents a range of values.
 // The range includes all values x such that Start <= x < End.
 type Range struct {
@@ -15,6 +15,7 @@
 package zoom
 
 import (
+	"errors"
 	"image"
 	"testing"
 
@@ -46,6 +47,9 @@ func TestTracker(t *testing.T) {
 		wantHighlight      bool
 		wantHighlightRange *Range
 		wantZoom           *axes.XDetails
+		wantBrushed        bool
+		wantBrushStart     float64
+		wantBrushEnd       float64
 		wantErr            bool
 		wantMutateErr      bool
 	}{
@@ -534,6 +538,161 @@ func TestTracker(t *testing.T) {
 				},
 			),
 		},
+		{
+			desc: "brush highlights columns while dragging",
+			opts: []Option{
+				Brush(),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       5,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 8, 8),
+			graphAr: image.Rect(2, 0, 8, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{3, 0},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				return tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{6, 0},
+					Button:   mouse.ButtonLeft,
+				})
+			},
+			wantHighlight:      true,
+			wantHighlightRange: &Range{Start: 1, End: 5, last: 4},
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 8, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       5,
+					ReqYWidth: 2,
+				},
+			),
+		},
+		{
+			desc: "brush reports the selected values instead of zooming",
+			opts: []Option{
+				Brush(),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       5,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 8, 8),
+			graphAr: image.Rect(2, 0, 8, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{3, 0},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{6, 0},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				return tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{6, 0},
+					Button:   mouse.ButtonRelease,
+				})
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 8, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       5,
+					ReqYWidth: 2,
+				},
+			),
+			wantBrushed:    true,
+			wantBrushStart: 1,
+			wantBrushEnd:   4,
+		},
+		{
+			desc: "brush reports a single selected column",
+			opts: []Option{
+				Brush(),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       5,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 8, 8),
+			graphAr: image.Rect(2, 0, 8, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{4, 0},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				return tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{4, 0},
+					Button:   mouse.ButtonRelease,
+				})
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 8, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       5,
+					ReqYWidth: 2,
+				},
+			),
+			wantBrushed:    true,
+			wantBrushStart: 2,
+			wantBrushEnd:   2,
+		},
+		{
+			desc: "brush reports the selection only once",
+			opts: []Option{
+				Brush(),
+			},
+			xp: &axes.XProperties{
+				Min:       0,
+				Max:       5,
+				ReqYWidth: 2,
+			},
+			cvsAr:   image.Rect(0, 0, 8, 8),
+			graphAr: image.Rect(2, 0, 8, 8),
+			mutate: func(tr *Tracker) error {
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{4, 0},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				if err := tr.Mouse(&terminalapi.Mouse{
+					Position: image.Point{4, 0},
+					Button:   mouse.ButtonRelease,
+				}); err != nil {
+					return err
+				}
+				if brushed, _, _ := tr.Brushed(); !brushed {
+					return errors.New("Brushed => false, want true")
+				}
+				return nil
+			},
+			wantHighlight: false,
+			wantZoom: mustNewXDetails(
+				image.Rect(0, 0, 8, 8),
+				&axes.XProperties{
+					Min:       0,
+					Max:       5,
+					ReqYWidth: 2,
+				},
+			),
+		},
 		{
 			desc: "highlights and zooms into the X axis twice",
 			xp: &axes.XProperties{
@@ -1225,6 +1384,11 @@ func TestTracker(t *testing.T) {
 			if diff := pretty.Compare(tc.wantZoom, gotZoom); diff != "" {
 				t.Errorf("Zoom => unexpected XDetails, diff (-want, +got):\n%s", diff)
 			}
+
+			gotBrushed, gotStart, gotEnd := tracker.Brushed()
+			if gotBrushed != tc.wantBrushed || gotStart != tc.wantBrushStart || gotEnd != tc.wantBrushEnd {
+				t.Errorf("Brushed => %v, %v, %v, want %v, %v, %v", gotBrushed, gotStart, gotEnd, tc.wantBrushed, tc.wantBrushStart, tc.wantBrushEnd)
+			}
 		})
 	}
 }
@@ -0,0 +1,156 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// interpolation.go contains code that computes the curve between the values
+// of a series.
+
+import (
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
+)
+
+// Interpolation is the method used to compute the line between two
+// consecutive values of a series.
+type Interpolation int
+
+// String implements fmt.Stringer()
+func (i Interpolation) String() string {
+	if n, ok := interpolationNames[i]; ok {
+		return n
+	}
+	return "InterpolationUnknown"
+}
+
+// interpolationNames maps Interpolation values to human readable names.
+var interpolationNames = map[Interpolation]string{
+	InterpolationLinear:     "InterpolationLinear",
+	InterpolationCatmullRom: "InterpolationCatmullRom",
+	InterpolationMonotone:   "InterpolationMonotone",
+}
+
+const (
+	// InterpolationLinear connects the values with straight lines.
+	InterpolationLinear Interpolation = iota
+
+	// InterpolationCatmullRom connects the values with a smooth Catmull-Rom
+	// spline. The curve can overshoot beyond the values it connects, e.g.
+	// when the series rises and then stays flat.
+	InterpolationCatmullRom
+
+	// InterpolationMonotone connects the values with a smooth monotone cubic
+	// spline. Between any two values the curve never leaves the range of
+	// these two values.
+	InterpolationMonotone
+)
+
+// x returns the position of the i-th value of the series on the X axis.
+func (sv *seriesValues) x(i int) float64 {
+	if sv.xs != nil {
+		return sv.xs[i]
+	}
+	return float64(i)
+}
+
+// secant returns the slope of the straight line between the values i-1 and i
+// and a bool indicating if both values exist and have distinct positions on
+// the X axis.
+func (sv *seriesValues) secant(i int) (float64, bool) {
+	if i < 1 || i >= len(sv.values) {
+		return 0, false
+	}
+	prev, v := sv.values[i-1], sv.values[i]
+	if math.IsNaN(prev) || math.IsNaN(v) {
+		return 0, false
+	}
+	dx := sv.x(i) - sv.x(i-1)
+	if dx == 0 {
+		return 0, false
+	}
+	return (v - prev) / dx, true
+}
+
+// tangent returns the slope of the curve at the i-th value of the series.
+// Values at the start or the end of the series, or next to a missing value,
+// use the slope of the only adjacent line.
+func (sv *seriesValues) tangent(i int) float64 {
+	before, okBefore := sv.secant(i)
+	after, okAfter := sv.secant(i + 1)
+	switch {
+	case !okBefore && !okAfter:
+		return 0
+	case !okBefore:
+		return after
+	case !okAfter:
+		return before
+	}
+
+	if sv.interpolation == InterpolationMonotone {
+		// The Fritsch-Butland weighted harmonic mean, the curve is flat at
+		// local extremes so it doesn't overshoot.
+		if before*after <= 0 {
+			return 0
+		}
+		hBefore, hAfter := sv.x(i)-sv.x(i-1), sv.x(i+1)-sv.x(i)
+		w1, w2 := 2*hAfter+hBefore, hAfter+2*hBefore
+		return (w1 + w2) / (w1/before + w2/after)
+	}
+	return (sv.values[i+1] - sv.values[i-1]) / (sv.x(i+1) - sv.x(i-1))
+}
+
+// interpolate returns the value of the curve between the values i-1 and i of
+// the series. The argument t is the fraction of the distance between the two
+// values on the X axis, in the range 0 <= t <= 1.
+func (sv *seriesValues) interpolate(i int, t float64) float64 {
+	prev, v := sv.values[i-1], sv.values[i]
+	dx := sv.x(i) - sv.x(i-1)
+	if sv.interpolation == InterpolationLinear || dx == 0 {
+		return prev + (v-prev)*t
+	}
+
+	// Cubic Hermite spline with the tangents scaled to the interval.
+	m0, m1 := sv.tangent(i-1)*dx, sv.tangent(i)*dx
+	t2, t3 := t*t, t*t*t
+	return (2*t3-3*t2+1)*prev + (t3-2*t2+t)*m0 + (-2*t3+3*t2)*v + (t3-t2)*m1
+}
+
+// curvePoints returns the pixels on the braille canvas that the curve between
+// the values i-1 and i of the series passes through, one for each column of
+// pixels between startX and endX. The Y coordinates are computed on the
+// provided scale, the curve is clipped to the range of the scale and the
+// minMax limits.
+func (sv *seriesValues) curvePoints(i, startX, endX int, scale *axes.YScale, minMax *customScale) ([]image.Point, error) {
+	var points []image.Point
+	for x := startX; x <= endX; x++ {
+		var t float64
+		if endX > startX {
+			t = float64(x-startX) / float64(endX-startX)
+		}
+		v := sv.interpolate(i, t)
+		if minMax != nil {
+			v = clip(v, minMax.min, minMax.max)
+		}
+		v = clip(v, scale.Min.Value, scale.Max.Value)
+
+		y, err := scale.ValueToPixel(v)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, image.Point{x, y})
+	}
+	return points, nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
+)
+
+func TestInterpolate(t *testing.T) {
+	tests := []struct {
+		desc          string
+		values        []float64
+		xs            []float64
+		interpolation Interpolation
+		// wantOvershoot asserts whether the curve is expected to leave the
+		// range of the two values it connects on at least one segment.
+		wantOvershoot bool
+	}{
+		{
+			desc:          "linear doesn't overshoot",
+			values:        []float64{0, 0, 4, 4},
+			interpolation: InterpolationLinear,
+		},
+		{
+			desc:          "Catmull-Rom overshoots when the series rises and stays flat",
+			values:        []float64{0, 0, 4, 4},
+			interpolation: InterpolationCatmullRom,
+			wantOvershoot: true,
+		},
+		{
+			desc:          "monotone doesn't overshoot when the series rises and stays flat",
+			values:        []float64{0, 0, 4, 4},
+			interpolation: InterpolationMonotone,
+		},
+		{
+			desc:          "monotone doesn't overshoot on local extremes",
+			values:        []float64{0, 5, 1, 8, -3, 2},
+			interpolation: InterpolationMonotone,
+		},
+		{
+			desc:          "monotone doesn't overshoot with uneven X coordinates",
+			values:        []float64{0, 1, 10, 10.5},
+			xs:            []float64{0, 0.5, 4, 10},
+			interpolation: InterpolationMonotone,
+		},
+		{
+			desc:          "monotone doesn't overshoot next to missing values",
+			values:        []float64{0, 4, math.NaN(), 4, 0, 0},
+			interpolation: InterpolationMonotone,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sv := newSeriesValues(tc.values)
+			sv.xs = tc.xs
+			sv.interpolation = tc.interpolation
+
+			var overshoot bool
+			for i := 1; i < len(sv.values); i++ {
+				prev, v := sv.values[i-1], sv.values[i]
+				if math.IsNaN(prev) || math.IsNaN(v) {
+					continue
+				}
+				if got := sv.interpolate(i, 0); got != prev {
+					t.Errorf("interpolate(%d, 0) => %v, want %v", i, got, prev)
+				}
+				if got := sv.interpolate(i, 1); math.Abs(got-v) > 1e-9 {
+					t.Errorf("interpolate(%d, 1) => %v, want %v", i, got, v)
+				}
+
+				min, max := math.Min(prev, v), math.Max(prev, v)
+				for step := 0; step <= 100; step++ {
+					got := sv.interpolate(i, float64(step)/100)
+					if got < min-1e-9 || got > max+1e-9 {
+						overshoot = true
+					}
+				}
+			}
+			if overshoot != tc.wantOvershoot {
+				t.Errorf("interpolate => overshoot: %v, want overshoot: %v", overshoot, tc.wantOvershoot)
+			}
+		})
+	}
+}
+
+func TestCurvePoints(t *testing.T) {
+	scale, err := axes.NewYScale(0, 4, 16, 2, axes.YScaleModeAnchored)
+	if err != nil {
+		t.Fatalf("NewYScale => unexpected error: %v", err)
+	}
+	sv := newSeriesValues([]float64{0, 0, 4, 4})
+	sv.interpolation = InterpolationMonotone
+
+	for i := 1; i < len(sv.values); i++ {
+		startX, endX := (i-1)*10, i*10
+		points, err := sv.curvePoints(i, startX, endX, scale, nil)
+		if err != nil {
+			t.Fatalf("curvePoints(%d) => unexpected error: %v", i, err)
+		}
+		if got, want := len(points), endX-startX+1; got != want {
+			t.Errorf("curvePoints(%d) => got %d points, want %d", i, got, want)
+		}
+
+		top, err := scale.ValueToPixel(math.Max(sv.values[i-1], sv.values[i]))
+		if err != nil {
+			t.Fatalf("ValueToPixel => unexpected error: %v", err)
+		}
+		bottom, err := scale.ValueToPixel(math.Min(sv.values[i-1], sv.values[i]))
+		if err != nil {
+			t.Fatalf("ValueToPixel => unexpected error: %v", err)
+		}
+		for j, p := range points {
+			if p.X != startX+j {
+				t.Errorf("curvePoints(%d) => point %d at column %d, want %d", i, j, p.X, startX+j)
+			}
+			if p.Y < top || p.Y > bottom {
+				t.Errorf("curvePoints(%d) => point %v falls outside of the pixel range [%d, %d] of the values", i, p, top, bottom)
+			}
+		}
+	}
+}
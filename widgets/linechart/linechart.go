@@ -21,6 +21,7 @@ import (
 	"image"
 	"math"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/mum4k/termdash/cell"
@@ -29,6 +30,8 @@ import (
 	"github.com/mum4k/termdash/internal/canvas/braille"
 	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/numbers"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
@@ -39,16 +42,35 @@ import (
 type seriesValues struct {
 	// values are the values in the series.
 	values []float64
+	// xs are the X coordinates of the values provided on a call to SeriesXY.
+	// Nil if the values are positioned on the X axis by their index.
+	xs []float64
 	// min is the smallest value, zero if values is empty.
 	min float64
 	// max is the largest value, zero if values is empty.
 	max float64
 
 	seriesCellOpts []cell.Option
+	// interpolation is the method used to draw the line between the values.
+	interpolation Interpolation
+	// plotAll indicates that all the values are plotted, even if the graph
+	// has fewer columns of pixels than there are values.
+	plotAll bool
+	// markers is the style of the markers drawn at the values.
+	markers MarkerStyle
 	// The custom labels provided on a call to Series and a bool indicating if
 	// the labels were provided. This allows resetting them to nil.
 	xLabelsSet bool
 	xLabels    map[int]string
+
+	// movingAverage indicates that the SeriesMovingAverage option was
+	// provided with the window and the cell options of the overlay.
+	movingAverage bool
+	maWindow      int
+	maCellOpts    []cell.Option
+	// average is the moving average overlay of the series, nil if the series
+	// doesn't have one.
+	average *seriesValues
 }
 
 // newSeriesValues returns a new seriesValues instance.
@@ -65,6 +87,24 @@ func newSeriesValues(values []float64) *seriesValues {
 	}
 }
 
+// valueAt returns the value of the series at the position on the X axis and
+// a bool indicating if the series has a value there.
+func (sv *seriesValues) valueAt(x int) (float64, bool) {
+	if sv.xs == nil {
+		if x >= len(sv.values) {
+			return 0, false
+		}
+		return sv.values[x], true
+	}
+
+	for i, sx := range sv.xs {
+		if sx == float64(x) {
+			return sv.values[i], true
+		}
+	}
+	return 0, false
+}
+
 // LineChart draws line charts.
 //
 // Each line chart has an identifying label and a set of values that are
@@ -76,9 +116,13 @@ func newSeriesValues(values []float64) *seriesValues {
 // The Y axis will be sized so that it can conveniently accommodate the largest
 // value among all the labeled line charts. This determines the used scale.
 //
+// Optionally, the data points can be inspected with the keyboard, see the
+// KeyboardSelection option.
+//
 // LineChart supports mouse based zoom, zooming is achieved by either
 // highlighting an area on the graph (left mouse clicking and dragging) or by
-// using the mouse scroll button.
+// using the mouse scroll button. Highlighting an area can instead report the
+// selected range to the application, see the OnBrush option.
 //
 // Implements widgetapi.Widget. This object is thread-safe.
 type LineChart struct {
@@ -104,6 +148,10 @@ type LineChart struct {
 
 	// zoom tracks the zooming of the X axis.
 	zoom *zoom.Tracker
+
+	// selected is the index of the data point selected with the keyboard or
+	// a negative number if no data point is selected.
+	selected int
 }
 
 // New returns a new line chart widget.
@@ -113,8 +161,9 @@ func New(opts ...Option) (*LineChart, error) {
 		return nil, err
 	}
 	return &LineChart{
-		series: map[string]*seriesValues{},
-		opts:   opt,
+		series:   map[string]*seriesValues{},
+		opts:     opt,
+		selected: -1,
 	}, nil
 }
 
@@ -160,8 +209,60 @@ func SeriesXLabels(labels map[int]string) SeriesOption {
 	})
 }
 
+// SeriesInterpolation sets the method used to draw the line between the
+// values of this series. Defaults to InterpolationLinear.
+// The smooth methods compute additional points between the values, which
+// only makes a difference when the values are at least a few pixels apart,
+// i.e. on sparse series.
+func SeriesInterpolation(i Interpolation) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.interpolation = i
+	})
+}
+
+// SeriesPlotAllValues disables the thinning of this series.
+// By default, when a series has more values than the graph has columns of
+// pixels, only the smallest and the largest value in each column are plotted,
+// which preserves the shape of the line while reducing the work done on each
+// redraw. With this option every value is plotted.
+func SeriesPlotAllValues() SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.plotAll = true
+	})
+}
+
+// SeriesMarkers draws a marker in the style at each plotted value of this
+// series on top of the lines, e.g. a filled circle for one category of series
+// and a hollow circle for another. The markers use the cell options of the
+// series, see SeriesCellOpts. Markers occupy a whole cell, so values that
+// share a cell share a marker. Defaults to MarkerNone.
+func SeriesMarkers(ms MarkerStyle) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.markers = ms
+	})
+}
+
+// SeriesMovingAverage plots the simple moving average of the values of this
+// series over the window as a separate line on top of the series, which
+// smooths out noisy data. Each value of the overlay is the mean of the window
+// of values that ends with it, the overlay starts at the first value that
+// has a full window. Missing values are excluded from the mean.
+// The provided cell options apply to the overlay, which is otherwise drawn
+// in the DefaultMovingAverageColor. The window must be a positive integer.
+func SeriesMovingAverage(window int, cOpts ...cell.Option) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.movingAverage = true
+		opts.maWindow = window
+		opts.maCellOpts = cOpts
+	})
+}
+
 // yMinMax determines the min and max values for the Y axis.
 func (lc *LineChart) yMinMax() (float64, float64) {
+	if r := lc.opts.yAxisRange; r != nil {
+		return r.min, r.max
+	}
+
 	var (
 		minimums []float64
 		maximums []float64
@@ -206,13 +307,23 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 		return errors.New("the label cannot be empty")
 	}
 
+	return lc.setSeries(label, newSeriesValues(values), opts)
+}
+
+// setSeries applies the options to the series and stores it under the label.
+func (lc *LineChart) setSeries(label string, series *seriesValues, opts []SeriesOption) error {
 	lc.mu.Lock()
 	defer lc.mu.Unlock()
 
-	series := newSeriesValues(values)
 	for _, opt := range opts {
 		opt.set(series)
 	}
+	if _, ok := interpolationNames[series.interpolation]; !ok {
+		return fmt.Errorf("invalid SeriesInterpolation %d", series.interpolation)
+	}
+	if _, ok := markerStyleNames[series.markers]; !ok {
+		return fmt.Errorf("invalid SeriesMarkers %d", series.markers)
+	}
 	if series.xLabelsSet {
 		for i, t := range series.xLabels {
 			if i < 0 {
@@ -224,6 +335,12 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 		}
 		lc.xLabels = series.xLabels
 	}
+	if series.movingAverage {
+		if series.maWindow <= 0 {
+			return fmt.Errorf("invalid SeriesMovingAverage window %d, must be a positive integer", series.maWindow)
+		}
+		series.average = series.averageSeries()
+	}
 
 	lc.series[label] = series
 	yMin, yMax := lc.yMinMax()
@@ -232,6 +349,38 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 	return nil
 }
 
+// SeriesXY is like Series, but positions the values on the X axis according
+// to the provided X coordinates instead of their indexes, i.e. ys[i] is
+// plotted at xs[i]. This allows plotting series sampled at different X
+// positions on the same LineChart, the X axis starts at zero and spans up to
+// the largest X coordinate among all the series, rounded up to a whole
+// number.
+// Both slices must have the same length. The X coordinates must be zero or
+// positive, must not be NaN and must be in a non-decreasing order.
+// Subsequent calls with the same label replace any previously provided values.
+func (lc *LineChart) SeriesXY(label string, xs, ys []float64, opts ...SeriesOption) error {
+	if len(xs) != len(ys) {
+		return fmt.Errorf("the X coordinates and values must have the same length, got %d and %d", len(xs), len(ys))
+	}
+	for i, x := range xs {
+		if math.IsNaN(x) || math.IsInf(x, 0) || x < 0 {
+			return fmt.Errorf("invalid xs[%d]: %v, the X coordinates must be zero or positive numbers", i, x)
+		}
+		if i > 0 && x < xs[i-1] {
+			return fmt.Errorf("invalid xs[%d]: %v, the X coordinates must be in a non-decreasing order, the previous one is %v", i, x, xs[i-1])
+		}
+	}
+	if label == "" {
+		return errors.New("the label cannot be empty")
+	}
+
+	series := newSeriesValues(ys)
+	// Copy to avoid external modifications. See #174.
+	series.xs = make([]float64, len(xs))
+	copy(series.xs, xs)
+	return lc.setSeries(label, series, opts)
+}
+
 // xDetails returns the details for the X axis given the specified minimum and
 // maximum value to display.
 func (lc *LineChart) xDetails(cvs *canvas.Canvas, reqYWidth, min, max int) (*axes.XDetails, error) {
@@ -317,9 +466,68 @@ func (lc *LineChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	if err != nil {
 		return err
 	}
-	return lc.drawAxes(cvs, adjXD, yd)
+	if err := lc.drawAxes(cvs, adjXD, yd); err != nil {
+		return err
+	}
+	if err := lc.drawVLineLabels(cvs, lc.graphAr(cvs, xd, yd), adjXD); err != nil {
+		return err
+	}
+	return lc.drawReadout(cvs, lc.graphAr(cvs, xd, yd))
 }
 
+// selectedIdx returns the index of the selected data point clamped to the
+// current X axis and a bool indicating if any data point is selected.
+func (lc *LineChart) selectedIdx() (int, bool) {
+	if lc.selected < 0 || len(lc.series) == 0 {
+		return 0, false
+	}
+	if max := lc.maxXValue(); lc.selected > max {
+		return max, true
+	}
+	return lc.selected, true
+}
+
+// drawReadout draws the values of all the series at the selected data point
+// right-aligned on the first line of the graph area.
+func (lc *LineChart) drawReadout(cvs *canvas.Canvas, graphAr image.Rectangle) error {
+	idx, ok := lc.selectedIdx()
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for name := range lc.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		v, ok := lc.series[name].valueAt(idx)
+		if !ok || math.IsNaN(v) {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", name, axes.NewValue(v, readoutDecimals).Text()))
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+
+	text := strings.Join(parts, ", ")
+	start := image.Point{graphAr.Max.X - runewidth.StringWidth(text), graphAr.Min.Y}
+	if start.X < graphAr.Min.X {
+		start.X = graphAr.Min.X
+	}
+	return draw.Text(cvs, text, start,
+		draw.TextMaxX(graphAr.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// readoutDecimals is the number of non-zero decimal places displayed for the
+// values in the readout of the selected data point.
+const readoutDecimals = 2
+
 // drawAxes draws the X,Y axes and their labels.
 func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YDetails) error {
 	lines := []draw.HVLine{
@@ -382,7 +590,13 @@ func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.
 	}
 
 	if lc.zoom == nil {
-		z, err := zoom.New(xdForCap, cvs.Area(), graphAr, zoom.ScrollStep(lc.opts.zoomStepPercent))
+		zOpts := []zoom.Option{
+			zoom.ScrollStep(lc.opts.zoomStepPercent),
+		}
+		if lc.opts.onBrush != nil {
+			zOpts = append(zOpts, zoom.Brush())
+		}
+		z, err := zoom.New(xdForCap, cvs.Area(), graphAr, zOpts...)
 		if err != nil {
 			return nil, err
 		}
@@ -393,7 +607,14 @@ func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.
 		}
 	}
 
+	if err := lc.drawZeroLine(bc, yd); err != nil {
+		return nil, err
+	}
+
 	xdZoomed := lc.zoom.Zoom()
+	if err := lc.drawVLines(bc, xdZoomed); err != nil {
+		return nil, err
+	}
 	var names []string
 	for name := range lc.series {
 		names = append(names, name)
@@ -402,71 +623,212 @@ func (lc *LineChart) drawSeries(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.
 
 	for _, name := range names {
 		sv := lc.series[name]
-		// Skip over series that don't have at least two points since we can't
-		// draw a line for just one point.
-		// Skip over series that fall under the minimum value on the X axis.
-		if got := len(sv.values); got <= 1 {
-			continue
+		if err := lc.drawLine(bc, xdZoomed, yd, name, sv); err != nil {
+			return nil, err
+		}
+		if sv.average != nil {
+			if err := lc.drawLine(bc, xdZoomed, yd, name, sv.average); err != nil {
+				return nil, err
+			}
 		}
+	}
 
-		var prev float64
-		for i := 1; i < len(sv.values); i++ {
-			v := sv.values[i]
-			prev = sv.values[i-1]
+	if highlight, hRange := lc.zoom.Highlight(); highlight {
+		if err := lc.highlightRange(bc, hRange); err != nil {
+			return nil, err
+		}
+	}
 
-			// Skip the values that are missing.
-			if math.IsNaN(v) || math.IsNaN(prev) {
-				continue
-			}
+	if idx, ok := lc.selectedIdx(); ok && idx >= int(xdZoomed.Scale.Min.Value) && idx <= int(xdZoomed.Scale.Max.Value) {
+		x, err := xdZoomed.Scale.ValueToPixel(idx)
+		if err != nil {
+			return nil, fmt.Errorf("failure for the selected point %d on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", idx, xdZoomed.Scale, idx, err)
+		}
+		cellAr := bc.CellArea()
+		col := x / braille.ColMult
+		ar := image.Rect(col, cellAr.Min.Y, col+1, cellAr.Max.Y)
+		if err := bc.SetAreaCellOpts(ar, cell.BgColor(lc.opts.selectionColor)); err != nil {
+			return nil, err
+		}
+	}
 
-			if i < int(xdZoomed.Scale.Min.Value)+1 || i > int(xdZoomed.Scale.Max.Value) {
-				// Don't draw lines for values that aren't supposed to be visible.
-				// These are either values outside of the current zoom or
-				// values at the beginning of a series that falls before athe
-				// start of an unscaled X axis when the XAxisUnscaled option is
-				// provided.
-				continue
-			}
+	if err := bc.CopyTo(cvs); err != nil {
+		return nil, fmt.Errorf("bc.Apply => %v", err)
+	}
+	for _, name := range names {
+		if err := lc.drawMarkers(cvs, graphAr, xdZoomed, yd, name, lc.series[name]); err != nil {
+			return nil, err
+		}
+	}
+	return xdZoomed, nil
+}
 
-			startX, err := xdZoomed.Scale.ValueToPixel(i - 1)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i-1, xdZoomed.Scale, i-1, err)
-			}
-			endX, err := xdZoomed.Scale.ValueToPixel(i)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.ValueToPixel(%v) => %v", name, i, xdZoomed.Scale, i, err)
-			}
+// drawLine draws the line of a series with the provided name onto the
+// braille canvas.
+func (lc *LineChart) drawLine(bc *braille.Canvas, xdZoomed *axes.XDetails, yd *axes.YDetails, name string, sv *seriesValues) error {
+	// Skip over series that don't have at least two points since we can't
+	// draw a line for just one point.
+	// Skip over series that fall under the minimum value on the X axis.
+	if got := len(sv.values); got <= 1 {
+		return nil
+	}
 
-			startY, err := yd.Scale.ValueToPixel(prev)
-			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, i-1, yd.Scale, prev, err)
-			}
+	// Values that aren't supposed to be visible aren't plotted. These are
+	// either values outside of the current zoom or values at the
+	// beginning of a series that falls before the start of an unscaled X
+	// axis when the XAxisUnscaled option is provided.
+	plotted, err := sv.plotted(xdZoomed.Scale)
+	if err != nil {
+		return fmt.Errorf("failure for series %v on scale %v, plotted => %v", name, xdZoomed.Scale, err)
+	}
+
+	var prev float64
+	for k := 1; k < len(plotted); k++ {
+		prevI, i := plotted[k-1], plotted[k]
+		v := sv.values[i]
+		prev = sv.values[prevI]
+
+		// Skip the values that are missing.
+		if math.IsNaN(v) || math.IsNaN(prev) {
+			continue
+		}
+
+		prevX, x := sv.x(prevI), sv.x(i)
+		startX, err := xdZoomed.Scale.FloatValueToPixel(prevX)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.FloatValueToPixel(%v) => %v", name, prevI, xdZoomed.Scale, prevX, err)
+		}
+		endX, err := xdZoomed.Scale.FloatValueToPixel(x)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.FloatValueToPixel(%v) => %v", name, i, xdZoomed.Scale, x, err)
+		}
+
+		if r := lc.opts.yAxisRange; r != nil {
+			prev = clip(prev, r.min, r.max)
+			v = clip(v, r.min, r.max)
+		}
+
+		startY, err := yd.Scale.ValueToPixel(prev)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, prevI, yd.Scale, prev, err)
+		}
 
-			endY, err := yd.Scale.ValueToPixel(v)
+		endY, err := yd.Scale.ValueToPixel(v)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, i, yd.Scale, v, err)
+		}
+
+		points := []image.Point{{startX, startY}, {endX, endY}}
+		// The curves are only computed between adjacent values, the
+		// values of thinned series are too dense for them to matter.
+		if sv.interpolation != InterpolationLinear && prevI == i-1 {
+			points, err = sv.curvePoints(i, startX, endX, yd.Scale, lc.opts.yAxisRange)
 			if err != nil {
-				return nil, fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, i, yd.Scale, v, err)
+				return fmt.Errorf("failure for series %v[%d] on scale %v, curvePoints => %v", name, i, yd.Scale, err)
 			}
-
+		}
+		for j := 1; j < len(points); j++ {
 			if err := draw.BrailleLine(bc,
-				image.Point{startX, startY},
-				image.Point{endX, endY},
+				points[j-1],
+				points[j],
 				draw.BrailleLineCellOpts(sv.seriesCellOpts...),
 			); err != nil {
-				return nil, fmt.Errorf("draw.BrailleLine => %v", err)
+				return fmt.Errorf("draw.BrailleLine => %v", err)
 			}
 		}
 	}
+	return nil
+}
 
-	if highlight, hRange := lc.zoom.Highlight(); highlight {
-		if err := lc.highlightRange(bc, hRange); err != nil {
-			return nil, err
+// drawZeroLine draws the line at the zero value of the Y axis if requested
+// and if the Y axis spans zero.
+func (lc *LineChart) drawZeroLine(bc *braille.Canvas, yd *axes.YDetails) error {
+	if !lc.opts.zeroLine || yd.Scale.Min.Value >= 0 || yd.Scale.Max.Value <= 0 {
+		return nil
+	}
+
+	y, err := yd.Scale.ValueToPixel(0)
+	if err != nil {
+		return fmt.Errorf("failure for the zero line on scale %v, yd.Scale.ValueToPixel(0) => %v", yd.Scale, err)
+	}
+	ar := bc.Area()
+	if err := draw.BrailleLine(bc,
+		image.Point{ar.Min.X, y},
+		image.Point{ar.Max.X - 1, y},
+		draw.BrailleLineCellOpts(lc.opts.zeroLineCellOpts...),
+	); err != nil {
+		return fmt.Errorf("draw.BrailleLine => %v", err)
+	}
+	return nil
+}
+
+// vLinePixel returns the X coordinate of the pixel of the vertical line on the
+// braille canvas and a bool indicating if the line falls onto the X axis.
+func vLinePixel(vl *vLine, xd *axes.XDetails) (int, bool, error) {
+	if vl.x < xd.Scale.Min.Value || vl.x > xd.Scale.Max.Value {
+		return 0, false, nil
+	}
+	x, err := xd.Scale.FloatValueToPixel(vl.x)
+	if err != nil {
+		return 0, false, fmt.Errorf("failure for the vertical line on scale %v, xd.Scale.FloatValueToPixel(%v) => %v", xd.Scale, vl.x, err)
+	}
+	return x, true, nil
+}
+
+// drawVLines draws the vertical lines requested via the VLine option.
+func (lc *LineChart) drawVLines(bc *braille.Canvas, xd *axes.XDetails) error {
+	ar := bc.Area()
+	for _, vl := range lc.opts.vLines {
+		x, ok, err := vLinePixel(vl, xd)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := draw.BrailleLine(bc,
+			image.Point{x, ar.Min.Y},
+			image.Point{x, ar.Max.Y - 1},
+			draw.BrailleLineCellOpts(vl.cellOpts...),
+		); err != nil {
+			return fmt.Errorf("draw.BrailleLine => %v", err)
 		}
 	}
+	return nil
+}
 
-	if err := bc.CopyTo(cvs); err != nil {
-		return nil, fmt.Errorf("bc.Apply => %v", err)
+// drawVLineLabels draws the labels of the vertical lines on the first line of
+// the graph area, starting at the column of the line. Labels that don't fit
+// are shifted to the left.
+func (lc *LineChart) drawVLineLabels(cvs *canvas.Canvas, graphAr image.Rectangle, xd *axes.XDetails) error {
+	for _, vl := range lc.opts.vLines {
+		if vl.label == "" {
+			continue
+		}
+		x, ok, err := vLinePixel(vl, xd)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		start := image.Point{graphAr.Min.X + x/braille.ColMult, graphAr.Min.Y}
+		if w := runewidth.StringWidth(vl.label); start.X+w > graphAr.Max.X {
+			start.X = graphAr.Max.X - w
+		}
+		if start.X < graphAr.Min.X {
+			start.X = graphAr.Min.X
+		}
+		if err := draw.Text(cvs, vl.label, start,
+			draw.TextCellOpts(vl.cellOpts...),
+			draw.TextMaxX(graphAr.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
 	}
-	return xdZoomed, nil
+	return nil
 }
 
 // highlightRange highlights the range of X columns on the braille canvas.
@@ -478,18 +840,66 @@ func (lc *LineChart) highlightRange(bc *braille.Canvas, hRange *zoom.Range) erro
 
 // Keyboard implements widgetapi.Widget.Keyboard.
 func (lc *LineChart) Keyboard(k *terminalapi.Keyboard) error {
-	return errors.New("the LineChart widget doesn't support keyboard events")
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if !lc.opts.keyboardSelection && !lc.opts.zoomReset {
+		return errors.New("the LineChart widget doesn't support keyboard events without the KeyboardSelection or the ZoomResetKey option")
+	}
+
+	if lc.opts.zoomReset && k.Key == lc.opts.zoomResetKey {
+		if lc.zoom != nil {
+			lc.zoom.Reset()
+		}
+		return nil
+	}
+	if !lc.opts.keyboardSelection {
+		return nil
+	}
+
+	// The series might have gotten shorter since the last key press.
+	max := lc.maxXValue()
+	if lc.selected > max {
+		lc.selected = max
+	}
+
+	switch k.Key {
+	case keyboard.KeyArrowLeft:
+		if lc.selected < 0 {
+			lc.selected = max
+		} else if lc.selected > 0 {
+			lc.selected--
+		}
+
+	case keyboard.KeyArrowRight:
+		if lc.selected < max {
+			lc.selected++
+		}
+	}
+	return nil
 }
 
 // Mouse implements widgetapi.Widget.Mouse.
 func (lc *LineChart) Mouse(m *terminalapi.Mouse) error {
 	lc.mu.Lock()
-	defer lc.mu.Unlock()
-
 	if lc.zoom == nil {
+		lc.mu.Unlock()
 		return nil
 	}
-	return lc.zoom.Mouse(m)
+	if err := lc.zoom.Mouse(m); err != nil {
+		lc.mu.Unlock()
+		return err
+	}
+	brushed, start, end := lc.zoom.Brushed()
+	lc.mu.Unlock()
+
+	// Mutex must be released when calling the callback.
+	// Users might call container methods from the callback like the
+	// Container.Update, see #205.
+	if brushed && lc.opts.onBrush != nil {
+		return lc.opts.onBrush(start, end)
+	}
+	return nil
 }
 
 // minSize determines the minimum required size to draw the line chart.
@@ -511,9 +921,14 @@ func (lc *LineChart) Options() widgetapi.Options {
 	lc.mu.RLock()
 	defer lc.mu.RUnlock()
 
+	wantKeyboard := widgetapi.KeyScopeNone
+	if lc.opts.keyboardSelection || lc.opts.zoomReset {
+		wantKeyboard = widgetapi.KeyScopeFocused
+	}
 	return widgetapi.Options{
-		MinimumSize: lc.minSize(),
-		WantMouse:   widgetapi.MouseScopeGlobal,
+		MinimumSize:  lc.minSize(),
+		WantKeyboard: wantKeyboard,
+		WantMouse:    widgetapi.MouseScopeGlobal,
 	}
 }
 
@@ -521,15 +936,24 @@ func (lc *LineChart) Options() widgetapi.Options {
 // lc.mu must be held when calling this method.
 func (lc *LineChart) maxXValue() int {
 	maxLen := 0
+	maxX := 0
 	for _, sv := range lc.series {
+		if sv.xs != nil {
+			if l := len(sv.xs); l > 0 {
+				if x := int(math.Ceil(sv.xs[l-1])); x > maxX {
+					maxX = x
+				}
+			}
+			continue
+		}
 		if l := len(sv.values); l > maxLen {
 			maxLen = l
 		}
 	}
-	if maxLen == 0 {
-		return 0
+	if maxLen-1 > maxX {
+		return maxLen - 1
 	}
-	return maxLen - 1
+	return maxX
 }
 
 // minMax is a wrapper around numbers.MinMax that controls
@@ -545,3 +969,8 @@ func minMax(values []float64) (x, y float64) {
 	}
 	return min, max
 }
+
+// clip returns the value clipped to the range min <= value <= max.
+func clip(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
@@ -15,6 +15,7 @@
 package linechart
 
 import (
+	"errors"
 	"image"
 	"math"
 	"testing"
@@ -22,11 +23,13 @@ import (
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/braille"
 	"github.com/mum4k/termdash/internal/canvas/braille/testbraille"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
 	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/draw/testdraw"
 	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
@@ -61,6 +64,15 @@ func TestLineChartDraws(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc:   "fails when the zoom reset key is used by the keyboard selection",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				KeyboardSelection(),
+				ZoomResetKey(keyboard.KeyArrowLeft),
+			},
+			wantErr: true,
+		},
 		{
 			desc:   "fails with custom scale where min is NaN",
 			canvas: image.Rect(0, 0, 3, 4),
@@ -93,6 +105,47 @@ func TestLineChartDraws(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc:   "fails with Y axis range where min is NaN",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisRange(math.NaN(), 1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails with Y axis range where max is NaN",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisRange(0, math.NaN()),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails with Y axis range where min > max",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisRange(1, 0),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails with Y axis range where min == max",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisRange(1, 1),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails with both custom scale and Y axis range",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YAxisCustomScale(0, 1),
+				YAxisRange(0, 1),
+			},
+			wantErr: true,
+		},
 		{
 			desc:   "series fails without name for the series",
 			canvas: image.Rect(0, 0, 3, 4),
@@ -101,6 +154,70 @@ func TestLineChartDraws(t *testing.T) {
 			},
 			wantWriteErr: true,
 		},
+		{
+			desc:   "series fails on an unknown interpolation",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", nil, SeriesInterpolation(Interpolation(-1)))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "series fails on a zero moving average window",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", nil, SeriesMovingAverage(0))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "series fails on an unknown marker style",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.Series("series", nil, SeriesMarkers(MarkerStyle(-1)))
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "SeriesXY fails without name for the series",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.SeriesXY("", []float64{0}, []float64{1})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "SeriesXY fails when the X coordinates and values have different lengths",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.SeriesXY("series", []float64{0, 1}, []float64{1})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "SeriesXY fails on a negative X coordinate",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.SeriesXY("series", []float64{-1, 1}, []float64{1, 2})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "SeriesXY fails on a NaN X coordinate",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.SeriesXY("series", []float64{0, math.NaN()}, []float64{1, 2})
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "SeriesXY fails on decreasing X coordinates",
+			canvas: image.Rect(0, 0, 3, 4),
+			writes: func(lc *LineChart) error {
+				return lc.SeriesXY("series", []float64{2, 1}, []float64{1, 2})
+			},
+			wantWriteErr: true,
+		},
 		{
 			desc:   "series fails when custom label has negative key",
 			canvas: image.Rect(0, 0, 3, 4),
@@ -312,6 +429,130 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "Y axis range, labels reflect the range and values above max are clipped to the top",
+			opts: []Option{
+				YAxisRange(0, 200),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 500})
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 7})
+				testdraw.MustText(c, "103.36", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "Y axis range, values below min are clipped to the bottom",
+			opts: []Option{
+				YAxisRange(0, 200),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{-500, 100})
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 7})
+				testdraw.MustText(c, "103.36", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 16})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "highlights the data point selected with the keyboard and displays its value",
+			opts: []Option{
+				YAxisCustomScale(0, 200),
+				KeyboardSelection(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				for i := 0; i < 2; i++ {
+					if err := lc.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowRight}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 7})
+				testdraw.MustText(c, "103.36", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line with the selected column highlighted.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 16})
+				testbraille.MustSetAreaCellOpts(bc, image.Rect(12, 0, 13, 8), cell.BgColor(cell.ColorNumber(238)))
+				testbraille.MustCopyTo(bc, c)
+
+				// Readout.
+				testdraw.MustText(c, "first: 100", image.Point{10, 0})
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "custom Y scale, zero based negative, values fit",
 			opts: []Option{
@@ -334,58 +575,357 @@ func TestLineChartDraws(t *testing.T) {
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "-200", image.Point{2, 7})
-				testdraw.MustText(c, "-96.64", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{7, 9})
-				testdraw.MustText(c, "1", image.Point{19, 9})
+				testdraw.MustText(c, "-200", image.Point{2, 7})
+				testdraw.MustText(c, "-96.64", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{25, 31})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom Y scale, negative and positive, values fit",
+			opts: []Option{
+				YAxisCustomScale(-200, 200),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 30,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{4, 0}, End: image.Point{4, 8}},
+					{Start: image.Point{4, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "-200", image.Point{0, 7})
+				testdraw.MustText(c, "6.57", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{5, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(5, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{29, 8})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the zero line when the Y axis spans zero",
+			opts: []Option{
+				YAxisCustomScale(-200, 200),
+				ZeroLine(cell.FgColor(cell.ColorRed)),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 30,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{4, 0}, End: image.Point{4, 8}},
+					{Start: image.Point{4, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "-200", image.Point{0, 7})
+				testdraw.MustText(c, "6.57", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{5, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Zero line and the braille line drawn over it.
+				graphAr := image.Rect(5, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{29, 16}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{29, 8})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "doesn't draw the zero line when the Y axis is entirely positive",
+			opts: []Option{
+				YAxisCustomScale(0, 200),
+				ZeroLine(cell.FgColor(cell.ColorRed)),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 8}},
+					{Start: image.Point{6, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 7})
+				testdraw.MustText(c, "103.36", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{7, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{25, 16})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "SeriesXY plots series with different X sampling at their X coordinates",
+			opts: []Option{
+				YAxisCustomScale(0, 4),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.SeriesXY("first", []float64{0, 2, 4}, []float64{0, 4, 0}); err != nil {
+					return err
+				}
+				return lc.SeriesXY("second", []float64{1, 3}, []float64{4, 4})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "2.080", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{10, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "4", image.Point{18, 9})
+
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{13, 0})
+				testdraw.MustBrailleLine(bc, image.Point{13, 0}, image.Point{27, 31})
+				testdraw.MustBrailleLine(bc, image.Point{7, 0}, image.Point{20, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "SeriesXY the shared X axis spans the combined range of all series",
+			opts: []Option{
+				YAxisCustomScale(0, 4),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 1}); err != nil {
+					return err
+				}
+				return lc.SeriesXY("second", []float64{0, 3.5}, []float64{0, 1})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels, the X axis extends to the rounded up 3.5.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "2.080", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{10, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "4", image.Point{18, 9})
+
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{7, 23})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{23, 23})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the vertical line at the scaled X position with its label at the top",
+			opts: []Option{
+				YAxisCustomScale(0, 4),
+				VLine(2, VLineLabel("now"), VLineCellOpts(cell.FgColor(cell.ColorRed))),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 0, 0, 0, 0})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "2.080", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{10, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "4", image.Point{18, 9})
+
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{13, 0}, image.Point{13, 31}, draw.BrailleLineCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{27, 31})
+				testbraille.MustCopyTo(bc, c)
+
+				// The label starts at the column of the line.
+				testdraw.MustText(c, "now", image.Point{12, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "shifts the label of the vertical line to the left when it doesn't fit",
+			opts: []Option{
+				YAxisCustomScale(0, 4),
+				VLine(4, VLineLabel("forecast")),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 0, 0, 0, 0})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "2.080", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{10, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "4", image.Point{18, 9})
 
-				// Braille line.
-				graphAr := image.Rect(7, 0, 20, 8)
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 0}, image.Point{25, 31})
+				testdraw.MustBrailleLine(bc, image.Point{27, 0}, image.Point{27, 31})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{27, 31})
 				testbraille.MustCopyTo(bc, c)
 
+				testdraw.MustText(c, "forecast", image.Point{12, 0})
+
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc: "custom Y scale, negative and positive, values fit",
+			desc: "doesn't draw the vertical line when it falls outside of the X axis",
 			opts: []Option{
-				YAxisCustomScale(-200, 200),
+				YAxisCustomScale(0, 4),
+				VLine(10, VLineLabel("now")),
 			},
 			canvas: image.Rect(0, 0, 20, 10),
 			writes: func(lc *LineChart) error {
-				return lc.Series("first", []float64{0, 100})
+				return lc.Series("first", []float64{0, 0, 0, 0, 0})
 			},
-			wantCapacity: 30,
+			wantCapacity: 28,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				// Y and X axis.
 				lines := []draw.HVLine{
-					{Start: image.Point{4, 0}, End: image.Point{4, 8}},
-					{Start: image.Point{4, 8}, End: image.Point{19, 8}},
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
 				}
 				testdraw.MustHVLines(c, lines)
 
 				// Value labels.
-				testdraw.MustText(c, "-200", image.Point{0, 7})
-				testdraw.MustText(c, "6.57", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{5, 9})
-				testdraw.MustText(c, "1", image.Point{19, 9})
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "2.080", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{10, 9})
+				testdraw.MustText(c, "2", image.Point{14, 9})
+				testdraw.MustText(c, "4", image.Point{18, 9})
 
-				// Braille line.
-				graphAr := image.Rect(5, 0, 20, 8)
+				// Braille lines.
+				graphAr := image.Rect(6, 0, 20, 8)
 				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 16}, image.Point{29, 8})
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{27, 31})
 				testbraille.MustCopyTo(bc, c)
 
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
+		{
+			desc:   "fails on a negative VLine position",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				VLine(-1),
+			},
+			wantErr: true,
+		},
 		{
 			desc: "custom Y scale, negative only, values fit",
 			opts: []Option{
@@ -1203,6 +1743,63 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "highlights brushed area while dragging",
+			opts: []Option{
+				OnBrush(func(xStart, xEnd float64) error { return nil }),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				// Draw once so zoom tracker is initialized.
+				cvs := testcanvas.MustNew(image.Rect(0, 0, 20, 10))
+				if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+					return err
+				}
+				if err := lc.Mouse(&terminalapi.Mouse{
+					Position: image.Point{6, 5},
+					Button:   mouse.ButtonLeft,
+				}); err != nil {
+					return err
+				}
+				return lc.Mouse(&terminalapi.Mouse{
+					Position: image.Point{8, 5},
+					Button:   mouse.ButtonLeft,
+				})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+
+				// Highlighted brushed area.
+				testbraille.MustSetAreaCellOpts(bc, image.Rect(0, 0, 3, 8), cell.BgColor(cell.ColorNumber(235)))
+
+				testbraille.MustCopyTo(bc, c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "highlights area for zoom to a custom color",
 			opts: []Option{
@@ -1702,6 +2299,236 @@ func TestLineChartDraws(t *testing.T) {
 	}
 }
 
+func TestSeriesInterpolationDraws(t *testing.T) {
+	// render draws a sparse series with a peak using the interpolation and
+	// returns the terminal.
+	render := func(interpolation Interpolation) *faketerm.Terminal {
+		t.Helper()
+		lc, err := New(YAxisCustomScale(0, 4))
+		if err != nil {
+			t.Fatalf("New => unexpected error: %v", err)
+		}
+		if err := lc.Series("series", []float64{0, 4, 0}, SeriesInterpolation(interpolation)); err != nil {
+			t.Fatalf("Series => unexpected error: %v", err)
+		}
+
+		ft := faketerm.MustNew(image.Point{30, 10})
+		c, err := canvas.New(ft.Area())
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := lc.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+
+	linear := render(InterpolationLinear).BackBuffer()
+	for _, interpolation := range []Interpolation{InterpolationCatmullRom, InterpolationMonotone} {
+		t.Run(interpolation.String(), func(t *testing.T) {
+			smooth := render(interpolation).BackBuffer()
+
+			var extra int
+			for col := range smooth {
+				for row := range smooth[col] {
+					lr, sr := linear[col][row].Rune, smooth[col][row].Rune
+					if (lr == 0 || lr == ' ') && sr != 0 && sr != ' ' {
+						extra++
+					}
+				}
+			}
+			if extra == 0 {
+				t.Errorf("%v didn't plot any cells between the values that %v didn't plot", interpolation, InterpolationLinear)
+			}
+		})
+	}
+}
+
+func TestSeriesMovingAverageDraws(t *testing.T) {
+	values := []float64{0, 8, 0, 8, 0, 8, 0, 8}
+	avgOpts := []cell.Option{cell.FgColor(cell.ColorRed)}
+
+	// render draws the series and returns the terminal.
+	render := func(series func(lc *LineChart) error) *faketerm.Terminal {
+		t.Helper()
+		lc, err := New(YAxisCustomScale(0, 8))
+		if err != nil {
+			t.Fatalf("New => unexpected error: %v", err)
+		}
+		if err := series(lc); err != nil {
+			t.Fatalf("Series => unexpected error: %v", err)
+		}
+
+		ft := faketerm.MustNew(image.Point{30, 10})
+		c, err := canvas.New(ft.Area())
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := lc.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+
+	got := render(func(lc *LineChart) error {
+		return lc.Series("series", values, SeriesMovingAverage(2, avgOpts...))
+	})
+	// The overlay is drawn like a separate series plotted after the raw one.
+	want := render(func(lc *LineChart) error {
+		if err := lc.Series("series", values); err != nil {
+			return err
+		}
+		return lc.Series("series2", []float64{math.NaN(), 4, 4, 4, 4, 4, 4, 4},
+			SeriesCellOpts(append([]cell.Option{cell.FgColor(DefaultMovingAverageColor)}, avgOpts...)...),
+		)
+	})
+	if diff := faketerm.Diff(want, got); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+
+	raw := render(func(lc *LineChart) error {
+		return lc.Series("series", values)
+	})
+	if diff := faketerm.Diff(raw, got); diff == "" {
+		t.Errorf("Draw => the moving average overlay didn't plot anything over the series")
+	}
+}
+
+func TestSeriesMarkers(t *testing.T) {
+	lc, err := New(YAxisCustomScale(0, 4))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := lc.Series("filled", []float64{0, 4}, SeriesMarkers(MarkerCircleFilled)); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	if err := lc.Series("hollow", []float64{4, 0}, SeriesMarkers(MarkerCircleHollow), SeriesCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	if err := lc.Series("plain", []float64{2, 2}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+
+	ft := faketerm.MustNew(image.Point{20, 10})
+	c, err := canvas.New(ft.Area())
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := lc.Draw(c, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if err := c.Apply(ft); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+
+	// markers returns the cells with the rune ordered by their column.
+	markers := func(r rune) []image.Point {
+		var res []image.Point
+		buf := ft.BackBuffer()
+		for x := range buf {
+			for y := range buf[x] {
+				if buf[x][y].Rune == r {
+					res = append(res, image.Point{x, y})
+				}
+			}
+		}
+		return res
+	}
+	filled, hollow := markers('●'), markers('○')
+	if len(filled) != 2 || len(hollow) != 2 {
+		t.Fatalf("got filled markers at %v and hollow markers at %v, want two of each", filled, hollow)
+	}
+	// The series start and end in the same columns on opposite sides.
+	for i := range filled {
+		if filled[i].X != hollow[i].X {
+			t.Errorf("filled marker %v and hollow marker %v aren't in the same column", filled[i], hollow[i])
+		}
+	}
+	if !(filled[0].Y > hollow[0].Y && filled[1].Y < hollow[1].Y) {
+		t.Errorf("got filled markers at %v and hollow markers at %v, want the filled series rising and the hollow falling", filled, hollow)
+	}
+
+	buf := ft.BackBuffer()
+	for _, p := range hollow {
+		if got := buf[p.X][p.Y].Opts.FgColor; got != cell.ColorRed {
+			t.Errorf("hollow marker at %v has color %v, want the color of the series %v", p, got, cell.ColorRed)
+		}
+	}
+	if got := len(markers('■')) + len(markers('□')); got != 0 {
+		t.Errorf("got %d square markers, want none", got)
+	}
+}
+
+func TestDrawsWithoutBraille(t *testing.T) {
+	// render draws a rising series and returns the terminal.
+	render := func() *faketerm.Terminal {
+		t.Helper()
+		lc, err := New(YAxisCustomScale(0, 10))
+		if err != nil {
+			t.Fatalf("New => unexpected error: %v", err)
+		}
+		if err := lc.Series("series", []float64{0, 3, 7, 10}); err != nil {
+			t.Fatalf("Series => unexpected error: %v", err)
+		}
+
+		ft := faketerm.MustNew(image.Point{30, 10})
+		c, err := canvas.New(ft.Area())
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := lc.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+
+	isBraille := func(r rune) bool {
+		return r >= 0x2800 && r <= 0x28FF
+	}
+	isEmpty := func(r rune) bool {
+		return r == 0 || r == ' ' || r == 0x2800
+	}
+
+	withBraille := render().BackBuffer()
+	braille.SetEnabled(false)
+	defer braille.SetEnabled(true)
+	withBlocks := render().BackBuffer()
+
+	var plotted int
+	for col := range withBlocks {
+		for row := range withBlocks[col] {
+			br, bl := withBraille[col][row].Rune, withBlocks[col][row].Rune
+			if isBraille(bl) && !isEmpty(bl) {
+				t.Errorf("cell(%d, %d) => %q, want a block element when braille is disabled", col, row, bl)
+			}
+			if isBraille(br) {
+				if isEmpty(br) != isEmpty(bl) {
+					t.Errorf("cell(%d, %d) => %q, want it to be empty only if the braille cell %q is empty", col, row, bl, br)
+				}
+				if !isEmpty(bl) {
+					plotted++
+				}
+				continue
+			}
+			if br != bl {
+				t.Errorf("cell(%d, %d) => %q, want %q as drawn with braille", col, row, bl, br)
+			}
+		}
+	}
+	if plotted == 0 {
+		t.Errorf("no cells of the line were plotted with braille disabled")
+	}
+}
+
 func TestKeyboard(t *testing.T) {
 	lc, err := New()
 	if err != nil {
@@ -1712,6 +2539,121 @@ func TestKeyboard(t *testing.T) {
 	}
 }
 
+func TestKeyboardSelection(t *testing.T) {
+	tests := []struct {
+		desc         string
+		values       []float64
+		keys         []keyboard.Key
+		wantSelected int
+		wantOk       bool
+	}{
+		{
+			desc:   "nothing selected initially",
+			values: []float64{1, 2, 3},
+		},
+		{
+			desc:         "right selects the first data point",
+			values:       []float64{1, 2, 3},
+			keys:         []keyboard.Key{keyboard.KeyArrowRight},
+			wantSelected: 0,
+			wantOk:       true,
+		},
+		{
+			desc:         "left selects the last data point",
+			values:       []float64{1, 2, 3},
+			keys:         []keyboard.Key{keyboard.KeyArrowLeft},
+			wantSelected: 2,
+			wantOk:       true,
+		},
+		{
+			desc:         "right advances the selected index",
+			values:       []float64{1, 2, 3},
+			keys:         []keyboard.Key{keyboard.KeyArrowRight, keyboard.KeyArrowRight},
+			wantSelected: 1,
+			wantOk:       true,
+		},
+		{
+			desc:         "left moves the selected index back",
+			values:       []float64{1, 2, 3},
+			keys:         []keyboard.Key{keyboard.KeyArrowLeft, keyboard.KeyArrowLeft},
+			wantSelected: 1,
+			wantOk:       true,
+		},
+		{
+			desc:   "selection clamps at the end",
+			values: []float64{1, 2, 3},
+			keys: []keyboard.Key{
+				keyboard.KeyArrowRight, keyboard.KeyArrowRight,
+				keyboard.KeyArrowRight, keyboard.KeyArrowRight,
+			},
+			wantSelected: 2,
+			wantOk:       true,
+		},
+		{
+			desc:   "selection clamps at the start",
+			values: []float64{1, 2, 3},
+			keys: []keyboard.Key{
+				keyboard.KeyArrowRight, keyboard.KeyArrowLeft,
+				keyboard.KeyArrowLeft,
+			},
+			wantSelected: 0,
+			wantOk:       true,
+		},
+		{
+			desc:   "other keys are ignored",
+			values: []float64{1, 2, 3},
+			keys:   []keyboard.Key{keyboard.KeyEnter},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New(KeyboardSelection())
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := lc.Series("series", tc.values); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+			for _, k := range tc.keys {
+				if err := lc.Keyboard(&terminalapi.Keyboard{Key: k}); err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+			}
+
+			gotSelected, gotOk := lc.selectedIdx()
+			if gotSelected != tc.wantSelected || gotOk != tc.wantOk {
+				t.Errorf("selectedIdx => %v, %v, want %v, %v", gotSelected, gotOk, tc.wantSelected, tc.wantOk)
+			}
+		})
+	}
+}
+
+func TestKeyboardSelectionClampsToShorterSeries(t *testing.T) {
+	lc, err := New(KeyboardSelection())
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := lc.Series("series", []float64{1, 2, 3, 4}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	if err := lc.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if err := lc.Series("series", []float64{1, 2}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	if got, _ := lc.selectedIdx(); got != 1 {
+		t.Errorf("selectedIdx => %v, want 1", got)
+	}
+	if err := lc.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if got, _ := lc.selectedIdx(); got != 0 {
+		t.Errorf("selectedIdx => %v, want 0", got)
+	}
+}
+
 func TestMouseDoesNothingWithoutZoomTracker(t *testing.T) {
 	lc, err := New()
 	if err != nil {
@@ -1722,6 +2664,187 @@ func TestMouseDoesNothingWithoutZoomTracker(t *testing.T) {
 	}
 }
 
+func TestOnBrush(t *testing.T) {
+	tests := []struct {
+		desc string
+		// series are the values on the linechart.
+		series []float64
+		// events are the mouse events sent after the initial draw.
+		events    []*terminalapi.Mouse
+		fnErr     error
+		wantCalls int
+		wantStart float64
+		wantEnd   float64
+		wantErr   bool
+	}{
+		{
+			desc:   "isn't called while dragging",
+			series: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{7, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{10, 5}, Button: mouse.ButtonLeft},
+			},
+		},
+		{
+			desc:   "drag to the right computes the start and end values",
+			series: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{7, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{10, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{10, 5}, Button: mouse.ButtonRelease},
+			},
+			wantCalls: 1,
+			wantStart: 2,
+			wantEnd:   5,
+		},
+		{
+			desc:   "drag to the left computes the start and end values",
+			series: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{12, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 5}, Button: mouse.ButtonRelease},
+			},
+			wantCalls: 1,
+			wantStart: 3,
+			wantEnd:   6,
+		},
+		{
+			desc:   "doesn't zoom after brushing",
+			series: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{7, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{10, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{10, 5}, Button: mouse.ButtonRelease},
+				{Position: image.Point{5, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{19, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{19, 5}, Button: mouse.ButtonRelease},
+			},
+			wantCalls: 2,
+			wantStart: 0,
+			wantEnd:   13,
+		},
+		{
+			desc:   "returns the error from the callback",
+			series: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{7, 5}, Button: mouse.ButtonLeft},
+				{Position: image.Point{7, 5}, Button: mouse.ButtonRelease},
+			},
+			fnErr:     errors.New("callback error"),
+			wantCalls: 1,
+			wantStart: 2,
+			wantEnd:   2,
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var (
+				gotCalls         int
+				gotStart, gotEnd float64
+			)
+			lc, err := New(
+				OnBrush(func(xStart, xEnd float64) error {
+					gotCalls++
+					gotStart, gotEnd = xStart, xEnd
+					return tc.fnErr
+				}),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := lc.Series("first", tc.series); err != nil {
+				t.Fatalf("Series => unexpected error: %v", err)
+			}
+
+			// Draw once so zoom tracker is initialized.
+			cvs := testcanvas.MustNew(image.Rect(0, 0, 20, 10))
+			if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for i, ev := range tc.events {
+				err := lc.Mouse(ev)
+				// Only the last event in test cases causes the error.
+				if i == len(tc.events)-1 {
+					if (err != nil) != tc.wantErr {
+						t.Errorf("Mouse => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+					}
+				} else if err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+
+			if gotCalls != tc.wantCalls || gotStart != tc.wantStart || gotEnd != tc.wantEnd {
+				t.Errorf("OnBrush called %d times, last with (%v, %v), want %d times, last with (%v, %v)", gotCalls, gotStart, gotEnd, tc.wantCalls, tc.wantStart, tc.wantEnd)
+			}
+		})
+	}
+}
+
+func TestZoomResetKey(t *testing.T) {
+	series := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}
+	// draw draws the linechart and returns the result.
+	draw := func(lc *LineChart) *faketerm.Terminal {
+		t.Helper()
+		ft := faketerm.MustNew(image.Point{20, 10})
+		cvs := testcanvas.MustNew(ft.Area())
+		if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		testcanvas.MustApply(cvs, ft)
+		return ft
+	}
+
+	lc, err := New(ZoomResetKey(keyboard.KeyHome))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := lc.Series("first", series); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	full := draw(lc)
+	wantMin, wantMax := lc.zoom.Zoom().Scale.Min.Value, lc.zoom.Zoom().Scale.Max.Value
+
+	// Narrow the view by selecting a range with the mouse.
+	for _, ev := range []*terminalapi.Mouse{
+		{Position: image.Point{7, 5}, Button: mouse.ButtonLeft},
+		{Position: image.Point{12, 5}, Button: mouse.ButtonLeft},
+		{Position: image.Point{12, 5}, Button: mouse.ButtonRelease},
+	} {
+		if err := lc.Mouse(ev); err != nil {
+			t.Fatalf("Mouse => unexpected error: %v", err)
+		}
+	}
+	zoomed := draw(lc)
+	if diff := faketerm.Diff(full, zoomed); diff == "" {
+		t.Fatalf("Draw => the chart isn't zoomed after selecting a range")
+	}
+	if gotMin, gotMax := lc.zoom.Zoom().Scale.Min.Value, lc.zoom.Zoom().Scale.Max.Value; gotMin == wantMin && gotMax == wantMax {
+		t.Fatalf("Zoom => X range %v..%v isn't narrowed", gotMin, gotMax)
+	}
+
+	// Other keys don't reset the zoom.
+	if err := lc.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnd}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(zoomed, draw(lc)); diff != "" {
+		t.Errorf("Draw after another key => %v", diff)
+	}
+
+	if err := lc.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyHome}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(full, draw(lc)); diff != "" {
+		t.Errorf("Draw after the reset => %v", diff)
+	}
+	if gotMin, gotMax := lc.zoom.Zoom().Scale.Min.Value, lc.zoom.Zoom().Scale.Max.Value; gotMin != wantMin || gotMax != wantMax {
+		t.Errorf("Zoom => X range %v..%v after the reset, want %v..%v", gotMin, gotMax, wantMin, wantMax)
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1783,6 +2906,28 @@ func TestOptions(t *testing.T) {
 				WantMouse:   widgetapi.MouseScopeGlobal,
 			},
 		},
+		{
+			desc: "wants keyboard events when focused with KeyboardSelection",
+			opts: []Option{
+				KeyboardSelection(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 4},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeGlobal,
+			},
+		},
+		{
+			desc: "wants keyboard events when focused with ZoomResetKey",
+			opts: []Option{
+				ZoomResetKey(keyboard.KeyHome),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 4},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeGlobal,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,115 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// markers.go contains code that draws the markers at the values of a series.
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/braille"
+	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
+)
+
+// MarkerStyle is the glyph drawn at the values of a series.
+// The shapes come in pairs of a filled and a hollow variant, which helps
+// distinguishing categories of series.
+type MarkerStyle int
+
+// String implements fmt.Stringer()
+func (ms MarkerStyle) String() string {
+	if n, ok := markerStyleNames[ms]; ok {
+		return n
+	}
+	return "MarkerStyleUnknown"
+}
+
+// markerStyleNames maps MarkerStyle values to human readable names.
+var markerStyleNames = map[MarkerStyle]string{
+	MarkerNone:         "MarkerNone",
+	MarkerCircleFilled: "MarkerCircleFilled",
+	MarkerCircleHollow: "MarkerCircleHollow",
+	MarkerSquareFilled: "MarkerSquareFilled",
+	MarkerSquareHollow: "MarkerSquareHollow",
+}
+
+const (
+	// MarkerNone indicates that no markers are drawn.
+	MarkerNone MarkerStyle = iota
+
+	// MarkerCircleFilled draws the values as '●'.
+	MarkerCircleFilled
+
+	// MarkerCircleHollow draws the values as '○'.
+	MarkerCircleHollow
+
+	// MarkerSquareFilled draws the values as '■'.
+	MarkerSquareFilled
+
+	// MarkerSquareHollow draws the values as '□'.
+	MarkerSquareHollow
+)
+
+// markerRunes maps the marker styles to their glyphs.
+var markerRunes = map[MarkerStyle]rune{
+	MarkerCircleFilled: '●',
+	MarkerCircleHollow: '○',
+	MarkerSquareFilled: '■',
+	MarkerSquareHollow: '□',
+}
+
+// drawMarkers draws the markers of the series into the cells of the graph
+// area that contain their plotted values, see SeriesMarkers.
+func (lc *LineChart) drawMarkers(cvs *canvas.Canvas, graphAr image.Rectangle, xdZoomed *axes.XDetails, yd *axes.YDetails, name string, sv *seriesValues) error {
+	r, ok := markerRunes[sv.markers]
+	if !ok {
+		return nil
+	}
+
+	plotted, err := sv.plotted(xdZoomed.Scale)
+	if err != nil {
+		return fmt.Errorf("failure for series %v on scale %v, plotted => %v", name, xdZoomed.Scale, err)
+	}
+	for _, i := range plotted {
+		v := sv.values[i]
+		if math.IsNaN(v) {
+			continue
+		}
+		if r := lc.opts.yAxisRange; r != nil {
+			v = clip(v, r.min, r.max)
+		}
+
+		x, err := xdZoomed.Scale.FloatValueToPixel(sv.x(i))
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d] on scale %v, xdZoomed.Scale.FloatValueToPixel(%v) => %v", name, i, xdZoomed.Scale, sv.x(i), err)
+		}
+		y, err := yd.Scale.ValueToPixel(v)
+		if err != nil {
+			return fmt.Errorf("failure for series %v[%d] on scale %v, yd.Scale.ValueToPixel(%v) => %v", name, i, yd.Scale, v, err)
+		}
+
+		p := graphAr.Min.Add(image.Point{x / braille.ColMult, y / braille.RowMult})
+		if !p.In(graphAr) {
+			continue
+		}
+		if _, err := cvs.SetCell(p, r, sv.seriesCellOpts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -15,10 +15,12 @@
 package linechart
 
 import (
+	"errors"
 	"fmt"
 	"math"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
 	"github.com/mum4k/termdash/widgets/linechart/internal/zoom"
 )
@@ -40,8 +42,17 @@ type options struct {
 	xAxisUnscaled       bool
 	yAxisMode           axes.YScaleMode
 	yAxisCustomScale    *customScale
+	yAxisRange          *customScale
 	zoomHightlightColor cell.Color
 	zoomStepPercent     int
+	onBrush             BrushFn
+	zoomReset           bool
+	zoomResetKey        keyboard.Key
+	keyboardSelection   bool
+	selectionColor      cell.Color
+	zeroLine            bool
+	zeroLineCellOpts    []cell.Option
+	vLines              []*vLine
 }
 
 // validate validates the provided options.
@@ -54,6 +65,28 @@ func (o *options) validate() error {
 			return fmt.Errorf("the min(%v) must be less than the max(%v) provided as custom Y scale", o.yAxisCustomScale.min, o.yAxisCustomScale.max)
 		}
 	}
+	if o.yAxisRange != nil {
+		if o.yAxisCustomScale != nil {
+			return errors.New("the YAxisCustomScale and the YAxisRange options cannot be provided at the same time")
+		}
+		if math.IsNaN(o.yAxisRange.min) || math.IsNaN(o.yAxisRange.max) {
+			return fmt.Errorf("both the min(%v) and the max(%v) provided as Y axis range must be valid numbers", o.yAxisRange.min, o.yAxisRange.max)
+		}
+		if o.yAxisRange.min >= o.yAxisRange.max {
+			return fmt.Errorf("the min(%v) must be less than the max(%v) provided as Y axis range", o.yAxisRange.min, o.yAxisRange.max)
+		}
+	}
+	for _, vl := range o.vLines {
+		if math.IsNaN(vl.x) || math.IsInf(vl.x, 0) || vl.x < 0 {
+			return fmt.Errorf("invalid VLine position %v, must be a zero or a positive number", vl.x)
+		}
+	}
+	if o.zoomReset && o.keyboardSelection {
+		switch o.zoomResetKey {
+		case keyboard.KeyArrowLeft, keyboard.KeyArrowRight:
+			return fmt.Errorf("invalid ZoomResetKey(%v), the key is used by the KeyboardSelection option", o.zoomResetKey)
+		}
+	}
 	if got, min, max := o.zoomStepPercent, 1, 100; got < min || got > max {
 		return fmt.Errorf("invalid ZoomStepPercent %d, must be in range %d <= value <= %d", got, min, max)
 	}
@@ -65,6 +98,7 @@ func newOptions(opts ...Option) *options {
 	opt := &options{
 		zoomHightlightColor: cell.ColorNumber(235),
 		zoomStepPercent:     zoom.DefaultScrollStep,
+		selectionColor:      cell.ColorNumber(238),
 	}
 	for _, o := range opts {
 		o.set(opt)
@@ -159,6 +193,25 @@ func YAxisCustomScale(min, max float64) Option {
 	})
 }
 
+// YAxisRange when provided, pins the Y axis to the specified minimum and
+// maximum value. Unlike YAxisCustomScale, the LineChart never rescales the Y
+// axis. Values outside of the range are clipped, i.e. values smaller than the
+// minimum are drawn on the bottom edge and values larger than the maximum on
+// the top edge of the graph.
+// Both the minimum and the maximum must be valid numbers and the minimum must
+// be smaller than the maximum. Cannot be combined with YAxisCustomScale.
+//
+// Providing this option also sets YAxisAdaptive.
+func YAxisRange(min, max float64) Option {
+	return option(func(opts *options) {
+		opts.yAxisRange = &customScale{
+			min: min,
+			max: max,
+		}
+		opts.yAxisMode = axes.YScaleModeAdaptive
+	})
+}
+
 // XAxisUnscaled when provided, stops the LineChart from rescaling the X axis
 // when it can't fit all the values in the series, instead the LineCharts only
 // displays the last n values that fit into its width. This is useful to create
@@ -194,3 +247,122 @@ func ZoomStepPercent(perc int) Option {
 		opts.zoomStepPercent = perc
 	})
 }
+
+// ZoomResetKey enables resetting the zoom with the keyboard. Pressing the
+// provided key while the LineChart is focused zooms it back out to fit all the
+// data on the X axis, undoing any zooming with the mouse. The key cannot be
+// one of the keys used by the KeyboardSelection option.
+func ZoomResetKey(k keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.zoomReset = true
+		opts.zoomResetKey = k
+	})
+}
+
+// BrushFn is a function called when the user selects a range of values on the
+// X axis by dragging the mouse.
+// The arguments are the values on the X axis at the start and the end of the
+// selected range.
+// The function must be thread-safe, since it is called from the goroutine that
+// processes mouse events. Errors returned by the function are reported by
+// termdash.
+type BrushFn func(xStart, xEnd float64) error
+
+// OnBrush changes the behavior of selecting an area on the graph with mouse
+// (left mouse clicking and dragging). Instead of zooming the linechart, the
+// selection calls the provided function once the mouse button is released.
+// The selected columns are highlighted while dragging as if zooming, see
+// ZoomHightlightColor. Zooming with the mouse scroll button isn't affected.
+func OnBrush(fn BrushFn) Option {
+	return option(func(opts *options) {
+		opts.onBrush = fn
+	})
+}
+
+// KeyboardSelection enables keyboard navigation of the data points. While the
+// LineChart is focused, the left and right arrow keys move a selection cursor
+// between the data points on the X axis. The first key press selects the first
+// or the last data point respectively and the selection stops at both ends of
+// the X axis. The selected column is highlighted, see SelectionColor, and the
+// values of all the series at the selected point are displayed in a readout
+// in the top right corner of the graph.
+func KeyboardSelection() Option {
+	return option(func(opts *options) {
+		opts.keyboardSelection = true
+	})
+}
+
+// SelectionColor sets the background color of the column with the data point
+// selected with the keyboard, see KeyboardSelection.
+// Defaults to color number 238.
+func SelectionColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.selectionColor = c
+	})
+}
+
+// ZeroLine draws a horizontal line across the graph at the zero value of the
+// Y axis, emphasizing where the series cross zero. The line is only drawn
+// when the Y axis spans both negative and positive values, otherwise zero is
+// either outside of the graph or on the X axis itself. The provided cell
+// options style the line, e.g. its color. The series are drawn over the line.
+func ZeroLine(co ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.zeroLine = true
+		opts.zeroLineCellOpts = co
+	})
+}
+
+// vLine is a vertical line provided via the VLine option.
+type vLine struct {
+	// x is the position of the line on the X axis.
+	x        float64
+	label    string
+	cellOpts []cell.Option
+}
+
+// VLineOption is used to provide options to VLine.
+type VLineOption interface {
+	// set sets the provided option.
+	set(*vLine)
+}
+
+// vLineOption implements VLineOption.
+type vLineOption func(*vLine)
+
+// set implements VLineOption.set.
+func (vlo vLineOption) set(vl *vLine) {
+	vlo(vl)
+}
+
+// VLineLabel sets a label that is displayed at the top of the vertical line.
+func VLineLabel(label string) VLineOption {
+	return vLineOption(func(vl *vLine) {
+		vl.label = label
+	})
+}
+
+// VLineCellOpts sets the cell options of the vertical line and its label.
+func VLineCellOpts(co ...cell.Option) VLineOption {
+	return vLineOption(func(vl *vLine) {
+		vl.cellOpts = co
+	})
+}
+
+// VLine draws a vertical line across the graph at the specified position on
+// the X axis, e.g. to mark the current time on a chart that displays both
+// historical and predicted values. The position is scaled the same way as
+// the values of the series, i.e. it is the index of a value provided to
+// Series or an X coordinate provided to SeriesXY. The position must be zero
+// or a positive number. The line isn't drawn when the position falls outside
+// of the X axis. The series are drawn over the line.
+// This option can be provided multiple times to draw multiple lines.
+func VLine(x float64, opts ...VLineOption) Option {
+	return option(func(o *options) {
+		vl := &vLine{x: x}
+		for _, opt := range opts {
+			opt.set(vl)
+		}
+		o.vLines = append(o.vLines, vl)
+	})
+}
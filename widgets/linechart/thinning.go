@@ -0,0 +1,101 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+// thinning.go contains code that selects the values of a series that are
+// plotted when the series has more values than the graph has pixels.
+
+import (
+	"math"
+
+	"github.com/mum4k/termdash/internal/canvas/braille"
+	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
+)
+
+// plotted returns the indexes of the values of the series that fall within
+// the X scale and get plotted, in an increasing order.
+//
+// When there are more such values than columns of pixels in the graph, only
+// the smallest and the largest value in each column are kept. These are the
+// values that determine the shape of the line in the column. The first and
+// the last value are always kept and so are the missing values next to the
+// present ones, so that the gaps in the line remain.
+func (sv *seriesValues) plotted(scale *axes.XScale) ([]int, error) {
+	var idx []int
+	for i := range sv.values {
+		if x := sv.x(i); x >= scale.Min.Value && x <= scale.Max.Value {
+			idx = append(idx, i)
+		}
+	}
+	if sv.plotAll || len(idx) <= scale.GraphWidth*braille.ColMult {
+		return idx, nil
+	}
+
+	var kept []int
+	col, lo, hi := -1, -1, -1
+	// flush keeps the smallest and the largest value of the current column.
+	flush := func() {
+		if lo < 0 {
+			return
+		}
+		first, last := lo, hi
+		if first > last {
+			first, last = last, first
+		}
+		kept = append(kept, first)
+		if last != first {
+			kept = append(kept, last)
+		}
+		col, lo, hi = -1, -1, -1
+	}
+
+	for k, i := range idx {
+		v := sv.values[i]
+		if math.IsNaN(v) {
+			flush()
+			prevPresent := k > 0 && !math.IsNaN(sv.values[idx[k-1]])
+			nextPresent := k < len(idx)-1 && !math.IsNaN(sv.values[idx[k+1]])
+			if prevPresent || nextPresent {
+				kept = append(kept, i)
+			}
+			continue
+		}
+
+		x, err := scale.FloatValueToPixel(sv.x(i))
+		if err != nil {
+			return nil, err
+		}
+		if x != col {
+			flush()
+			col, lo, hi = x, i, i
+			continue
+		}
+		if v < sv.values[lo] {
+			lo = i
+		}
+		if v > sv.values[hi] {
+			hi = i
+		}
+	}
+	flush()
+
+	if first := idx[0]; len(kept) == 0 || kept[0] != first {
+		kept = append([]int{first}, kept...)
+	}
+	if last := idx[len(idx)-1]; kept[len(kept)-1] != last {
+		kept = append(kept, last)
+	}
+	return kept, nil
+}
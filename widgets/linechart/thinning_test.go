@@ -0,0 +1,200 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
+)
+
+// noisy returns n values that oscillate around a slowly rising trend.
+func noisy(n int) []float64 {
+	var values []float64
+	for i := 0; i < n; i++ {
+		values = append(values, float64(i)/10+math.Sin(float64(i))*5)
+	}
+	return values
+}
+
+func TestPlotted(t *testing.T) {
+	tests := []struct {
+		desc   string
+		values []float64
+		opts   []SeriesOption
+		// min and max are the values on the X scale.
+		min, max int
+		// graphWidth is the width of the graph in cells, each cell has two
+		// columns of pixels.
+		graphWidth int
+		// wantMax is the maximum number of plotted values.
+		wantMax int
+		// want are the expected indexes, only compared if not nil.
+		want []int
+	}{
+		{
+			desc:       "plots all values that fit",
+			values:     []float64{0, 1, 2, 3},
+			max:        3,
+			graphWidth: 2,
+			wantMax:    4,
+			want:       []int{0, 1, 2, 3},
+		},
+		{
+			desc:       "plots only the values within the scale",
+			values:     []float64{0, 1, 2, 3, 4, 5},
+			min:        2,
+			max:        4,
+			graphWidth: 2,
+			wantMax:    3,
+			want:       []int{2, 3, 4},
+		},
+		{
+			desc:       "1000 values in 50 columns of pixels",
+			values:     noisy(1000),
+			max:        999,
+			graphWidth: 25,
+			wantMax:    2*50 + 2,
+		},
+		{
+			desc:       "SeriesPlotAllValues disables the thinning",
+			values:     noisy(1000),
+			opts:       []SeriesOption{SeriesPlotAllValues()},
+			max:        999,
+			graphWidth: 25,
+			wantMax:    1000,
+		},
+		{
+			desc:       "keeps the smallest and the largest value in each column",
+			values:     []float64{5, 1, 9, 4, 4, 2, 8, 3},
+			max:        7,
+			graphWidth: 1,
+			wantMax:    8,
+			// Columns of pixels: {0, 1, 2, 3}, {4, 5, 6, 7}.
+			want: []int{0, 1, 2, 5, 6, 7},
+		},
+		{
+			desc:       "keeps the missing values next to the present ones",
+			values:     []float64{5, 1, math.NaN(), math.NaN(), math.NaN(), 2, 8, 3},
+			max:        7,
+			graphWidth: 1,
+			wantMax:    8,
+			want:       []int{0, 1, 2, 4, 5, 6, 7},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			scale, err := axes.NewXScale(tc.min, tc.max, tc.graphWidth, 2)
+			if err != nil {
+				t.Fatalf("NewXScale => unexpected error: %v", err)
+			}
+			sv := newSeriesValues(tc.values)
+			for _, opt := range tc.opts {
+				opt.set(sv)
+			}
+
+			got, err := sv.plotted(scale)
+			if err != nil {
+				t.Fatalf("plotted => unexpected error: %v", err)
+			}
+
+			if len(got) > tc.wantMax {
+				t.Errorf("plotted => %d values, want at most %d", len(got), tc.wantMax)
+			}
+			if first, last := tc.min, tc.max; got[0] != first || got[len(got)-1] != last {
+				t.Errorf("plotted => starts at %d and ends at %d, want %d and %d", got[0], got[len(got)-1], first, last)
+			}
+			for k := 1; k < len(got); k++ {
+				if got[k] <= got[k-1] {
+					t.Errorf("plotted => %v, the indexes must be increasing", got)
+					break
+				}
+			}
+			minI, maxI := tc.min, tc.min
+			for i := tc.min; i <= tc.max; i++ {
+				if tc.values[i] < tc.values[minI] {
+					minI = i
+				}
+				if tc.values[i] > tc.values[maxI] {
+					maxI = i
+				}
+			}
+			if !containsIdx(got, minI) || !containsIdx(got, maxI) {
+				t.Errorf("plotted => %v, want it to contain the smallest value at %d and the largest at %d", got, minI, maxI)
+			}
+
+			if tc.want != nil {
+				if diff := pretty.Compare(tc.want, got); diff != "" {
+					t.Errorf("plotted => unexpected diff (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+// containsIdx asserts whether the index is in the slice.
+func containsIdx(idx []int, i int) bool {
+	for _, got := range idx {
+		if got == i {
+			return true
+		}
+	}
+	return false
+}
+
+func TestThinnedRampDrawsLikeAllValues(t *testing.T) {
+	var ramp []float64
+	for i := 0; i < 1000; i++ {
+		ramp = append(ramp, float64(i))
+	}
+
+	// render draws the ramp and returns the terminal.
+	render := func(opts ...SeriesOption) *faketerm.Terminal {
+		t.Helper()
+		lc, err := New()
+		if err != nil {
+			t.Fatalf("New => unexpected error: %v", err)
+		}
+		if err := lc.Series("ramp", ramp, opts...); err != nil {
+			t.Fatalf("Series => unexpected error: %v", err)
+		}
+
+		ft := faketerm.MustNew(image.Point{50, 10})
+		c, err := canvas.New(ft.Area())
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := lc.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+
+	all := render(SeriesPlotAllValues())
+	thinned := render()
+	if diff := faketerm.Diff(all, thinned); diff != "" {
+		t.Errorf("thinned series drawn differently than with SeriesPlotAllValues:\n%s", diff)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package livebadge implements a small indicator that shows whether the data
+// of a dashboard are being updated.
+package livebadge
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/area"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// LiveBadge displays a dot that toggles each time Update is called, followed
+// by an optional label.
+//
+// The dot toggles at most once per the duration specified by the MinRefresh
+// option. Once no update arrives for the duration specified by the IdleAfter
+// option, the badge dims to the IdleColor. The badge is also dimmed until the
+// first update.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type LiveBadge struct {
+	// mu protects the widget.
+	mu sync.Mutex
+
+	// on indicates whether the dot is currently displayed.
+	on bool
+
+	// updated indicates whether Update was called at least once.
+	updated bool
+
+	// lastUpdate is the time of the last call to Update.
+	lastUpdate time.Time
+
+	// lastToggle is the time the dot was last toggled.
+	lastToggle time.Time
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new LiveBadge.
+func New(opts ...Option) (*LiveBadge, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &LiveBadge{
+		opts: opt,
+	}, nil
+}
+
+// Vars to be replaced from tests.
+var (
+	// timeNow returns the current time.
+	timeNow = time.Now
+)
+
+// Update signals that new data arrived. Call this from the data path of the
+// dashboard. Toggles the dot unless it was already toggled within the
+// duration specified by the MinRefresh option.
+func (lb *LiveBadge) Update() {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	now := timeNow()
+	if !lb.updated || now.Sub(lb.lastToggle) >= lb.opts.minRefresh {
+		lb.on = !lb.on
+		lb.lastToggle = now
+	}
+	lb.updated = true
+	lb.lastUpdate = now
+}
+
+// idle determines if the badge should be dimmed.
+func (lb *LiveBadge) idle() bool {
+	return !lb.updated || timeNow().Sub(lb.lastUpdate) >= lb.opts.idleAfter
+}
+
+// Draw draws the LiveBadge widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (lb *LiveBadge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	needAr, err := area.FromSize(lb.minSize())
+	if err != nil {
+		return err
+	}
+	if !needAr.In(cvs.Area()) {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	color := lb.opts.activeColor
+	idle := lb.idle()
+	if idle {
+		color = lb.opts.idleColor
+	}
+
+	start := cvs.Area().Min
+	if idle || lb.on {
+		if _, err := cvs.SetCell(start, lb.opts.dot, cell.FgColor(color)); err != nil {
+			return err
+		}
+	}
+
+	if lb.opts.label != "" {
+		labelStart := image.Point{start.X + 2, start.Y}
+		if err := draw.Text(cvs, lb.opts.label, labelStart, draw.TextCellOpts(cell.FgColor(color))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the LiveBadge widget.
+func (*LiveBadge) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the LiveBadge widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the LiveBadge widget.
+func (*LiveBadge) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the LiveBadge widget doesn't support mouse events")
+}
+
+// minSize determines the minimum required size of the canvas.
+func (lb *LiveBadge) minSize() image.Point {
+	width := 1 // The dot.
+	if lb.opts.label != "" {
+		// A space between the dot and the label.
+		width += 1 + runewidth.StringWidth(lb.opts.label)
+	}
+	return image.Point{width, 1}
+}
+
+// Options implements widgetapi.Widget.Options.
+func (lb *LiveBadge) Options() widgetapi.Options {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	return widgetapi.Options{
+		MinimumSize:  lb.minSize(),
+		MaximumSize:  lb.minSize(),
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livebadge
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// fakeClock is a clock that only moves when advanced.
+type fakeClock struct {
+	now time.Time
+}
+
+// advance moves the clock forward by the provided duration.
+func (fc *fakeClock) advance(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+// mustDrawBadge draws the dot when dot is true and the label in the provided
+// color.
+func mustDrawBadge(cvs *canvas.Canvas, dot bool, label string, color cell.Color) {
+	if dot {
+		testcanvas.MustSetCell(cvs, image.Point{0, 0}, DefaultDot, cell.FgColor(color))
+	}
+	if label != "" {
+		testdraw.MustText(cvs, label, image.Point{2, 0}, draw.TextCellOpts(cell.FgColor(color)))
+	}
+}
+
+func TestLiveBadge(t *testing.T) {
+	idleColor := cell.ColorNumber(DefaultIdleColorNumber)
+	tests := []struct {
+		desc string
+		opts []Option
+		// events are executed before the widget is drawn.
+		events  func(lb *LiveBadge, fc *fakeClock)
+		canvas  image.Rectangle
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:   "displays resize needed when the canvas is too small for the label",
+			opts:   []Option{Label("LIVE")},
+			canvas: image.Rect(0, 0, 5, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "dimmed before the first update",
+			opts:   []Option{Label("LIVE")},
+			canvas: image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawBadge(cvs, true, "LIVE", idleColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the first update turns the dot on",
+			opts: []Option{Label("LIVE")},
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+			},
+			canvas: image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawBadge(cvs, true, "LIVE", DefaultActiveColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the second update turns the dot off",
+			opts: []Option{Label("LIVE")},
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+				fc.advance(DefaultMinRefresh)
+				lb.Update()
+			},
+			canvas: image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawBadge(cvs, false, "LIVE", DefaultActiveColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the third update turns the dot back on",
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+				fc.advance(DefaultMinRefresh)
+				lb.Update()
+				fc.advance(DefaultMinRefresh)
+				lb.Update()
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawBadge(cvs, true, "", DefaultActiveColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "updates within MinRefresh don't toggle the dot",
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+				fc.advance(DefaultMinRefresh - time.Millisecond)
+				lb.Update()
+				fc.advance(time.Millisecond)
+				lb.Update()
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Only the first and the last update toggled the dot.
+				mustDrawBadge(cvs, false, "", DefaultActiveColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "zero MinRefresh toggles on every update",
+			opts: []Option{MinRefresh(0)},
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+				lb.Update()
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawBadge(cvs, false, "", DefaultActiveColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "stays active just before the idle interval",
+			opts: []Option{Label("LIVE")},
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+				fc.advance(DefaultIdleAfter - time.Millisecond)
+			},
+			canvas: image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawBadge(cvs, true, "LIVE", DefaultActiveColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "dims after the idle interval even when the dot was off",
+			opts: []Option{Label("LIVE")},
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+				fc.advance(DefaultMinRefresh)
+				lb.Update()
+				fc.advance(DefaultIdleAfter)
+			},
+			canvas: image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawBadge(cvs, true, "LIVE", idleColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "an update after idling reactivates the badge",
+			opts: []Option{
+				IdleAfter(time.Second),
+				IdleColor(cell.ColorBlue),
+			},
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+				fc.advance(2 * time.Second)
+				lb.Update()
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				mustDrawBadge(cvs, false, "", DefaultActiveColor)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom dot and colors",
+			opts: []Option{
+				Dot('*'),
+				ActiveColor(cell.ColorRed),
+				Label("on air"),
+			},
+			events: func(lb *LiveBadge, fc *fakeClock) {
+				lb.Update()
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(cvs, image.Point{0, 0}, '*', cell.FgColor(cell.ColorRed))
+				testdraw.MustText(cvs, "on air", image.Point{2, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			fc := &fakeClock{
+				now: time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC),
+			}
+			timeNow = func() time.Time {
+				return fc.now
+			}
+			defer func() {
+				timeNow = time.Now
+			}()
+
+			lb, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.events != nil {
+				tc.events(lb, fc)
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			err = lb.Draw(c, &widgetapi.Meta{})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Draw => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestNewFails(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+	}{
+		{
+			desc: "dot occupies more than one cell",
+			opts: []Option{Dot('世')},
+		},
+		{
+			desc: "label contains a control character",
+			opts: []Option{Label("a\tb")},
+		},
+		{
+			desc: "label contains a newline",
+			opts: []Option{Label("a\nb")},
+		},
+		{
+			desc: "negative MinRefresh",
+			opts: []Option{MinRefresh(-1)},
+		},
+		{
+			desc: "zero IdleAfter",
+			opts: []Option{IdleAfter(0)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := New(tc.opts...); err == nil {
+				t.Errorf("New => got nil err, wanted one")
+			}
+		})
+	}
+}
+
+func TestEventsNotSupported(t *testing.T) {
+	lb, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := lb.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+	if err := lb.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "only the dot",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				MaximumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc: "dot and label",
+			opts: []Option{Label("LIVE")},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{6, 1},
+				MaximumSize:  image.Point{6, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lb, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			got := lb.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary livebadgedemo shows the functionality of a live badge widget.
+package main
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/livebadge"
+)
+
+// feed simulates a data source that periodically stops sending updates, so
+// that the badge dims.
+func feed(ctx context.Context, lb *livebadge.LiveBadge) {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	paused := time.Time{}
+	for {
+		select {
+		case <-ticker.C:
+			if time.Now().Before(paused) {
+				continue
+			}
+			if rand.Intn(50) == 0 {
+				paused = time.Now().Add(5 * time.Second)
+				continue
+			}
+			lb.Update()
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lb, err := livebadge.New(
+		livebadge.Label("LIVE"),
+		livebadge.IdleAfter(2*time.Second),
+	)
+	if err != nil {
+		panic(err)
+	}
+	go feed(ctx, lb)
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(lb),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(100*time.Millisecond)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livebadge
+
+// options.go contains configurable options for LiveBadge.
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/internal/wrap"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	dot         rune
+	label       string
+	activeColor cell.Color
+	idleColor   cell.Color
+	minRefresh  time.Duration
+	idleAfter   time.Duration
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got := runewidth.RuneWidth(o.dot); got != 1 {
+		return fmt.Errorf("invalid Dot %q, must be a rune that occupies exactly one cell, this one occupies %d", o.dot, got)
+	}
+	if o.label != "" {
+		if err := wrap.ValidText(o.label); err != nil {
+			return fmt.Errorf("invalid Label %q: %v", o.label, err)
+		}
+		if strings.ContainsRune(o.label, '\n') {
+			return fmt.Errorf("invalid Label %q, cannot contain newline characters", o.label)
+		}
+	}
+	if o.minRefresh < 0 {
+		return fmt.Errorf("invalid MinRefresh %v, must be zero or a positive duration", o.minRefresh)
+	}
+	if o.idleAfter <= 0 {
+		return fmt.Errorf("invalid IdleAfter %v, must be a positive duration", o.idleAfter)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		dot:         DefaultDot,
+		activeColor: DefaultActiveColor,
+		idleColor:   cell.ColorNumber(DefaultIdleColorNumber),
+		minRefresh:  DefaultMinRefresh,
+		idleAfter:   DefaultIdleAfter,
+	}
+}
+
+// DefaultDot is the default value for the Dot option.
+const DefaultDot = '●'
+
+// Dot sets the rune that is toggled on each update. The rune must occupy
+// exactly one cell on the terminal.
+// Defaults to DefaultDot.
+func Dot(dot rune) Option {
+	return option(func(opts *options) {
+		opts.dot = dot
+	})
+}
+
+// Label sets a text displayed after the dot, e.g. "LIVE". The text must not
+// contain any control characters or newlines.
+// Defaults to no label.
+func Label(text string) Option {
+	return option(func(opts *options) {
+		opts.label = text
+	})
+}
+
+// DefaultActiveColor is the default value for the ActiveColor option.
+const DefaultActiveColor = cell.ColorGreen
+
+// ActiveColor sets the color of the dot and the label while updates are
+// arriving.
+// Defaults to DefaultActiveColor.
+func ActiveColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.activeColor = c
+	})
+}
+
+// DefaultIdleColorNumber is the default value for the IdleColor option.
+const DefaultIdleColorNumber = 240
+
+// IdleColor sets the color of the dot and the label once no update arrived
+// for the duration specified by the IdleAfter option.
+// Defaults to DefaultIdleColorNumber.
+func IdleColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.idleColor = c
+	})
+}
+
+// DefaultMinRefresh is the default value for the MinRefresh option.
+const DefaultMinRefresh = 500 * time.Millisecond
+
+// MinRefresh sets the minimum duration between two toggles of the dot.
+// Updates that arrive sooner after the last toggle keep the badge active but
+// don't toggle the dot, so that the badge doesn't flicker on data sources
+// that update very frequently. A zero duration toggles the dot on every
+// update.
+// Defaults to DefaultMinRefresh.
+func MinRefresh(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.minRefresh = d
+	})
+}
+
+// DefaultIdleAfter is the default value for the IdleAfter option.
+const DefaultIdleAfter = 5 * time.Second
+
+// IdleAfter sets the duration without any updates after which the badge dims
+// to the IdleColor. Must be a positive duration.
+// Defaults to DefaultIdleAfter.
+func IdleAfter(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.idleAfter = d
+	})
+}
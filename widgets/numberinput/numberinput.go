@@ -0,0 +1,332 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package numberinput implements a widget that displays a number and allows
+// the user to change it using arrows or by typing in a new value.
+package numberinput
+
+import (
+	"image"
+	"strconv"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// NumberInput displays an integer value followed by an up and a down arrow.
+//
+// The arrow up and arrow down keys or clicking the arrows with the left mouse
+// button increment or decrement the value by the configured step. Typing
+// digits starts entering a new value which replaces the current one when the
+// user presses Enter. Pressing Esc discards the typed value.
+//
+// The arrows aren't drawn if the terminal doesn't support the mouse, the value
+// occupies the entire width of the widget instead.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type NumberInput struct {
+	// mu protects the widget.
+	mu sync.Mutex
+
+	// value is the current value.
+	value int
+
+	// editing indicates that the user is typing in a new value.
+	editing bool
+	// edit is the value typed in by the user so far.
+	edit string
+
+	// upAr and downAr are the areas of the up and the down arrow on the
+	// canvas as of the last call to Draw.
+	upAr   image.Rectangle
+	downAr image.Rectangle
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new NumberInput.
+func New(opts ...Option) (*NumberInput, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+
+	ni := &NumberInput{
+		opts: opt,
+	}
+	ni.value = ni.clamp(opt.initialValue)
+	return ni, nil
+}
+
+// Value returns the current value.
+func (ni *NumberInput) Value() int {
+	ni.mu.Lock()
+	defer ni.mu.Unlock()
+
+	return ni.value
+}
+
+// SetValue sets the value, clamping it to the configured bounds. Discards any
+// value the user is currently typing in. Doesn't call the OnChange callback.
+func (ni *NumberInput) SetValue(v int) {
+	ni.mu.Lock()
+	defer ni.mu.Unlock()
+
+	ni.value = ni.clamp(v)
+	ni.editing = false
+	ni.edit = ""
+}
+
+// clamp returns the value clamped to the configured bounds.
+func (ni *NumberInput) clamp(v int) int {
+	if !ni.opts.bounded {
+		return v
+	}
+	switch {
+	case v < ni.opts.minValue:
+		return ni.opts.minValue
+	case v > ni.opts.maxValue:
+		return ni.opts.maxValue
+	default:
+		return v
+	}
+}
+
+const (
+	// maxInt is the largest value of the int type.
+	maxInt = int(^uint(0) >> 1)
+	// minInt is the smallest value of the int type.
+	minInt = -maxInt - 1
+)
+
+// add returns the sum of the two values, saturating at the limits of the int
+// type instead of overflowing.
+func add(v, d int) int {
+	switch {
+	case d > 0 && v > maxInt-d:
+		return maxInt
+	case d < 0 && v < minInt-d:
+		return minInt
+	default:
+		return v + d
+	}
+}
+
+// commit replaces the value with the one typed in by the user. The typed
+// value is discarded if it isn't a valid number.
+func (ni *NumberInput) commit() {
+	if !ni.editing {
+		return
+	}
+	if v, err := strconv.Atoi(ni.edit); err == nil {
+		ni.value = ni.clamp(v)
+	}
+	ni.editing = false
+	ni.edit = ""
+}
+
+// typeRune appends the rune to the value the user is typing in. Runes that would
+// result in an invalid number are ignored.
+func (ni *NumberInput) typeRune(r rune) {
+	switch {
+	case r == '-' && (!ni.editing || ni.edit == ""):
+	case r >= '0' && r <= '9':
+		if _, err := strconv.Atoi(ni.edit + string(r)); err != nil {
+			return // Out of range for the int type.
+		}
+	default:
+		return
+	}
+	ni.editing = true
+	ni.edit += string(r)
+}
+
+// step increments or decrements the value by the configured step.
+func (ni *NumberInput) step(up bool) {
+	ni.commit()
+	d := ni.opts.step
+	if !up {
+		d = -d
+	}
+	ni.value = ni.clamp(add(ni.value, d))
+}
+
+// changed returns a bool indicating if the value changed since before and
+// whether the callback should be called.
+func (ni *NumberInput) changed(before int) bool {
+	return ni.value != before && ni.opts.onChange != nil
+}
+
+const (
+	// minWidth is the minimum width in cells, one cell for the value, a
+	// space and the two arrows.
+	minWidth = 4
+
+	// height is the height of the widget in cells.
+	height = 1
+
+	// arrowsWidth is the width of the space and the arrows following the
+	// value.
+	arrowsWidth = 3
+)
+
+// Draw draws the NumberInput widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (ni *NumberInput) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	ni.mu.Lock()
+	defer ni.mu.Unlock()
+
+	ar := cvs.Area()
+	if size := cvs.Size(); size.X < minWidth || size.Y < height {
+		ni.upAr = image.ZR
+		ni.downAr = image.ZR
+		return draw.ResizeNeeded(cvs)
+	}
+
+	valueAr := image.Rect(ar.Min.X, ar.Min.Y, ar.Max.X-arrowsWidth, ar.Min.Y+height)
+	ni.upAr = image.Rect(ar.Max.X-2, ar.Min.Y, ar.Max.X-1, ar.Min.Y+height)
+	ni.downAr = image.Rect(ar.Max.X-1, ar.Min.Y, ar.Max.X, ar.Min.Y+height)
+	if meta.MouseUnsupported {
+		// The arrows can only be clicked, leave the space to the value.
+		valueAr.Max.X = ar.Max.X
+		ni.upAr = image.ZR
+		ni.downAr = image.ZR
+	}
+
+	text := strconv.Itoa(ni.value)
+	textOpts := []cell.Option{cell.FgColor(ni.opts.textColor)}
+	if ni.editing {
+		text = ni.edit
+		textOpts = append(textOpts, cell.BgColor(ni.opts.editColor))
+		if err := cvs.SetAreaCells(valueAr, ' ', cell.BgColor(ni.opts.editColor)); err != nil {
+			return err
+		}
+	}
+	if err := draw.Text(cvs, text, valueAr.Min,
+		draw.TextMaxX(valueAr.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(textOpts...),
+	); err != nil {
+		return err
+	}
+
+	if meta.MouseUnsupported {
+		return nil
+	}
+	arrowOpts := cell.FgColor(ni.opts.arrowColor)
+	if _, err := cvs.SetCell(ni.upAr.Min, '▲', arrowOpts); err != nil {
+		return err
+	}
+	if _, err := cvs.SetCell(ni.downAr.Min, '▼', arrowOpts); err != nil {
+		return err
+	}
+	return nil
+}
+
+// keyboard processes keyboard events.
+// Returns a bool indicating if the value changed and the new value.
+func (ni *NumberInput) keyboard(k *terminalapi.Keyboard) (bool, int) {
+	ni.mu.Lock()
+	defer ni.mu.Unlock()
+
+	before := ni.value
+	switch k.Key {
+	case keyboard.KeyArrowUp:
+		ni.step(true)
+
+	case keyboard.KeyArrowDown:
+		ni.step(false)
+
+	case keyboard.KeyEnter:
+		ni.commit()
+
+	case keyboard.KeyEsc:
+		ni.editing = false
+		ni.edit = ""
+
+	case keyboard.KeyBackspace, keyboard.KeyBackspace2:
+		if ni.editing && len(ni.edit) > 0 {
+			ni.edit = ni.edit[:len(ni.edit)-1]
+		}
+
+	default:
+		ni.typeRune(rune(k.Key))
+	}
+	return ni.changed(before), ni.value
+}
+
+// Keyboard processes keyboard events.
+// Implements widgetapi.Widget.Keyboard.
+func (ni *NumberInput) Keyboard(k *terminalapi.Keyboard) error {
+	if changed, v := ni.keyboard(k); changed {
+		// Mutex must be released when calling the callback.
+		// Users might call container methods from the callback like the
+		// Container.Update, see #205.
+		return ni.opts.onChange(v)
+	}
+	return nil
+}
+
+// mouse processes mouse events.
+// Returns a bool indicating if the value changed and the new value.
+func (ni *NumberInput) mouse(m *terminalapi.Mouse) (bool, int) {
+	ni.mu.Lock()
+	defer ni.mu.Unlock()
+
+	if m.Button != mouse.ButtonLeft {
+		return false, ni.value
+	}
+
+	before := ni.value
+	switch {
+	case m.Position.In(ni.upAr):
+		ni.step(true)
+	case m.Position.In(ni.downAr):
+		ni.step(false)
+	}
+	return ni.changed(before), ni.value
+}
+
+// Mouse processes mouse events, a left click on the up or the down arrow
+// increments or decrements the value.
+// Implements widgetapi.Widget.Mouse.
+func (ni *NumberInput) Mouse(m *terminalapi.Mouse) error {
+	if changed, v := ni.mouse(m); changed {
+		// Mutex must be released when calling the callback.
+		// Users might call container methods from the callback like the
+		// Container.Update, see #205.
+		return ni.opts.onChange(v)
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*NumberInput) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{minWidth, height},
+		MaximumSize:  image.Point{0, height},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
@@ -0,0 +1,659 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package numberinput
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// callbackTracker tracks the calls of the callback.
+type callbackTracker struct {
+	// wantErr when set to true, makes callback return an error.
+	wantErr bool
+
+	// values are the values the callback was called with.
+	values []int
+
+	// mu protects the tracker.
+	mu sync.Mutex
+}
+
+// callback is the callback function.
+func (ct *callbackTracker) callback(value int) error {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+
+	ct.values = append(ct.values, value)
+	return nil
+}
+
+// keys returns keyboard events for the provided keys.
+func keys(ks ...keyboard.Key) []*terminalapi.Keyboard {
+	var res []*terminalapi.Keyboard
+	for _, k := range ks {
+		res = append(res, &terminalapi.Keyboard{Key: k})
+	}
+	return res
+}
+
+// typed returns keyboard events for the runes in the string.
+func typed(s string) []*terminalapi.Keyboard {
+	var res []*terminalapi.Keyboard
+	for _, r := range s {
+		res = append(res, &terminalapi.Keyboard{Key: keyboard.Key(r)})
+	}
+	return res
+}
+
+// events concatenates the provided keyboard events.
+func events(evs ...[]*terminalapi.Keyboard) []*terminalapi.Keyboard {
+	var res []*terminalapi.Keyboard
+	for _, e := range evs {
+		res = append(res, e...)
+	}
+	return res
+}
+
+func TestKeyboard(t *testing.T) {
+	tests := []struct {
+		desc     string
+		callback *callbackTracker
+		opts     []Option
+		events   []*terminalapi.Keyboard
+
+		wantValue       int
+		wantCallback    *callbackTracker
+		wantCallbackErr bool
+	}{
+		{
+			desc:      "starts at zero by default",
+			wantValue: 0,
+		},
+		{
+			desc: "initial value is clamped to the bounds",
+			opts: []Option{
+				InitialValue(20),
+				Bounds(0, 10),
+			},
+			wantValue: 10,
+		},
+		{
+			desc: "arrow up increments by the step",
+			opts: []Option{
+				InitialValue(1),
+				Step(5),
+			},
+			events:    keys(keyboard.KeyArrowUp, keyboard.KeyArrowUp),
+			wantValue: 11,
+		},
+		{
+			desc: "arrow down decrements by the step",
+			opts: []Option{
+				InitialValue(1),
+				Step(5),
+			},
+			events:    keys(keyboard.KeyArrowDown),
+			wantValue: -4,
+		},
+		{
+			desc: "increment is clamped at the maximum",
+			opts: []Option{
+				InitialValue(8),
+				Step(3),
+				Bounds(0, 10),
+			},
+			events:    keys(keyboard.KeyArrowUp, keyboard.KeyArrowUp),
+			wantValue: 10,
+		},
+		{
+			desc: "decrement is clamped at the minimum",
+			opts: []Option{
+				InitialValue(2),
+				Step(3),
+				Bounds(0, 10),
+			},
+			events:    keys(keyboard.KeyArrowDown, keyboard.KeyArrowDown),
+			wantValue: 0,
+		},
+		{
+			desc: "unbounded increment saturates instead of overflowing",
+			opts: []Option{
+				InitialValue(maxInt - 1),
+				Step(5),
+			},
+			events:    keys(keyboard.KeyArrowUp),
+			wantValue: maxInt,
+		},
+		{
+			desc: "typed value replaces the current value on enter",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events:    events(typed("137"), keys(keyboard.KeyEnter)),
+			wantValue: 137,
+		},
+		{
+			desc: "typed negative value",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events:    events(typed("-7"), keys(keyboard.KeyEnter)),
+			wantValue: -7,
+		},
+		{
+			desc: "typed value is clamped to the bounds",
+			opts: []Option{
+				Bounds(-5, 5),
+			},
+			events:    events(typed("99"), keys(keyboard.KeyEnter)),
+			wantValue: 5,
+		},
+		{
+			desc: "typed value isn't applied until enter",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events:    typed("137"),
+			wantValue: 42,
+		},
+		{
+			desc: "esc discards the typed value",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events:    events(typed("137"), keys(keyboard.KeyEsc, keyboard.KeyEnter)),
+			wantValue: 42,
+		},
+		{
+			desc: "backspace deletes the last typed digit",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events:    events(typed("137"), keys(keyboard.KeyBackspace2, keyboard.KeyEnter)),
+			wantValue: 13,
+		},
+		{
+			desc: "non-numeric runes and minus after digits are ignored",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events:    events(typed("1a-2"), keys(keyboard.KeyEnter)),
+			wantValue: 12,
+		},
+		{
+			desc: "lone minus sign is discarded on enter",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events:    events(typed("-"), keys(keyboard.KeyEnter)),
+			wantValue: 42,
+		},
+		{
+			desc: "arrows apply the typed value before stepping",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events:    events(typed("10"), keys(keyboard.KeyArrowUp)),
+			wantValue: 11,
+		},
+		{
+			desc:     "calls the callback on every change",
+			callback: &callbackTracker{},
+			opts: []Option{
+				Bounds(0, 1),
+			},
+			events: events(
+				keys(keyboard.KeyArrowUp, keyboard.KeyArrowUp, keyboard.KeyArrowDown),
+				typed("0"), keys(keyboard.KeyEnter),
+				typed("1"), keys(keyboard.KeyEnter),
+			),
+			wantValue: 1,
+			wantCallback: &callbackTracker{
+				values: []int{1, 0, 1},
+			},
+		},
+		{
+			desc: "returns the error from the callback",
+			callback: &callbackTracker{
+				wantErr: true,
+			},
+			events:    keys(keyboard.KeyArrowUp),
+			wantValue: 1,
+			wantCallback: &callbackTracker{
+				wantErr: true,
+			},
+			wantCallbackErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			opts := tc.opts
+			if tc.callback != nil {
+				opts = append(opts, OnChange(tc.callback.callback))
+			}
+			ni, err := New(opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			for i, ev := range tc.events {
+				err := ni.Keyboard(ev)
+				// Only the last event in each test case is allowed to fail.
+				if i == len(tc.events)-1 {
+					if (err != nil) != tc.wantCallbackErr {
+						t.Fatalf("Keyboard => unexpected error: %v, wantCallbackErr: %v", err, tc.wantCallbackErr)
+					}
+					if err != nil {
+						return
+					}
+				} else if err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+			}
+
+			if got := ni.Value(); got != tc.wantValue {
+				t.Errorf("Value => %d, want %d", got, tc.wantValue)
+			}
+			if diff := pretty.Compare(tc.wantCallback, tc.callback); diff != "" {
+				t.Errorf("callback => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMouse(t *testing.T) {
+	tests := []struct {
+		desc     string
+		callback *callbackTracker
+		opts     []Option
+		canvas   image.Rectangle
+		meta     *widgetapi.Meta
+		noDraw   bool
+		events   []*terminalapi.Mouse
+
+		wantValue    int
+		wantCallback *callbackTracker
+	}{
+		{
+			desc:   "click on the up arrow increments",
+			opts:   []Option{Step(2)},
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 0}, Button: mouse.ButtonRelease},
+			},
+			wantValue: 2,
+		},
+		{
+			desc:   "click on the down arrow decrements",
+			opts:   []Option{Step(2)},
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+			},
+			wantValue: -2,
+		},
+		{
+			desc: "clicks on the arrows are clamped at the bounds",
+			opts: []Option{
+				Bounds(0, 1),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+			},
+			wantValue: 0,
+		},
+		{
+			desc:   "clicks on the value and the space before the arrows are ignored",
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{6, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{7, 0}, Button: mouse.ButtonLeft},
+			},
+			wantValue: 0,
+		},
+		{
+			desc:   "buttons other than left are ignored",
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonRight},
+				{Position: image.Point{9, 0}, Button: mouse.ButtonWheelUp},
+			},
+			wantValue: 0,
+		},
+		{
+			desc:   "clicks are ignored before the first draw",
+			canvas: image.Rect(0, 0, 10, 1),
+			noDraw: true,
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+			},
+			wantValue: 0,
+		},
+		{
+			desc:   "clicks are ignored when the mouse is unsupported",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta:   &widgetapi.Meta{MouseUnsupported: true},
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+			},
+			wantValue: 0,
+		},
+		{
+			desc:   "clicks are ignored when the canvas is too small",
+			canvas: image.Rect(0, 0, 3, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{2, 0}, Button: mouse.ButtonLeft},
+			},
+			wantValue: 0,
+		},
+		{
+			desc:     "calls the callback on every change",
+			callback: &callbackTracker{},
+			canvas:   image.Rect(0, 0, 10, 1),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+				{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+			},
+			wantValue: -1,
+			wantCallback: &callbackTracker{
+				values: []int{1, 0, -1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			opts := tc.opts
+			if tc.callback != nil {
+				opts = append(opts, OnChange(tc.callback.callback))
+			}
+			ni, err := New(opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			if !tc.noDraw {
+				c, err := canvas.New(tc.canvas)
+				if err != nil {
+					t.Fatalf("canvas.New => unexpected error: %v", err)
+				}
+				meta := tc.meta
+				if meta == nil {
+					meta = &widgetapi.Meta{}
+				}
+				if err := ni.Draw(c, meta); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			for _, ev := range tc.events {
+				if err := ni.Mouse(ev); err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+
+			if got := ni.Value(); got != tc.wantValue {
+				t.Errorf("Value => %d, want %d", got, tc.wantValue)
+			}
+			if diff := pretty.Compare(tc.wantCallback, tc.callback); diff != "" {
+				t.Errorf("callback => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNumberInput(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		events []*terminalapi.Keyboard
+		canvas image.Rectangle
+		meta   *widgetapi.Meta
+		want   func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:   "displays resize needed when the canvas is too small",
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the value and the arrows",
+			opts: []Option{
+				InitialValue(-42),
+			},
+			canvas: image.Rect(0, 0, 8, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "-42", image.Point{0, 0})
+				testdraw.MustText(cvs, "▲▼", image.Point{6, 0})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "omits the arrows when the mouse is unsupported",
+			opts: []Option{
+				InitialValue(1234567),
+			},
+			canvas: image.Rect(0, 0, 8, 1),
+			meta:   &widgetapi.Meta{MouseUnsupported: true},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "1234567", image.Point{0, 0})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws with custom colors",
+			opts: []Option{
+				InitialValue(7),
+				TextColor(cell.ColorRed),
+				ArrowColor(cell.ColorBlue),
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "7", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(cvs, "▲▼", image.Point{2, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "trims values that don't fit",
+			opts: []Option{
+				InitialValue(123456),
+			},
+			canvas: image.Rect(0, 0, 7, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "123…", image.Point{0, 0})
+				testdraw.MustText(cvs, "▲▼", image.Point{5, 0})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the typed value on the edit background",
+			opts: []Option{
+				InitialValue(42),
+			},
+			events: typed("13"),
+			canvas: image.Rect(0, 0, 8, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				editBg := cell.BgColor(cell.ColorNumber(DefaultEditColorNumber))
+				testcanvas.MustSetAreaCells(cvs, image.Rect(0, 0, 5, 1), ' ', editBg)
+				testdraw.MustText(cvs, "13", image.Point{0, 0}, draw.TextCellOpts(editBg))
+				testdraw.MustText(cvs, "▲▼", image.Point{6, 0})
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ni, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := ni.Keyboard(ev); err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			meta := tc.meta
+			if meta == nil {
+				meta = &widgetapi.Meta{}
+			}
+			if err := ni.Draw(c, meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestNewFails(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+	}{
+		{
+			desc: "zero step",
+			opts: []Option{Step(0)},
+		},
+		{
+			desc: "negative step",
+			opts: []Option{Step(-1)},
+		},
+		{
+			desc: "minimum greater than the maximum",
+			opts: []Option{Bounds(2, 1)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := New(tc.opts...); err == nil {
+				t.Errorf("New => got nil err, wanted one")
+			}
+		})
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	ct := &callbackTracker{}
+	ni, err := New(Bounds(0, 10), OnChange(ct.callback))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	for _, ev := range typed("5") {
+		if err := ni.Keyboard(ev); err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	}
+
+	ni.SetValue(20)
+	if got, want := ni.Value(), 10; got != want {
+		t.Errorf("Value => %d, want %d", got, want)
+	}
+
+	// The typed value was discarded by SetValue.
+	if err := ni.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if got, want := ni.Value(), 10; got != want {
+		t.Errorf("Value => %d, want %d", got, want)
+	}
+	if len(ct.values) != 0 {
+		t.Errorf("callback => called with %v, want no calls", ct.values)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	ni, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := ni.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{4, 1},
+		MaximumSize:  image.Point{0, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary numberinputdemo shows the functionality of a number input widget.
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/gauge"
+	"github.com/mum4k/termdash/widgets/numberinput"
+)
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	const initial = 30
+	g, err := gauge.New(
+		gauge.TextLabel(fmt.Sprintf("Volume %d%%", initial)),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := g.Percent(initial); err != nil {
+		panic(err)
+	}
+
+	ni, err := numberinput.New(
+		numberinput.InitialValue(initial),
+		numberinput.Step(5),
+		numberinput.Bounds(0, 100),
+		numberinput.OnChange(func(value int) error {
+			return g.Percent(value, gauge.TextLabel(fmt.Sprintf("Volume %d%%", value)))
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.Border(linestyle.Light),
+				container.BorderTitle("Use arrows, click them or type a number and press Enter"),
+				container.PlaceWidget(ni),
+				container.AlignHorizontal(align.HorizontalCenter),
+				container.AlignVertical(align.VerticalMiddle),
+			),
+			container.Bottom(
+				container.PlaceWidget(g),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter), termdash.RedrawInterval(100*time.Millisecond)); err != nil {
+		panic(err)
+	}
+}
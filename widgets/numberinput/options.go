@@ -0,0 +1,146 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package numberinput
+
+// options.go contains configurable options for NumberInput.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	initialValue int
+	step         int
+	bounded      bool
+	minValue     int
+	maxValue     int
+	textColor    cell.Color
+	arrowColor   cell.Color
+	editColor    cell.Color
+	onChange     ChangeFn
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.step <= 0 {
+		return fmt.Errorf("invalid Step(%d), must be a positive number", o.step)
+	}
+	if o.bounded && o.minValue > o.maxValue {
+		return fmt.Errorf("invalid Bounds(%d, %d), the minimum cannot be greater than the maximum", o.minValue, o.maxValue)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		step:      DefaultStep,
+		editColor: cell.ColorNumber(DefaultEditColorNumber),
+	}
+}
+
+// InitialValue sets the value displayed when the widget is created. The value
+// is clamped to the range set via the Bounds option.
+// Defaults to zero.
+func InitialValue(v int) Option {
+	return option(func(opts *options) {
+		opts.initialValue = v
+	})
+}
+
+// DefaultStep is the default value for the Step option.
+const DefaultStep = 1
+
+// Step sets the amount the value is incremented or decremented by when the
+// user presses the arrow keys or clicks the arrows. Must be a positive number.
+// Defaults to DefaultStep.
+func Step(s int) Option {
+	return option(func(opts *options) {
+		opts.step = s
+	})
+}
+
+// Bounds sets the minimum and the maximum value, both inclusive. Values that
+// fall outside of the bounds, whether reached via the arrows or typed in by
+// the user, are clamped to the nearest bound.
+// The value is unbounded by default.
+func Bounds(min, max int) Option {
+	return option(func(opts *options) {
+		opts.bounded = true
+		opts.minValue = min
+		opts.maxValue = max
+	})
+}
+
+// TextColor sets the color of the value.
+// Defaults to the default terminal color.
+func TextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.textColor = c
+	})
+}
+
+// ArrowColor sets the color of the up and down arrows.
+// Defaults to the default terminal color.
+func ArrowColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.arrowColor = c
+	})
+}
+
+// DefaultEditColorNumber is the default color number for the EditColor
+// option.
+const DefaultEditColorNumber = 250
+
+// EditColor sets the background color of the value while the user is typing
+// in a new one.
+// Defaults to DefaultEditColorNumber.
+func EditColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.editColor = c
+	})
+}
+
+// ChangeFn if provided is called when the value changes due to a keyboard or
+// mouse event. The argument is the new value.
+//
+// The callback function must be thread-safe as the keyboard or mouse event
+// that changes the value comes from a separate goroutine.
+type ChangeFn func(value int) error
+
+// OnChange sets a function that will be called with the new value every time
+// the user changes the value.
+func OnChange(fn ChangeFn) Option {
+	return option(func(opts *options) {
+		opts.onChange = fn
+	})
+}
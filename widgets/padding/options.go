@@ -0,0 +1,83 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package padding
+
+// options.go contains configurable options for Padding.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	top     int
+	right   int
+	bottom  int
+	left    int
+	bgColor cell.Color
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.top < 0 || o.right < 0 || o.bottom < 0 || o.left < 0 {
+		return fmt.Errorf("invalid ContentPadding(top:%d, right:%d, bottom:%d, left:%d), all values must be zero or positive", o.top, o.right, o.bottom, o.left)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		bgColor: cell.ColorDefault,
+	}
+}
+
+// ContentPadding sets the number of cells left empty between each edge of the
+// canvas and the content of the wrapped widget. The values must be zero or
+// positive.
+// Defaults to no padding.
+func ContentPadding(top, right, bottom, left int) Option {
+	return option(func(opts *options) {
+		opts.top = top
+		opts.right = right
+		opts.bottom = bottom
+		opts.left = left
+	})
+}
+
+// BgColor sets the background color of the padding cells. This should
+// typically match the background color used by the wrapped widget.
+// Defaults to cell.ColorDefault.
+func BgColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.bgColor = c
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package padding implements a widget that insets the content of another
+// widget within its canvas.
+//
+// This is finer-grained than the padding of a container, since the padding
+// cells belong to the widget and are filled with its background color.
+package padding
+
+import (
+	"errors"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Padding wraps a widget and draws it on a canvas reduced by the configured
+// padding. The wrapped widget only ever sees the reduced canvas, mouse events
+// are forwarded with coordinates relative to it.
+//
+// Implements widgetapi.Widget. This object is thread-safe as long as the
+// wrapped widget is thread-safe.
+type Padding struct {
+	// mu protects the Padding.
+	mu sync.Mutex
+
+	// content is the area inside the padding on the last drawn canvas.
+	content image.Rectangle
+
+	// widget is the wrapped widget.
+	widget widgetapi.Widget
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Padding that wraps the provided widget.
+func New(w widgetapi.Widget, opts ...Option) (*Padding, error) {
+	if w == nil {
+		return nil, errors.New("the wrapped widget cannot be nil")
+	}
+
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Padding{
+		widget: w,
+		opts:   opt,
+	}, nil
+}
+
+// contentArea returns the area inside the padding given the area of the
+// canvas. The returned area is empty if the padding doesn't leave any space.
+func (p *Padding) contentArea(ar image.Rectangle) image.Rectangle {
+	content := image.Rect(
+		ar.Min.X+p.opts.left,
+		ar.Min.Y+p.opts.top,
+		ar.Max.X-p.opts.right,
+		ar.Max.Y-p.opts.bottom,
+	)
+	if content.Empty() {
+		return image.ZR
+	}
+	return content
+}
+
+// Draw fills the padding with the background color and draws the wrapped
+// widget inside of it.
+// Implements widgetapi.Widget.Draw.
+func (p *Padding) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	ar := cvs.Area()
+	content := p.contentArea(ar)
+	needSize := p.widget.Options().MinimumSize
+	if content.Empty() || content.Dx() < needSize.X || content.Dy() < needSize.Y {
+		p.setContent(image.ZR)
+		return draw.ResizeNeeded(cvs)
+	}
+	p.setContent(content)
+
+	strips := []image.Rectangle{
+		image.Rect(ar.Min.X, ar.Min.Y, ar.Max.X, content.Min.Y),           // Top.
+		image.Rect(ar.Min.X, content.Max.Y, ar.Max.X, ar.Max.Y),           // Bottom.
+		image.Rect(ar.Min.X, content.Min.Y, content.Min.X, content.Max.Y), // Left.
+		image.Rect(content.Max.X, content.Min.Y, ar.Max.X, content.Max.Y), // Right.
+	}
+	for _, s := range strips {
+		if s.Empty() {
+			continue
+		}
+		if err := cvs.SetAreaCells(s, ' ', cell.BgColor(p.opts.bgColor)); err != nil {
+			return err
+		}
+	}
+
+	contentCvs, err := cvs.SubCanvas(content)
+	if err != nil {
+		return err
+	}
+	return p.widget.Draw(contentCvs, meta)
+}
+
+// setContent records the area inside the padding.
+func (p *Padding) setContent(content image.Rectangle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.content = content
+}
+
+// getContent returns the area inside the padding on the last drawn canvas.
+func (p *Padding) getContent() image.Rectangle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.content
+}
+
+// Keyboard forwards the keyboard event to the wrapped widget.
+// Implements widgetapi.Widget.Keyboard.
+func (p *Padding) Keyboard(k *terminalapi.Keyboard) error {
+	return p.widget.Keyboard(k)
+}
+
+// Mouse forwards the mouse event to the wrapped widget, adjusting its
+// position to be relative to the area inside the padding. Widgets that want
+// mouse events only on their canvas don't receive events that fall onto the
+// padding.
+// Implements widgetapi.Widget.Mouse.
+func (p *Padding) Mouse(m *terminalapi.Mouse) error {
+	if content := p.getContent(); m.Position.In(content) {
+		return p.widget.Mouse(&terminalapi.Mouse{
+			Position: m.Position.Sub(content.Min),
+			Button:   m.Button,
+		})
+	}
+
+	if p.widget.Options().WantMouse == widgetapi.MouseScopeWidget {
+		return nil
+	}
+	return p.widget.Mouse(&terminalapi.Mouse{
+		Position: image.Point{-1, -1},
+		Button:   m.Button,
+	})
+}
+
+// Options returns the options of the wrapped widget with the minimum and
+// maximum sizes increased by the padding.
+// Implements widgetapi.Widget.Options.
+func (p *Padding) Options() widgetapi.Options {
+	opts := p.widget.Options()
+	pad := image.Point{
+		p.opts.left + p.opts.right,
+		p.opts.top + p.opts.bottom,
+	}
+
+	minSize := opts.MinimumSize
+	if minSize.X < 1 {
+		minSize.X = 1
+	}
+	if minSize.Y < 1 {
+		minSize.Y = 1
+	}
+	opts.MinimumSize = minSize.Add(pad)
+
+	if opts.MaximumSize.X > 0 {
+		opts.MaximumSize.X += pad.X
+	}
+	if opts.MaximumSize.Y > 0 {
+		opts.MaximumSize.Y += pad.Y
+	}
+	return opts
+}
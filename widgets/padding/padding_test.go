@@ -0,0 +1,328 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package padding
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
+	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/internal/fakewidget"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mustDrawPadded draws the expected content of the Mirror widget inside the
+// content area and fills the rest of the terminal with the background color.
+func mustDrawPadded(ft *faketerm.Terminal, content image.Rectangle, bg cell.Color, wOpts widgetapi.Options, events ...terminalapi.Event) {
+	cvs := testcanvas.MustNew(ft.Area())
+	testcanvas.MustSetAreaCells(cvs, cvs.Area(), ' ', cell.BgColor(bg))
+	testcanvas.MustApply(cvs, ft)
+
+	contentCvs := testcanvas.MustNew(content)
+	fakewidget.MustDraw(ft, contentCvs, &widgetapi.Meta{}, wOpts, events...)
+}
+
+func TestPadding(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		wOpts   widgetapi.Options
+		canvas  image.Rectangle
+		events  []terminalapi.Event
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc:   "no padding by default",
+			canvas: image.Rect(0, 0, 20, 8),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{}, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc: "wrapped widget receives the inset area",
+			opts: []Option{
+				ContentPadding(1, 2, 3, 4),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawPadded(ft, image.Rect(4, 1, 18, 7), cell.ColorDefault, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc: "padding cells use the background color",
+			opts: []Option{
+				ContentPadding(1, 1, 1, 1),
+				BgColor(cell.ColorBlue),
+			},
+			canvas: image.Rect(0, 0, 20, 8),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawPadded(ft, image.Rect(1, 1, 19, 7), cell.ColorBlue, widgetapi.Options{})
+				return ft
+			},
+		},
+		{
+			desc: "displays resize needed when the padding leaves no space",
+			opts: []Option{
+				ContentPadding(2, 2, 2, 2),
+			},
+			canvas: image.Rect(0, 0, 4, 8),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "displays resize needed when the inset area is below the widget's minimum size",
+			opts: []Option{
+				ContentPadding(1, 1, 1, 1),
+			},
+			wOpts: widgetapi.Options{
+				MinimumSize: image.Point{19, 2},
+			},
+			canvas: image.Rect(0, 0, 20, 8),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc: "forwards keyboard events",
+			opts: []Option{
+				ContentPadding(1, 1, 1, 1),
+			},
+			wOpts: widgetapi.Options{
+				WantKeyboard: widgetapi.KeyScopeFocused,
+			},
+			canvas: image.Rect(0, 0, 20, 8),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawPadded(ft, image.Rect(1, 1, 19, 7), cell.ColorDefault,
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "mouse events are relative to the inset area",
+			opts: []Option{
+				ContentPadding(1, 2, 3, 4),
+			},
+			wOpts: widgetapi.Options{
+				WantMouse: widgetapi.MouseScopeWidget,
+			},
+			canvas: image.Rect(0, 0, 30, 10),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{5, 2}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawPadded(ft, image.Rect(4, 1, 28, 7), cell.ColorDefault,
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "mouse events on the padding are dropped for widget scope",
+			opts: []Option{
+				ContentPadding(1, 2, 3, 4),
+			},
+			wOpts: widgetapi.Options{
+				WantMouse: widgetapi.MouseScopeWidget,
+			},
+			canvas: image.Rect(0, 0, 30, 10),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{5, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{28, 2}, Button: mouse.ButtonMiddle},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawPadded(ft, image.Rect(4, 1, 28, 7), cell.ColorDefault,
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeWidget},
+					&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+		{
+			desc: "mouse events on the padding are outside of the canvas for global scope",
+			opts: []Option{
+				ContentPadding(1, 2, 3, 4),
+			},
+			wOpts: widgetapi.Options{
+				WantMouse: widgetapi.MouseScopeGlobal,
+			},
+			canvas: image.Rect(0, 0, 30, 10),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawPadded(ft, image.Rect(4, 1, 28, 7), cell.ColorDefault,
+					widgetapi.Options{WantMouse: widgetapi.MouseScopeGlobal},
+					&terminalapi.Mouse{Position: image.Point{-1, -1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := New(fakewidget.New(tc.wOpts), tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// Draw once so that the padding knows the size of its canvas.
+			if err := p.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Mouse:
+					err = p.Mouse(e)
+				case *terminalapi.Keyboard:
+					err = p.Keyboard(e)
+				}
+				if err != nil {
+					t.Fatalf("event %v => unexpected error: %v", ev, err)
+				}
+			}
+
+			err = p.Draw(c, &widgetapi.Meta{})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Draw => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestNewFails(t *testing.T) {
+	tests := []struct {
+		desc   string
+		widget widgetapi.Widget
+		opts   []Option
+	}{
+		{
+			desc: "nil widget",
+		},
+		{
+			desc:   "negative padding",
+			widget: fakewidget.New(widgetapi.Options{}),
+			opts:   []Option{ContentPadding(0, -1, 0, 0)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := New(tc.widget, tc.opts...); err == nil {
+				t.Errorf("New => got nil err, wanted one")
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc  string
+		opts  []Option
+		wOpts widgetapi.Options
+		want  widgetapi.Options
+	}{
+		{
+			desc: "no padding",
+			want: widgetapi.Options{
+				MinimumSize: image.Point{1, 1},
+			},
+		},
+		{
+			desc: "adds the padding to the sizes and forwards other options",
+			opts: []Option{
+				ContentPadding(1, 2, 3, 4),
+			},
+			wOpts: widgetapi.Options{
+				MinimumSize:  image.Point{5, 3},
+				MaximumSize:  image.Point{10, 0},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{11, 7},
+				MaximumSize:  image.Point{16, 0},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := New(fakewidget.New(tc.wOpts), tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			got := p.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
 )
 
 // options.go contains configurable options for SegmentDisplay.
@@ -42,6 +43,8 @@ type options struct {
 	vAlign          align.Vertical
 	maximizeSegSize bool
 	gapPercent      int
+	bgColor         cell.Color
+	compact         bool
 }
 
 // validate validates the provided options.
@@ -110,3 +113,26 @@ func GapPercent(perc int) Option {
 		opts.gapPercent = perc
 	})
 }
+
+// CompactFallback tells the widget to display the text as plain characters
+// when the canvas is too small for a display segment, i.e. when it has fewer
+// than 6 columns or fewer than 5 rows. The characters are aligned according
+// to the AlignHorizontal and AlignVertical options, use the cell options of
+// their text chunks and are trimmed to the width of the canvas.
+// Without this option the widget fails to draw on such canvas and requires at
+// least the size of one display segment.
+func CompactFallback() Option {
+	return option(func(opts *options) {
+		opts.compact = true
+	})
+}
+
+// BgColor sets the background color of the entire area of the widget, the
+// segments are drawn over it. Cell options provided for the text chunks take
+// precedence over this color in the cells of the segments.
+// Defaults to cell.ColorDefault.
+func BgColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.bgColor = c
+	})
+}
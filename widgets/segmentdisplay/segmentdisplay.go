@@ -23,6 +23,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/alignfor"
 	"github.com/mum4k/termdash/internal/attrrange"
 	"github.com/mum4k/termdash/internal/canvas"
@@ -196,12 +197,19 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	sd.mu.Lock()
 	defer sd.mu.Unlock()
 
+	if ar := cvs.Area(); sd.opts.compact && (ar.Dx() < sixteen.MinCols || ar.Dy() < sixteen.MinRows) {
+		return sd.drawCompact(cvs)
+	}
+
 	segAr, err := sd.preprocess(cvs.Area())
 	if err != nil {
 		return err
 	}
 
 	sd.lastCanFit = segAr.canFit
+	if err := sd.fillBg(cvs); err != nil {
+		return err
+	}
 	if sd.buff.Len() == 0 {
 		return nil
 	}
@@ -242,6 +250,10 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 			return fmt.Errorf("canvas.New => %v", err)
 		}
 
+		if err := sd.fillBg(dCvs); err != nil {
+			return err
+		}
+
 		if i >= optRange.High { // Get the next write options.
 			or, err := sd.wOptsTracker.ForPosition(i)
 			if err != nil {
@@ -262,6 +274,47 @@ func (sd *SegmentDisplay) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	return nil
 }
 
+// drawCompact draws the text as plain characters, see CompactFallback.
+func (sd *SegmentDisplay) drawCompact(cvs *canvas.Canvas) error {
+	sd.lastCanFit = cvs.Area().Dx()
+	if err := sd.fillBg(cvs); err != nil {
+		return err
+	}
+	if sd.buff.Len() == 0 {
+		return nil
+	}
+
+	// We're guaranteed by Write to only have ASCII characters.
+	text := sd.buff.String()
+	if len(text) > sd.lastCanFit {
+		text = text[:sd.lastCanFit]
+	}
+	aligned, err := alignfor.Rectangle(cvs.Area(), image.Rect(0, 0, len(text), 1), sd.opts.hAlign, sd.opts.vAlign)
+	if err != nil {
+		return fmt.Errorf("alignfor.Rectangle => %v", err)
+	}
+
+	for i, c := range text {
+		optRange, err := sd.wOptsTracker.ForPosition(i)
+		if err != nil {
+			return err
+		}
+		wOpts := sd.givenWOpts[optRange.AttrIdx]
+		if _, err := cvs.SetCell(image.Point{aligned.Min.X + i, aligned.Min.Y}, c, wOpts.cellOpts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillBg fills the canvas with the background color if one was set.
+func (sd *SegmentDisplay) fillBg(cvs *canvas.Canvas) error {
+	if sd.opts.bgColor == cell.ColorDefault {
+		return nil
+	}
+	return cvs.SetAreaCells(cvs.Area(), ' ', cell.BgColor(sd.opts.bgColor))
+}
+
 // Keyboard input isn't supported on the SegmentDisplay widget.
 func (*SegmentDisplay) Keyboard(k *terminalapi.Keyboard) error {
 	return errors.New("the SegmentDisplay widget doesn't support keyboard events")
@@ -274,9 +327,17 @@ func (*SegmentDisplay) Mouse(m *terminalapi.Mouse) error {
 
 // Options implements widgetapi.Widget.Options.
 func (sd *SegmentDisplay) Options() widgetapi.Options {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	// The smallest supported size of a display segment.
+	min := image.Point{sixteen.MinCols, sixteen.MinRows}
+	if sd.opts.compact {
+		// One plain character.
+		min = image.Point{1, 1}
+	}
 	return widgetapi.Options{
-		// The smallest supported size of a display segment.
-		MinimumSize:  image.Point{sixteen.MinCols, sixteen.MinRows},
+		MinimumSize:  min,
 		WantKeyboard: widgetapi.KeyScopeNone,
 		WantMouse:    widgetapi.MouseScopeNone,
 	}
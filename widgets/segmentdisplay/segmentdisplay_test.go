@@ -23,6 +23,8 @@ import (
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/draw/testdraw"
 	"github.com/mum4k/termdash/internal/faketerm"
 	"github.com/mum4k/termdash/internal/segdisp/sixteen"
 	"github.com/mum4k/termdash/internal/segdisp/sixteen/testsixteen"
@@ -98,6 +100,88 @@ func TestSegmentDisplay(t *testing.T) {
 			},
 			wantDrawErr: true,
 		},
+		{
+			desc: "compact fallback draws plain text when narrower than a segment",
+			opts: []Option{
+				CompactFallback(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols-1, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("12")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "12", image.Point{1, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: sixteen.MinCols - 1,
+		},
+		{
+			desc: "compact fallback draws plain text when lower than a segment",
+			opts: []Option{
+				CompactFallback(),
+				AlignHorizontal(align.HorizontalLeft),
+				AlignVertical(align.VerticalTop),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows-1),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("12")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "12", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: sixteen.MinCols,
+		},
+		{
+			desc: "compact fallback trims the text and uses the cell options of the chunks",
+			opts: []Option{
+				CompactFallback(),
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("1", WriteCellOpts(cell.FgColor(cell.ColorRed))),
+					NewChunk("234"),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "1", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "23", image.Point{1, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 3,
+		},
+		{
+			desc: "compact fallback draws segments at the size of a segment",
+			opts: []Option{
+				CompactFallback(),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{NewChunk("1")})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawChar(c, '1', image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
 		{
 			desc:   "write fails without chunks",
 			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
@@ -278,6 +362,80 @@ func TestSegmentDisplay(t *testing.T) {
 			},
 			wantCapacity: 2,
 		},
+		{
+			desc:   "fills the background without text",
+			opts:   []Option{BgColor(cell.ColorBlue)},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetAreaCells(cvs, cvs.Area(), ' ', cell.BgColor(cell.ColorBlue))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
+		{
+			desc: "draws the segments over the background",
+			opts: []Option{
+				GapPercent(0),
+				BgColor(cell.ColorBlue),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols*2, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("1", WriteCellOpts(cell.FgColor(cell.ColorRed))),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				bg := cell.BgColor(cell.ColorBlue)
+				testcanvas.MustSetAreaCells(cvs, cvs.Area(), ' ', bg)
+
+				d := sixteen.New()
+				testsixteen.MustSetCharacter(d, '1')
+				dCvs := testcanvas.MustNew(image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows))
+				testcanvas.MustSetAreaCells(dCvs, dCvs.Area(), ' ', bg)
+				testsixteen.MustDraw(d, dCvs, sixteen.CellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustCopyTo(dCvs, cvs)
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 2,
+		},
+		{
+			desc: "cell options of the text chunk take precedence over the background",
+			opts: []Option{
+				BgColor(cell.ColorBlue),
+			},
+			canvas: image.Rect(0, 0, sixteen.MinCols, sixteen.MinRows),
+			update: func(sd *SegmentDisplay) error {
+				return sd.Write([]*TextChunk{
+					NewChunk("1", WriteCellOpts(
+						cell.FgColor(cell.ColorRed),
+						cell.BgColor(cell.ColorYellow),
+					)),
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetAreaCells(cvs, cvs.Area(), ' ', cell.BgColor(cell.ColorBlue))
+
+				d := sixteen.New()
+				testsixteen.MustSetCharacter(d, '1')
+				testsixteen.MustDraw(d, cvs, sixteen.CellOpts(
+					cell.FgColor(cell.ColorRed),
+					cell.BgColor(cell.ColorYellow),
+				))
+
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+			wantCapacity: 1,
+		},
 		{
 			desc: "reset resets the text content and reports capacity when maximizing fit and with gaps",
 			opts: []Option{
@@ -947,3 +1105,19 @@ func TestOptions(t *testing.T) {
 	}
 
 }
+
+func TestOptionsCompactFallback(t *testing.T) {
+	sd, err := New(CompactFallback())
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	got := sd.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
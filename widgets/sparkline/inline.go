@@ -0,0 +1,88 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparkline
+
+// inline.go contains helpers that render miniature bars and sparklines as
+// strings, e.g. for display within a single line of text or a table cell.
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// barBlocks are the characters used to draw the partial cell at the end of an
+// InlineBar, the i-th block fills (i+1)/8 of the cell.
+var barBlocks = []rune{'▏', '▎', '▍', '▌', '▋', '▊', '▉', '█'}
+
+// InlineBar returns a horizontal bar that represents the value as a fraction
+// of the max value, drawn in the specified number of cells. The bar uses the
+// eighth-block characters, so its length is rounded to the nearest eighth of
+// a cell. Values outside of the range 0 <= value <= max are clipped to the
+// range. The returned string is padded with spaces to the width.
+func InlineBar(value, max float64, width int) (string, error) {
+	if width < 0 {
+		return "", fmt.Errorf("invalid width %d, must be 0 <= width", width)
+	}
+	if math.IsNaN(value) || math.IsNaN(max) || max <= 0 {
+		return "", fmt.Errorf("invalid value %v or max %v, the max must be larger than zero and neither can be NaN", value, max)
+	}
+
+	fraction := math.Max(0, math.Min(1, value/max))
+	eighths := int(math.Round(fraction * float64(width*len(barBlocks))))
+	full, part := eighths/len(barBlocks), eighths%len(barBlocks)
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat(string(barBlocks[len(barBlocks)-1]), full))
+	cells := full
+	if part > 0 {
+		b.WriteRune(barBlocks[part-1])
+		cells++
+	}
+	b.WriteString(strings.Repeat(" ", width-cells))
+	return b.String(), nil
+}
+
+// InlineSparks returns a sparkline that is one cell high and displays the
+// most recent data points that fit into the specified number of cells, one
+// cell per data point. The values are scaled to the maximum among the
+// displayed data points. The returned string is padded with spaces on the
+// left to the width.
+func InlineSparks(data []int, width int) (string, error) {
+	if width < 0 {
+		return "", fmt.Errorf("invalid width %d, must be 0 <= width", width)
+	}
+	for i, d := range data {
+		if d < 0 {
+			return "", fmt.Errorf("data point[%d]: %v must be a positive integer", i, d)
+		}
+	}
+
+	visible, max := visibleMax(data, width)
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", width-len(visible)))
+	for _, v := range visible {
+		blocks := toBlocks(v, max, 1)
+		switch {
+		case blocks.full > 0:
+			b.WriteRune(sparks[len(sparks)-1])
+		case blocks.partSpark != 0:
+			b.WriteRune(blocks.partSpark)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return b.String(), nil
+}
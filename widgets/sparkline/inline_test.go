@@ -0,0 +1,199 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sparkline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInlineBar(t *testing.T) {
+	tests := []struct {
+		desc    string
+		value   float64
+		max     float64
+		width   int
+		want    string
+		wantErr bool
+	}{
+		{
+			desc:    "fails on negative width",
+			value:   1,
+			max:     1,
+			width:   -1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on zero max",
+			value:   1,
+			max:     0,
+			width:   4,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on NaN value",
+			value:   math.NaN(),
+			max:     1,
+			width:   4,
+			wantErr: true,
+		},
+		{
+			desc:  "empty string for zero width",
+			value: 1,
+			max:   1,
+			width: 0,
+			want:  "",
+		},
+		{
+			desc:  "zero value is padded to the width",
+			value: 0,
+			max:   10,
+			width: 4,
+			want:  "    ",
+		},
+		{
+			desc:  "max value fills the width",
+			value: 10,
+			max:   10,
+			width: 4,
+			want:  "████",
+		},
+		{
+			desc:  "half of the max value fills half of the width",
+			value: 5,
+			max:   10,
+			width: 4,
+			want:  "██  ",
+		},
+		{
+			desc:  "small value uses a partial block",
+			value: 1,
+			max:   10,
+			width: 4,
+			want:  "▍   ",
+		},
+		{
+			desc:  "fraction rounds up to the nearest eighth block",
+			value: 5.5,
+			max:   10,
+			width: 4,
+			want:  "██▎ ",
+		},
+		{
+			desc:  "fraction rounds down to the nearest eighth block",
+			value: 5.3,
+			max:   10,
+			width: 4,
+			want:  "██▏ ",
+		},
+		{
+			desc:  "fraction closer to a whole cell rounds to the whole cell",
+			value: 5.1,
+			max:   10,
+			width: 4,
+			want:  "██  ",
+		},
+		{
+			desc:  "value larger than max is clipped",
+			value: 20,
+			max:   10,
+			width: 3,
+			want:  "███",
+		},
+		{
+			desc:  "negative value is clipped",
+			value: -5,
+			max:   10,
+			width: 3,
+			want:  "   ",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := InlineBar(tc.value, tc.max, tc.width)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("InlineBar => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.want {
+				t.Errorf("InlineBar(%v, %v, %v) => %q, want %q", tc.value, tc.max, tc.width, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInlineSparks(t *testing.T) {
+	tests := []struct {
+		desc    string
+		data    []int
+		width   int
+		want    string
+		wantErr bool
+	}{
+		{
+			desc:    "fails on negative width",
+			data:    []int{1},
+			width:   -1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative data point",
+			data:    []int{1, -1},
+			width:   2,
+			wantErr: true,
+		},
+		{
+			desc:  "no data is padded to the width",
+			width: 3,
+			want:  "   ",
+		},
+		{
+			desc:  "one cell per data point scaled to the max",
+			data:  []int{0, 1, 2, 3, 4, 5, 6, 7, 8},
+			width: 9,
+			want:  " ▁▂▃▄▅▆▇█",
+		},
+		{
+			desc:  "data narrower than the width is padded on the left",
+			data:  []int{4, 8},
+			width: 4,
+			want:  "  ▄█",
+		},
+		{
+			desc:  "displays only the most recent data points that fit",
+			data:  []int{100, 4, 8},
+			width: 2,
+			want:  "▄█",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := InlineSparks(tc.data, tc.width)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("InlineSparks => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.want {
+				t.Errorf("InlineSparks(%v, %v) => %q, want %q", tc.data, tc.width, got, tc.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package sparkline
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 )
@@ -42,12 +44,23 @@ type options struct {
 	labelCellOpts []cell.Option
 	height        int
 	color         cell.Color
+	showYAxis     bool
+	yAxisCellOpts []cell.Option
+	yAxisFormat   ValueFormatter
+
+	readout         bool
+	readoutCellOpts []cell.Option
+
+	peakHold         bool
+	peakHoldDecay    time.Duration
+	peakHoldCellOpts []cell.Option
 }
 
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
-		color: DefaultColor,
+		color:       DefaultColor,
+		yAxisFormat: DefaultValueFormatter,
 	}
 }
 
@@ -56,6 +69,9 @@ func (o *options) validate() error {
 	if got, min := o.height, 0; got < min {
 		return fmt.Errorf("invalid Height %d, must be %d <= Height", got, min)
 	}
+	if o.peakHold && o.peakHoldDecay <= 0 {
+		return fmt.Errorf("invalid PeakHold decay %v, must be a positive duration", o.peakHoldDecay)
+	}
 	return nil
 }
 
@@ -86,3 +102,71 @@ func Color(c cell.Color) Option {
 		opts.color = c
 	})
 }
+
+// ShowYAxis displays the maximum, the middle and the minimum value of the
+// scale of the SparkLine as labels in a column reserved on the left of the
+// SparkLine. This reduces the number of data points that fit into the width
+// of the SparkLine. The labels are formatted with the ValueFormatter, see
+// YAxisValueFormatter. The provided cell options apply to the labels.
+func ShowYAxis(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.showYAxis = true
+		opts.yAxisCellOpts = cOpts
+	})
+}
+
+// ValueFormatter formats values displayed as labels on the Y axis.
+type ValueFormatter func(value float64) string
+
+// DefaultValueFormatter is the default value for the YAxisValueFormatter
+// option. Formats the value with the smallest number of digits necessary.
+func DefaultValueFormatter(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// YAxisValueFormatter sets the function that formats the labels on the Y
+// axis displayed when ShowYAxis is provided and the values displayed by the
+// Readout.
+// Defaults to DefaultValueFormatter.
+func YAxisValueFormatter(f ValueFormatter) Option {
+	return option(func(opts *options) {
+		opts.yAxisFormat = f
+	})
+}
+
+// Readout makes the SparkLine display the value of the data point under the
+// mouse pointer. The value is formatted with the ValueFormatter, see
+// YAxisValueFormatter, and drawn in the top row of the SparkLine next to the
+// column of the data point. The provided cell options apply to the value.
+// Mouse events outside of the bars, e.g. over the label or the Y axis, remove
+// the readout.
+//
+// Terminals only report the position of the mouse pointer with button
+// presses, releases, drags and wheel events, so the readout doesn't follow a
+// pointer that moves without pressing any buttons.
+func Readout(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.readout = true
+		opts.readoutCellOpts = cOpts
+	})
+}
+
+// DefaultPeakHoldRune is the rune used to draw the marker of the PeakHold
+// option.
+const DefaultPeakHoldRune = '─'
+
+// PeakHold draws a marker at the level of the recent peak across the columns
+// of the SparkLine that don't reach it, like the peak indicator of a VU meter.
+// A data point added via Add that reaches the marker resets it to its value.
+// Otherwise the marker decays linearly and disappears when the decay
+// duration passes without a new peak. The marker stays in the top row while
+// the peak is above the largest visible data point. The provided cell options
+// apply to the marker, which is otherwise drawn in the color of the
+// SparkLine. The decay must be a positive duration.
+func PeakHold(decay time.Duration, cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.peakHold = true
+		opts.peakHoldDecay = decay
+		opts.peakHoldCellOpts = cOpts
+	})
+}
@@ -19,12 +19,15 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"math"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/area"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 )
@@ -42,6 +45,18 @@ type SparkLine struct {
 	// lastWidth is the width of the canvas as of the last time when Draw was called.
 	lastWidth int
 
+	// sparkAr is the area of the canvas with the bars as of the last time
+	// when Draw was called.
+	sparkAr image.Rectangle
+	// pointer is the column of the canvas with the mouse pointer displayed by
+	// the readout, nil if the pointer isn't over the bars. See Readout.
+	pointer *int
+
+	// peak is the data point that set the marker of the PeakHold option and
+	// peakAt the time when it was added.
+	peak   int
+	peakAt time.Time
+
 	// mu protects the SparkLine.
 	mu sync.Mutex
 
@@ -64,6 +79,12 @@ func New(opts ...Option) (*SparkLine, error) {
 	}, nil
 }
 
+// Vars to be replaced from tests.
+var (
+	// timeNow returns the current time.
+	timeNow = time.Now
+)
+
 // Draw draws the SparkLine widget onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (sl *SparkLine) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
@@ -71,6 +92,7 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	defer sl.mu.Unlock()
 
 	sl.lastWidth = cvs.Area().Dx()
+	sl.sparkAr = image.ZR
 	needAr, err := area.FromSize(sl.minSize())
 	if err != nil {
 		return err
@@ -80,13 +102,33 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	ar := sl.area(cvs)
+	var labelAr image.Rectangle
+	if sl.opts.showYAxis {
+		_, max := visibleMax(sl.data, ar.Dx())
+		labelAr = image.Rect(ar.Min.X, ar.Min.Y, ar.Min.X+sl.yLabelsWidth(max), ar.Max.Y)
+		// One column of space between the labels and the SparkLine.
+		ar.Min.X = labelAr.Max.X + 1
+		if ar.Dx() < 1 {
+			sl.lastWidth = 0
+			return draw.ResizeNeeded(cvs)
+		}
+		sl.lastWidth = ar.Dx()
+	}
+
+	sl.sparkAr = ar
 	visible, max := visibleMax(sl.data, ar.Dx())
+	if sl.opts.showYAxis {
+		if err := sl.drawYLabels(cvs, labelAr, max); err != nil {
+			return err
+		}
+	}
 	var curX int
 	if len(visible) < ar.Dx() {
 		curX = ar.Max.X - len(visible)
 	} else {
 		curX = ar.Min.X
 	}
+	firstX := curX
 
 	for _, v := range visible {
 		blocks := toBlocks(v, max, ar.Dy())
@@ -116,6 +158,20 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		curX++
 	}
 
+	if sl.opts.peakHold {
+		if err := sl.drawPeakHold(cvs, ar, visible, max); err != nil {
+			return err
+		}
+	}
+
+	if sl.pointer != nil {
+		if i := *sl.pointer - firstX; i >= 0 && i < len(visible) {
+			if err := sl.drawReadout(cvs, ar, *sl.pointer, visible[i]); err != nil {
+				return err
+			}
+		}
+	}
+
 	if sl.opts.label != "" {
 		// Label is placed immediately above the SparkLine.
 		lStart := image.Point{ar.Min.X, ar.Min.Y - 1}
@@ -129,6 +185,131 @@ func (sl *SparkLine) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	return nil
 }
 
+// yLabel is a label on the Y axis.
+type yLabel struct {
+	// text is the formatted value.
+	text string
+	// row is the row of the label relative to the top of the SparkLine.
+	row int
+}
+
+// yLabels returns the labels on the Y axis of a SparkLine of the specified
+// height whose largest visible value is max. Labels that would share a row
+// are omitted, giving precedence to the maximum and the minimum.
+func (sl *SparkLine) yLabels(max, height int) []yLabel {
+	res := []yLabel{
+		{text: sl.opts.yAxisFormat(float64(max)), row: 0},
+	}
+	if height > 1 {
+		res = append(res, yLabel{text: sl.opts.yAxisFormat(0), row: height - 1})
+	}
+	if height > 2 {
+		res = append(res, yLabel{text: sl.opts.yAxisFormat(float64(max) / 2), row: (height - 1) / 2})
+	}
+	return res
+}
+
+// yLabelsWidth returns the width of the widest label on the Y axis when the
+// largest visible value is max.
+func (sl *SparkLine) yLabelsWidth(max int) int {
+	var width int
+	for _, l := range sl.yLabels(max, 3) {
+		if w := runewidth.StringWidth(l.text); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// drawYLabels draws the labels on the Y axis right-aligned into the provided
+// area.
+func (sl *SparkLine) drawYLabels(cvs *canvas.Canvas, labelAr image.Rectangle, max int) error {
+	for _, l := range sl.yLabels(max, labelAr.Dy()) {
+		x := labelAr.Max.X - runewidth.StringWidth(l.text)
+		if x < labelAr.Min.X {
+			x = labelAr.Min.X
+		}
+		if err := draw.Text(cvs, l.text, image.Point{x, labelAr.Min.Y + l.row},
+			draw.TextCellOpts(sl.opts.yAxisCellOpts...),
+			draw.TextMaxX(labelAr.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawReadout draws the value of the data point in column x of the area with
+// the bars, see Readout. The value is drawn in the top row on the right of the
+// column or on its left if there isn't enough space on the right.
+func (sl *SparkLine) drawReadout(cvs *canvas.Canvas, ar image.Rectangle, x, value int) error {
+	text := sl.opts.yAxisFormat(float64(value))
+	width := runewidth.StringWidth(text)
+	start := x + 1
+	if start+width > ar.Max.X {
+		start = x - width
+	}
+	if start < ar.Min.X {
+		start = ar.Min.X
+	}
+	return draw.Text(cvs, text, image.Point{start, ar.Min.Y},
+		draw.TextCellOpts(sl.opts.readoutCellOpts...),
+		draw.TextMaxX(ar.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// heldPeak returns the level of the marker of the PeakHold option at the
+// provided time.
+func (sl *SparkLine) heldPeak(now time.Time) float64 {
+	elapsed := now.Sub(sl.peakAt)
+	if sl.peak == 0 || elapsed >= sl.opts.peakHoldDecay {
+		return 0
+	}
+	return float64(sl.peak) * (1 - float64(elapsed)/float64(sl.opts.peakHoldDecay))
+}
+
+// barCells returns the number of cells from the bottom of a SparkLine of the
+// specified height that the bar representing the value reaches into.
+func barCells(value, max, height int) int {
+	b := toBlocks(value, max, height)
+	if b.partSpark != 0 {
+		return b.full + 1
+	}
+	return b.full
+}
+
+// drawPeakHold draws the marker of the PeakHold option into the area with the
+// bars, skipping the columns whose bars reach the row of the marker.
+// The visible data points are aligned to the right edge of the area.
+func (sl *SparkLine) drawPeakHold(cvs *canvas.Canvas, ar image.Rectangle, visible []int, max int) error {
+	peak := int(math.Round(sl.heldPeak(timeNow())))
+	if peak == 0 || max == 0 {
+		return nil
+	}
+	cells := barCells(peak, max, ar.Dy())
+	if cells == 0 {
+		return nil
+	}
+	if cells > ar.Dy() {
+		cells = ar.Dy()
+	}
+	y := ar.Max.Y - cells
+
+	cOpts := append([]cell.Option{cell.FgColor(sl.opts.color)}, sl.opts.peakHoldCellOpts...)
+	firstX := ar.Max.X - len(visible)
+	for x := ar.Min.X; x < ar.Max.X; x++ {
+		if i := x - firstX; i >= 0 && barCells(visible[i], max, ar.Dy()) >= cells {
+			continue
+		}
+		if _, err := cvs.SetCell(image.Point{x, y}, DefaultPeakHoldRune, cOpts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ValueCapacity returns the number of values that can fit into the canvas.
 // This is essentially the number of available cells on the canvas as observed
 // on the last call to draw. Returns zero if draw wasn't called.
@@ -170,6 +351,16 @@ func (sl *SparkLine) Add(data []int, opts ...Option) error {
 		}
 	}
 	sl.data = append(sl.data, data...)
+
+	if sl.opts.peakHold {
+		now := timeNow()
+		for _, d := range data {
+			if float64(d) >= sl.heldPeak(now) {
+				sl.peak = d
+				sl.peakAt = now
+			}
+		}
+	}
 	return nil
 }
 
@@ -180,6 +371,8 @@ func (sl *SparkLine) Clear() {
 	defer sl.mu.Unlock()
 
 	sl.data = nil
+	sl.pointer = nil
+	sl.peak = 0
 }
 
 // Keyboard input isn't supported on the SparkLine widget.
@@ -187,9 +380,22 @@ func (*SparkLine) Keyboard(k *terminalapi.Keyboard) error {
 	return errors.New("the SparkLine widget doesn't support keyboard events")
 }
 
-// Mouse input isn't supported on the SparkLine widget.
-func (*SparkLine) Mouse(m *terminalapi.Mouse) error {
-	return errors.New("the SparkLine widget doesn't support mouse events")
+// Mouse updates the readout of the value under the mouse pointer.
+// Mouse input is only supported with the Readout option.
+func (sl *SparkLine) Mouse(m *terminalapi.Mouse) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	if !sl.opts.readout {
+		return errors.New("the SparkLine widget only supports mouse events with the Readout option")
+	}
+	if !m.Position.In(sl.sparkAr) {
+		sl.pointer = nil
+		return nil
+	}
+	x := m.Position.X
+	sl.pointer = &x
+	return nil
 }
 
 // area returns the area of the canvas available to the SparkLine.
@@ -218,7 +424,10 @@ func (sl *SparkLine) area(cvs *canvas.Canvas) image.Rectangle {
 
 // minSize returns the minimum canvas size for the SparkLine based on the options.
 func (sl *SparkLine) minSize() image.Point {
-	const minWidth = 1 // At least one data point.
+	minWidth := 1 // At least one data point.
+	if sl.opts.showYAxis {
+		minWidth += 2 // At least one character of the labels and a space.
+	}
 
 	var minHeight int
 	if sl.opts.height > 0 {
@@ -244,10 +453,14 @@ func (sl *SparkLine) Options() widgetapi.Options {
 		max = min // Fix the height to the one specified.
 	}
 
+	wantMouse := widgetapi.MouseScopeNone
+	if sl.opts.readout {
+		wantMouse = widgetapi.MouseScopeWidget
+	}
 	return widgetapi.Options{
 		MinimumSize:  min,
 		MaximumSize:  max,
 		WantKeyboard: widgetapi.KeyScopeNone,
-		WantMouse:    widgetapi.MouseScopeNone,
+		WantMouse:    wantMouse,
 	}
 }
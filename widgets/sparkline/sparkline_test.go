@@ -15,8 +15,10 @@
 package sparkline
 
 import (
+	"fmt"
 	"image"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/cell"
@@ -25,6 +27,8 @@ import (
 	"github.com/mum4k/termdash/internal/draw"
 	"github.com/mum4k/termdash/internal/draw/testdraw"
 	"github.com/mum4k/termdash/internal/faketerm"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
@@ -55,6 +59,20 @@ func TestSparkLine(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "fails on zero PeakHold decay",
+			opts: []Option{
+				PeakHold(0),
+			},
+			update: func(sl *SparkLine) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
 		{
 			desc: "draws empty for no data points",
 			update: func(sl *SparkLine) error {
@@ -248,6 +266,80 @@ func TestSparkLine(t *testing.T) {
 			},
 			wantCapacity: 4,
 		},
+		{
+			desc: "displays the Y axis labels on the left of the sparkline",
+			opts: []Option{
+				ShowYAxis(cell.FgColor(cell.ColorBlue)),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 100, 50, 85})
+			},
+			canvas: image.Rect(0, 0, 8, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				labelOpts := draw.TextCellOpts(cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "100", image.Point{0, 0}, labelOpts)
+				testdraw.MustText(c, "50", image.Point{1, 1}, labelOpts)
+				testdraw.MustText(c, "0", image.Point{2, 3}, labelOpts)
+
+				sparkOpts := draw.TextCellOpts(cell.FgColor(DefaultColor))
+				testdraw.MustText(c, "█", image.Point{5, 0}, sparkOpts)
+				testdraw.MustText(c, "▃", image.Point{7, 0}, sparkOpts)
+				testdraw.MustText(c, "█", image.Point{5, 1}, sparkOpts)
+				testdraw.MustText(c, "█", image.Point{7, 1}, sparkOpts)
+				testdraw.MustText(c, "███", image.Point{5, 2}, sparkOpts)
+				testdraw.MustText(c, "███", image.Point{5, 3}, sparkOpts)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 4,
+		},
+		{
+			desc: "Y axis with custom formatter on a single height sparkline shows only the maximum",
+			opts: []Option{
+				ShowYAxis(),
+				YAxisValueFormatter(func(v float64) string {
+					return fmt.Sprintf("%.0f", v)
+				}),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{0, 1, 2, 3, 4, 5, 6, 7, 8})
+			},
+			canvas: image.Rect(0, 0, 13, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "8", image.Point{0, 0})
+				testdraw.MustText(c, "▁▂▃▄▅▆▇█", image.Point{5, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultColor),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 11,
+		},
+		{
+			desc: "draws resize needed character when the Y axis labels leave no space for the sparkline",
+			opts: []Option{
+				ShowYAxis(),
+			},
+			update: func(sl *SparkLine) error {
+				return sl.Add([]int{100})
+			},
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCapacity: 0,
+		},
 		{
 			desc: "stretches up to the height of the container with label",
 			opts: []Option{
@@ -541,6 +633,28 @@ func TestOptions(t *testing.T) {
 				WantMouse:    widgetapi.MouseScopeNone,
 			},
 		},
+		{
+			desc: "Y axis reserves space for the labels",
+			opts: []Option{
+				ShowYAxis(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{3, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc: "readout wants mouse events",
+			opts: []Option{
+				Readout(),
+			},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
 		{
 			desc: "label and fixed height",
 			opts: []Option{
@@ -570,3 +684,244 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestReadout(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		data    []int
+		canvas  image.Rectangle
+		events  []*terminalapi.Mouse
+		readout func(c *canvas.Canvas) // Draws the expected readout.
+	}{
+		{
+			desc:   "no readout without mouse events",
+			data:   []int{1, 2, 3, 4},
+			canvas: image.Rect(0, 0, 6, 2),
+		},
+		{
+			desc:   "displays the value on the right of the column",
+			data:   []int{1, 2, 3, 4},
+			canvas: image.Rect(0, 0, 6, 2),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{3, 1}, Button: mouse.ButtonLeft},
+			},
+			readout: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "2", image.Point{4, 0})
+			},
+		},
+		{
+			desc:   "displays the value on the left of the last column",
+			data:   []int{1, 2, 3, 4},
+			canvas: image.Rect(0, 0, 6, 2),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+			},
+			readout: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "4", image.Point{4, 0})
+			},
+		},
+		{
+			desc: "formats the value and applies the cell options",
+			opts: []Option{
+				YAxisValueFormatter(func(v float64) string {
+					return fmt.Sprintf("%.0fB", v)
+				}),
+				Readout(cell.FgColor(cell.ColorRed)),
+			},
+			data:   []int{1, 2, 3, 4},
+			canvas: image.Rect(0, 0, 6, 2),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{2, 1}, Button: mouse.ButtonLeft},
+			},
+			readout: func(c *canvas.Canvas) {
+				testdraw.MustText(c, "1B", image.Point{3, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+			},
+		},
+		{
+			desc:   "nothing over a column without a data point",
+			data:   []int{1, 2, 3, 4},
+			canvas: image.Rect(0, 0, 6, 2),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+			},
+		},
+		{
+			desc: "moving off the bars removes the readout",
+			opts: []Option{
+				Label("x"),
+			},
+			data:   []int{1, 2, 3, 4},
+			canvas: image.Rect(0, 0, 6, 3),
+			events: []*terminalapi.Mouse{
+				{Position: image.Point{3, 2}, Button: mouse.ButtonLeft},
+				{Position: image.Point{3, 0}, Button: mouse.ButtonLeft},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sp, err := New(append([]Option{Readout()}, tc.opts...)...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := sp.Add(tc.data); err != nil {
+				t.Fatalf("Add => unexpected error: %v", err)
+			}
+
+			c := testcanvas.MustNew(tc.canvas)
+			if err := sp.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				if err := sp.Mouse(ev); err != nil {
+					t.Fatalf("Mouse => unexpected error: %v", err)
+				}
+			}
+			c = testcanvas.MustNew(tc.canvas)
+			if err := sp.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			got := faketerm.MustNew(c.Size())
+			testcanvas.MustApply(c, got)
+
+			// The same SparkLine that didn't receive any mouse events.
+			ref, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := ref.Add(tc.data); err != nil {
+				t.Fatalf("Add => unexpected error: %v", err)
+			}
+			wantCvs := testcanvas.MustNew(tc.canvas)
+			if err := ref.Draw(wantCvs, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if tc.readout != nil {
+				tc.readout(wantCvs)
+			}
+			want := faketerm.MustNew(c.Size())
+			testcanvas.MustApply(wantCvs, want)
+
+			if diff := faketerm.Diff(want, got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestMouseWithoutReadout(t *testing.T) {
+	sp, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := sp.Mouse(&terminalapi.Mouse{Button: mouse.ButtonLeft}); err == nil {
+		t.Errorf("Mouse => got nil error, want an error without the Readout option")
+	}
+}
+
+// fakeClock is a clock that only moves when advanced.
+type fakeClock struct {
+	now time.Time
+}
+
+// advance moves the clock forward by the provided duration.
+func (fc *fakeClock) advance(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+func TestPeakHold(t *testing.T) {
+	fc := &fakeClock{
+		now: time.Date(2026, time.October, 14, 10, 0, 0, 0, time.UTC),
+	}
+	timeNow = func() time.Time {
+		return fc.now
+	}
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	markerOpts := []cell.Option{cell.FgColor(cell.ColorRed)}
+	sp, err := New(PeakHold(time.Second, markerOpts...))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	// ref is the same SparkLine without the PeakHold option.
+	ref, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	frames := []struct {
+		desc    string
+		advance time.Duration
+		data    []int
+		// markers are the columns with the marker and the row it is in.
+		markers []int
+		row     int
+	}{
+		{
+			desc:    "spike sets the marker at its level",
+			data:    []int{8, 1, 1, 1},
+			markers: []int{0, 1, 3, 4, 5},
+			row:     0,
+		},
+		{
+			desc:    "marker decays when values drop",
+			advance: 250 * time.Millisecond,
+			markers: []int{0, 1, 3, 4, 5},
+			row:     1,
+		},
+		{
+			desc:    "smaller data point doesn't reset the marker",
+			advance: 250 * time.Millisecond,
+			data:    []int{2},
+			markers: []int{0, 2, 3, 4, 5},
+			row:     2,
+		},
+		{
+			desc:    "marker disappears after the decay",
+			advance: 500 * time.Millisecond,
+		},
+		{
+			desc:    "new spike sets the marker again",
+			data:    []int{6},
+			markers: []int{1, 2, 3, 4},
+			row:     1,
+		},
+	}
+
+	for _, f := range frames {
+		fc.advance(f.advance)
+		if len(f.data) > 0 {
+			if err := sp.Add(f.data); err != nil {
+				t.Fatalf("%s: Add => unexpected error: %v", f.desc, err)
+			}
+			if err := ref.Add(f.data); err != nil {
+				t.Fatalf("%s: Add => unexpected error: %v", f.desc, err)
+			}
+		}
+
+		c := testcanvas.MustNew(image.Rect(0, 0, 6, 4))
+		if err := sp.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("%s: Draw => unexpected error: %v", f.desc, err)
+		}
+		got := faketerm.MustNew(c.Size())
+		testcanvas.MustApply(c, got)
+
+		wantCvs := testcanvas.MustNew(c.Area())
+		if err := ref.Draw(wantCvs, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("%s: Draw => unexpected error: %v", f.desc, err)
+		}
+		for _, x := range f.markers {
+			testcanvas.MustSetCell(wantCvs, image.Point{x, f.row}, DefaultPeakHoldRune, markerOpts...)
+		}
+		want := faketerm.MustNew(c.Size())
+		testcanvas.MustApply(wantCvs, want)
+
+		if diff := faketerm.Diff(want, got); diff != "" {
+			t.Errorf("%s: Draw => %v", f.desc, diff)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// control.go contains code that handles control characters in the text.
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// sanitizeControl returns the text with the control and space characters
+// other than '\n' and ' ' handled according to the mode.
+func sanitizeControl(text string, mode ControlChars) string {
+	if mode == ControlCharsReject {
+		return text
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		if r == ' ' || r == '\n' || !(unicode.IsControl(r) || unicode.IsSpace(r)) {
+			b.WriteRune(r)
+			continue
+		}
+		if mode == ControlCharsStrip {
+			continue
+		}
+		b.WriteString(caret(r))
+	}
+	return b.String()
+}
+
+// caret returns the visible representation of the control character.
+func caret(r rune) string {
+	switch {
+	case r < 0x20:
+		return fmt.Sprintf("^%c", r+'@')
+	case r == 0x7f:
+		return "^?"
+	default:
+		return fmt.Sprintf("<%U>", r)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import "testing"
+
+func TestSanitizeControl(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+		mode ControlChars
+		want string
+	}{
+		{
+			desc: "reject mode keeps the text",
+			text: "a\rb",
+			mode: ControlCharsReject,
+			want: "a\rb",
+		},
+		{
+			desc: "caret mode keeps text without control characters",
+			text: "hello world\n",
+			mode: ControlCharsCaret,
+			want: "hello world\n",
+		},
+		{
+			desc: "caret mode renders ASCII control characters",
+			text: "a\r\nb\tc\x00d\x1be\x7f",
+			mode: ControlCharsCaret,
+			want: "a^M\nb^Ic^@d^[e^?",
+		},
+		{
+			desc: "caret mode renders other control and space characters",
+			text: "a\u0085b c",
+			mode: ControlCharsCaret,
+			want: "a<U+0085>b<U+00A0>c",
+		},
+		{
+			desc: "strip mode removes control and space characters",
+			text: "a\r\nb\tc\u0085d e f",
+			mode: ControlCharsStrip,
+			want: "a\nbcde f",
+		},
+		{
+			desc: "strip mode can remove all the text",
+			text: "\r\t",
+			mode: ControlCharsStrip,
+			want: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := sanitizeControl(tc.text, tc.mode); got != tc.want {
+				t.Errorf("sanitizeControl(%q, %v) => %q, want %q", tc.text, tc.mode, got, tc.want)
+			}
+		})
+	}
+}
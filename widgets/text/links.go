@@ -0,0 +1,101 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// links.go contains code that tracks the links in the content of the Text
+// widget.
+
+import (
+	"github.com/mum4k/termdash/internal/canvas/buffer"
+)
+
+// LinkFn is a function called when the user activates a link written with
+// the WriteLink option.
+//
+// The callback function must be light-weight, ideally just storing a value and
+// returning, since more link activations might occur.
+//
+// The callback function must be thread-safe as the mouse or keyboard events
+// that activate the link are processed in a separate goroutine.
+//
+// If the function returns an error, the widget will forward it back to the
+// termdash infrastructure which causes a panic, unless the user provided a
+// termdash.ErrorHandler.
+type LinkFn func() error
+
+// link is a piece of the content written with the WriteLink option.
+type link struct {
+	// cells are the cells of the content that belong to the link.
+	cells []*buffer.Cell
+	// fn is called when the link is activated.
+	fn LinkFn
+}
+
+// addLink records a link that consists of the cells.
+// The caller must hold t.mu.
+func (t *Text) addLink(cells []*buffer.Cell, fn LinkFn) {
+	if t.cellLinks == nil {
+		t.cellLinks = map[*buffer.Cell]*link{}
+	}
+	l := &link{
+		cells: cells,
+		fn:    fn,
+	}
+	for _, c := range cells {
+		t.cellLinks[c] = l
+	}
+	t.links = append(t.links, l)
+}
+
+// dropLinks forgets the dropped cells and the links that no longer have any
+// cells in the content.
+// The caller must hold t.mu.
+func (t *Text) dropLinks(dropped []*buffer.Cell) {
+	if len(t.links) == 0 {
+		return
+	}
+	for _, c := range dropped {
+		delete(t.cellLinks, c)
+	}
+
+	var kept []*link
+	for _, l := range t.links {
+		if _, ok := t.cellLinks[l.cells[len(l.cells)-1]]; ok {
+			kept = append(kept, l)
+			continue
+		}
+		if l == t.focusedLink {
+			t.focusedLink = nil
+		}
+	}
+	t.links = kept
+}
+
+// focusNextLink moves the focus to the next link in the order of the content,
+// wrapping around to the first link after the last one.
+// The caller must hold t.mu.
+func (t *Text) focusNextLink() {
+	if len(t.links) == 0 {
+		return
+	}
+	next := 0
+	for i, l := range t.links {
+		if l == t.focusedLink {
+			next = (i + 1) % len(t.links)
+			break
+		}
+	}
+	t.focusedLink = t.links[next]
+}
@@ -0,0 +1,215 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// clicks counts activations of the links by their name.
+type clicks map[string]int
+
+// fn returns a LinkFn that counts the activations of the named link.
+func (c clicks) fn(name string) LinkFn {
+	return func() error {
+		c[name]++
+		return nil
+	}
+}
+
+// focusedText draws the widget and returns the text of the cells that are
+// highlighted as the focused link.
+func focusedText(t *testing.T, txt *Text) string {
+	t.Helper()
+
+	cvs, err := canvas.New(image.Rect(0, 0, 20, 2))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := txt.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	var b strings.Builder
+	for y := 0; y < cvs.Area().Dy(); y++ {
+		for x := 0; x < cvs.Area().Dx(); x++ {
+			c, err := cvs.Cell(image.Point{x, y})
+			if err != nil {
+				t.Fatalf("Cell => unexpected error: %v", err)
+			}
+			if c.Opts.BgColor == cell.ColorNumber(DefaultLinkFocusColorNumber) {
+				b.WriteRune(c.Rune)
+			}
+		}
+	}
+	return b.String()
+}
+
+// mustKey sends the key to the widget.
+func mustKey(t *testing.T, txt *Text, k keyboard.Key) {
+	t.Helper()
+	if err := txt.Keyboard(&terminalapi.Keyboard{Key: k}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	got := clicks{}
+	txt, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	for _, w := range []struct {
+		text string
+		link string
+	}{
+		{text: "see "},
+		{text: "one", link: "one"},
+		{text: " and\n"},
+		{text: "two", link: "two"},
+		{text: " or "},
+		{text: "six", link: "six"},
+	} {
+		var opts []WriteOption
+		if w.link != "" {
+			opts = append(opts, WriteLink(got.fn(w.link)))
+		}
+		if err := txt.Write(w.text, opts...); err != nil {
+			t.Fatalf("Write => unexpected error: %v", err)
+		}
+	}
+
+	if want, f := "", focusedText(t, txt); f != want {
+		t.Errorf("initially focused %q, want %q", f, want)
+	}
+	mustKey(t, txt, DefaultLinkKeyActivate)
+	if len(got) != 0 {
+		t.Errorf("activate without a focused link called %v, want no calls", got)
+	}
+
+	// Tab cycles through the links in the order of the content.
+	for _, want := range []string{"one", "two", "six", "one"} {
+		mustKey(t, txt, DefaultLinkKeyNext)
+		if f := focusedText(t, txt); f != want {
+			t.Errorf("after the next key focused %q, want %q", f, want)
+		}
+	}
+
+	mustKey(t, txt, DefaultLinkKeyActivate)
+	if want := (clicks{"one": 1}); len(got) != 1 || got["one"] != 1 {
+		t.Errorf("activate called %v, want %v", got, want)
+	}
+
+	// Clicking activates and focuses the link, "two" starts the second line.
+	if err := txt.Mouse(&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft}); err != nil {
+		t.Fatalf("Mouse => unexpected error: %v", err)
+	}
+	if got["two"] != 1 {
+		t.Errorf("click called %v, want the link %q called once", got, "two")
+	}
+	if want, f := "two", focusedText(t, txt); f != want {
+		t.Errorf("after the click focused %q, want %q", f, want)
+	}
+
+	// Clicking outside of the links does nothing.
+	if err := txt.Mouse(&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft}); err != nil {
+		t.Fatalf("Mouse => unexpected error: %v", err)
+	}
+	if want := (clicks{"one": 1, "two": 1}); len(got) != 2 || got["one"] != 1 || got["two"] != 1 {
+		t.Errorf("click outside of the links called %v, want %v", got, want)
+	}
+}
+
+func TestLinksDroppedWithOldLines(t *testing.T) {
+	txt, err := New(MaxLines(1))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := txt.Write("old", WriteLink(func() error { return nil })); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	mustKey(t, txt, DefaultLinkKeyNext)
+	if want, f := "old", focusedText(t, txt); f != want {
+		t.Fatalf("focused %q, want %q", f, want)
+	}
+
+	if err := txt.Write("\nnew", WriteLink(func() error { return nil })); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if want, f := "", focusedText(t, txt); f != want {
+		t.Errorf("after the link was dropped focused %q, want %q", f, want)
+	}
+	mustKey(t, txt, DefaultLinkKeyNext)
+	if want, f := "new", focusedText(t, txt); f != want {
+		t.Errorf("after the next key focused %q, want %q", f, want)
+	}
+}
+
+func TestLinkKeysValidation(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+	}{
+		{
+			desc: "same next and activate keys",
+			opts: []Option{LinkKeys(keyboard.KeyTab, keyboard.KeyTab)},
+		},
+		{
+			desc: "link key same as a scroll key",
+			opts: []Option{LinkKeys(DefaultScrollKeyUp, keyboard.KeyEnter)},
+		},
+		{
+			desc: "link key same as the follow key",
+			opts: []Option{LinkKeys(keyboard.KeyTab, DefaultFollowKey)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := New(tc.opts...); err == nil {
+				t.Errorf("New => nil error, want an error")
+			}
+		})
+	}
+}
+
+func TestDefaultLinkKeysYieldToScrollKeys(t *testing.T) {
+	got := clicks{}
+	txt, err := New(ScrollKeys(keyboard.KeyArrowUp, DefaultLinkKeyNext, DefaultLinkKeyActivate, keyboard.KeyPgDn))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := txt.Write("one", WriteLink(got.fn("one"))); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+
+	mustKey(t, txt, DefaultLinkKeyNext)
+	if want, f := "", focusedText(t, txt); f != want {
+		t.Errorf("after the scroll down key focused %q, want %q", f, want)
+	}
+	mustKey(t, txt, DefaultLinkKeyActivate)
+	if len(got) != 0 {
+		t.Errorf("the scroll page up key called %v, want no calls", got)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// minimap.go contains code that draws the overview of the content.
+
+import (
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/internal/canvas"
+	"github.com/mum4k/termdash/internal/canvas/buffer"
+	"github.com/mum4k/termdash/internal/runewidth"
+)
+
+const (
+	// minimapWidth is the width of the minimap in cells.
+	minimapWidth = 1
+
+	// minMinimapCanvasWidth is the minimum width of the canvas for the
+	// minimap to be displayed. At least one cell must remain for the text.
+	minMinimapCanvasWidth = minimapWidth + 1
+)
+
+// splitMinimap splits the canvas into the canvas for the text and the canvas
+// for the minimap.
+func splitMinimap(cvs *canvas.Canvas) (*canvas.Canvas, *canvas.Canvas, error) {
+	ar := cvs.Area()
+	textCvs, err := cvs.SubCanvas(image.Rect(ar.Min.X, ar.Min.Y, ar.Max.X-minimapWidth, ar.Max.Y))
+	if err != nil {
+		return nil, nil, err
+	}
+	mapCvs, err := cvs.SubCanvas(image.Rect(ar.Max.X-minimapWidth, ar.Min.Y, ar.Max.X, ar.Max.Y))
+	if err != nil {
+		return nil, nil, err
+	}
+	return textCvs, mapCvs, nil
+}
+
+// densityRunes are the runes that represent the density of the lines on the
+// minimap, from the least to the most dense.
+var densityRunes = []rune{'░', '▒', '▓', '█'}
+
+// densityRune returns the rune representing lines whose cells are filled
+// with text at the provided ratio in the range 0.0 to 1.0.
+func densityRune(ratio float64) rune {
+	if ratio <= 0 {
+		return ' '
+	}
+	idx := int(math.Ceil(ratio*float64(len(densityRunes)))) - 1
+	if idx >= len(densityRunes) {
+		idx = len(densityRunes) - 1
+	}
+	return densityRunes[idx]
+}
+
+// lineDensity returns the ratio of the cells that contain text to all the
+// cells the lines can occupy on a canvas of the provided width.
+func lineDensity(lines [][]*buffer.Cell, width int) float64 {
+	var filled int
+	for _, line := range lines {
+		var lineFilled int
+		for _, c := range line {
+			if c.Rune != ' ' {
+				lineFilled += runewidth.RuneWidth(c.Rune)
+			}
+		}
+		if lineFilled > width {
+			lineFilled = width // Trimmed lines.
+		}
+		filled += lineFilled
+	}
+	return float64(filled) / float64(len(lines)*width)
+}
+
+// drawMinimap draws the overview of the lines onto the minimap canvas. The
+// lines are drawn on a canvas of the specified width and the first visible
+// line is at the index first.
+func drawMinimap(cvs *canvas.Canvas, lines [][]*buffer.Cell, width, first int, highlight cell.Color) error {
+	height := cvs.Area().Dy()
+	total := len(lines)
+	rows := total
+	if rows > height {
+		rows = height
+	}
+
+	lastVisible := first + height
+	if lastVisible > total {
+		lastVisible = total
+	}
+	for row := 0; row < rows; row++ {
+		// The range of lines represented by this row.
+		from := row * total / rows
+		to := (row + 1) * total / rows
+
+		var opts []cell.Option
+		if from < lastVisible && to > first {
+			opts = append(opts, cell.BgColor(highlight))
+		}
+		r := densityRune(lineDensity(lines[from:to], width))
+		if _, err := cvs.SetCell(image.Point{0, row}, r, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
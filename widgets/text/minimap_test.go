@@ -0,0 +1,41 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"testing"
+)
+
+func TestDensityRune(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  rune
+	}{
+		{ratio: 0, want: ' '},
+		{ratio: 0.1, want: '░'},
+		{ratio: 0.25, want: '░'},
+		{ratio: 0.26, want: '▒'},
+		{ratio: 0.5, want: '▒'},
+		{ratio: 0.75, want: '▓'},
+		{ratio: 0.9, want: '█'},
+		{ratio: 1, want: '█'},
+	}
+
+	for _, tc := range tests {
+		if got := densityRune(tc.ratio); got != tc.want {
+			t.Errorf("densityRune(%v) => %q, want %q", tc.ratio, got, tc.want)
+		}
+	}
+}
@@ -16,7 +16,10 @@ package text
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/wrap"
 	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/mouse"
@@ -33,6 +36,9 @@ type Option interface {
 // options stores the provided options.
 type options struct {
 	wrapMode         wrap.Mode
+	longWord         LongWord
+	controlChars     ControlChars
+	hAlign           align.Horizontal
 	rollContent      bool
 	disableScrolling bool
 	mouseUpButton    mouse.Button
@@ -41,6 +47,24 @@ type options struct {
 	keyDown          keyboard.Key
 	keyPgUp          keyboard.Key
 	keyPgDown        keyboard.Key
+	keyFollow        keyboard.Key
+	keyFollowSet     bool
+	keyLinkNext      keyboard.Key
+	keyLinkActivate  keyboard.Key
+	keyLinksSet      bool
+	linkFocusColor   cell.Color
+
+	scrollSensitivity int
+	scrollInertia     int
+	smoothScroll      bool
+	maxLines          int
+	tailView          int
+
+	followIndicator     string
+	followIndicatorOpts []cell.Option
+
+	showMinimap           bool
+	minimapHighlightColor cell.Color
 }
 
 // newOptions returns a new options instance.
@@ -52,6 +76,14 @@ func newOptions(opts ...Option) *options {
 		keyDown:         DefaultScrollKeyDown,
 		keyPgUp:         DefaultScrollKeyPageUp,
 		keyPgDown:       DefaultScrollKeyPageDown,
+		keyFollow:       DefaultFollowKey,
+		keyLinkNext:     DefaultLinkKeyNext,
+		keyLinkActivate: DefaultLinkKeyActivate,
+		linkFocusColor:  cell.ColorNumber(DefaultLinkFocusColorNumber),
+
+		scrollSensitivity: DefaultScrollSensitivity,
+
+		minimapHighlightColor: cell.ColorNumber(DefaultMinimapHighlightColorNumber),
 	}
 	for _, o := range opts {
 		o.set(opt)
@@ -70,9 +102,54 @@ func (o *options) validate() error {
 	if len(keys) != 4 {
 		return fmt.Errorf("invalid ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown)
 	}
+	if o.keyFollowSet && keys[o.keyFollow] {
+		return fmt.Errorf("invalid FollowKey(%v), the key must be different from the ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v)", o.keyFollow, o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown)
+	}
+	if o.keyLinksSet {
+		if o.keyLinkNext == o.keyLinkActivate {
+			return fmt.Errorf("invalid LinkKeys(next:%v, activate:%v), the keys must be unique", o.keyLinkNext, o.keyLinkActivate)
+		}
+		keys[o.keyFollow] = true
+		for _, k := range []keyboard.Key{o.keyLinkNext, o.keyLinkActivate} {
+			if keys[k] {
+				return fmt.Errorf("invalid LinkKeys(next:%v, activate:%v), the keys must be different from the ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v) and the FollowKey(%v)", o.keyLinkNext, o.keyLinkActivate, o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown, o.keyFollow)
+			}
+		}
+	}
 	if o.mouseUpButton == o.mouseDownButton {
 		return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the buttons must be unique", o.mouseUpButton, o.mouseDownButton)
 	}
+	if o.scrollSensitivity < 1 {
+		return fmt.Errorf("invalid ScrollSensitivity(%d), must be a positive number", o.scrollSensitivity)
+	}
+	if o.scrollInertia < 0 {
+		return fmt.Errorf("invalid ScrollInertia(%d), must be zero or a positive number", o.scrollInertia)
+	}
+	if _, ok := wrapLongWords[o.longWord]; !ok {
+		return fmt.Errorf("invalid LongWordMode(%v), unsupported long word mode", o.longWord)
+	}
+	if _, ok := controlCharsNames[o.controlChars]; !ok {
+		return fmt.Errorf("invalid ControlCharacters(%v), unsupported control characters mode", o.controlChars)
+	}
+	switch o.hAlign {
+	case align.HorizontalLeft, align.HorizontalCenter, align.HorizontalRight:
+	default:
+		return fmt.Errorf("invalid Align(%v), unsupported horizontal alignment", o.hAlign)
+	}
+	if o.maxLines < 0 {
+		return fmt.Errorf("invalid MaxLines(%d), must be zero or a positive number", o.maxLines)
+	}
+	if o.tailView < 0 {
+		return fmt.Errorf("invalid TailView(%d), must be zero or a positive number", o.tailView)
+	}
+	if o.followIndicator != "" {
+		if err := wrap.ValidText(o.followIndicator); err != nil {
+			return fmt.Errorf("invalid FollowIndicator(%q): %v", o.followIndicator, err)
+		}
+		if strings.ContainsRune(o.followIndicator, '\n') {
+			return fmt.Errorf("invalid FollowIndicator(%q), cannot contain newline characters", o.followIndicator)
+		}
+	}
 	return nil
 }
 
@@ -102,6 +179,110 @@ func WrapAtRunes() Option {
 	})
 }
 
+// LongWord determines how words longer than the width of the widget are
+// placed when wrapping at words.
+type LongWord int
+
+// String implements fmt.Stringer()
+func (lw LongWord) String() string {
+	if n, ok := longWordNames[lw]; ok {
+		return n
+	}
+	return "LongWordUnknown"
+}
+
+// longWordNames maps LongWord values to human readable names.
+var longWordNames = map[LongWord]string{
+	LongWordHyphenate: "LongWordHyphenate",
+	LongWordBreak:     "LongWordBreak",
+	LongWordTruncate:  "LongWordTruncate",
+}
+
+// wrapLongWords maps LongWord values to the ones used by the wrap package.
+var wrapLongWords = map[LongWord]wrap.LongWord{
+	LongWordHyphenate: wrap.LongWordHyphenate,
+	LongWordBreak:     wrap.LongWordBreak,
+	LongWordTruncate:  wrap.LongWordTruncate,
+}
+
+const (
+	// LongWordHyphenate breaks the word across multiple lines and replaces
+	// the last character on each broken line with a dash.
+	LongWordHyphenate LongWord = iota
+
+	// LongWordBreak breaks the word across multiple lines without inserting
+	// any dashes.
+	LongWordBreak
+
+	// LongWordTruncate places the word on its own line and truncates it,
+	// the last character that fits is replaced with the horizontal ellipsis
+	// '…'.
+	LongWordTruncate
+)
+
+// LongWordMode sets how words longer than the width of the widget are placed
+// when the WrapAtWords option is provided. Has no effect otherwise.
+// Defaults to LongWordHyphenate.
+func LongWordMode(mode LongWord) Option {
+	return option(func(opts *options) {
+		opts.longWord = mode
+	})
+}
+
+// ControlChars determines how the widget handles control characters in the
+// written text, e.g. tabs or carriage returns in pasted content.
+type ControlChars int
+
+// String implements fmt.Stringer()
+func (cc ControlChars) String() string {
+	if n, ok := controlCharsNames[cc]; ok {
+		return n
+	}
+	return "ControlCharsUnknown"
+}
+
+// controlCharsNames maps ControlChars values to human readable names.
+var controlCharsNames = map[ControlChars]string{
+	ControlCharsReject: "ControlCharsReject",
+	ControlCharsCaret:  "ControlCharsCaret",
+	ControlCharsStrip:  "ControlCharsStrip",
+}
+
+const (
+	// ControlCharsReject makes Write return an error if the text contains
+	// control characters.
+	ControlCharsReject ControlChars = iota
+
+	// ControlCharsCaret renders the control characters as visible symbols.
+	// The ASCII control characters use the caret notation, e.g. the
+	// carriage return is displayed as "^M" and the tab as "^I". Other
+	// control and space characters are displayed as their Unicode code
+	// point, e.g. "<U+0085>".
+	ControlCharsCaret
+
+	// ControlCharsStrip removes the control characters from the text.
+	ControlCharsStrip
+)
+
+// ControlCharacters sets how the widget handles control characters other
+// than the newline in the written text. The newline always starts a new
+// line. Defaults to ControlCharsReject.
+func ControlCharacters(mode ControlChars) Option {
+	return option(func(opts *options) {
+		opts.controlChars = mode
+	})
+}
+
+// Align sets the horizontal alignment of each line of text within the width
+// of the widget. Lines that were wrapped are aligned individually. Lines that
+// don't fit and are trimmed are always drawn from the left edge. Defaults to
+// align.HorizontalLeft.
+func Align(h align.Horizontal) Option {
+	return option(func(opts *options) {
+		opts.hAlign = h
+	})
+}
+
 // RollContent configures the text widget so that it rolls the text content up
 // if more text than the size of the container is added. If not provided, the
 // content is trimmed instead.
@@ -111,6 +292,65 @@ func RollContent() Option {
 	})
 }
 
+// FollowIndicator sets a text that is displayed right-aligned on the last line
+// of the canvas while content rolling is paused, i.e. after the user scrolled
+// away from the last line of content that was rolled by the RollContent
+// option. The text must fit on a single line. Not displayed by default.
+func FollowIndicator(text string, cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.followIndicator = text
+		opts.followIndicatorOpts = cOpts
+	})
+}
+
+// ShowMinimap reserves the last column of the widget for an overview of the
+// entire content. Each cell of the column represents one or more lines of the
+// content, the glyph indicates how densely the lines are filled with text.
+// The cells that represent the lines currently visible are highlighted. The
+// overview only shrinks the content when it has more lines than the height
+// of the widget. Not displayed when the widget is narrower than two cells.
+func ShowMinimap() Option {
+	return option(func(opts *options) {
+		opts.showMinimap = true
+	})
+}
+
+// DefaultMinimapHighlightColorNumber is the default color number for the
+// MinimapHighlightColor option.
+const DefaultMinimapHighlightColorNumber = 240
+
+// MinimapHighlightColor sets the background color of the cells of the
+// minimap that represent the visible lines, see ShowMinimap.
+// Defaults to DefaultMinimapHighlightColorNumber.
+func MinimapHighlightColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.minimapHighlightColor = c
+	})
+}
+
+// MaxLines limits the number of lines of text the widget stores. When more
+// lines are written, the oldest lines are discarded from the top of the
+// content. Lines are delimited by the newline character in the written text,
+// a single line can occupy multiple lines on the canvas when wrapping is
+// enabled. Zero means no limit, which is the default.
+func MaxLines(n int) Option {
+	return option(func(opts *options) {
+		opts.maxLines = n
+	})
+}
+
+// TailView configures the widget to only display the last n lines of the
+// content, e.g. the latest lines of a log. New lines appended to the content
+// replace the oldest displayed lines. Unlike MaxLines, this doesn't discard
+// any content. Lines are counted as drawn on the canvas, i.e. after wrapping.
+// Scrolling is disabled while the tail view is active. Zero disables the tail
+// view, which is the default.
+func TailView(n int) Option {
+	return option(func(opts *options) {
+		opts.tailView = n
+	})
+}
+
 // DisableScrolling disables the scrolling of the content using keyboard and
 // mouse.
 func DisableScrolling() Option {
@@ -135,6 +375,46 @@ func ScrollMouseButtons(up, down mouse.Button) Option {
 	})
 }
 
+// DefaultScrollSensitivity is the default number of lines scrolled per mouse
+// wheel tick.
+const DefaultScrollSensitivity = 1
+
+// ScrollSensitivity configures the number of lines the content scrolls by on
+// each tick of the mouse wheel, i.e. on each press of the buttons configured
+// via ScrollMouseButtons. Must be a positive number. Defaults to
+// DefaultScrollSensitivity. Doesn't affect scrolling with the keyboard.
+func ScrollSensitivity(lines int) Option {
+	return option(func(opts *options) {
+		opts.scrollSensitivity = lines
+	})
+}
+
+// ScrollInertia enables inertial scrolling with the mouse wheel. After each
+// wheel tick the content continues scrolling in the same direction for the
+// specified number of frames with a step that decays on each frame. A frame
+// is a redraw of the widget, so the duration of the inertia depends on the
+// redraw interval of termdash. Zero disables inertial scrolling, which is the
+// default.
+func ScrollInertia(frames int) Option {
+	return option(func(opts *options) {
+		opts.scrollInertia = frames
+	})
+}
+
+// SmoothScroll makes the scroll keys configured via ScrollKeys scroll the
+// content up and down by half a line. When the content is scrolled by half a
+// line, the partially visible lines at the top and bottom of the canvas are
+// drawn with half block characters ('▄' and '▀') in place of their text.
+// Requires a canvas at least two cells high. Scrolling by a page or with the
+// mouse wheel still moves by whole lines. Widgets linked via LinkScroll share
+// their position in whole lines, so they scroll by a line on every other
+// press of the scroll keys.
+func SmoothScroll() Option {
+	return option(func(opts *options) {
+		opts.smoothScroll = true
+	})
+}
+
 // The default keys for content scrolling.
 const (
 	DefaultScrollKeyUp       = keyboard.KeyArrowUp
@@ -154,3 +434,54 @@ func ScrollKeys(up, down, pageUp, pageDown keyboard.Key) Option {
 		opts.keyPgDown = pageDown
 	})
 }
+
+// DefaultFollowKey is the default key for the FollowKey option.
+const DefaultFollowKey = keyboard.KeyEnd
+
+// FollowKey configures the keyboard key that scrolls the content to the last
+// line. When the RollContent option is provided, this also resumes rolling of
+// new content if the user scrolled away from the last line. The key must be
+// different from the keys configured via ScrollKeys. If not provided, the
+// DefaultFollowKey is used unless it is one of the ScrollKeys.
+func FollowKey(k keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyFollow = k
+		opts.keyFollowSet = true
+	})
+}
+
+// The default keys for the navigation between links.
+const (
+	DefaultLinkKeyNext     = keyboard.KeyTab
+	DefaultLinkKeyActivate = keyboard.KeyEnter
+)
+
+// LinkKeys configures the keyboard keys that navigate between the links
+// written with the WriteLink option. The next key focuses the next link in the
+// order of the content, wrapping around after the last one. The activate key
+// calls the function of the focused link. The keys must be unique and
+// different from the keys configured via ScrollKeys and FollowKey. If not
+// provided, the default link keys are used unless they are one of the
+// ScrollKeys or the FollowKey.
+// Ignored if the DisableScrolling option is provided, since the widget
+// doesn't receive any keyboard events then.
+func LinkKeys(next, activate keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyLinkNext = next
+		opts.keyLinkActivate = activate
+		opts.keyLinksSet = true
+	})
+}
+
+// DefaultLinkFocusColorNumber is the default color number for the
+// LinkFocusColor option.
+const DefaultLinkFocusColorNumber = 24
+
+// LinkFocusColor sets the background color of the cells of the link focused
+// with the keyboard, see LinkKeys.
+// Defaults to DefaultLinkFocusColorNumber.
+func LinkFocusColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.linkFocusColor = c
+	})
+}
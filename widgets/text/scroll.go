@@ -16,7 +16,11 @@ package text
 
 // scroll.go contains code that tracks the current scrolling position.
 
-import "math"
+import (
+	"math"
+
+	"github.com/mum4k/termdash/widgetapi"
+)
 
 // scrollTracker tracks the current scrolling position for the Text widget.
 //
@@ -47,31 +51,143 @@ type scrollTracker struct {
 	// means down by two pages.
 	scrollPage int
 
+	// halfScroll stores user requests to scroll up (negative) or down
+	// (positive) by half a line, see SmoothScroll.
+	halfScroll int
+
+	// end indicates a user request to scroll to the last line.
+	end bool
+
 	// first tracks the first line that will be printed.
 	first int
 
+	// half indicates that the content is scrolled down by half a line below
+	// the first line.
+	half bool
+
+	// smooth indicates that the scrolling by a line moves by half a line
+	// instead.
+	smooth bool
+
+	// paused indicates that content rolling is paused, because the user
+	// scrolled away from the last line.
+	paused bool
+
 	// state is the state of the scrolling FSM.
 	state rollState
+
+	// sensitivity is the number of lines scrolled per mouse wheel tick.
+	sensitivity int
+
+	// inertia is the number of frames the scrolling continues for after a
+	// mouse wheel tick. Zero means no inertial scrolling.
+	inertia int
+
+	// velocity is the number of lines and the direction of the mouse wheel
+	// tick that started the inertial scrolling.
+	velocity int
+
+	// coastFrame is the number of the next frame of inertial scrolling,
+	// counting from one. Zero means no inertial scrolling is in progress.
+	coastFrame int
 }
 
 // newScrollTracker returns a new scroll tracker.
 func newScrollTracker(opts *options) *scrollTracker {
+	st := &scrollTracker{
+		sensitivity: opts.scrollSensitivity,
+		inertia:     opts.scrollInertia,
+		smooth:      opts.smoothScroll,
+	}
+	if st.sensitivity < 1 {
+		st.sensitivity = 1
+	}
+
 	if opts.rollContent {
-		return &scrollTracker{state: rollToEnd}
+		st.state = rollToEnd
+	} else {
+		st.state = rollingDisabled
 	}
-	return &scrollTracker{state: rollingDisabled}
+	return st
 }
 
-// upOneLine processes a user request to scroll up by one line.
+// upOneLine processes a user request to scroll up by one line, or by half a
+// line when smooth scrolling.
 func (st *scrollTracker) upOneLine() {
+	if st.smooth {
+		st.halfScroll--
+		return
+	}
 	st.scroll--
 }
 
-// downOneLine processes a user request to scroll down by one line.
+// downOneLine processes a user request to scroll down by one line, or by half
+// a line when smooth scrolling.
 func (st *scrollTracker) downOneLine() {
+	if st.smooth {
+		st.halfScroll++
+		return
+	}
 	st.scroll++
 }
 
+// wheelUp processes a mouse wheel tick that scrolls up by the configured
+// number of lines.
+func (st *scrollTracker) wheelUp() {
+	st.wheel(-st.sensitivity)
+}
+
+// wheelDown processes a mouse wheel tick that scrolls down by the configured
+// number of lines.
+func (st *scrollTracker) wheelDown() {
+	st.wheel(st.sensitivity)
+}
+
+// wheel scrolls by the specified number of lines and starts the inertial
+// scrolling if it is enabled. Any inertial scrolling already in progress is
+// replaced.
+func (st *scrollTracker) wheel(lines int) {
+	st.scroll += lines
+	if st.inertia > 0 {
+		st.velocity = lines
+		st.coastFrame = 0
+	}
+}
+
+// coast adds the next step of the inertial scrolling to the outstanding
+// scroll requests. The step decays linearly over the configured number of
+// frames, starting on the frame after the mouse wheel tick.
+func (st *scrollTracker) coast() {
+	if st.velocity == 0 {
+		return
+	}
+	if st.coastFrame == 0 {
+		// The frame that consumes the wheel tick itself.
+		st.coastFrame = 1
+		return
+	}
+
+	remaining := st.inertia - st.coastFrame + 1
+	// Round away from zero so that every frame scrolls by at least a line.
+	step := (abs(st.velocity)*remaining + st.inertia) / (st.inertia + 1)
+	if st.velocity < 0 {
+		step = -step
+	}
+	st.scroll += step
+
+	st.coastFrame++
+	if st.coastFrame > st.inertia {
+		st.velocity = 0
+		st.coastFrame = 0
+	}
+}
+
+// toEnd processes a user request to scroll to the last line. Resumes content
+// rolling if it was paused.
+func (st *scrollTracker) toEnd() {
+	st.end = true
+}
+
 // upOnePage processes a user request to scroll up by one page.
 func (st *scrollTracker) upOnePage() {
 	st.scrollPage--
@@ -82,24 +198,75 @@ func (st *scrollTracker) downOnePage() {
 	st.scrollPage++
 }
 
+// linesRemoved adjusts the scrolling position after the specified number of
+// lines were removed from the top of the content, so that the same content
+// remains visible.
+func (st *scrollTracker) linesRemoved(n int) {
+	st.first -= n
+	if st.first < 0 {
+		st.first = 0
+	}
+}
+
 // doScroll processes any outstanding scroll requests and calculates the
 // resulting first line.
 func (st *scrollTracker) doScroll(lines, height int) int {
-	first := st.first + st.scroll + st.scrollPage*height
+	// The position in half lines.
+	pos := 2*(st.first+st.scroll+st.scrollPage*height) + st.halfScroll
+	if st.half {
+		pos++
+	}
+	first, half := pos>>1, pos&1 == 1
+	if st.end {
+		first, half = math.MaxUint32, false
+	}
 	st.scroll = 0
 	st.scrollPage = 0
-	return normalizeScroll(first, lines, height)
+	st.halfScroll = 0
+	st.end = false
+
+	norm := normalizeScroll(first, lines, height)
+	// Only scrolled by half a line if there is a line below the canvas to
+	// show the top half of.
+	st.half = half && norm == first && norm < lines-height
+	return norm
 }
 
 // firstLine returns the number of the first line that should be drawn on a
 // canvas of the specified height if there is the provided number of lines of
 // text.
 func (st *scrollTracker) firstLine(lines, height int) int {
+	st.coast()
+
 	// Execute the scrolling FSM.
 	st.state = st.state(st, lines, height)
 	return st.first
 }
 
+// linkedFirstLine is like firstLine, but the first line comes from the
+// scrolling position shared by the link. Any outstanding scroll requests move
+// the shared position. Content rolling doesn't apply to linked widgets.
+func (st *scrollTracker) linkedFirstLine(l widgetapi.ScrollLink, w widgetapi.Scrollable, lines, height int) int {
+	st.coast()
+
+	// The shared position is in whole lines, every two half line steps move
+	// it by a line and an odd step waits for the next one.
+	delta := st.scroll + st.scrollPage*height + st.halfScroll/2
+	st.halfScroll %= 2
+	if st.end {
+		delta = math.MaxUint32
+		st.halfScroll = 0
+	}
+	st.scroll = 0
+	st.scrollPage = 0
+	st.end = false
+	st.half = false
+
+	maxFirst := normalizeScroll(math.MaxUint32, lines, height)
+	st.first = normalizeScroll(l.Scroll(w, delta, maxFirst), lines, height)
+	return st.first
+}
+
 // rollState is a state in the scrolling FSM.
 type rollState func(st *scrollTracker, lines, height int) rollState
 
@@ -115,8 +282,9 @@ func rollingDisabled(st *scrollTracker, lines, height int) rollState {
 func rollToEnd(st *scrollTracker, lines, height int) rollState {
 	// If the user didn't scroll, just roll the content so that the last line
 	// is visible.
-	if st.scroll == 0 && st.scrollPage == 0 {
+	if st.scroll == 0 && st.scrollPage == 0 && st.halfScroll == 0 && !st.end {
 		st.first = normalizeScroll(math.MaxUint32, lines, height)
+		st.half = false
 		return rollToEnd
 	}
 
@@ -124,6 +292,7 @@ func rollToEnd(st *scrollTracker, lines, height int) rollState {
 	if lastLineVisible(st.first, lines, height) {
 		return rollToEnd
 	}
+	st.paused = true
 	return rollingPaused
 }
 
@@ -132,6 +301,7 @@ func rollToEnd(st *scrollTracker, lines, height int) rollState {
 func rollingPaused(st *scrollTracker, lines, height int) rollState {
 	st.first = st.doScroll(lines, height)
 	if lastLineVisible(st.first, lines, height) {
+		st.paused = false
 		return rollToEnd
 	}
 	return rollingPaused
@@ -163,3 +333,11 @@ func normalizeScroll(first, lines, height int) int {
 	}
 	return first
 }
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
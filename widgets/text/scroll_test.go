@@ -16,6 +16,8 @@ package text
 
 import (
 	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
 )
 
 func TestScrollTrackerNoContentRolling(t *testing.T) {
@@ -269,6 +271,210 @@ func TestScrollTrackerContentRolling(t *testing.T) {
 	}
 }
 
+func TestScrollTrackerFollow(t *testing.T) {
+	st := newScrollTracker(&options{rollContent: true})
+	// All of these test cases act on the same instance of the scroll tracker.
+	tests := []struct {
+		desc       string
+		lines      int
+		height     int
+		events     func()
+		want       int
+		wantPaused bool
+	}{
+		{
+			desc:   "follows the last line",
+			lines:  4,
+			height: 2,
+			want:   2,
+		},
+		{
+			desc:   "scrolling away from the last line pauses following",
+			lines:  4,
+			height: 2,
+			events: func() {
+				st.upOnePage()
+			},
+			want:       0,
+			wantPaused: true,
+		},
+		{
+			desc:       "appending while paused doesn't move the viewport",
+			lines:      8,
+			height:     2,
+			want:       0,
+			wantPaused: true,
+		},
+		{
+			desc:   "scrolling to the end resumes following",
+			lines:  8,
+			height: 2,
+			events: func() {
+				st.toEnd()
+			},
+			want: 6,
+		},
+		{
+			desc:   "follows new lines after resuming",
+			lines:  10,
+			height: 2,
+			want:   8,
+		},
+		{
+			desc:   "scrolling to the end overrides other scroll requests",
+			lines:  10,
+			height: 2,
+			events: func() {
+				st.upOneLine()
+				st.toEnd()
+			},
+			want: 8,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.events != nil {
+				tc.events()
+			}
+			got := st.firstLine(tc.lines, tc.height)
+			if got != tc.want {
+				t.Errorf("firstLine => got %d, want %d", got, tc.want)
+			}
+			if st.paused != tc.wantPaused {
+				t.Errorf("paused => got %v, want %v", st.paused, tc.wantPaused)
+			}
+		})
+	}
+}
+
+func TestScrollTrackerToEndWithoutRolling(t *testing.T) {
+	st := newScrollTracker(&options{})
+	st.toEnd()
+	if got, want := st.firstLine(5, 2), 3; got != want {
+		t.Errorf("firstLine => got %d, want %d", got, want)
+	}
+}
+
+func TestScrollTrackerMouseWheel(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   *options
+		lines  int
+		height int
+		events func(*scrollTracker)
+		// want is the expected first line on each of the consecutive frames.
+		want []int
+	}{
+		{
+			desc:   "scrolls down by one line per tick by default",
+			opts:   &options{},
+			lines:  20,
+			height: 2,
+			events: func(st *scrollTracker) {
+				st.wheelDown()
+			},
+			want: []int{1, 1},
+		},
+		{
+			desc:   "sensitivity multiplies the scroll down",
+			opts:   &options{scrollSensitivity: 3},
+			lines:  20,
+			height: 2,
+			events: func(st *scrollTracker) {
+				st.wheelDown()
+				st.wheelDown()
+			},
+			want: []int{6, 6},
+		},
+		{
+			desc:   "sensitivity multiplies the scroll up",
+			opts:   &options{scrollSensitivity: 3},
+			lines:  20,
+			height: 2,
+			events: func(st *scrollTracker) {
+				st.downOnePage()
+				st.downOnePage()
+				st.downOnePage()
+				st.wheelUp()
+			},
+			want: []int{3, 3},
+		},
+		{
+			desc:   "sensitivity doesn't affect keyboard scrolling",
+			opts:   &options{scrollSensitivity: 3},
+			lines:  20,
+			height: 2,
+			events: func(st *scrollTracker) {
+				st.downOneLine()
+			},
+			want: []int{1, 1},
+		},
+		{
+			desc:   "inertia produces decaying scroll steps over subsequent frames",
+			opts:   &options{scrollSensitivity: 3, scrollInertia: 3},
+			lines:  20,
+			height: 2,
+			events: func(st *scrollTracker) {
+				st.wheelDown()
+			},
+			// Steps of 3, 3, 2, 1 and then stops.
+			want: []int{3, 6, 8, 9, 9},
+		},
+		{
+			desc:   "inertia upwards",
+			opts:   &options{scrollSensitivity: 3, scrollInertia: 3},
+			lines:  20,
+			height: 2,
+			events: func(st *scrollTracker) {
+				st.downOnePage()
+				st.downOnePage()
+				st.downOnePage()
+				st.downOnePage()
+				st.downOnePage()
+				st.wheelUp()
+			},
+			want: []int{7, 4, 2, 1, 1},
+		},
+		{
+			desc:   "inertia is capped at the last line",
+			opts:   &options{scrollSensitivity: 3, scrollInertia: 3},
+			lines:  7,
+			height: 2,
+			events: func(st *scrollTracker) {
+				st.wheelDown()
+			},
+			want: []int{3, 5, 5, 5, 5},
+		},
+		{
+			desc:   "no inertia with a single frame",
+			opts:   &options{scrollSensitivity: 2, scrollInertia: 1},
+			lines:  20,
+			height: 2,
+			events: func(st *scrollTracker) {
+				st.wheelDown()
+			},
+			want: []int{2, 3, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			st := newScrollTracker(tc.opts)
+			if tc.events != nil {
+				tc.events(st)
+			}
+			var got []int
+			for range tc.want {
+				got = append(got, st.firstLine(tc.lines, tc.height))
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("firstLine on consecutive frames => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestNormalizeScroll(t *testing.T) {
 	tests := []struct {
 		desc   string
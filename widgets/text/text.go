@@ -16,13 +16,20 @@
 package text
 
 import (
+	"encoding/json"
 	"fmt"
 	"image"
+	"math"
 	"sync"
 
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/buffer"
+	"github.com/mum4k/termdash/internal/draw"
+	"github.com/mum4k/termdash/internal/runewidth"
 	"github.com/mum4k/termdash/internal/wrap"
+	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	"github.com/mum4k/termdash/widgetapi"
 )
@@ -36,6 +43,10 @@ import (
 // By default the widget supports scrolling of content with either the keyboard
 // or mouse. See the options for the default keys and mouse buttons.
 //
+// Parts of the content written with the WriteLink option are links, which are
+// activated by clicking on them with the mouse or by focusing them with the
+// keyboard and pressing the activation key, see the LinkKeys option.
+//
 // Implements widgetapi.Widget. This object is thread-safe.
 type Text struct {
 	// content is the text content that will be displayed in the widget as
@@ -46,6 +57,9 @@ type Text struct {
 
 	// scroll tracks scrolling the position.
 	scroll *scrollTracker
+	// scrollLink is the shared scrolling position, nil unless the widget was
+	// linked with other widgets, see LinkScroll.
+	scrollLink widgetapi.ScrollLink
 
 	// lastWidth stores the width of the last canvas the widget drew on.
 	// Used to determine if the previous line wrapping was invalidated.
@@ -55,6 +69,17 @@ type Text struct {
 	// invalidated.
 	contentChanged bool
 
+	// links are the links written with the WriteLink option in the order of
+	// the content.
+	links []*link
+	// cellLinks maps the cells of the content to the links they belong to.
+	cellLinks map[*buffer.Cell]*link
+	// focusedLink is the link focused with the keyboard, nil if none is.
+	focusedLink *link
+	// linkAt maps the points on the canvas to the links drawn there on the
+	// last call to Draw.
+	linkAt map[image.Point]*link
+
 	// mu protects the Text widget.
 	mu sync.Mutex
 
@@ -88,30 +113,102 @@ func (t *Text) reset() {
 	t.scroll = newScrollTracker(t.opts)
 	t.lastWidth = 0
 	t.contentChanged = true
+	t.links = nil
+	t.cellLinks = nil
+	t.focusedLink = nil
+	t.linkAt = nil
 }
 
 // Write writes text for the widget to display. Multiple calls append
 // additional text. The text contain cannot control characters
 // (unicode.IsControl) or space character (unicode.IsSpace) other than:
-//   ' ', '\n'
+//
+//	' ', '\n'
+//
 // Any newline ('\n') characters are interpreted as newlines when displaying
-// the text.
+// the text. The ControlCharacters option allows writing text with other
+// control characters.
 func (t *Text) Write(text string, wOpts ...WriteOption) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	opts := newWriteOptions(wOpts...)
+	if text != "" {
+		text = sanitizeControl(text, t.opts.controlChars)
+		if text == "" {
+			// Only stripped control characters were written.
+			if opts.replace {
+				t.reset()
+			}
+			return nil
+		}
+	}
 	if err := wrap.ValidText(text); err != nil {
 		return err
 	}
 
-	opts := newWriteOptions(wOpts...)
 	if opts.replace {
 		t.reset()
 	}
+	start := len(t.content)
 	for _, r := range text {
 		t.content = append(t.content, buffer.NewCell(r, opts.cellOpts))
 	}
+	if opts.link != nil && len(t.content) > start {
+		t.addLink(t.content[start:], opts.link)
+	}
 	t.contentChanged = true
+	return t.dropOldLines()
+}
+
+// dropOldLines discards the oldest lines from the content if it has more lines
+// than allowed by the MaxLines option.
+// The caller must hold t.mu.
+func (t *Text) dropOldLines() error {
+	if t.opts.maxLines == 0 {
+		return nil
+	}
+
+	var newlines []int
+	for i, c := range t.content {
+		if c.Rune == '\n' {
+			newlines = append(newlines, i)
+		}
+	}
+	lines := len(newlines)
+	if l := len(t.content); l > 0 && t.content[l-1].Rune != '\n' {
+		lines++ // The last line isn't terminated by a newline.
+	}
+
+	drop := lines - t.opts.maxLines
+	if drop <= 0 {
+		return nil
+	}
+
+	if t.lastWidth > 0 {
+		// Keep the same content visible if the user scrolled, the
+		// scroll position is in wrapped lines.
+		var wrapped, start int
+		for _, nl := range newlines[:drop] {
+			wr, err := wrap.Cells(t.content[start:nl], t.lastWidth, t.opts.wrapMode, wrap.LongWords(wrapLongWords[t.opts.longWord]))
+			if err != nil {
+				return err
+			}
+			if len(wr) == 0 {
+				wrapped++ // An empty line.
+			} else {
+				wrapped += len(wr)
+			}
+			start = nl + 1
+		}
+		t.scroll.linesRemoved(wrapped)
+	}
+
+	// Copy so that the discarded cells can be garbage collected.
+	t.dropLinks(t.content[:newlines[drop-1]+1])
+	remaining := t.content[newlines[drop-1]+1:]
+	t.content = make([]*buffer.Cell, len(remaining))
+	copy(t.content, remaining)
 	return nil
 }
 
@@ -124,7 +221,7 @@ const minLinesForMarkers = 3
 // marker was drawn.
 func (t *Text) drawScrollUp(cvs *canvas.Canvas, cur image.Point, fromLine int) (bool, error) {
 	height := cvs.Area().Dy()
-	if cur.Y == 0 && height >= minLinesForMarkers && fromLine > 0 {
+	if cur.Y == 0 && height >= minLinesForMarkers && fromLine > 0 && t.opts.tailView == 0 {
 		cells, err := cvs.SetCell(cur, '⇧')
 		if err != nil {
 			return false, err
@@ -156,13 +253,59 @@ func (t *Text) drawScrollDown(cvs *canvas.Canvas, cur image.Point, fromLine int)
 	return false, nil
 }
 
+// tailFirstLine returns the number of the first line that should be drawn on a
+// canvas of the specified height in order to only display the last n of the
+// provided number of lines, see TailView.
+func tailFirstLine(lines, n, height int) int {
+	if n > height {
+		n = height
+	}
+	if first := lines - n; first > 0 {
+		return first
+	}
+	return 0
+}
+
 // draw draws the text context on the canvas starting at the specified line.
 func (t *Text) draw(cvs *canvas.Canvas) error {
 	var cur image.Point // Tracks the current drawing position on the canvas.
 	height := cvs.Area().Dy()
-	fromLine := t.scroll.firstLine(len(t.wrapped), height)
+	var fromLine int
+	switch {
+	case t.opts.tailView > 0:
+		fromLine = tailFirstLine(len(t.wrapped), t.opts.tailView, height)
+		t.scroll.first = fromLine
+		t.scroll.half = false
+	case t.scrollLink != nil:
+		fromLine = t.scroll.linkedFirstLine(t.scrollLink, t, len(t.wrapped), height)
+	default:
+		fromLine = t.scroll.firstLine(len(t.wrapped), height)
+	}
+
+	lines := t.wrapped[fromLine:]
+	last := height // The row under the last row of text.
+	if t.scroll.half && height >= minHalfScrollHeight {
+		// Scrolled by half a line, the bottom half of the first line is
+		// visible at the top and the top half of the last line at the
+		// bottom.
+		if err := t.drawHalfLine(cvs, 0, lines[0], '▄'); err != nil {
+			return err
+		}
+		if height-1 < len(lines) {
+			if err := t.drawHalfLine(cvs, height-1, lines[height-1], '▀'); err != nil {
+				return err
+			}
+		}
+		lines = lines[1:]
+		cur = image.Point{0, 1}
+		last = height - 1
+	}
+
+	for _, line := range lines {
+		if cur.Y >= last {
+			break // Skip all lines falling after (under) the canvas.
+		}
 
-	for _, line := range t.wrapped[fromLine:] {
 		// Scroll up marker.
 		scrlUp, err := t.drawScrollUp(cvs, cur, fromLine)
 		if err != nil {
@@ -183,8 +326,9 @@ func (t *Text) draw(cvs *canvas.Canvas) error {
 			break // Skip all lines falling after (under) the canvas.
 		}
 
-		for _, cell := range line {
-			tr, err := lineTrim(cvs, cur, cell.Rune, t.opts)
+		cur = image.Point{t.lineStart(cvs, line), cur.Y}
+		for _, c := range line {
+			tr, err := lineTrim(cvs, cur, c.Rune, t.opts)
 			if err != nil {
 				return err
 			}
@@ -193,10 +337,20 @@ func (t *Text) draw(cvs *canvas.Canvas) error {
 				break // Skip over any characters trimmed on the current line.
 			}
 
-			cells, err := cvs.SetCell(cur, cell.Rune, cell.Opts)
+			l := t.cellLinks[c]
+			cOpts := []cell.Option{c.Opts}
+			if l != nil && l == t.focusedLink {
+				cOpts = append(cOpts, cell.BgColor(t.opts.linkFocusColor))
+			}
+			cells, err := cvs.SetCell(cur, c.Rune, cOpts...)
 			if err != nil {
 				return err
 			}
+			if l != nil {
+				for i := 0; i < cells; i++ {
+					t.linkAt[image.Point{cur.X + i, cur.Y}] = l
+				}
+			}
 			cur = image.Point{cur.X + cells, cur.Y} // Move within the same line.
 		}
 		cur = image.Point{0, cur.Y + 1} // Move to the next line.
@@ -204,23 +358,83 @@ func (t *Text) draw(cvs *canvas.Canvas) error {
 	return nil
 }
 
+// minHalfScrollHeight is the minimum height of the canvas required to draw
+// the content scrolled by half a line, see SmoothScroll.
+const minHalfScrollHeight = 2
+
+// drawHalfLine draws the partially visible line on the specified row of the
+// canvas, each cell that contains text is drawn as the provided half block
+// rune in the colors of the text.
+func (t *Text) drawHalfLine(cvs *canvas.Canvas, y int, line []*buffer.Cell, block rune) error {
+	width := cvs.Area().Dx()
+	x := t.lineStart(cvs, line)
+	for _, c := range line {
+		rw := runewidth.RuneWidth(c.Rune)
+		if x+rw > width {
+			break
+		}
+		if c.Rune != ' ' {
+			for i := 0; i < rw; i++ {
+				if _, err := cvs.SetCell(image.Point{x + i, y}, block, c.Opts); err != nil {
+					return err
+				}
+			}
+		}
+		x += rw
+	}
+	return nil
+}
+
+// lineStart returns the X coordinate where drawing of the line starts in
+// order to honor the configured horizontal alignment. Lines that are as wide
+// or wider than the canvas start at the left edge.
+func (t *Text) lineStart(cvs *canvas.Canvas, line []*buffer.Cell) int {
+	var lineWidth int
+	for _, c := range line {
+		lineWidth += runewidth.RuneWidth(c.Rune)
+	}
+
+	gap := cvs.Area().Dx() - lineWidth
+	if gap <= 0 {
+		return 0
+	}
+	switch t.opts.hAlign {
+	case align.HorizontalCenter:
+		return gap / 2
+	case align.HorizontalRight:
+		return gap
+	default:
+		return 0
+	}
+}
+
 // Draw draws the text onto the canvas.
 // Implements widgetapi.Widget.Draw.
 func (t *Text) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	var mapCvs *canvas.Canvas
+	if t.opts.showMinimap && cvs.Area().Dx() >= minMinimapCanvasWidth {
+		textCvs, mc, err := splitMinimap(cvs)
+		if err != nil {
+			return err
+		}
+		cvs, mapCvs = textCvs, mc
+	}
+
 	width := cvs.Area().Dx()
 	if len(t.content) > 0 && (t.contentChanged || t.lastWidth != width) {
 		// The previous text preprocessing (line wrapping) is invalidated when
 		// new text is added or the width of the canvas changed.
-		wr, err := wrap.Cells(t.content, width, t.opts.wrapMode)
+		wr, err := wrap.Cells(t.content, width, t.opts.wrapMode, wrap.LongWords(wrapLongWords[t.opts.longWord]))
 		if err != nil {
 			return err
 		}
 		t.wrapped = wr
 	}
 	t.lastWidth = width
+	t.linkAt = map[image.Point]*link{}
 
 	if len(t.wrapped) == 0 {
 		return nil // Nothing to draw if there's no text.
@@ -229,15 +443,60 @@ func (t *Text) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	if err := t.draw(cvs); err != nil {
 		return err
 	}
+	if mapCvs != nil {
+		if err := drawMinimap(mapCvs, t.wrapped, width, t.scroll.first, t.opts.minimapHighlightColor); err != nil {
+			return err
+		}
+	}
+	if err := t.drawFollowIndicator(cvs); err != nil {
+		return err
+	}
 	t.contentChanged = false
 	return nil
 }
 
+// drawFollowIndicator draws the text provided via the FollowIndicator option
+// on the last line if the content rolling is paused.
+func (t *Text) drawFollowIndicator(cvs *canvas.Canvas) error {
+	if !t.scroll.paused || t.opts.followIndicator == "" {
+		return nil
+	}
+
+	ar := cvs.Area()
+	x := ar.Max.X - runewidth.StringWidth(t.opts.followIndicator)
+	if x < ar.Min.X {
+		x = ar.Min.X
+	}
+	start := image.Point{x, ar.Max.Y - 1}
+	if err := cvs.SetAreaCells(image.Rect(start.X, start.Y, ar.Max.X, ar.Max.Y), ' '); err != nil {
+		return err
+	}
+	return draw.Text(cvs, t.opts.followIndicator, start,
+		draw.TextCellOpts(t.opts.followIndicatorOpts...),
+		draw.TextMaxX(ar.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
 // Keyboard implements widgetapi.Widget.Keyboard.
 func (t *Text) Keyboard(k *terminalapi.Keyboard) error {
+	if fn := t.keyboard(k); fn != nil {
+		// Mutex must be released when calling the callback.
+		// Users might call container methods from the callback like the
+		// Container.Update, see #205.
+		return fn()
+	}
+	return nil
+}
+
+// keyboard processes the keyboard event and returns the callback of the link
+// activated by the event, if any.
+func (t *Text) keyboard(k *terminalapi.Keyboard) LinkFn {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	// The scroll keys take precedence over the follow and link keys, which
+	// might collide with them when left at their default values.
 	switch {
 	case k.Key == t.opts.keyUp:
 		t.scroll.upOneLine()
@@ -247,20 +506,145 @@ func (t *Text) Keyboard(k *terminalapi.Keyboard) error {
 		t.scroll.upOnePage()
 	case k.Key == t.opts.keyPgDown:
 		t.scroll.downOnePage()
+	case k.Key == t.opts.keyFollow:
+		t.scroll.toEnd()
+	case k.Key == t.opts.keyLinkNext:
+		t.focusNextLink()
+	case k.Key == t.opts.keyLinkActivate && t.focusedLink != nil:
+		return t.focusedLink.fn
 	}
 	return nil
 }
 
 // Mouse implements widgetapi.Widget.Mouse.
 func (t *Text) Mouse(m *terminalapi.Mouse) error {
+	if fn := t.mouse(m); fn != nil {
+		// Mutex must be released when calling the callback.
+		// Users might call container methods from the callback like the
+		// Container.Update, see #205.
+		return fn()
+	}
+	return nil
+}
+
+// mouse processes the mouse event and returns the callback of the link
+// activated by the event, if any.
+func (t *Text) mouse(m *terminalapi.Mouse) LinkFn {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
 	switch b := m.Button; {
 	case b == t.opts.mouseUpButton:
-		t.scroll.upOneLine()
+		t.scroll.wheelUp()
 	case b == t.opts.mouseDownButton:
-		t.scroll.downOneLine()
+		t.scroll.wheelDown()
+	case b == mouse.ButtonLeft:
+		if l := t.linkAt[m.Position]; l != nil {
+			t.focusedLink = l
+			return l.fn
+		}
+	}
+	return nil
+}
+
+// Measure returns the size the text content occupies when wrapped to the
+// width of the constraint according to the configured wrapping mode.
+// Lines that are trimmed instead of wrapped count only up to the constraint.
+// Implements widgetapi.Measurer.
+func (t *Text) Measure(constraint image.Point) image.Point {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.content) == 0 {
+		return image.Point{}
+	}
+
+	width := constraint.X
+	if width <= 0 {
+		width = math.MaxInt32
+	}
+	var mapWidth int
+	if t.opts.showMinimap && width >= minMinimapCanvasWidth {
+		mapWidth = minimapWidth
+		width -= mapWidth
+	}
+	lines, err := wrap.Cells(t.content, width, t.opts.wrapMode, wrap.LongWords(wrapLongWords[t.opts.longWord]))
+	if err != nil {
+		// The content was already validated on Write, Measure has no way to
+		// report the error, the following Draw will return it.
+		return image.Point{}
+	}
+
+	var size image.Point
+	for _, line := range lines {
+		var lineWidth int
+		for _, c := range line {
+			lineWidth += runewidth.RuneWidth(c.Rune)
+		}
+		if lineWidth > width {
+			lineWidth = width
+		}
+		if lineWidth > size.X {
+			size.X = lineWidth
+		}
+	}
+	size.X += mapWidth
+	size.Y = len(lines)
+	if constraint.Y > 0 && size.Y > constraint.Y {
+		size.Y = constraint.Y
+	}
+	return size
+}
+
+// LinkScroll links the scrolling position of the widget with other widgets.
+// While linked, the content doesn't roll, see RollContent.
+// Implements widgetapi.Scrollable.
+func (t *Text) LinkScroll(l widgetapi.ScrollLink) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.scrollLink = l
+}
+
+// textState is the serialized state of the Text widget.
+type textState struct {
+	// First is the first line drawn as of the last call to Draw.
+	First int `json:"first"`
+	// Paused indicates that content rolling was paused by scrolling.
+	Paused bool `json:"paused"`
+}
+
+// SaveState returns the serialized scrolling position.
+// Implements widgetapi.Stateful.
+func (t *Text) SaveState() ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return json.Marshal(&textState{
+		First:  t.scroll.first,
+		Paused: t.scroll.paused,
+	})
+}
+
+// RestoreState restores the scrolling position saved by SaveState. The
+// position is applied on the next call to Draw.
+// Implements widgetapi.Stateful.
+func (t *Text) RestoreState(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	st := &textState{}
+	if err := json.Unmarshal(data, st); err != nil {
+		return fmt.Errorf("unable to parse the Text widget state: %v", err)
+	}
+	if st.First < 0 {
+		return fmt.Errorf("invalid first line %d in the Text widget state, must be a positive number", st.First)
+	}
+
+	t.scroll.first = st.First
+	if t.opts.rollContent && st.Paused {
+		t.scroll.paused = true
+		t.scroll.state = rollingPaused
 	}
 	return nil
 }
@@ -269,7 +653,7 @@ func (t *Text) Mouse(m *terminalapi.Mouse) error {
 func (t *Text) Options() widgetapi.Options {
 	var ks widgetapi.KeyScope
 	var ms widgetapi.MouseScope
-	if t.opts.disableScrolling {
+	if t.opts.disableScrolling || t.opts.tailView > 0 {
 		ks = widgetapi.KeyScopeNone
 		ms = widgetapi.MouseScopeNone
 	} else {
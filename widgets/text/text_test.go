@@ -15,11 +15,15 @@
 package text
 
 import (
+	"fmt"
 	"image"
+	"strings"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
 	"github.com/mum4k/termdash/internal/canvas"
 	"github.com/mum4k/termdash/internal/canvas/testcanvas"
 	"github.com/mum4k/termdash/internal/draw"
@@ -54,6 +58,28 @@ func TestTextDraws(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "fails when the follow key is one of the scroll keys",
+			opts: []Option{
+				FollowKey(DefaultScrollKeyPageUp),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails when the follow indicator contains a newline",
+			opts: []Option{
+				FollowIndicator("paused\n"),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
 		{
 			desc: "fails when scroll mouse buttons aren't unique",
 			opts: []Option{
@@ -65,6 +91,39 @@ func TestTextDraws(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "fails when scroll sensitivity isn't positive",
+			opts: []Option{
+				ScrollSensitivity(0),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails when scroll inertia is negative",
+			opts: []Option{
+				ScrollInertia(-1),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails when max lines is negative",
+			opts: []Option{
+				MaxLines(-1),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
 		{
 			desc:   "empty when no written text",
 			canvas: image.Rect(0, 0, 1, 1),
@@ -83,6 +142,84 @@ func TestTextDraws(t *testing.T) {
 			},
 			wantWriteErr: true,
 		},
+		{
+			desc: "renders control characters in caret notation",
+			opts: []Option{
+				ControlCharacters(ControlCharsCaret),
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: func(widget *Text) error {
+				return widget.Write("ab\r\ncd\te")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "ab^M", image.Point{0, 0})
+				testdraw.MustText(c, "cd^Ie", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "strips control characters",
+			opts: []Option{
+				ControlCharacters(ControlCharsStrip),
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: func(widget *Text) error {
+				return widget.Write("ab\r\ncd\te")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				testdraw.MustText(c, "cde", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "writing only stripped control characters draws nothing",
+			opts: []Option{
+				ControlCharacters(ControlCharsStrip),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("\r\t")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "replacing the content with only stripped control characters clears it",
+			opts: []Option{
+				ControlCharacters(ControlCharsStrip),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			writes: func(widget *Text) error {
+				if err := widget.Write("abc"); err != nil {
+					return err
+				}
+				return widget.Write("\a", WriteReplace())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "fails on unsupported control characters mode",
+			opts: []Option{
+				ControlCharacters(ControlChars(-1)),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
 		{
 			desc:   "draws line of text",
 			canvas: image.Rect(0, 0, 10, 1),
@@ -304,57 +441,114 @@ func TestTextDraws(t *testing.T) {
 			},
 		},
 		{
-			desc:   "doesn't draw the scroll up marker on small canvas",
-			canvas: image.Rect(0, 0, 10, 2),
+			desc: "fails on negative TailView",
+			opts: []Option{
+				TailView(-1),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "TailView draws only the last lines",
+			opts: []Option{
+				TailView(2),
+			},
+			canvas: image.Rect(0, 0, 10, 4),
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3")
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
 			},
-			events: func(widget *Text) {
-				widget.Mouse(&terminalapi.Mouse{
-					Button: mouse.ButtonWheelDown,
-				})
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line3", image.Point{0, 0})
+				testdraw.MustText(c, "line4", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "TailView longer than the canvas draws the last lines that fit without markers",
+			opts: []Option{
+				TailView(5),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line2", image.Point{0, 0})
+				testdraw.MustText(c, "line3", image.Point{0, 1})
+				testdraw.MustText(c, "line4", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "TailView counts wrapped lines",
+			opts: []Option{
+				TailView(2),
+				WrapAtRunes(),
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1 and")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
 				testdraw.MustText(c, "line1", image.Point{0, 0})
-				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, " and", image.Point{0, 1})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls down on down arrow a line at a time",
-			canvas: image.Rect(0, 0, 10, 3),
+			desc: "TailView ignores scrolling",
+			opts: []Option{
+				TailView(2),
+			},
+			canvas: image.Rect(0, 0, 10, 4),
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3")
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
 			},
 			events: func(widget *Text) {
+				widget.Mouse(&terminalapi.Mouse{
+					Button: mouse.ButtonWheelUp,
+				})
 				widget.Keyboard(&terminalapi.Keyboard{
-					Key: keyboard.KeyArrowDown,
+					Key: DefaultScrollKeyUp,
 				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "⇧", image.Point{0, 0})
-				testdraw.MustText(c, "line2", image.Point{0, 1})
-				testdraw.MustText(c, "line3", image.Point{0, 2})
+				testdraw.MustText(c, "line3", image.Point{0, 0})
+				testdraw.MustText(c, "line4", image.Point{0, 1})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls down on pageDn a page at a time",
+			desc: "scrolls down on mouse wheel down by the configured sensitivity",
+			opts: []Option{
+				ScrollSensitivity(2),
+			},
 			canvas: image.Rect(0, 0, 10, 3),
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3\nline4\nline5\nline6")
+				return widget.Write("line0\nline1\nline2\nline3\nline4\nline5")
 			},
 			events: func(widget *Text) {
-				widget.Keyboard(&terminalapi.Keyboard{
-					Key: keyboard.KeyPgDn,
+				widget.Mouse(&terminalapi.Mouse{
+					Button: mouse.ButtonWheelDown,
 				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
@@ -362,49 +556,61 @@ func TestTextDraws(t *testing.T) {
 				c := testcanvas.MustNew(ft.Area())
 
 				testdraw.MustText(c, "⇧", image.Point{0, 0})
-				testdraw.MustText(c, "line4", image.Point{0, 1})
+				testdraw.MustText(c, "line3", image.Point{0, 1})
 				testdraw.MustText(c, "⇩", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls down using custom mouse button a line at a time",
-			canvas: image.Rect(0, 0, 10, 3),
+			desc: "drops the oldest lines over the max lines",
 			opts: []Option{
-				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonRight),
+				MaxLines(2),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line1", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
 			},
+		},
+		{
+			desc:   "doesn't draw the scroll up marker on small canvas",
+			canvas: image.Rect(0, 0, 10, 2),
 			writes: func(widget *Text) error {
 				return widget.Write("line0\nline1\nline2\nline3")
 			},
 			events: func(widget *Text) {
 				widget.Mouse(&terminalapi.Mouse{
-					Button: mouse.ButtonRight,
+					Button: mouse.ButtonWheelDown,
 				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 0})
 				testdraw.MustText(c, "line2", image.Point{0, 1})
-				testdraw.MustText(c, "line3", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls down using custom key a line at a time",
+			desc:   "scrolls down on down arrow a line at a time",
 			canvas: image.Rect(0, 0, 10, 3),
-			opts: []Option{
-				ScrollKeys('u', 'd', 'k', 'l'),
-			},
 			writes: func(widget *Text) error {
 				return widget.Write("line0\nline1\nline2\nline3")
 			},
 			events: func(widget *Text) {
 				widget.Keyboard(&terminalapi.Keyboard{
-					Key: 'd',
+					Key: keyboard.KeyArrowDown,
 				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
@@ -419,147 +625,175 @@ func TestTextDraws(t *testing.T) {
 			},
 		},
 		{
-			desc:   "scrolls down using custom key a page at a time",
-			canvas: image.Rect(0, 0, 10, 3),
+			desc: "smooth scrolling draws partial lines with half blocks",
 			opts: []Option{
-				ScrollKeys('u', 'd', 'k', 'l'),
+				SmoothScroll(),
 			},
+			canvas: image.Rect(0, 0, 10, 3),
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3\nline4\nline5\nline6")
+				return widget.Write("l 0\nline1\nline2\nline3", WriteCellOpts(cell.FgColor(cell.ColorRed)))
 			},
 			events: func(widget *Text) {
 				widget.Keyboard(&terminalapi.Keyboard{
-					Key: 'l',
+					Key: keyboard.KeyArrowDown,
 				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "⇧", image.Point{0, 0})
-				testdraw.MustText(c, "line4", image.Point{0, 1})
-				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				red := draw.TextCellOpts(cell.FgColor(cell.ColorRed))
+				testdraw.MustText(c, "▄", image.Point{0, 0}, red)
+				testdraw.MustText(c, "▄", image.Point{2, 0}, red)
+				testdraw.MustText(c, "line1", image.Point{0, 1}, red)
+				testdraw.MustText(c, "▀▀▀▀▀", image.Point{0, 2}, red)
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "wraps lines at half-width rune boundaries",
-			canvas: image.Rect(0, 0, 10, 5),
+			desc: "smooth scrolling by a full line matches the normal rendering",
 			opts: []Option{
-				WrapAtRunes(),
+				SmoothScroll(),
 			},
+			canvas: image.Rect(0, 0, 10, 3),
 			writes: func(widget *Text) error {
-				return widget.Write("hello world\nshort\nand long again")
+				return widget.Write("line0\nline1\nline2\nline3")
+			},
+			events: func(widget *Text) {
+				for i := 0; i < 2; i++ {
+					widget.Keyboard(&terminalapi.Keyboard{
+						Key: keyboard.KeyArrowDown,
+					})
+				}
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "hello worl", image.Point{0, 0})
-				testdraw.MustText(c, "d", image.Point{0, 1})
-				testdraw.MustText(c, "short", image.Point{0, 2})
-				testdraw.MustText(c, "and long a", image.Point{0, 3})
-				testdraw.MustText(c, "gain", image.Point{0, 4})
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "line3", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "wraps lines at full-width rune boundaries",
-			canvas: image.Rect(0, 0, 10, 6),
+			desc: "smooth scrolling doesn't scroll by half a line past the last line",
 			opts: []Option{
-				WrapAtRunes(),
+				SmoothScroll(),
 			},
+			canvas: image.Rect(0, 0, 10, 3),
 			writes: func(widget *Text) error {
-				return widget.Write("hello wor你\nhello wor你d\nand long 世")
+				return widget.Write("line0\nline1\nline2\nline3")
+			},
+			events: func(widget *Text) {
+				for i := 0; i < 3; i++ {
+					widget.Keyboard(&terminalapi.Keyboard{
+						Key: keyboard.KeyArrowDown,
+					})
+				}
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "hello wor", image.Point{0, 0})
-				testdraw.MustText(c, "你", image.Point{0, 1})
-				testdraw.MustText(c, "hello wor", image.Point{0, 2})
-				testdraw.MustText(c, "你d", image.Point{0, 3})
-				testdraw.MustText(c, "and long ", image.Point{0, 4})
-				testdraw.MustText(c, "世", image.Point{0, 5})
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "line3", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "wraps lines at word boundaries",
-			canvas: image.Rect(0, 0, 10, 6),
+			desc: "smooth scrolling up by half a line",
 			opts: []Option{
-				WrapAtWords(),
+				SmoothScroll(),
 			},
+			canvas: image.Rect(0, 0, 10, 3),
 			writes: func(widget *Text) error {
-				return widget.Write("hello wor你\nhello wor你d\nand long 世")
+				return widget.Write("line0\nline1\nline2\nline3")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowDown,
+				})
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowUp,
+				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "hello", image.Point{0, 0})
-				testdraw.MustText(c, "wor你", image.Point{0, 1})
-				testdraw.MustText(c, "hello", image.Point{0, 2})
-				testdraw.MustText(c, "wor你d", image.Point{0, 3})
-				testdraw.MustText(c, "and long", image.Point{0, 4})
-				testdraw.MustText(c, "世", image.Point{0, 5})
+				testdraw.MustText(c, "line0", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "wraps lines at word boundaries, inserts dash for long words",
-			canvas: image.Rect(0, 0, 10, 6),
-			opts: []Option{
-				WrapAtWords(),
-			},
+			desc:   "scrolls down on pageDn a page at a time",
+			canvas: image.Rect(0, 0, 10, 3),
 			writes: func(widget *Text) error {
-				return widget.Write("hello thisisalongword world")
+				return widget.Write("line0\nline1\nline2\nline3\nline4\nline5\nline6")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyPgDn,
+				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "hello", image.Point{0, 0})
-				testdraw.MustText(c, "thisisalo-", image.Point{0, 1})
-				testdraw.MustText(c, "ngword", image.Point{0, 2})
-				testdraw.MustText(c, "world", image.Point{0, 3})
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line4", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "rolls content upwards and trims lines",
-			canvas: image.Rect(0, 0, 10, 2),
+			desc:   "scrolls down using custom mouse button a line at a time",
+			canvas: image.Rect(0, 0, 10, 3),
 			opts: []Option{
-				RollContent(),
+				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonRight),
 			},
 			writes: func(widget *Text) error {
-				return widget.Write("hello world\nshort\nand long again")
+				return widget.Write("line0\nline1\nline2\nline3")
+			},
+			events: func(widget *Text) {
+				widget.Mouse(&terminalapi.Mouse{
+					Button: mouse.ButtonRight,
+				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "short", image.Point{0, 0})
-				testdraw.MustText(c, "and long …", image.Point{0, 1})
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "line3", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "rolls content upwards and draws an up scroll marker",
+			desc:   "scrolls down using custom key a line at a time",
 			canvas: image.Rect(0, 0, 10, 3),
 			opts: []Option{
-				RollContent(),
+				ScrollKeys('u', 'd', 'k', 'l'),
 			},
 			writes: func(widget *Text) error {
 				return widget.Write("line0\nline1\nline2\nline3")
 			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: 'd',
+				})
+			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
@@ -572,41 +806,42 @@ func TestTextDraws(t *testing.T) {
 			},
 		},
 		{
-			desc:   "rolls content upwards and wraps lines at rune boundaries",
-			canvas: image.Rect(0, 0, 10, 2),
+			desc:   "scrolls down using custom key a page at a time",
+			canvas: image.Rect(0, 0, 10, 3),
 			opts: []Option{
-				RollContent(),
-				WrapAtRunes(),
+				ScrollKeys('u', 'd', 'k', 'l'),
 			},
 			writes: func(widget *Text) error {
-				return widget.Write("hello world\nshort\nand long again")
+				return widget.Write("line0\nline1\nline2\nline3\nline4\nline5\nline6")
+			},
+			events: func(widget *Text) {
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: 'l',
+				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "and long a", image.Point{0, 0})
-				testdraw.MustText(c, "gain", image.Point{0, 1})
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line4", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls up on mouse wheel up a line at a time",
+			desc:   "scroll key wins over the default follow key",
 			canvas: image.Rect(0, 0, 10, 3),
 			opts: []Option{
-				RollContent(),
+				ScrollKeys('u', 'd', 'k', DefaultFollowKey),
 			},
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3\nline4")
+				return widget.Write("line0\nline1\nline2\nline3\nline4\nline5\nline6")
 			},
 			events: func(widget *Text) {
-				// Draw once to roll the content all the way down before we scroll.
-				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
-					panic(err)
-				}
-				widget.Mouse(&terminalapi.Mouse{
-					Button: mouse.ButtonWheelUp,
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: DefaultFollowKey,
 				})
 			},
 			want: func(size image.Point) *faketerm.Terminal {
@@ -614,206 +849,970 @@ func TestTextDraws(t *testing.T) {
 				c := testcanvas.MustNew(ft.Area())
 
 				testdraw.MustText(c, "⇧", image.Point{0, 0})
-				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "line4", image.Point{0, 1})
 				testdraw.MustText(c, "⇩", image.Point{0, 2})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls up on up arrow a line at a time",
-			canvas: image.Rect(0, 0, 10, 3),
+			desc:   "wraps lines at half-width rune boundaries",
+			canvas: image.Rect(0, 0, 10, 5),
 			opts: []Option{
-				RollContent(),
+				WrapAtRunes(),
 			},
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3\nline4")
-			},
-			events: func(widget *Text) {
-				// Draw once to roll the content all the way down before we scroll.
-				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
-					panic(err)
-				}
-				widget.Keyboard(&terminalapi.Keyboard{
-					Key: keyboard.KeyArrowUp,
-				})
+				return widget.Write("hello world\nshort\nand long again")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "⇧", image.Point{0, 0})
-				testdraw.MustText(c, "line2", image.Point{0, 1})
-				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testdraw.MustText(c, "hello worl", image.Point{0, 0})
+				testdraw.MustText(c, "d", image.Point{0, 1})
+				testdraw.MustText(c, "short", image.Point{0, 2})
+				testdraw.MustText(c, "and long a", image.Point{0, 3})
+				testdraw.MustText(c, "gain", image.Point{0, 4})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls up on pageUp a page at a time",
-			canvas: image.Rect(0, 0, 10, 3),
+			desc:   "wraps lines at full-width rune boundaries",
+			canvas: image.Rect(0, 0, 10, 6),
 			opts: []Option{
-				RollContent(),
+				WrapAtRunes(),
 			},
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3\nline4")
-			},
-			events: func(widget *Text) {
-				// Draw once to roll the content all the way down before we scroll.
-				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
-					panic(err)
-				}
-				widget.Keyboard(&terminalapi.Keyboard{
-					Key: keyboard.KeyPgUp,
-				})
+				return widget.Write("hello wor你\nhello wor你d\nand long 世")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "line0", image.Point{0, 0})
-				testdraw.MustText(c, "line1", image.Point{0, 1})
-				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testdraw.MustText(c, "hello wor", image.Point{0, 0})
+				testdraw.MustText(c, "你", image.Point{0, 1})
+				testdraw.MustText(c, "hello wor", image.Point{0, 2})
+				testdraw.MustText(c, "你d", image.Point{0, 3})
+				testdraw.MustText(c, "and long ", image.Point{0, 4})
+				testdraw.MustText(c, "世", image.Point{0, 5})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls up using custom mouse button a line at a time",
-			canvas: image.Rect(0, 0, 10, 3),
+			desc:   "wraps lines at word boundaries",
+			canvas: image.Rect(0, 0, 10, 6),
 			opts: []Option{
-				RollContent(),
-				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonRight),
+				WrapAtWords(),
 			},
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3\nline4")
-			},
-			events: func(widget *Text) {
-				// Draw once to roll the content all the way down before we scroll.
-				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
-					panic(err)
-				}
-				widget.Mouse(&terminalapi.Mouse{
-					Button: mouse.ButtonLeft,
-				})
+				return widget.Write("hello wor你\nhello wor你d\nand long 世")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "⇧", image.Point{0, 0})
-				testdraw.MustText(c, "line2", image.Point{0, 1})
-				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testdraw.MustText(c, "hello", image.Point{0, 0})
+				testdraw.MustText(c, "wor你", image.Point{0, 1})
+				testdraw.MustText(c, "hello", image.Point{0, 2})
+				testdraw.MustText(c, "wor你d", image.Point{0, 3})
+				testdraw.MustText(c, "and long", image.Point{0, 4})
+				testdraw.MustText(c, "世", image.Point{0, 5})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls up using custom key a line at a time",
-			canvas: image.Rect(0, 0, 10, 3),
+			desc:   "wraps lines at word boundaries, inserts dash for long words",
+			canvas: image.Rect(0, 0, 10, 6),
 			opts: []Option{
-				ScrollKeys('u', 'd', 'k', 'l'),
-				RollContent(),
+				WrapAtWords(),
 			},
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3\nline4")
-			},
-			events: func(widget *Text) {
-				// Draw once to roll the content all the way down before we scroll.
-				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
-					panic(err)
-				}
-				widget.Keyboard(&terminalapi.Keyboard{
-					Key: 'u',
-				})
+				return widget.Write("hello thisisalongword world")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "⇧", image.Point{0, 0})
-				testdraw.MustText(c, "line2", image.Point{0, 1})
-				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testdraw.MustText(c, "hello", image.Point{0, 0})
+				testdraw.MustText(c, "thisisalo-", image.Point{0, 1})
+				testdraw.MustText(c, "ngword", image.Point{0, 2})
+				testdraw.MustText(c, "world", image.Point{0, 3})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
 		{
-			desc:   "scrolls up using custom key a page at a time",
-			canvas: image.Rect(0, 0, 10, 3),
+			desc:   "wraps lines at word boundaries, breaks long words without dashes",
+			canvas: image.Rect(0, 0, 10, 5),
 			opts: []Option{
-				ScrollKeys('u', 'd', 'k', 'l'),
-				RollContent(),
+				WrapAtWords(),
+				LongWordMode(LongWordBreak),
 			},
 			writes: func(widget *Text) error {
-				return widget.Write("line0\nline1\nline2\nline3\nline4")
-			},
-			events: func(widget *Text) {
-				// Draw once to roll the content all the way down before we scroll.
-				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
-					panic(err)
-				}
-				widget.Keyboard(&terminalapi.Keyboard{
-					Key: 'k',
-				})
+				return widget.Write("abcdefghijklmnopqrstuvwxyz1234 end")
 			},
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				testdraw.MustText(c, "line0", image.Point{0, 0})
-				testdraw.MustText(c, "line1", image.Point{0, 1})
-				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testdraw.MustText(c, "abcdefghij", image.Point{0, 0})
+				testdraw.MustText(c, "klmnopqrst", image.Point{0, 1})
+				testdraw.MustText(c, "uvwxyz1234", image.Point{0, 2})
+				testdraw.MustText(c, "end", image.Point{0, 3})
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.desc, func(t *testing.T) {
-			c, err := canvas.New(tc.canvas)
-			if err != nil {
-				t.Fatalf("canvas.New => unexpected error: %v", err)
-			}
-
-			widget, err := New(tc.opts...)
-			if (err != nil) != tc.wantErr {
-				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
-			}
-			if err != nil {
-				return
-			}
+		{
+			desc:   "wraps lines at word boundaries, truncates long words",
+			canvas: image.Rect(0, 0, 10, 5),
+			opts: []Option{
+				WrapAtWords(),
+				LongWordMode(LongWordTruncate),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("start abcdefghijklmnopqrstuvwxyz1234 end")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
 
-			if tc.writes != nil {
-				err := tc.writes(widget)
-				if (err != nil) != tc.wantWriteErr {
-					t.Errorf("Write => unexpected error: %v, wantWriteErr: %v", err, tc.wantWriteErr)
-				}
-				if err != nil {
-					return
+				testdraw.MustText(c, "start", image.Point{0, 0})
+				testdraw.MustText(c, "abcdefghi…", image.Point{0, 1})
+				testdraw.MustText(c, "end", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fails on unsupported long word mode",
+			opts: []Option{
+				LongWordMode(LongWord(-1)),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on unsupported horizontal alignment",
+			opts: []Option{
+				Align(align.Horizontal(-1)),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "centers short lines, the extra cell goes to the right",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				Align(align.HorizontalCenter),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcd\nabc\n你好")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abcd", image.Point{3, 0})
+				testdraw.MustText(c, "abc", image.Point{3, 1})
+				testdraw.MustText(c, "你好", image.Point{3, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "right-aligns short lines",
+			canvas: image.Rect(0, 0, 10, 2),
+			opts: []Option{
+				Align(align.HorizontalRight),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abc\n你")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abc", image.Point{7, 0})
+				testdraw.MustText(c, "你", image.Point{8, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "aligned lines that don't fit are trimmed from the left edge",
+			canvas: image.Rect(0, 0, 10, 1),
+			opts: []Option{
+				Align(align.HorizontalRight),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "hello wor…", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "centers each wrapped line individually",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				WrapAtWords(),
+				Align(align.HorizontalCenter),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello wor你 世界")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "hello", image.Point{2, 0})
+				testdraw.MustText(c, "wor你 世界", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "right-aligns each wrapped line individually",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				WrapAtWords(),
+				Align(align.HorizontalRight),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello wor你 world")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "hello", image.Point{5, 0})
+				testdraw.MustText(c, "wor你", image.Point{5, 1})
+				testdraw.MustText(c, "world", image.Point{5, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "minimap maps each line to a row when the content fits",
+			canvas: image.Rect(0, 0, 6, 6),
+			opts: []Option{
+				ShowMinimap(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("abcde\nab\n\nx y")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abcde", image.Point{0, 0})
+				testdraw.MustText(c, "ab", image.Point{0, 1})
+				testdraw.MustText(c, "x y", image.Point{0, 3})
+
+				hl := cell.BgColor(cell.ColorNumber(DefaultMinimapHighlightColorNumber))
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '█', hl)
+				testcanvas.MustSetCell(c, image.Point{5, 1}, '▒', hl)
+				testcanvas.MustSetCell(c, image.Point{5, 2}, ' ', hl)
+				testcanvas.MustSetCell(c, image.Point{5, 3}, '▒', hl)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "minimap compresses content taller than the canvas and highlights the visible lines",
+			canvas: image.Rect(0, 0, 6, 2),
+			opts: []Option{
+				ShowMinimap(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nab\nab\nab\nab")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line0", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 1})
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '█', cell.BgColor(cell.ColorNumber(DefaultMinimapHighlightColorNumber)))
+				testcanvas.MustSetCell(c, image.Point{5, 1}, '▒')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "minimap highlight follows the scroll offset",
+			canvas: image.Rect(0, 0, 6, 2),
+			opts: []Option{
+				ShowMinimap(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nab\nab\nab\nab")
+			},
+			events: func(widget *Text) {
+				for i := 0; i < 4; i++ {
+					widget.Keyboard(&terminalapi.Keyboard{Key: DefaultScrollKeyDown})
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "ab", image.Point{0, 0})
+				testdraw.MustText(c, "ab", image.Point{0, 1})
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '█')
+				testcanvas.MustSetCell(c, image.Point{5, 1}, '▒', cell.BgColor(cell.ColorNumber(DefaultMinimapHighlightColorNumber)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "minimap highlights all rows the visible lines fall into",
+			canvas: image.Rect(0, 0, 6, 2),
+			opts: []Option{
+				ShowMinimap(),
+				MinimapHighlightColor(cell.ColorRed),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nab\nab\nab\nab")
+			},
+			events: func(widget *Text) {
+				for i := 0; i < 3; i++ {
+					widget.Keyboard(&terminalapi.Keyboard{Key: DefaultScrollKeyDown})
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line3", image.Point{0, 0})
+				testdraw.MustText(c, "ab", image.Point{0, 1})
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '█', cell.BgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{5, 1}, '▒', cell.BgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "minimap isn't displayed when the canvas is too narrow",
+			canvas: image.Rect(0, 0, 1, 1),
+			opts: []Option{
+				ShowMinimap(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("a")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "a", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "rolls content upwards and trims lines",
+			canvas: image.Rect(0, 0, 10, 2),
+			opts: []Option{
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world\nshort\nand long again")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "short", image.Point{0, 0})
+				testdraw.MustText(c, "and long …", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "rolls content upwards and draws an up scroll marker",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "line3", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "rolls content upwards and wraps lines at rune boundaries",
+			canvas: image.Rect(0, 0, 10, 2),
+			opts: []Option{
+				RollContent(),
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world\nshort\nand long again")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "and long a", image.Point{0, 0})
+				testdraw.MustText(c, "gain", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls up on mouse wheel up a line at a time",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Mouse(&terminalapi.Mouse{
+					Button: mouse.ButtonWheelUp,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls up on up arrow a line at a time",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowUp,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls up on pageUp a page at a time",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyPgUp,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line0", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls up using custom mouse button a line at a time",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				RollContent(),
+				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonRight),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Mouse(&terminalapi.Mouse{
+					Button: mouse.ButtonLeft,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls up using custom key a line at a time",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				ScrollKeys('u', 'd', 'k', 'l'),
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: 'u',
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the follow indicator while content rolling is paused",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				RollContent(),
+				FollowIndicator("paused", cell.FgColor(cell.ColorRed)),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowUp,
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testdraw.MustText(c, "paused", image.Point{4, 2}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "appending while content rolling is paused doesn't move the content",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowUp,
+				})
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				if err := widget.Write("\nline5"); err != nil {
+					panic(err)
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line2", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "follow key resumes content rolling and hides the follow indicator",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				RollContent(),
+				FollowIndicator("paused"),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: keyboard.KeyArrowUp,
+				})
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: DefaultFollowKey,
+				})
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				if err := widget.Write("\nline5"); err != nil {
+					panic(err)
+				}
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⇧", image.Point{0, 0})
+				testdraw.MustText(c, "line4", image.Point{0, 1})
+				testdraw.MustText(c, "line5", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls up using custom key a page at a time",
+			canvas: image.Rect(0, 0, 10, 3),
+			opts: []Option{
+				ScrollKeys('u', 'd', 'k', 'l'),
+				RollContent(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("line0\nline1\nline2\nline3\nline4")
+			},
+			events: func(widget *Text) {
+				// Draw once to roll the content all the way down before we scroll.
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 3)), &widgetapi.Meta{}); err != nil {
+					panic(err)
+				}
+				widget.Keyboard(&terminalapi.Keyboard{
+					Key: 'k',
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "line0", image.Point{0, 0})
+				testdraw.MustText(c, "line1", image.Point{0, 1})
+				testdraw.MustText(c, "⇩", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+
+			widget, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.writes != nil {
+				err := tc.writes(widget)
+				if (err != nil) != tc.wantWriteErr {
+					t.Errorf("Write => unexpected error: %v, wantWriteErr: %v", err, tc.wantWriteErr)
 				}
+				if err != nil {
+					return
+				}
+			}
+
+			if tc.events != nil {
+				tc.events(widget)
+			}
+
+			if err := widget.Draw(c, tc.meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
 			}
 
-			if tc.events != nil {
-				tc.events(widget)
-			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestTailView(t *testing.T) {
+	widget, err := New(TailView(2))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	steps := []struct {
+		desc  string
+		write string
+		want  []string
+	}{
+		{
+			desc:  "fewer lines than the tail",
+			write: "line0",
+			want:  []string{"line0"},
+		},
+		{
+			desc:  "as many lines as the tail",
+			write: "\nline1",
+			want:  []string{"line0", "line1"},
+		},
+		{
+			desc:  "appended line replaces the oldest one",
+			write: "\nline2",
+			want:  []string{"line1", "line2"},
+		},
+		{
+			desc:  "multiple appended lines",
+			write: "\nline3\nline4",
+			want:  []string{"line3", "line4"},
+		},
+	}
+
+	for _, s := range steps {
+		if err := widget.Write(s.write); err != nil {
+			t.Fatalf("%s: Write => unexpected error: %v", s.desc, err)
+		}
+		c := testcanvas.MustNew(image.Rect(0, 0, 10, 4))
+		if err := widget.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("%s: Draw => unexpected error: %v", s.desc, err)
+		}
+		got := faketerm.MustNew(c.Size())
+		testcanvas.MustApply(c, got)
 
-			if err := widget.Draw(c, tc.meta); err != nil {
-				t.Fatalf("Draw => unexpected error: %v", err)
-			}
+		want := faketerm.MustNew(c.Size())
+		wantCvs := testcanvas.MustNew(want.Area())
+		for i, line := range s.want {
+			testdraw.MustText(wantCvs, line, image.Point{0, i})
+		}
+		testcanvas.MustApply(wantCvs, want)
 
-			got, err := faketerm.New(c.Size())
+		if diff := faketerm.Diff(want, got); diff != "" {
+			t.Errorf("%s: Draw => %v", s.desc, diff)
+		}
+	}
+}
+
+func TestMaxLines(t *testing.T) {
+	tests := []struct {
+		desc        string
+		opts        []Option
+		do          func(*Text) error
+		wantContent string
+		// wantFirst is the expected first line of the scroll tracker.
+		wantFirst int
+	}{
+		{
+			desc: "keeps all lines without a limit",
+			do: func(widget *Text) error {
+				return widget.Write("a\nb\nc")
+			},
+			wantContent: "a\nb\nc",
+		},
+		{
+			desc: "keeps all lines at the limit",
+			opts: []Option{
+				MaxLines(3),
+			},
+			do: func(widget *Text) error {
+				return widget.Write("a\nb\nc\n")
+			},
+			wantContent: "a\nb\nc\n",
+		},
+		{
+			desc: "exceeding the cap drops the oldest lines",
+			opts: []Option{
+				MaxLines(2),
+			},
+			do: func(widget *Text) error {
+				return widget.Write("a\nb\nc\nd")
+			},
+			wantContent: "c\nd",
+		},
+		{
+			desc: "drops lines across multiple writes",
+			opts: []Option{
+				MaxLines(2),
+			},
+			do: func(widget *Text) error {
+				if err := widget.Write("a\nb\n"); err != nil {
+					return err
+				}
+				return widget.Write("c")
+			},
+			wantContent: "b\nc",
+		},
+		{
+			desc: "empty lines count as lines",
+			opts: []Option{
+				MaxLines(2),
+			},
+			do: func(widget *Text) error {
+				return widget.Write("\n\n\na")
+			},
+			wantContent: "\na",
+		},
+		{
+			desc: "line count stays at the limit after many appends",
+			opts: []Option{
+				MaxLines(3),
+			},
+			do: func(widget *Text) error {
+				for i := 0; i < 1000; i++ {
+					if err := widget.Write(fmt.Sprintf("line%d\n", i)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			wantContent: "line997\nline998\nline999\n",
+		},
+		{
+			desc: "keeps the scrolled viewport anchored when lines are dropped",
+			opts: []Option{
+				MaxLines(3),
+			},
+			do: func(widget *Text) error {
+				if err := widget.Write("a\nb\nc"); err != nil {
+					return err
+				}
+				widget.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowDown})
+				widget.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowDown})
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 10, 1)), nil); err != nil {
+					return err
+				}
+				return widget.Write("\nd")
+			},
+			wantContent: "b\nc\nd",
+			wantFirst:   1,
+		},
+		{
+			desc: "accounts for wrapped lines when anchoring the viewport",
+			opts: []Option{
+				MaxLines(2),
+				WrapAtRunes(),
+			},
+			do: func(widget *Text) error {
+				if err := widget.Write("aaaa\nbb"); err != nil {
+					return err
+				}
+				// "aaaa" occupies two lines on a canvas of width two.
+				widget.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowDown})
+				widget.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowDown})
+				if err := widget.Draw(testcanvas.MustNew(image.Rect(0, 0, 2, 1)), nil); err != nil {
+					return err
+				}
+				return widget.Write("\ncc")
+			},
+			wantContent: "bb\ncc",
+			wantFirst:   0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			widget, err := New(tc.opts...)
 			if err != nil {
-				t.Fatalf("faketerm.New => unexpected error: %v", err)
+				t.Fatalf("New => unexpected error: %v", err)
 			}
 
-			if err := c.Apply(got); err != nil {
-				t.Fatalf("Apply => unexpected error: %v", err)
+			if err := tc.do(widget); err != nil {
+				t.Fatalf("tc.do => unexpected error: %v", err)
 			}
 
-			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
-				t.Errorf("Draw => %v", diff)
+			var got strings.Builder
+			for _, c := range widget.content {
+				got.WriteRune(c.Rune)
+			}
+			if got.String() != tc.wantContent {
+				t.Errorf("content => got %q, want %q", got.String(), tc.wantContent)
+			}
+			if got := widget.scroll.first; got != tc.wantFirst {
+				t.Errorf("scroll.first => got %d, want %d", got, tc.wantFirst)
 			}
 		})
 	}
@@ -860,3 +1859,365 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestRestoreState(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		data       string
+		wantFirst  int
+		wantPaused bool
+		wantErr    bool
+	}{
+		{
+			desc:    "fails on malformed data",
+			data:    "{",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative first line",
+			data:    `{"first": -1}`,
+			wantErr: true,
+		},
+		{
+			desc:      "restores the first line",
+			data:      `{"first": 3, "paused": true}`,
+			wantFirst: 3,
+		},
+		{
+			desc: "restores paused rolling",
+			opts: []Option{
+				RollContent(),
+			},
+			data:       `{"first": 3, "paused": true}`,
+			wantFirst:  3,
+			wantPaused: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			text, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			err = text.RestoreState([]byte(tc.data))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("RestoreState => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := text.scroll
+			if got.first != tc.wantFirst || got.paused != tc.wantPaused {
+				t.Errorf("RestoreState => first:%d, paused:%v, want first:%d, paused:%v", got.first, got.paused, tc.wantFirst, tc.wantPaused)
+			}
+
+			data, err := text.SaveState()
+			if err != nil {
+				t.Fatalf("SaveState => unexpected error: %v", err)
+			}
+			restored, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := restored.RestoreState(data); err != nil {
+				t.Fatalf("RestoreState => unexpected error: %v", err)
+			}
+			if r := restored.scroll; r.first != got.first || r.paused != got.paused {
+				t.Errorf("SaveState and RestoreState => first:%d, paused:%v, want first:%d, paused:%v", r.first, r.paused, got.first, got.paused)
+			}
+		})
+	}
+}
+
+func TestLinkScrollSmooth(t *testing.T) {
+	var widgets []*Text
+	sg := container.NewScrollGroup()
+	for i := 0; i < 2; i++ {
+		w, err := New(SmoothScroll())
+		if err != nil {
+			t.Fatalf("New => unexpected error: %v", err)
+		}
+		if err := w.Write("0\n1\n2\n3\n4\n5"); err != nil {
+			t.Fatalf("Write => unexpected error: %v", err)
+		}
+		if err := sg.Join(w); err != nil {
+			t.Fatalf("Join => unexpected error: %v", err)
+		}
+		widgets = append(widgets, w)
+	}
+
+	steps := []struct {
+		desc string
+		key  keyboard.Key
+		want int
+	}{
+		{
+			desc: "a single half line step doesn't move the shared position",
+			key:  keyboard.KeyArrowDown,
+			want: 0,
+		},
+		{
+			desc: "the second half line step moves it by a line",
+			key:  keyboard.KeyArrowDown,
+			want: 1,
+		},
+		{
+			desc: "the third half line step waits for the next one",
+			key:  keyboard.KeyArrowDown,
+			want: 1,
+		},
+		{
+			desc: "scrolling back up cancels the odd step",
+			key:  keyboard.KeyArrowUp,
+			want: 1,
+		},
+		{
+			desc: "the first half line step up doesn't move it",
+			key:  keyboard.KeyArrowUp,
+			want: 1,
+		},
+		{
+			desc: "the second half line step up moves it back by a line",
+			key:  keyboard.KeyArrowUp,
+			want: 0,
+		},
+	}
+
+	for _, s := range steps {
+		if err := widgets[0].Keyboard(&terminalapi.Keyboard{Key: s.key}); err != nil {
+			t.Fatalf("%s: Keyboard => unexpected error: %v", s.desc, err)
+		}
+		for _, w := range widgets {
+			c := testcanvas.MustNew(image.Rect(0, 0, 3, 2))
+			if err := w.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("%s: Draw => unexpected error: %v", s.desc, err)
+			}
+		}
+		for i, w := range widgets {
+			if got := w.scroll.first; got != s.want {
+				t.Errorf("%s: widget %d first line => %d, want %d", s.desc, i, got, s.want)
+			}
+		}
+	}
+}
+
+func TestLinkScroll(t *testing.T) {
+	long, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := long.Write("0\n1\n2\n3\n4\n5\n6\n7\n8\n9"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	short, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := short.Write("0\n1\n2\n3"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+
+	sg := container.NewScrollGroup()
+	for _, w := range []*Text{long, short} {
+		if err := sg.Join(w); err != nil {
+			t.Fatalf("Join => unexpected error: %v", err)
+		}
+	}
+
+	// draw draws both widgets twice and returns their first lines.
+	// The widget drawn first only follows the scrolling of the other one on
+	// its next draw.
+	draw := func() (int, int) {
+		for i := 0; i < 2; i++ {
+			for _, w := range []*Text{long, short} {
+				c := testcanvas.MustNew(image.Rect(0, 0, 3, 2))
+				if err := w.Draw(c, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+		}
+		return long.scroll.first, short.scroll.first
+	}
+
+	type firstLines struct {
+		long  int
+		short int
+	}
+	steps := []struct {
+		desc   string
+		widget *Text
+		keys   []keyboard.Key
+		want   firstLines
+	}{
+		{
+			desc: "initial draw",
+			want: firstLines{0, 0},
+		},
+		{
+			desc:   "scrolling the long widget scrolls the short one",
+			widget: long,
+			keys:   []keyboard.Key{keyboard.KeyArrowDown},
+			want:   firstLines{1, 1},
+		},
+		{
+			desc:   "the short widget stops at its last line",
+			widget: long,
+			keys:   []keyboard.Key{keyboard.KeyArrowDown, keyboard.KeyArrowDown, keyboard.KeyArrowDown},
+			want:   firstLines{4, 2},
+		},
+		{
+			desc:   "scrolling the short widget back keeps both aligned",
+			widget: short,
+			keys:   []keyboard.Key{keyboard.KeyArrowUp, keyboard.KeyArrowUp},
+			want:   firstLines{2, 2},
+		},
+		{
+			desc:   "scrolling to the end follows the long widget",
+			widget: short,
+			keys:   []keyboard.Key{DefaultFollowKey},
+			want:   firstLines{8, 2},
+		},
+	}
+
+	for _, s := range steps {
+		for _, k := range s.keys {
+			if err := s.widget.Keyboard(&terminalapi.Keyboard{Key: k}); err != nil {
+				t.Fatalf("%s: Keyboard => unexpected error: %v", s.desc, err)
+			}
+		}
+		gotLong, gotShort := draw()
+		if got := (firstLines{gotLong, gotShort}); got != s.want {
+			t.Errorf("%s: first lines => %+v, want %+v", s.desc, got, s.want)
+		}
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		writes     func(*Text) error
+		constraint image.Point
+		want       image.Point
+	}{
+		{
+			desc:       "zero size when no written text",
+			constraint: image.Point{10, 10},
+			want:       image.Point{0, 0},
+		},
+		{
+			desc: "single line shorter than the constraint",
+			writes: func(widget *Text) error {
+				return widget.Write("hello")
+			},
+			constraint: image.Point{10, 10},
+			want:       image.Point{5, 1},
+		},
+		{
+			desc: "counts newlines",
+			writes: func(widget *Text) error {
+				return widget.Write("hello\nworld\nhi")
+			},
+			constraint: image.Point{10, 10},
+			want:       image.Point{5, 3},
+		},
+		{
+			desc: "reserves a column for the minimap",
+			opts: []Option{
+				ShowMinimap(),
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello")
+			},
+			constraint: image.Point{5, 10},
+			want:       image.Point{5, 2},
+		},
+		{
+			desc: "lines are trimmed to the width without wrapping",
+			writes: func(widget *Text) error {
+				return widget.Write("hello world\nhi")
+			},
+			constraint: image.Point{5, 10},
+			want:       image.Point{5, 2},
+		},
+		{
+			desc: "wraps at runes to the width",
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world")
+			},
+			constraint: image.Point{4, 10},
+			want:       image.Point{4, 3},
+		},
+		{
+			desc: "wraps at words to the width",
+			opts: []Option{
+				WrapAtWords(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world and more")
+			},
+			constraint: image.Point{9, 10},
+			want:       image.Point{9, 3},
+		},
+		{
+			desc: "accounts for full-width runes",
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("你好，世界")
+			},
+			constraint: image.Point{5, 10},
+			want:       image.Point{4, 3},
+		},
+		{
+			desc: "height is limited by the constraint",
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world")
+			},
+			constraint: image.Point{2, 3},
+			want:       image.Point{2, 3},
+		},
+		{
+			desc: "zero constraint means unlimited",
+			opts: []Option{
+				WrapAtRunes(),
+			},
+			writes: func(widget *Text) error {
+				return widget.Write("hello world\nhi")
+			},
+			constraint: image.Point{0, 0},
+			want:       image.Point{11, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			text, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.writes != nil {
+				if err := tc.writes(text); err != nil {
+					t.Fatalf("Write => unexpected error: %v", err)
+				}
+			}
+
+			var m widgetapi.Measurer = text
+			if got := m.Measure(tc.constraint); got != tc.want {
+				t.Errorf("Measure(%v) => %v, want %v", tc.constraint, got, tc.want)
+			}
+		})
+	}
+}
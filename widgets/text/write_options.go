@@ -30,6 +30,7 @@ type WriteOption interface {
 type writeOptions struct {
 	cellOpts *cell.Options
 	replace  bool
+	link     LinkFn
 }
 
 // newWriteOptions returns new writeOptions instance.
@@ -65,3 +66,12 @@ func WriteReplace() WriteOption {
 		wOpts.replace = true
 	})
 }
+
+// WriteLink makes the written text a link. The provided function is called
+// when the user clicks on the link with the left mouse button or presses the
+// activation key while the link is focused, see the LinkKeys option.
+func WriteLink(fn LinkFn) WriteOption {
+	return writeOption(func(wOpts *writeOptions) {
+		wOpts.link = fn
+	})
+}
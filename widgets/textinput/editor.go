@@ -256,6 +256,37 @@ func (fd *fieldData) fitRunes(firstRune, curPos, cells int) (string, int, int) {
 	return b.String(), start, end
 }
 
+// editor edits the content of the text input field.
+type editor interface {
+	// viewFor returns the currently visible data inside a text field with the
+	// specified width and the cursor position within the field.
+	viewFor(width int) (string, int, error)
+	// content returns the string content in the editor.
+	content() string
+	// reset resets the content back to zero.
+	reset()
+
+	// insert inserts the rune at the current position of the cursor.
+	insert(r rune)
+	// delete deletes the rune at the current position of the cursor.
+	delete()
+	// deleteBefore deletes the rune that is immediately to the left of the
+	// cursor.
+	deleteBefore()
+
+	// cursorRight moves the cursor one position to the right.
+	cursorRight()
+	// cursorLeft moves the cursor one position to the left.
+	cursorLeft()
+	// cursorStart moves the cursor to the beginning of the data.
+	cursorStart()
+	// cursorEnd moves the cursor to the end of the data.
+	cursorEnd()
+	// cursorRelCell sets the cursor onto the cell index within the visible
+	// area.
+	cursorRelCell(cellIdx int)
+}
+
 // fieldEditor maintains the cursor position and allows editing of the data in
 // the text input field.
 // This object isn't thread-safe.
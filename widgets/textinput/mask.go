@@ -0,0 +1,213 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+// mask.go contains an editor of text input fields with an input mask.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mum4k/termdash/internal/numbers"
+	"github.com/mum4k/termdash/internal/runewidth"
+	"github.com/mum4k/termdash/internal/wrap"
+)
+
+// MaskSlot is the rune that marks input slots in the template of the Mask
+// option.
+const MaskSlot = '_'
+
+// validateMask validates the template of the Mask option.
+func validateMask(template string) error {
+	if err := wrap.ValidText(template); err != nil {
+		return fmt.Errorf("invalid Mask template %q: %v", template, err)
+	}
+	if !strings.ContainsRune(template, MaskSlot) {
+		return fmt.Errorf("invalid Mask template %q, must contain at least one input slot %q", template, MaskSlot)
+	}
+	for _, r := range template {
+		if got, want := runewidth.RuneWidth(r), 1; got != want {
+			return fmt.Errorf("invalid Mask template %q, rune %c(%d) has rune width of %d cells, only runes with width of %d are accepted", template, r, r, got, want)
+		}
+	}
+	return nil
+}
+
+// maskEditor maintains the cursor position and allows editing of the data in
+// a text input field with an input mask.
+// The data fill the input slots of the template from left to right, the
+// cursor moves between the input slots only and skips over the literals.
+// This object isn't thread-safe.
+type maskEditor struct {
+	// template is the input mask.
+	template []rune
+
+	// slots are the indexes of the input slots within the template.
+	slots []int
+
+	// data are the runes filled into the input slots in order.
+	data fieldData
+
+	// curDataPos is the current position of the cursor within the data.
+	// The cursor is allowed to go one slot beyond the data so appending is
+	// possible.
+	curDataPos int
+
+	// firstCell is the index of the first displayed cell of the template.
+	firstCell int
+
+	// width is the width of the text input field last time viewFor was called.
+	width int
+}
+
+// newMaskEditor returns a new maskEditor for the template.
+func newMaskEditor(template string) *maskEditor {
+	me := &maskEditor{
+		template: []rune(template),
+	}
+	for i, r := range me.template {
+		if r == MaskSlot {
+			me.slots = append(me.slots, i)
+		}
+	}
+	return me
+}
+
+// curCell returns the index of the cell of the template the cursor is in.
+// When all the input slots are filled, the cursor is in the cell after the
+// template.
+func (me *maskEditor) curCell() int {
+	if me.curDataPos < len(me.slots) {
+		return me.slots[me.curDataPos]
+	}
+	return len(me.template)
+}
+
+// view returns the template with the input slots filled with the data.
+func (me *maskEditor) view() []rune {
+	view := make([]rune, len(me.template))
+	copy(view, me.template)
+	for i, r := range me.data {
+		view[me.slots[i]] = r
+	}
+	return view
+}
+
+// viewFor returns the currently visible part of the template inside a text
+// field with the specified width and the cursor position within the field.
+func (me *maskEditor) viewFor(width int) (string, int, error) {
+	if min := minFieldWidth; width < min {
+		return "", -1, fmt.Errorf("width %d is too small, the minimum is %d", width, min)
+	}
+
+	cur := me.curCell()
+	switch {
+	case cur < me.firstCell:
+		me.firstCell = cur
+	case cur >= me.firstCell+width:
+		me.firstCell = cur - width + 1
+	}
+	me.width = width
+
+	view := me.view()
+	end, _ := numbers.MinMaxInts([]int{me.firstCell + width, len(view)})
+	if me.firstCell >= end {
+		return "", cur - me.firstCell, nil
+	}
+	return string(view[me.firstCell:end]), cur - me.firstCell, nil
+}
+
+// content returns the data together with the literals of the template that
+// precede the last filled input slot.
+func (me *maskEditor) content() string {
+	if len(me.data) == 0 {
+		return ""
+	}
+	return string(me.view()[:me.slots[len(me.data)-1]+1])
+}
+
+// reset resets the content back to zero.
+func (me *maskEditor) reset() {
+	me.data = nil
+	me.curDataPos = 0
+	me.firstCell = 0
+}
+
+// insert inserts the rune at the current position of the cursor.
+// Runes are ignored once all the input slots are filled.
+func (me *maskEditor) insert(r rune) {
+	if runewidth.RuneWidth(r) != 1 || len(me.data) >= len(me.slots) {
+		// Each input slot is one cell wide.
+		return
+	}
+	me.data.insertAt(me.curDataPos, r)
+	me.curDataPos++
+}
+
+// delete deletes the rune at the current position of the cursor.
+func (me *maskEditor) delete() {
+	if me.curDataPos >= len(me.data) {
+		// Cursor not on a filled input slot, nothing to do.
+		return
+	}
+	me.data.deleteAt(me.curDataPos)
+}
+
+// deleteBefore deletes the rune in the filled input slot that is immediately
+// to the left of the cursor.
+func (me *maskEditor) deleteBefore() {
+	if me.curDataPos == 0 {
+		// Cursor at the beginning, nothing to do.
+		return
+	}
+	me.cursorLeft()
+	me.delete()
+}
+
+// cursorRight moves the cursor onto the next input slot.
+func (me *maskEditor) cursorRight() {
+	me.curDataPos, _ = numbers.MinMaxInts([]int{me.curDataPos + 1, len(me.data)})
+}
+
+// cursorLeft moves the cursor onto the previous input slot.
+func (me *maskEditor) cursorLeft() {
+	_, me.curDataPos = numbers.MinMaxInts([]int{me.curDataPos - 1, 0})
+}
+
+// cursorStart moves the cursor onto the first input slot.
+func (me *maskEditor) cursorStart() {
+	me.curDataPos = 0
+}
+
+// cursorEnd moves the cursor onto the first input slot that isn't filled.
+func (me *maskEditor) cursorEnd() {
+	me.curDataPos = len(me.data)
+}
+
+// cursorRelCell moves the cursor onto the first input slot at or after the
+// cell index within the visible area.
+// If no such input slot is filled, the cursor is moved onto the first input
+// slot that isn't filled.
+func (me *maskEditor) cursorRelCell(cellIdx int) {
+	cell := me.firstCell + cellIdx
+	pos := len(me.slots)
+	for i, s := range me.slots {
+		if s >= cell {
+			pos = i
+			break
+		}
+	}
+	me.curDataPos, _ = numbers.MinMaxInts([]int{pos, len(me.data)})
+}
@@ -0,0 +1,351 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textinput
+
+import "testing"
+
+func TestMaskEditor(t *testing.T) {
+	tests := []struct {
+		desc        string
+		template    string
+		width       int
+		ops         func(*maskEditor) error
+		wantView    string
+		wantContent string
+		wantCurIdx  int
+		wantErr     bool
+	}{
+		{
+			desc:     "fails for width too small",
+			template: "__/__",
+			width:    3,
+			wantErr:  true,
+		},
+		{
+			desc:        "no data shows the template",
+			template:    "__/__/____",
+			width:       11,
+			wantView:    "__/__/____",
+			wantContent: "",
+			wantCurIdx:  0,
+		},
+		{
+			desc:     "cursor starts on the first input slot",
+			template: "(___) ___",
+			width:    10,
+			ops: func(me *maskEditor) error {
+				me.cursorStart()
+				return nil
+			},
+			wantView:    "(___) ___",
+			wantContent: "",
+			wantCurIdx:  1,
+		},
+		{
+			desc:     "typing fills the input slots",
+			template: "__/__/____",
+			width:    11,
+			ops: func(me *maskEditor) error {
+				me.insert('1')
+				return nil
+			},
+			wantView:    "1_/__/____",
+			wantContent: "1",
+			wantCurIdx:  1,
+		},
+		{
+			desc:     "typing advances past the literals",
+			template: "__/__/____",
+			width:    11,
+			ops: func(me *maskEditor) error {
+				me.insert('1')
+				me.insert('2')
+				return nil
+			},
+			wantView:    "12/__/____",
+			wantContent: "12",
+			wantCurIdx:  3,
+		},
+		{
+			desc:     "content includes the literals between filled slots",
+			template: "__/__/____",
+			width:    11,
+			ops: func(me *maskEditor) error {
+				for _, r := range "12032" {
+					me.insert(r)
+				}
+				return nil
+			},
+			wantView:    "12/03/2___",
+			wantContent: "12/03/2",
+			wantCurIdx:  7,
+		},
+		{
+			desc:     "cursor after the template when all slots are filled",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				for _, r := range "1203" {
+					me.insert(r)
+				}
+				return nil
+			},
+			wantView:    "12/03",
+			wantContent: "12/03",
+			wantCurIdx:  5,
+		},
+		{
+			desc:     "ignores runes when all slots are filled",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				for _, r := range "12034" {
+					me.insert(r)
+				}
+				return nil
+			},
+			wantView:    "12/03",
+			wantContent: "12/03",
+			wantCurIdx:  5,
+		},
+		{
+			desc:     "ignores full-width runes",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				me.insert('世')
+				return nil
+			},
+			wantView:    "__/__",
+			wantContent: "",
+			wantCurIdx:  0,
+		},
+		{
+			desc:     "backspace removes the last filled slot",
+			template: "__/__/____",
+			width:    11,
+			ops: func(me *maskEditor) error {
+				for _, r := range "120" {
+					me.insert(r)
+				}
+				me.deleteBefore()
+				return nil
+			},
+			wantView:    "12/__/____",
+			wantContent: "12",
+			wantCurIdx:  3,
+		},
+		{
+			desc:     "backspace after a literal removes the slot before the literal",
+			template: "__/__/____",
+			width:    11,
+			ops: func(me *maskEditor) error {
+				for _, r := range "12" {
+					me.insert(r)
+				}
+				me.deleteBefore()
+				return nil
+			},
+			wantView:    "1_/__/____",
+			wantContent: "1",
+			wantCurIdx:  1,
+		},
+		{
+			desc:     "backspace at the start does nothing",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				me.insert('1')
+				me.cursorStart()
+				me.deleteBefore()
+				return nil
+			},
+			wantView:    "1_/__",
+			wantContent: "1",
+			wantCurIdx:  0,
+		},
+		{
+			desc:     "backspace in the middle shifts the data left",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				for _, r := range "1203" {
+					me.insert(r)
+				}
+				me.cursorLeft()
+				me.cursorLeft()
+				me.deleteBefore()
+				return nil
+			},
+			wantView:    "10/3_",
+			wantContent: "10/3",
+			wantCurIdx:  1,
+		},
+		{
+			desc:     "delete removes the rune under the cursor",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				for _, r := range "1203" {
+					me.insert(r)
+				}
+				me.cursorStart()
+				me.delete()
+				return nil
+			},
+			wantView:    "20/3_",
+			wantContent: "20/3",
+			wantCurIdx:  0,
+		},
+		{
+			desc:     "cursor movement skips the literals",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				for _, r := range "120" {
+					me.insert(r)
+				}
+				me.cursorLeft()
+				me.cursorLeft()
+				return nil
+			},
+			wantView:    "12/0_",
+			wantContent: "12/0",
+			wantCurIdx:  1,
+		},
+		{
+			desc:     "cursor doesn't move right past the filled slots",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				me.insert('1')
+				me.cursorStart()
+				me.cursorRight()
+				me.cursorRight()
+				return nil
+			},
+			wantView:    "1_/__",
+			wantContent: "1",
+			wantCurIdx:  1,
+		},
+		{
+			desc:     "inserts in the middle shift the data right",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				for _, r := range "123" {
+					me.insert(r)
+				}
+				me.cursorStart()
+				me.insert('0')
+				return nil
+			},
+			wantView:    "01/23",
+			wantContent: "01/23",
+			wantCurIdx:  1,
+		},
+		{
+			desc:     "moves cursor to the slot after a literal cell",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				for _, r := range "1203" {
+					me.insert(r)
+				}
+				if _, _, err := me.viewFor(6); err != nil {
+					return err
+				}
+				me.cursorRelCell(2)
+				return nil
+			},
+			wantView:    "12/03",
+			wantContent: "12/03",
+			wantCurIdx:  3,
+		},
+		{
+			desc:     "moves cursor at most to the first empty slot",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				me.insert('1')
+				if _, _, err := me.viewFor(6); err != nil {
+					return err
+				}
+				me.cursorRelCell(4)
+				return nil
+			},
+			wantView:    "1_/__",
+			wantContent: "1",
+			wantCurIdx:  1,
+		},
+		{
+			desc:     "template wider than the field scrolls with the cursor",
+			template: "__/__/____",
+			width:    4,
+			ops: func(me *maskEditor) error {
+				for _, r := range "120320" {
+					me.insert(r)
+				}
+				return nil
+			},
+			wantView:    "/20_",
+			wantContent: "12/03/20",
+			wantCurIdx:  3,
+		},
+		{
+			desc:     "reset clears the data",
+			template: "__/__",
+			width:    6,
+			ops: func(me *maskEditor) error {
+				for _, r := range "12" {
+					me.insert(r)
+				}
+				me.reset()
+				return nil
+			},
+			wantView:    "__/__",
+			wantContent: "",
+			wantCurIdx:  0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			me := newMaskEditor(tc.template)
+			if tc.ops != nil {
+				if err := tc.ops(me); err != nil {
+					t.Fatalf("ops => unexpected error: %v", err)
+				}
+			}
+
+			gotView, gotCurIdx, err := me.viewFor(tc.width)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("viewFor(%d) => unexpected error: %v, wantErr: %v", tc.width, err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if gotView != tc.wantView || gotCurIdx != tc.wantCurIdx {
+				t.Errorf("viewFor(%d) => (%q, %d), want (%q, %d)", tc.width, gotView, gotCurIdx, tc.wantView, tc.wantCurIdx)
+			}
+
+			gotContent := me.content()
+			if gotContent != tc.wantContent {
+				t.Errorf("content -> %q, want %q", gotContent, tc.wantContent)
+			}
+		})
+	}
+}
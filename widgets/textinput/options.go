@@ -58,6 +58,7 @@ type options struct {
 
 	placeHolder  string
 	hideTextWith rune
+	mask         string
 
 	filter        FilterFn
 	onSubmit      SubmitFn
@@ -80,6 +81,11 @@ func (o *options) validate() error {
 			return fmt.Errorf("invalid HideTextWidth rune %c(%d), has rune width of %d cells, only runes with width of %d are accepted", r, r, got, want)
 		}
 	}
+	if o.mask != "" {
+		if err := validateMask(o.mask); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -223,6 +229,21 @@ func HideTextWith(r rune) Option {
 	})
 }
 
+// Mask sets a template for formatted input like dates or phone numbers, e.g.
+// "__/__/____". Each MaskSlot rune in the template is an input slot that
+// accepts one rune, all other runes are literals that are displayed in the
+// field. The typed runes fill the input slots from left to right and the
+// cursor skips over the literals.
+// Read returns the filled input slots together with the literals between
+// them, e.g. "12/03".
+// The template must contain at least one input slot and only runes with cell
+// width of one.
+func Mask(template string) Option {
+	return option(func(opts *options) {
+		opts.mask = template
+	})
+}
+
 // FilterFn if provided can be used to filter runes that are allowed in the
 // text input field. Any rune for which this function returns false will be
 // rejected.
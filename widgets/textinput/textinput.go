@@ -50,7 +50,7 @@ type TextInput struct {
 	mu sync.Mutex
 
 	// editor tracks the edits and the state of the text input field.
-	editor *fieldEditor
+	editor editor
 
 	// forField is the area that was occupied by the text input field last
 	// time Draw() was called.
@@ -69,8 +69,14 @@ func New(opts ...Option) (*TextInput, error) {
 	if err := opt.validate(); err != nil {
 		return nil, err
 	}
+	var ed editor
+	if opt.mask != "" {
+		ed = newMaskEditor(opt.mask)
+	} else {
+		ed = newFieldEditor()
+	}
 	return &TextInput{
-		editor: newFieldEditor(),
+		editor: ed,
 		opts:   opt,
 	}, nil
 }
@@ -117,6 +117,20 @@ func TestTextInput(t *testing.T) {
 			},
 			wantNewErr: true,
 		},
+		{
+			desc: "fails on Mask without input slots",
+			opts: []Option{
+				Mask("--/--"),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on Mask with full-width rune",
+			opts: []Option{
+				Mask("__世__"),
+			},
+			wantNewErr: true,
+		},
 		{
 			desc:   "takes all space without label",
 			canvas: image.Rect(0, 0, 10, 1),
@@ -1522,6 +1536,7 @@ func TestTextInput(t *testing.T) {
 func TestTextInputRead(t *testing.T) {
 	tests := []struct {
 		desc   string
+		opts   []Option
 		events []terminalapi.Event
 		want   string
 	}{
@@ -1539,11 +1554,38 @@ func TestTextInputRead(t *testing.T) {
 			},
 			want: "abc",
 		},
+		{
+			desc: "reads written text with the literals of the mask",
+			opts: []Option{
+				Mask("__/__/____"),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: '1'},
+				&terminalapi.Keyboard{Key: '2'},
+				&terminalapi.Keyboard{Key: '0'},
+				&terminalapi.Keyboard{Key: '3'},
+			},
+			want: "12/03",
+		},
+		{
+			desc: "backspace removes the last filled slot of the mask",
+			opts: []Option{
+				Mask("__/__/____"),
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: '1'},
+				&terminalapi.Keyboard{Key: '2'},
+				&terminalapi.Keyboard{Key: '0'},
+				&terminalapi.Keyboard{Key: keyboard.KeyBackspace},
+				&terminalapi.Keyboard{Key: '5'},
+			},
+			want: "12/5",
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			ti, err := New()
+			ti, err := New(tc.opts...)
 			if err != nil {
 				t.Fatalf("New => unexpected error: %v", err)
 			}